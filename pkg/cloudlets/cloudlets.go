@@ -26,6 +26,7 @@ type (
 
 	cloudlets struct {
 		session.Session
+		errorBodyLimit int
 	}
 
 	// Option defines a Cloudlets option
@@ -46,3 +47,11 @@ func Client(sess session.Session, opts ...Option) Cloudlets {
 	}
 	return c
 }
+
+// WithErrorBodyLimit sets the number of bytes of a response body which is not a valid API error,
+// e.g. an HTML page returned by a proxy, that is kept in the error detail. Defaults to DefaultErrorBodyLimit.
+func WithErrorBodyLimit(limit int) Option {
+	return func(c *cloudlets) {
+		c.errorBodyLimit = limit
+	}
+}
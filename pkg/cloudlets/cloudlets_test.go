@@ -52,6 +52,13 @@ func TestClient(t *testing.T) {
 				Session: nil,
 			},
 		},
+		"error body limit option": {
+			options: []Option{WithErrorBodyLimit(1024)},
+			expected: &cloudlets{
+				Session:        sess,
+				errorBodyLimit: 1024,
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
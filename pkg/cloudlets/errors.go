@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/errs"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
 )
 
 type (
@@ -22,9 +23,16 @@ type (
 		StatusCode    int             `json:"statusCode,omitempty"`
 		Errors        json.RawMessage `json:"errors,omitempty"`
 		Warnings      json.RawMessage `json:"warnings,omitempty"`
+		Method        string          `json:"method,omitempty"`
+		URL           string          `json:"url,omitempty"`
+		ContentType   string          `json:"contentType,omitempty"`
 	}
 )
 
+// DefaultErrorBodyLimit is the default number of bytes of a response body that is not a valid API error
+// which is kept in the error detail (see WithErrorBodyLimit)
+const DefaultErrorBodyLimit = 512
+
 // Error parses an error from the response
 func (c *cloudlets) Error(r *http.Response) error {
 	var e Error
@@ -42,11 +50,20 @@ func (c *cloudlets) Error(r *http.Response) error {
 
 	if err := json.Unmarshal(body, &e); err != nil {
 		c.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		limit := c.errorBodyLimit
+		if limit <= 0 {
+			limit = DefaultErrorBodyLimit
+		}
 		e.Title = "Failed to unmarshal error body. Cloudlets API failed. Check details for more information."
-		e.Detail = errs.UnescapeContent(string(body))
+		e.Detail = errs.Preview(body, limit)
+		e.ContentType = r.Header.Get("Content-Type")
 	}
 
 	e.StatusCode = r.StatusCode
+	if r.Request != nil {
+		e.Method = r.Request.Method
+		e.URL = r.Request.URL.Path
+	}
 
 	return &e
 }
@@ -59,6 +76,11 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("API error: \n%s", msg)
 }
 
+// Category classifies the error by its status and problem type (see session.ClassifyError)
+func (e *Error) Category() session.ErrorCategory {
+	return session.ClassifyError(e.StatusCode, e.Type)
+}
+
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
 	var t *Error
@@ -74,5 +96,14 @@ func (e *Error) Is(target error) bool {
 		return false
 	}
 
-	return e.Error() == t.Error()
+	// request details are only compared when the target sets them
+	actual := *e
+	if t.Method == "" && t.URL == "" {
+		actual.Method, actual.URL = "", ""
+	}
+	if t.ContentType == "" {
+		actual.ContentType = ""
+	}
+
+	return actual.Error() == t.Error()
 }
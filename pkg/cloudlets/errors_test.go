@@ -41,6 +41,8 @@ func TestNewError(t *testing.T) {
 				Title:      "b",
 				Detail:     "c",
 				StatusCode: http.StatusInternalServerError,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"invalid response body, assign status code": {
@@ -56,6 +58,8 @@ func TestNewError(t *testing.T) {
 				Title:      "Failed to unmarshal error body. Cloudlets API failed. Check details for more information.",
 				Detail:     "test",
 				StatusCode: http.StatusInternalServerError,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 	}
@@ -112,6 +116,7 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 	require.NoError(t, err)
 	tests := map[string]struct {
 		input    *http.Response
+		limit    int
 		expected *Error
 	}{
 		"API failure with HTML response": {
@@ -125,6 +130,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. Cloudlets API failed. Check details for more information.",
 				Detail:     "<HTML><HEAD>...</HEAD><BODY>...</BODY></HTML>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with plain text response": {
@@ -138,6 +145,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. Cloudlets API failed. Check details for more information.",
 				Detail:     "Your request did not succeed as this operation has reached  the limit for your account. Please try after 2024-01-16T15:20:55.945Z",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with XML response": {
@@ -151,6 +160,25 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. Cloudlets API failed. Check details for more information.",
 				Detail:     "<Root><Item id=\"1\" name=\"Example\" /></Root>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
+			},
+		},
+		"API failure with HTML gateway page exceeding the body limit": {
+			input: &http.Response{
+				Request:    req,
+				Status:     "OK",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`<html><body>502 Bad Gateway</body></html>`))},
+			limit: 27,
+			expected: &Error{
+				Title:       "Failed to unmarshal error body. Cloudlets API failed. Check details for more information.",
+				Detail:      "<html><body>502 Bad Gateway...",
+				StatusCode:  http.StatusBadGateway,
+				Method:      http.MethodHead,
+				URL:         "/",
+				ContentType: "text/html; charset=utf-8",
 			},
 		},
 	}
@@ -159,7 +187,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			sess, _ := session.New()
 			c := cloudlets{
-				Session: sess,
+				Session:        sess,
+				errorBodyLimit: test.limit,
 			}
 			assert.Equal(t, test.expected, c.Error(test.input))
 		})
@@ -189,6 +189,14 @@ func (m *Mock) GetPolicyProperties(ctx context.Context, req GetPolicyPropertiesR
 	return args.Get(0).(map[string]PolicyProperty), args.Error(1)
 }
 
+func (m *Mock) ListActivePolicyProperties(ctx context.Context, req ListActivePolicyPropertiesRequest) ([]ActivePolicyProperty, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ActivePolicyProperty), args.Error(1)
+}
+
 func (m *Mock) ListLoadBalancerVersions(ctx context.Context, req ListLoadBalancerVersionsRequest) ([]LoadBalancerVersion, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
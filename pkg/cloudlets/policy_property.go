@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
 
@@ -20,6 +21,12 @@ type (
 		// See: https://techdocs.akamai.com/cloudlets/v2/reference/get-policy-properties
 		GetPolicyProperties(context.Context, GetPolicyPropertiesRequest) (map[string]PolicyProperty, error)
 
+		// ListActivePolicyProperties lists the property versions referencing the policy which are active
+		// on the staging or production network, sorted by property name and network.
+		//
+		// See: https://techdocs.akamai.com/cloudlets/v2/reference/get-policy-properties
+		ListActivePolicyProperties(context.Context, ListActivePolicyPropertiesRequest) ([]ActivePolicyProperty, error)
+
 		// DeletePolicyProperty removes a property from a policy activation associated_properties list.
 		DeletePolicyProperty(context.Context, DeletePolicyPropertyRequest) error
 	}
@@ -29,6 +36,20 @@ type (
 		PolicyID int64
 	}
 
+	// ListActivePolicyPropertiesRequest contains request parameters for ListActivePolicyProperties
+	ListActivePolicyPropertiesRequest struct {
+		PolicyID int64
+	}
+
+	// ActivePolicyProperty is a property version referencing a policy which is active on a network
+	ActivePolicyProperty struct {
+		GroupID         int64
+		ID              int64
+		Name            string
+		Network         PolicyActivationNetwork
+		PropertyVersion int64
+	}
+
 	// PolicyProperty contains the response data for a single property
 	PolicyProperty struct {
 		GroupID       int64         `json:"groupId"`
@@ -69,10 +90,26 @@ type (
 var (
 	// ErrGetPolicyProperties is returned when GetPolicyProperties fails
 	ErrGetPolicyProperties = errors.New("get policy properties")
+	// ErrListActivePolicyProperties is returned when ListActivePolicyProperties fails
+	ErrListActivePolicyProperties = errors.New("list active policy properties")
 	// ErrDeletePolicyProperty is returned when DeletePolicyProperty fails
 	ErrDeletePolicyProperty = errors.New("delete policy property")
 )
 
+// Validate validates GetPolicyPropertiesRequest
+func (r GetPolicyPropertiesRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+	})
+}
+
+// Validate validates ListActivePolicyPropertiesRequest
+func (r ListActivePolicyPropertiesRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+	})
+}
+
 // Validate validates DeletePolicyPropertyRequest
 func (r DeletePolicyPropertyRequest) Validate() error {
 	errs := validation.Errors{
@@ -86,6 +123,10 @@ func (c *cloudlets) GetPolicyProperties(ctx context.Context, params GetPolicyPro
 	logger := c.Log(ctx)
 	logger.Debug("GetPolicyProperties")
 
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrGetPolicyProperties, ErrStructValidation, err)
+	}
+
 	uri, err := url.Parse(fmt.Sprintf("/cloudlets/api/v2/policies/%d/properties", params.PolicyID))
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetPolicyProperties, err)
@@ -109,6 +150,49 @@ func (c *cloudlets) GetPolicyProperties(ctx context.Context, params GetPolicyPro
 	return result, nil
 }
 
+func (c *cloudlets) ListActivePolicyProperties(ctx context.Context, params ListActivePolicyPropertiesRequest) ([]ActivePolicyProperty, error) {
+	c.Log(ctx).Debug("ListActivePolicyProperties")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrListActivePolicyProperties, ErrStructValidation, err)
+	}
+
+	properties, err := c.GetPolicyProperties(ctx, GetPolicyPropertiesRequest{PolicyID: params.PolicyID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrListActivePolicyProperties, err)
+	}
+
+	result := make([]ActivePolicyProperty, 0)
+	for _, property := range properties {
+		for _, activation := range []struct {
+			network PolicyActivationNetwork
+			status  NetworkStatus
+		}{
+			{network: PolicyActivationNetworkStaging, status: property.Staging},
+			{network: PolicyActivationNetworkProduction, status: property.Production},
+		} {
+			if activation.status.Version == 0 {
+				continue
+			}
+			result = append(result, ActivePolicyProperty{
+				GroupID:         property.GroupID,
+				ID:              property.ID,
+				Name:            property.Name,
+				Network:         activation.network,
+				PropertyVersion: activation.status.Version,
+			})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Network > result[j].Network
+	})
+
+	return result, nil
+}
+
 func (c *cloudlets) DeletePolicyProperty(ctx context.Context, params DeletePolicyPropertyRequest) error {
 	c.Log(ctx).Debug("DeletePolicyProperty")
 
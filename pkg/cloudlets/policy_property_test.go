@@ -195,6 +195,12 @@ func TestGetPolicyProperties(t *testing.T) {
 				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
 			},
 		},
+		"validation error - missing policy ID": {
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "PolicyID: cannot be blank")
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -218,6 +224,107 @@ func TestGetPolicyProperties(t *testing.T) {
 	}
 }
 
+func TestCloudlets_ListActivePolicyProperties(t *testing.T) {
+	tests := map[string]struct {
+		policyID         int64
+		responseStatus   int
+		responseBody     string
+		expectedResponse []ActivePolicyProperty
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			policyID:       11754,
+			responseStatus: http.StatusOK,
+			responseBody: `
+				{
+					"www.myproperty.com": {
+						"groupId": 40498,
+						"id": 179120478,
+						"name": "www.myproperty.com",
+						"newestVersion": {"version": 6},
+						"production": {"version": 4},
+						"staging": {"version": 5}
+					},
+					"api.myproperty.com": {
+						"groupId": 40498,
+						"id": 179120479,
+						"name": "api.myproperty.com",
+						"newestVersion": {"version": 2},
+						"production": {"version": 0},
+						"staging": {"version": 2}
+					},
+					"old.myproperty.com": {
+						"groupId": 40498,
+						"id": 179120480,
+						"name": "old.myproperty.com",
+						"newestVersion": {"version": 1},
+						"production": {"version": 0},
+						"staging": {"version": 0}
+					}
+				}
+			`,
+			expectedResponse: []ActivePolicyProperty{
+				{GroupID: 40498, ID: 179120479, Name: "api.myproperty.com", Network: PolicyActivationNetworkStaging, PropertyVersion: 2},
+				{GroupID: 40498, ID: 179120478, Name: "www.myproperty.com", Network: PolicyActivationNetworkStaging, PropertyVersion: 5},
+				{GroupID: 40498, ID: 179120478, Name: "www.myproperty.com", Network: PolicyActivationNetworkProduction, PropertyVersion: 4},
+			},
+		},
+		"200 OK - no properties": {
+			policyID:         11754,
+			responseStatus:   http.StatusOK,
+			responseBody:     `{}`,
+			expectedResponse: []ActivePolicyProperty{},
+		},
+		"500 Internal Server Error": {
+			policyID:       11754,
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+				{
+					"type": "internal_error",
+					"title": "Internal Server Error",
+					"detail": "Error making request",
+					"status": 500
+				}
+			`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error making request",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error - missing policy ID": {
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "list active policy properties: struct validation:\nPolicyID: cannot be blank")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cloudlets/api/v2/policies/11754/properties", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListActivePolicyProperties(context.Background(), ListActivePolicyPropertiesRequest{PolicyID: test.policyID})
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
 func TestCloudlets_DeletePolicyProperty(t *testing.T) {
 	tests := map[string]struct {
 		params         DeletePolicyPropertyRequest
@@ -0,0 +1,295 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ChangeLists contains operations available on zone change lists. A change list collects record set changes
+	// which are applied to the zone as a single new version, incrementing the SOA serial once, when submitted.
+	ChangeLists interface {
+		// CreateChangeList creates a new change list based on the most recent version of the zone.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/post-changelists
+		CreateChangeList(ctx context.Context, zone string) (*ChangeListResponse, error)
+		// AddChangeListRecordSet adds a record set change to the change list of the zone.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/post-changelists-zone-recordsets-add-change
+		AddChangeListRecordSet(ctx context.Context, zone string, change *ChangeListRecordSet) error
+		// GetChangeListDiff compares the record sets of the change list with the current version of the zone
+		// and returns the record sets the change list adds, modifies and deletes, ordered by name and type.
+		GetChangeListDiff(ctx context.Context, zone string) (*ChangeListDiff, error)
+		// SubmitChangeList applies the change list to the zone and removes it.
+		// Unlike SubmitChangelist, it only requires the zone name.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/post-changelists-zone-submit
+		SubmitChangeList(ctx context.Context, zone string) error
+		// DiscardChangeList removes the change list of the zone without applying it.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/delete-changelist
+		DiscardChangeList(ctx context.Context, zone string) error
+	}
+
+	// ChangeListRecordSet describes a record set change of a change list
+	ChangeListRecordSet struct {
+		Name  string           `json:"name"`
+		Type  string           `json:"type"`
+		Op    ChangeListOpType `json:"op"`
+		TTL   int              `json:"ttl,omitempty"`
+		Rdata []string         `json:"rdata,omitempty"`
+	}
+
+	// ChangeListOpType is the operation applied by a ChangeListRecordSet
+	ChangeListOpType string
+
+	// ChangeListDiff contains the pending changes of a change list
+	ChangeListDiff struct {
+		Zone     string
+		Added    []RecordSet
+		Modified []RecordSetDiff
+		Deleted  []RecordSet
+	}
+
+	// RecordSetDiff contains the current and the proposed version of a modified record set
+	RecordSetDiff struct {
+		Current  RecordSet
+		Proposed RecordSet
+	}
+)
+
+const (
+	// ChangeListOpAdd adds a new record set
+	ChangeListOpAdd ChangeListOpType = "ADD"
+	// ChangeListOpEdit replaces an existing record set
+	ChangeListOpEdit ChangeListOpType = "EDIT"
+	// ChangeListOpDelete removes an existing record set
+	ChangeListOpDelete ChangeListOpType = "DELETE"
+)
+
+// Validate validates ChangeListRecordSet
+func (c *ChangeListRecordSet) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Name": validation.Validate(c.Name, validation.Required),
+		"Type": validation.Validate(c.Type, validation.Required),
+		"Op": validation.Validate(c.Op, validation.Required, validation.In(ChangeListOpAdd, ChangeListOpEdit, ChangeListOpDelete).
+			Error(fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s' or '%s'", c.Op, ChangeListOpAdd, ChangeListOpEdit, ChangeListOpDelete))),
+		"TTL":   validation.Validate(c.TTL, validation.When(c.Op != ChangeListOpDelete, validation.Required)),
+		"Rdata": validation.Validate(c.Rdata, validation.When(c.Op != ChangeListOpDelete, validation.Required)),
+	})
+}
+
+func (d *dns) CreateChangeList(ctx context.Context, zone string) (*ChangeListResponse, error) {
+	logger := d.Log(ctx)
+	logger.Debug("CreateChangeList")
+
+	if zone == "" {
+		return nil, fmt.Errorf("%w: zone is required", ErrBadRequest)
+	}
+
+	postURL := fmt.Sprintf("/config-dns/v2/changelists?zone=%s", url.QueryEscape(zone))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CreateChangeList request: %w", err)
+	}
+
+	var result ChangeListResponse
+	resp, err := d.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("CreateChangeList request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, d.Error(resp)
+	}
+
+	return &result, nil
+}
+
+func (d *dns) AddChangeListRecordSet(ctx context.Context, zone string, change *ChangeListRecordSet) error {
+	logger := d.Log(ctx)
+	logger.Debug("AddChangeListRecordSet")
+
+	if change == nil {
+		return fmt.Errorf("%w: change is required", ErrBadRequest)
+	}
+	if err := change.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrStructValidation, err)
+	}
+
+	reqBody, err := convertStructToReqBody(change)
+	if err != nil {
+		return fmt.Errorf("failed to generate request body: %w", err)
+	}
+
+	postURL := fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets/add-change", zone)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create AddChangeListRecordSet request: %w", err)
+	}
+
+	resp, err := d.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("AddChangeListRecordSet request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return d.Error(resp)
+	}
+
+	return nil
+}
+
+func (d *dns) GetChangeListDiff(ctx context.Context, zone string) (*ChangeListDiff, error) {
+	logger := d.Log(ctx)
+	logger.Debug("GetChangeListDiff")
+
+	proposed, err := d.getAllRecordSets(ctx, fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets", zone))
+	if err != nil {
+		return nil, fmt.Errorf("GetChangeListDiff: %w", err)
+	}
+	current, err := d.getAllRecordSets(ctx, fmt.Sprintf("/config-dns/v2/zones/%s/recordsets", zone))
+	if err != nil {
+		return nil, fmt.Errorf("GetChangeListDiff: %w", err)
+	}
+
+	return diffRecordSets(zone, current, proposed), nil
+}
+
+func (d *dns) SubmitChangeList(ctx context.Context, zone string) error {
+	// Submitting creates a new zone version, so it is serialized with the other zone writes
+	// (see WithWriteSerialization) for the SOA serial to be incremented properly
+
+	defer d.lockWrite(&zoneWriteLock, zone)()
+	defer d.cache.invalidateZone(zone)
+
+	logger := d.Log(ctx)
+	logger.Debug("SubmitChangeList")
+
+	postURL := fmt.Sprintf("/config-dns/v2/changelists/%s/submit", zone)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create SubmitChangeList request: %w", err)
+	}
+
+	resp, err := d.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("SubmitChangeList request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return d.Error(resp)
+	}
+
+	return nil
+}
+
+func (d *dns) DiscardChangeList(ctx context.Context, zone string) error {
+	logger := d.Log(ctx)
+	logger.Debug("DiscardChangeList")
+
+	deleteURL := fmt.Sprintf("/config-dns/v2/changelists/%s", zone)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, deleteURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create DiscardChangeList request: %w", err)
+	}
+
+	resp, err := d.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("DiscardChangeList request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return d.Error(resp)
+	}
+
+	return nil
+}
+
+// getAllRecordSets fetches all pages of a record sets listing endpoint
+func (d *dns) getAllRecordSets(ctx context.Context, getURL string) ([]RecordSet, error) {
+	var recordSets []RecordSet
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Add("page", strconv.Itoa(page))
+		q.Add("pageSize", strconv.Itoa(exportPageSize))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL+"?"+q.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GetRecordsets request: %w", err)
+		}
+
+		var result RecordSetResponse
+		resp, err := d.Exec(req, &result)
+		if err != nil {
+			return nil, fmt.Errorf("GetRecordsets request failed: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, d.Error(resp)
+		}
+
+		recordSets = append(recordSets, result.RecordSets...)
+		if len(result.RecordSets) == 0 || page >= result.Metadata.LastPage {
+			return recordSets, nil
+		}
+	}
+}
+
+// diffRecordSets returns the changes turning the current record sets into the proposed ones.
+// Record sets are matched by name and type, names are compared case-insensitively and rdata order is ignored.
+func diffRecordSets(zone string, current, proposed []RecordSet) *ChangeListDiff {
+	key := func(rs RecordSet) recordKey {
+		return recordKey{name: canonicalName(rs.Name), recordType: strings.ToUpper(rs.Type)}
+	}
+	currentByKey := make(map[recordKey]RecordSet, len(current))
+	for _, rs := range current {
+		currentByKey[key(rs)] = rs
+	}
+
+	diff := &ChangeListDiff{Zone: zone}
+	seen := make(map[recordKey]struct{}, len(proposed))
+	for _, rs := range proposed {
+		k := key(rs)
+		seen[k] = struct{}{}
+		cur, ok := currentByKey[k]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, rs)
+		case cur.TTL != rs.TTL || !reflect.DeepEqual(sortedRdata(cur.Rdata), sortedRdata(rs.Rdata)):
+			diff.Modified = append(diff.Modified, RecordSetDiff{Current: cur, Proposed: rs})
+		}
+	}
+	for _, rs := range current {
+		if _, ok := seen[key(rs)]; !ok {
+			diff.Deleted = append(diff.Deleted, rs)
+		}
+	}
+
+	less := func(a, b RecordSet) bool {
+		ka, kb := key(a), key(b)
+		if ka.name != kb.name {
+			return ka.name < kb.name
+		}
+		return ka.recordType < kb.recordType
+	}
+	sort.SliceStable(diff.Added, func(i, j int) bool { return less(diff.Added[i], diff.Added[j]) })
+	sort.SliceStable(diff.Deleted, func(i, j int) bool { return less(diff.Deleted[i], diff.Deleted[j]) })
+	sort.SliceStable(diff.Modified, func(i, j int) bool { return less(diff.Modified[i].Proposed, diff.Modified[j].Proposed) })
+
+	return diff
+}
+
+func sortedRdata(rdata []string) []string {
+	sorted := make([]string, len(rdata))
+	copy(sorted, rdata)
+	sort.Strings(sorted)
+	return sorted
+}
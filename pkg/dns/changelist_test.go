@@ -0,0 +1,372 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_CreateChangeList(t *testing.T) {
+	tests := map[string]struct {
+		zone             string
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ChangeListResponse
+		withError        error
+	}{
+		"201 Created": {
+			zone:           "example.com",
+			responseStatus: http.StatusCreated,
+			responseBody: `
+{
+	"zone": "example.com",
+	"changeTag": "476754f4-d605-479f-853b-db854d7254fa",
+	"zoneVersionId": "1d9c887c-49bb-4382-87a6-d1bf690aa58f",
+	"lastModifiedDate": "2017-02-01T12:00:12.524Z",
+	"stale": false
+}`,
+			expectedResponse: &ChangeListResponse{
+				Zone:             "example.com",
+				ChangeTag:        "476754f4-d605-479f-853b-db854d7254fa",
+				ZoneVersionID:    "1d9c887c-49bb-4382-87a6-d1bf690aa58f",
+				LastModifiedDate: "2017-02-01T12:00:12.524Z",
+			},
+		},
+		"missing zone": {
+			withError: ErrBadRequest,
+		},
+		"409 conflict": {
+			zone:           "example.com",
+			responseStatus: http.StatusConflict,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/changelist-exists",
+	"title": "Conflict",
+	"detail": "A changelist already exists for example.com",
+	"status": 409
+}`,
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/authoritative-dns/changelist-exists",
+				Title:      "Conflict",
+				Detail:     "A changelist already exists for example.com",
+				StatusCode: http.StatusConflict,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/changelists?zone=example.com", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateChangeList(context.Background(), test.zone)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDNS_AddChangeListRecordSet(t *testing.T) {
+	tests := map[string]struct {
+		change          *ChangeListRecordSet
+		responseStatus  int
+		responseBody    string
+		expectedRequest string
+		withError       error
+	}{
+		"204 add": {
+			change: &ChangeListRecordSet{
+				Name:  "www.example.com",
+				Type:  "A",
+				Op:    ChangeListOpAdd,
+				TTL:   300,
+				Rdata: []string{"10.0.0.1"},
+			},
+			responseStatus:  http.StatusNoContent,
+			expectedRequest: `{"name":"www.example.com","type":"A","op":"ADD","ttl":300,"rdata":["10.0.0.1"]}`,
+		},
+		"204 delete without ttl and rdata": {
+			change: &ChangeListRecordSet{
+				Name: "www.example.com",
+				Type: "A",
+				Op:   ChangeListOpDelete,
+			},
+			responseStatus:  http.StatusNoContent,
+			expectedRequest: `{"name":"www.example.com","type":"A","op":"DELETE"}`,
+		},
+		"missing change": {
+			withError: ErrBadRequest,
+		},
+		"invalid op": {
+			change: &ChangeListRecordSet{
+				Name:  "www.example.com",
+				Type:  "A",
+				Op:    "UPSERT",
+				TTL:   300,
+				Rdata: []string{"10.0.0.1"},
+			},
+			withError: ErrStructValidation,
+		},
+		"edit without rdata": {
+			change: &ChangeListRecordSet{
+				Name: "www.example.com",
+				Type: "A",
+				Op:   ChangeListOpEdit,
+				TTL:  300,
+			},
+			withError: ErrStructValidation,
+		},
+		"404 no changelist": {
+			change: &ChangeListRecordSet{
+				Name:  "www.example.com",
+				Type:  "A",
+				Op:    ChangeListOpEdit,
+				TTL:   300,
+				Rdata: []string{"10.0.0.2"},
+			},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+	"title": "Not Found",
+	"detail": "No changelist exists for example.com",
+	"status": 404
+}`,
+			expectedRequest: `{"name":"www.example.com","type":"A","op":"EDIT","ttl":300,"rdata":["10.0.0.2"]}`,
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+				Title:      "Not Found",
+				Detail:     "No changelist exists for example.com",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/changelists/example.com/recordsets/add-change", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.AddChangeListRecordSet(context.Background(), "example.com", test.change)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDNS_GetChangeListDiff(t *testing.T) {
+	tests := map[string]struct {
+		responses        map[string]string
+		responseStatus   int
+		expectedResponse *ChangeListDiff
+		withError        error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responses: map[string]string{
+				"/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500": `
+{
+	"metadata": {"page": 1, "pageSize": 500, "lastPage": 1, "totalElements": 4},
+	"recordsets": [
+		{"name": "example.com", "type": "SOA", "ttl": 86400, "rdata": ["a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"]},
+		{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.1", "10.0.0.2"]},
+		{"name": "old.example.com", "type": "CNAME", "ttl": 300, "rdata": ["www.example.com."]},
+		{"name": "mail.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.1.1"]}
+	]
+}`,
+				"/config-dns/v2/changelists/example.com/recordsets?page=1&pageSize=500": `
+{
+	"metadata": {"page": 1, "pageSize": 500, "lastPage": 2, "totalElements": 5},
+	"recordsets": [
+		{"name": "example.com", "type": "SOA", "ttl": 86400, "rdata": ["a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"]},
+		{"name": "WWW.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2", "10.0.0.1"]},
+		{"name": "mail.example.com", "type": "A", "ttl": 600, "rdata": ["10.0.1.1"]}
+	]
+}`,
+				"/config-dns/v2/changelists/example.com/recordsets?page=2&pageSize=500": `
+{
+	"metadata": {"page": 2, "pageSize": 500, "lastPage": 2, "totalElements": 5},
+	"recordsets": [
+		{"name": "new.example.com", "type": "TXT", "ttl": 300, "rdata": ["\"hello\""]},
+		{"name": "api.example.com", "type": "AAAA", "ttl": 300, "rdata": ["2001:db8::1"]}
+	]
+}`,
+			},
+			expectedResponse: &ChangeListDiff{
+				Zone: "example.com",
+				Added: []RecordSet{
+					{Name: "api.example.com", Type: "AAAA", TTL: 300, Rdata: []string{"2001:db8::1"}},
+					{Name: "new.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"hello"`}},
+				},
+				Modified: []RecordSetDiff{
+					{
+						Current:  RecordSet{Name: "mail.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.1.1"}},
+						Proposed: RecordSet{Name: "mail.example.com", Type: "A", TTL: 600, Rdata: []string{"10.0.1.1"}},
+					},
+				},
+				Deleted: []RecordSet{
+					{Name: "old.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.com."}},
+				},
+			},
+		},
+		"404 no changelist": {
+			responseStatus: http.StatusNotFound,
+			responses: map[string]string{
+				"/config-dns/v2/changelists/example.com/recordsets?page=1&pageSize=500": `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+	"title": "Not Found",
+	"detail": "No changelist exists for example.com",
+	"status": 404
+}`,
+			},
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+				Title:      "Not Found",
+				Detail:     "No changelist exists for example.com",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				body, ok := test.responses[r.URL.String()]
+				require.True(t, ok, "unexpected request: %s", r.URL)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetChangeListDiff(context.Background(), "example.com")
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDNS_SubmitChangeList(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   string
+		withError      error
+	}{
+		"204 No Content": {
+			responseStatus: http.StatusNoContent,
+		},
+		"409 stale changelist": {
+			responseStatus: http.StatusConflict,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/stale-changelist",
+	"title": "Conflict",
+	"detail": "The changelist is stale",
+	"status": 409
+}`,
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/authoritative-dns/stale-changelist",
+				Title:      "Conflict",
+				Detail:     "The changelist is stale",
+				StatusCode: http.StatusConflict,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/changelists/example.com/submit", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.SubmitChangeList(context.Background(), "example.com")
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDNS_DiscardChangeList(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   string
+		withError      error
+	}{
+		"204 No Content": {
+			responseStatus: http.StatusNoContent,
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error discarding changelist",
+	"status": 500
+}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error discarding changelist",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/changelists/example.com", r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DiscardChangeList(context.Background(), "example.com")
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
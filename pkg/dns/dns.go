@@ -4,8 +4,12 @@
 package dns
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
 )
@@ -19,17 +23,30 @@ type (
 	// DNS is the dns api interface
 	DNS interface {
 		Authorities
+		ChangeLists
 		Data
 		Recordsets
 		Records
 		TSIGKeys
+		ZoneHistory
+		ZonePlans
 		Zones
 	}
 
 	dns struct {
 		session.Session
+		writeSerialization WriteSerialization
+		errorBodyLimit     int
+		dryRun             bool
+		reconcileCreates   bool
+		normalization      *NormalizationPolicy
+		cache              *recordCache
+		zoneRecordLimit    int
 	}
 
+	// WriteSerialization defines how concurrent writes issued by a dns client are serialized
+	WriteSerialization string
+
 	// Option defines a DNS option
 	Option func(*dns)
 
@@ -37,10 +54,24 @@ type (
 	ClientFunc func(sess session.Session, opts ...Option) DNS
 )
 
+const (
+	// Global serializes all writes of a kind (e.g. record writes) regardless of the zone
+	Global WriteSerialization = "GLOBAL"
+	// PerZone serializes writes targeting the same zone, writes to different zones run concurrently
+	PerZone WriteSerialization = "PER_ZONE"
+	// None does not serialize writes, e.g. when the caller already serializes them upstream
+	None WriteSerialization = "NONE"
+)
+
+var (
+	zoneLocks sync.Map
+)
+
 // Client returns a new dns Client instance with the specified controller
 func Client(sess session.Session, opts ...Option) DNS {
 	d := &dns{
-		Session: sess,
+		Session:            sess,
+		writeSerialization: PerZone,
 	}
 
 	for _, opt := range opts {
@@ -49,6 +80,94 @@ func Client(sess session.Session, opts ...Option) DNS {
 	return d
 }
 
+// WithWriteSerialization sets the default serialization of writes issued by the client.
+// The recLock argument of writable endpoints still overrides it per call.
+func WithWriteSerialization(mode WriteSerialization) Option {
+	return func(d *dns) {
+		d.writeSerialization = mode
+	}
+}
+
+// WithErrorBodyLimit sets the number of bytes of a response body which is not a valid API error,
+// e.g. an HTML page returned by a proxy, that is kept in the error detail. Defaults to DefaultErrorBodyLimit.
+func WithErrorBodyLimit(limit int) Option {
+	return func(d *dns) {
+		d.errorBodyLimit = limit
+	}
+}
+
+// WithDryRun makes record writes validate and serialize the request, then return a *DryRunError
+// describing it instead of issuing it. No write lock is taken in dry-run mode.
+func WithDryRun(dryRun bool) Option {
+	return func(d *dns) {
+		d.dryRun = dryRun
+	}
+}
+
+// WithCreateReconciliation makes record creation safe to retry after a network error. Edge DNS does not accept
+// idempotency keys, so when the first attempt of a create succeeded but its response was lost, the retry fails with
+// 409 Conflict. With reconciliation enabled, CreateRecord and CreateRecordSets then get the existing record sets and
+// succeed when they match the requested ones, i.e. have the same TTL and rdata, instead of returning the conflict.
+func WithCreateReconciliation(enabled bool) Option {
+	return func(d *dns) {
+		d.reconcileCreates = enabled
+	}
+}
+
+// WithZoneRecordLimit sets the maximum number of record sets of a zone checked by CheckZoneCapacity.
+// The limit depends on the contract, so there is no default and zones are not checked unless it is set.
+func WithZoneRecordLimit(limit int) Option {
+	return func(d *dns) {
+		d.zoneRecordLimit = limit
+	}
+}
+
+// dryRunRequest logs the request a write would issue and returns it as a *DryRunError
+func (d *dns) dryRunRequest(ctx context.Context, method, url string, body interface{}) error {
+	e := &DryRunError{
+		Method: method,
+		URL:    url,
+	}
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to generate request body: %w", err)
+		}
+		e.Body = b
+	}
+	d.Log(ctx).Info(e.Error())
+	return e
+}
+
+// lockWrite acquires the write lock for the zone according to the client write serialization
+// and returns the function releasing it. An explicit recLock argument overrides the client setting:
+// false disables locking and true locks per zone when the client does not serialize writes.
+func (d *dns) lockWrite(global *sync.Mutex, zone string, recLock ...bool) func() {
+	mode := d.writeSerialization
+	for _, lock := range recLock {
+		// should only be one entry
+		if !lock {
+			return func() {}
+		}
+		if mode == None {
+			mode = PerZone
+		}
+	}
+
+	switch mode {
+	case None:
+		return func() {}
+	case Global:
+		global.Lock()
+		return global.Unlock
+	default:
+		l, _ := zoneLocks.LoadOrStore(canonicalName(zone), &sync.Mutex{})
+		zoneLock := l.(*sync.Mutex)
+		zoneLock.Lock()
+		return zoneLock.Unlock
+	}
+}
+
 // Exec overrides the session.Exec to add dns options
 func (d *dns) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	return d.Session.Exec(r, out, in...)
@@ -14,7 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func mockAPIClient(t *testing.T, mockServer *httptest.Server) DNS {
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) DNS {
 	serverURL, err := url.Parse(mockServer.URL)
 	require.NoError(t, err)
 	certPool := x509.NewCertPool()
@@ -28,7 +28,7 @@ func mockAPIClient(t *testing.T, mockServer *httptest.Server) DNS {
 	}
 	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
 	assert.NoError(t, err)
-	return Client(s)
+	return Client(s, opts...)
 }
 
 func dummyOpt() Option {
@@ -47,13 +47,38 @@ func TestClient(t *testing.T) {
 		"no options provided, return default": {
 			options: nil,
 			expected: &dns{
-				Session: sess,
+				Session:            sess,
+				writeSerialization: PerZone,
 			},
 		},
 		"dummy option": {
 			options: []Option{dummyOpt()},
 			expected: &dns{
-				Session: sess,
+				Session:            sess,
+				writeSerialization: PerZone,
+			},
+		},
+		"write serialization option": {
+			options: []Option{WithWriteSerialization(None)},
+			expected: &dns{
+				Session:            sess,
+				writeSerialization: None,
+			},
+		},
+		"error body limit option": {
+			options: []Option{WithErrorBodyLimit(1024)},
+			expected: &dns{
+				Session:            sess,
+				writeSerialization: PerZone,
+				errorBodyLimit:     1024,
+			},
+		},
+		"zone record limit option": {
+			options: []Option{WithZoneRecordLimit(10000)},
+			expected: &dns{
+				Session:            sess,
+				writeSerialization: PerZone,
+				zoneRecordLimit:    10000,
 			},
 		},
 	}
@@ -8,11 +8,45 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/errs"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
 )
 
 var (
 	// ErrBadRequest is returned when a required parameter is missing
 	ErrBadRequest = errors.New("missing argument")
+	// ErrUnsupportedRecordType is returned when a record type is not supported by Edge DNS
+	ErrUnsupportedRecordType = errors.New("unsupported record type")
+	// ErrRecordNotFound is returned when a record does not exist, it also matches API errors with 404 Not Found status
+	ErrRecordNotFound = errors.New("record not found")
+	// ErrRecordAlreadyExists is returned by CreateRecord when the record set already exists, it wraps the API error
+	ErrRecordAlreadyExists = errors.New("record already exists")
+	// ErrNoMatchingZone is returned when none of the candidate zones contains a name
+	ErrNoMatchingZone = errors.New("no matching zone")
+	// ErrInvalidMasterFile is returned when master file content cannot be parsed
+	ErrInvalidMasterFile = errors.New("invalid master file")
+	// ErrInvalidImport is returned when records to import cannot be decoded or are invalid
+	ErrInvalidImport = errors.New("invalid record import")
+	// ErrInvalidCNAME is returned when a CNAME record is placed where it cannot coexist with other records
+	ErrInvalidCNAME = errors.New("invalid CNAME record")
+	// ErrInvalidSVCBRecord is returned when the rdata of a SVCB or HTTPS record cannot be parsed or has invalid params
+	ErrInvalidSVCBRecord = errors.New("invalid SVCB record")
+	// ErrDryRun is returned by writes of a client created with WithDryRun instead of issuing the request
+	ErrDryRun = errors.New("dry run")
+	// ErrTSIGKeyNotFound is returned by GetTSIGKey and DeleteTSIGKey when the zone has no TSIG key, it wraps the API error
+	ErrTSIGKeyNotFound = errors.New("TSIG key not found")
+	// ErrResultTruncated is returned by GetRecordList when fewer recordsets than the reported total could be listed
+	ErrResultTruncated = errors.New("result truncated")
+	// ErrNotModified is returned by GetRecordIfModified when the recordset has not changed since the given ETag
+	ErrNotModified = errors.New("not modified")
+	// ErrMassDeletionBlocked is returned when a zone plan deletes a larger share of the record sets of the zone than
+	// allowed, see ZonePlan.MaxDeletionPercent
+	ErrMassDeletionBlocked = errors.New("mass deletion blocked")
+	// ErrTTLConflict is returned by MergeRecordSets when records of the same recordset have different TTLs
+	// and the TTLConflictStrict policy is used
+	ErrTTLConflict = errors.New("conflicting TTLs")
+	// ErrZoneCapacityExceeded is returned by CheckZoneCapacity when a zone would have more record sets than the limit
+	// set with WithZoneRecordLimit
+	ErrZoneCapacityExceeded = errors.New("zone capacity exceeded")
 )
 
 type (
@@ -25,9 +59,23 @@ type (
 		BehaviorName  string `json:"behaviorName,omitempty"`
 		ErrorLocation string `json:"errorLocation,omitempty"`
 		StatusCode    int    `json:"-"`
+		Method        string `json:"-"`
+		URL           string `json:"-"`
+		ContentType   string `json:"-"`
+	}
+
+	// DryRunError describes the request a write would have issued when the client is in dry-run mode, it matches ErrDryRun
+	DryRunError struct {
+		Method string
+		URL    string
+		Body   []byte
 	}
 )
 
+// DefaultErrorBodyLimit is the default number of bytes of a response body that is not a valid API error
+// which is kept in the error detail (see WithErrorBodyLimit)
+const DefaultErrorBodyLimit = 512
+
 // Error parses an error from the response
 func (d *dns) Error(r *http.Response) error {
 	var e Error
@@ -45,21 +93,46 @@ func (d *dns) Error(r *http.Response) error {
 
 	if err := json.Unmarshal(body, &e); err != nil {
 		d.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		limit := d.errorBodyLimit
+		if limit <= 0 {
+			limit = DefaultErrorBodyLimit
+		}
 		e.Title = fmt.Sprintf("Failed to unmarshal error body. DNS API failed. Check details for more information.")
-		e.Detail = errs.UnescapeContent(string(body))
+		e.Detail = errs.Preview(body, limit)
+		e.ContentType = r.Header.Get("Content-Type")
 	}
 
 	e.StatusCode = r.StatusCode
+	if r.Request != nil {
+		e.Method = r.Request.Method
+		e.URL = r.Request.URL.Path
+	}
 
 	return &e
 }
 
 func (e *Error) Error() string {
-	return fmt.Sprintf("Title: %s; Type: %s; Detail: %s", e.Title, e.Type, e.Detail)
+	msg := fmt.Sprintf("Title: %s; Type: %s; Detail: %s", e.Title, e.Type, e.Detail)
+	if e.Method != "" || e.URL != "" {
+		msg += fmt.Sprintf("; Request: %s %s", e.Method, e.URL)
+	}
+	if e.ContentType != "" {
+		msg += fmt.Sprintf("; Content-Type: %s", e.ContentType)
+	}
+	return msg
+}
+
+// Category classifies the error by its status and problem type (see session.ClassifyError)
+func (e *Error) Category() session.ErrorCategory {
+	return session.ClassifyError(e.StatusCode, e.Type)
 }
 
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
+	if errors.Is(target, ErrRecordNotFound) && e.StatusCode == http.StatusNotFound {
+		return true
+	}
+
 	var t *Error
 	if !errors.As(target, &t) {
 		return false
@@ -73,5 +146,26 @@ func (e *Error) Is(target error) bool {
 		return false
 	}
 
-	return e.Error() == t.Error()
+	// request details are only compared when the target sets them
+	actual := *e
+	if t.Method == "" && t.URL == "" {
+		actual.Method, actual.URL = "", ""
+	}
+	if t.ContentType == "" {
+		actual.ContentType = ""
+	}
+
+	return actual.Error() == t.Error()
+}
+
+func (e *DryRunError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("%s: %s %s", ErrDryRun, e.Method, e.URL)
+	}
+	return fmt.Sprintf("%s: %s %s: %s", ErrDryRun, e.Method, e.URL, e.Body)
+}
+
+// Is handles error comparisons
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
 }
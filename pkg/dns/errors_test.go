@@ -2,6 +2,7 @@ package dns
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -22,6 +23,7 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 	require.NoError(t, err)
 	tests := map[string]struct {
 		input    *http.Response
+		limit    int
 		expected *Error
 	}{
 		"API failure with HTML response": {
@@ -35,6 +37,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. DNS API failed. Check details for more information.",
 				Detail:     "<HTML><HEAD>...</HEAD><BODY>...</BODY></HTML>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with plain text response": {
@@ -48,6 +52,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. DNS API failed. Check details for more information.",
 				Detail:     "Your request did not succeed as this operation has reached  the limit for your account. Please try after 2024-01-16T15:20:55.945Z",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with XML response": {
@@ -61,6 +67,26 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. DNS API failed. Check details for more information.",
 				Detail:     "<Root><Item id=\"1\" name=\"Example\" /></Root>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
+			},
+		},
+		"API failure with HTML gateway page exceeding the body limit": {
+			input: &http.Response{
+				Request:    req,
+				Status:     "OK",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`<html><body>502 Bad Gateway</body></html>`))},
+			limit: 27,
+			expected: &Error{
+				Type:        "",
+				Title:       "Failed to unmarshal error body. DNS API failed. Check details for more information.",
+				Detail:      "<html><body>502 Bad Gateway...",
+				StatusCode:  http.StatusBadGateway,
+				Method:      http.MethodHead,
+				URL:         "/",
+				ContentType: "text/html; charset=utf-8",
 			},
 		},
 	}
@@ -69,9 +95,46 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			sess, _ := session.New()
 			d := dns{
-				Session: sess,
+				Session:        sess,
+				errorBodyLimit: test.limit,
 			}
 			assert.Equal(t, test.expected, d.Error(test.input))
 		})
 	}
 }
+
+func TestErrorCategory(t *testing.T) {
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, "/config-dns/v2/zones", nil)
+	require.NoError(t, err)
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedCategory session.ErrorCategory
+	}{
+		"401 unauthorized": {
+			responseStatus:   http.StatusUnauthorized,
+			responseBody:     `{"type": "https://problems.luna.akamaiapis.net/-/pep-authn/deny", "title": "Not authorized", "status": 401}`,
+			expectedCategory: session.ErrorCategoryAuth,
+		},
+		"500 with HTML response": {
+			responseStatus:   http.StatusInternalServerError,
+			responseBody:     `<HTML><HEAD>...</HEAD><BODY>...</BODY></HTML>`,
+			expectedCategory: session.ErrorCategoryServer,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sess, _ := session.New()
+			d := dns{
+				Session: sess,
+			}
+			err := fmt.Errorf("GetZones request failed: %w", d.Error(&http.Response{
+				Request:    req,
+				StatusCode: test.responseStatus,
+				Body:       ioutil.NopCloser(strings.NewReader(test.responseBody)),
+			}))
+			assert.Equal(t, test.expectedCategory, session.ErrorCategoryOf(err))
+		})
+	}
+}
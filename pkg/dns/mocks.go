@@ -4,15 +4,25 @@ package dns
 
 import (
 	"context"
+	"io"
+	"net"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
 
+// Mock is a testify/mock implementation of the DNS interface, which can be used
+// to unit test code depending on any of its parts, e.g. Records, without an API server.
+// Like the mocks of the other packages, it is not build-tagged so that tests of other
+// modules can import it; binaries which do not reference it do not link its methods.
 type Mock struct {
 	mock.Mock
 }
 
-var _ DNS = &Mock{}
+var (
+	_ DNS     = &Mock{}
+	_ Records = &Mock{}
+)
 
 func (d *Mock) ListZones(ctx context.Context, query ...ZoneListQueryArgs) (*ZoneListResponse, error) {
 	var args mock.Arguments
@@ -66,6 +76,38 @@ func (d *Mock) GetMasterZoneFile(ctx context.Context, param string) (string, err
 	return args.String(0), args.Error(1)
 }
 
+func (d *Mock) ExportZone(ctx context.Context, param string) (string, error) {
+	args := d.Called(ctx, param)
+
+	return args.String(0), args.Error(1)
+}
+
+func (d *Mock) ParseMasterFile(ctx context.Context, origin, defaultTTL, content string) ([]*RecordBody, error) {
+	args := d.Called(ctx, origin, defaultTTL, content)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*RecordBody), args.Error(1)
+}
+
+func (d *Mock) ImportRecords(ctx context.Context, format ImportFormat, r io.Reader) ([]*RecordBody, error) {
+	args := d.Called(ctx, format, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*RecordBody), args.Error(1)
+}
+
+func (d *Mock) FindZoneForName(ctx context.Context, name string, candidateZones []string) (string, error) {
+	args := d.Called(ctx, name, candidateZones)
+
+	return args.String(0), args.Error(1)
+}
+
 func (d *Mock) CreateZone(ctx context.Context, param1 *ZoneCreate, param2 ZoneQueryString, param3 ...bool) error {
 	var args mock.Arguments
 
@@ -90,6 +132,92 @@ func (d *Mock) SubmitChangelist(ctx context.Context, param *ZoneCreate) error {
 	return args.Error(0)
 }
 
+func (d *Mock) CreateChangeList(ctx context.Context, zone string) (*ChangeListResponse, error) {
+	args := d.Called(ctx, zone)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ChangeListResponse), args.Error(1)
+}
+
+func (d *Mock) AddChangeListRecordSet(ctx context.Context, zone string, change *ChangeListRecordSet) error {
+	args := d.Called(ctx, zone, change)
+
+	return args.Error(0)
+}
+
+func (d *Mock) GetChangeListDiff(ctx context.Context, zone string) (*ChangeListDiff, error) {
+	args := d.Called(ctx, zone)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ChangeListDiff), args.Error(1)
+}
+
+func (d *Mock) SubmitChangeList(ctx context.Context, zone string) error {
+	args := d.Called(ctx, zone)
+
+	return args.Error(0)
+}
+
+func (d *Mock) DiscardChangeList(ctx context.Context, zone string) error {
+	args := d.Called(ctx, zone)
+
+	return args.Error(0)
+}
+
+func (d *Mock) PlanZone(ctx context.Context, zone string, desired []*RecordBody, opts ...ZonePlanOptions) (*ZonePlan, error) {
+	var args mock.Arguments
+
+	if len(opts) > 0 {
+		args = d.Called(ctx, zone, desired, opts[0])
+	} else {
+		args = d.Called(ctx, zone, desired)
+	}
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ZonePlan), args.Error(1)
+}
+
+func (d *Mock) ApplyZonePlan(ctx context.Context, plan *ZonePlan) error {
+	args := d.Called(ctx, plan)
+
+	return args.Error(0)
+}
+
+func (d *Mock) ApplyZonePlansThrottled(ctx context.Context, plans []*ZonePlan, opts ThrottleOptions) error {
+	args := d.Called(ctx, plans, opts)
+
+	return args.Error(0)
+}
+
+func (d *Mock) SyncRecordSets(ctx context.Context, zone string, desired []*RecordBody, opts SyncOptions) (*SyncReport, error) {
+	args := d.Called(ctx, zone, desired, opts)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*SyncReport), args.Error(1)
+}
+
+func (d *Mock) GetZoneHistory(ctx context.Context, zone string, since time.Time) ([]ZoneChange, error) {
+	args := d.Called(ctx, zone, since)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]ZoneChange), args.Error(1)
+}
+
 func (d *Mock) UpdateZone(ctx context.Context, param1 *ZoneCreate, param2 ZoneQueryString) error {
 	args := d.Called(ctx, param1, param2)
 
@@ -198,8 +326,14 @@ func (d *Mock) GetNameServerRecordList(ctx context.Context, param string) ([]str
 	return args.Get(0).([]string), args.Error(1)
 }
 
-func (d *Mock) GetRecordList(ctx context.Context, param string, param2 string, param3 string) (*RecordSetResponse, error) {
-	args := d.Called(ctx, param, param2, param3)
+func (d *Mock) GetRecordList(ctx context.Context, param string, param2 string, param3 string, param4 ...RecordListQueryArgs) (*RecordSetResponse, error) {
+	var args mock.Arguments
+
+	if len(param4) > 0 {
+		args = d.Called(ctx, param, param2, param3, param4)
+	} else {
+		args = d.Called(ctx, param, param2, param3)
+	}
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -218,6 +352,14 @@ func (d *Mock) GetRdata(ctx context.Context, param string, param2 string, param3
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (d *Mock) Normalize(record *RecordBody) *RecordBody {
+	args := d.Called(record)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*RecordBody)
+}
+
 func (d *Mock) ProcessRdata(ctx context.Context, param []string, param2 string) []string {
 	args := d.Called(ctx, param, param2)
 	if args.Get(0) == nil {
@@ -234,6 +376,52 @@ func (d *Mock) ParseRData(ctx context.Context, param string, param2 []string) ma
 	return args.Get(0).(map[string]interface{})
 }
 
+func (d *Mock) RecordToMap(ctx context.Context, param *RecordBody) (map[string]interface{}, error) {
+	args := d.Called(ctx, param)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
+
+func (d *Mock) DerivePTRRecords(ctx context.Context, forwardRecords []*RecordBody, opts ...PTROptions) (map[string][]*RecordBody, error) {
+	var args mock.Arguments
+
+	if len(opts) > 0 {
+		args = d.Called(ctx, forwardRecords, opts[0])
+	} else {
+		args = d.Called(ctx, forwardRecords)
+	}
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[string][]*RecordBody), args.Error(1)
+}
+
+func (d *Mock) NewPTRRecord(ctx context.Context, ip net.IP, target string, ttl int) (*RecordBody, string, error) {
+	args := d.Called(ctx, ip, target, ttl)
+
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+
+	return args.Get(0).(*RecordBody), args.String(1), args.Error(2)
+}
+
+func (d *Mock) GetRecordParsed(ctx context.Context, param string, param2 string, param3 string) (*RecordBody, map[string]interface{}, error) {
+	args := d.Called(ctx, param, param2, param3)
+
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).(*RecordBody), args.Get(1).(map[string]interface{}), args.Error(2)
+}
+
 func (d *Mock) GetRecord(ctx context.Context, param string, param2 string, param3 string) (*RecordBody, error) {
 	args := d.Called(ctx, param, param2, param3)
 
@@ -244,6 +432,38 @@ func (d *Mock) GetRecord(ctx context.Context, param string, param2 string, param
 	return args.Get(0).(*RecordBody), args.Error(1)
 }
 
+func (d *Mock) GetRecordIfModified(ctx context.Context, zone, name, recordType, etag string) (*RecordBody, string, error) {
+	args := d.Called(ctx, zone, name, recordType, etag)
+
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+
+	return args.Get(0).(*RecordBody), args.String(1), args.Error(2)
+}
+
+func (d *Mock) RecordExists(ctx context.Context, zone, name, recordType string) (bool, error) {
+	args := d.Called(ctx, zone, name, recordType)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (d *Mock) GetRecords(ctx context.Context, zone string, keys []RecordKey, concurrency int, opts ...GetRecordsOptions) (map[RecordKey]*RecordBody, error) {
+	var args mock.Arguments
+
+	if len(opts) > 0 {
+		args = d.Called(ctx, zone, keys, concurrency, opts[0])
+	} else {
+		args = d.Called(ctx, zone, keys, concurrency)
+	}
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[RecordKey]*RecordBody), args.Error(1)
+}
+
 func (d *Mock) CreateRecord(ctx context.Context, param *RecordBody, param2 string, param3 ...bool) error {
 	var args mock.Arguments
 
@@ -280,6 +500,42 @@ func (d *Mock) UpdateRecord(ctx context.Context, param *RecordBody, param2 strin
 	return args.Error(0)
 }
 
+func (d *Mock) WithZoneLock(ctx context.Context, zone string, fn func() error) error {
+	args := d.Called(ctx, zone, fn)
+
+	return args.Error(0)
+}
+
+func (d *Mock) DelegateSubzone(ctx context.Context, parentZone, childName string, nameservers []string, glue map[string][]net.IP, ttl int) error {
+	args := d.Called(ctx, parentZone, childName, nameservers, glue, ttl)
+
+	return args.Error(0)
+}
+
+func (d *Mock) WaitForRecordPropagation(ctx context.Context, zone, name, recordType string, expected []string, resolvers []string, pollInterval time.Duration) error {
+	args := d.Called(ctx, zone, name, recordType, expected, resolvers, pollInterval)
+
+	return args.Error(0)
+}
+
+func (d *Mock) CreateOrUpdateRecord(ctx context.Context, param *RecordBody, param2 string, param3 ...bool) error {
+	var args mock.Arguments
+
+	if len(param3) > 0 {
+		args = d.Called(ctx, param, param2, param3)
+	} else {
+		args = d.Called(ctx, param, param2)
+	}
+
+	return args.Error(0)
+}
+
+func (d *Mock) CheckZoneCapacity(ctx context.Context, zone string, additional int) error {
+	args := d.Called(ctx, zone, additional)
+
+	return args.Error(0)
+}
+
 func (d *Mock) GetRecordSets(ctx context.Context, param string, param2 ...RecordSetQueryArgs) (*RecordSetResponse, error) {
 	var args mock.Arguments
 
@@ -374,6 +630,16 @@ func (d *Mock) GetBulkZoneCreateResult(ctx context.Context, param string) (*Bulk
 
 	return args.Get(0).(*BulkCreateResultResponse), args.Error(1)
 }
+
+func (d *Mock) WaitForBulkZoneCreate(ctx context.Context, requestID string, pollInterval time.Duration) (*BulkCreateResultResponse, error) {
+	args := d.Called(ctx, requestID, pollInterval)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkCreateResultResponse), args.Error(1)
+}
 func (d *Mock) GetBulkZoneDeleteResult(ctx context.Context, param string) (*BulkDeleteResultResponse, error) {
 	args := d.Called(ctx, param)
 
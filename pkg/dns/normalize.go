@@ -0,0 +1,173 @@
+package dns
+
+import (
+	"net"
+	"strings"
+)
+
+type (
+	// NormalizationPolicy defines the canonical form of record names and rdata, so that desired records and the records
+	// returned by the API compare equal regardless of cosmetic differences. The zero value leaves rdata unchanged
+	// and only removes the trailing dot of record names.
+	NormalizationPolicy struct {
+		// LowercaseNames lowercases record names and the domain names in rdata, e.g. CNAME and MX targets
+		LowercaseNames bool
+		// TrailingDot adds or removes the trailing dot of the domain names in rdata, or leaves them as they are when unset.
+		// Record names never have a trailing dot, which is the form the API returns them in.
+		TrailingDot TriState
+		// IPv6Form is the form of AAAA addresses, left as they are when empty
+		IPv6Form IPv6Form
+	}
+
+	// TriState is a boolean setting which can also be left unset
+	TriState int
+
+	// IPv6Form is the textual form of IPv6 addresses
+	IPv6Form string
+)
+
+const (
+	// TriStateUnset leaves the setting to the data
+	TriStateUnset TriState = iota
+	// TriStateTrue enables the setting
+	TriStateTrue
+	// TriStateFalse disables the setting
+	TriStateFalse
+)
+
+const (
+	// IPv6Expanded writes the 8 groups of 4 hex digits, e.g. 2001:0db8:0000:0000:0000:0000:0000:0001, the form returned by the API
+	IPv6Expanded IPv6Form = "expanded"
+	// IPv6Compressed writes the RFC 5952 form, e.g. 2001:db8::1
+	IPv6Compressed IPv6Form = "compressed"
+)
+
+// APINormalizationPolicy is the form in which the API returns records, which ProcessRdata converts rdata to
+var APINormalizationPolicy = NormalizationPolicy{
+	TrailingDot: TriStateTrue,
+	IPv6Form:    IPv6Expanded,
+}
+
+// WithNormalizationPolicy makes the client normalize records with the policy: the records and rdata returned by
+// GetRecord, GetRecordList, GetRdata and GetRecordSets, and the records written by CreateRecord, UpdateRecord
+// and DeleteRecord. Normalize uses it too, so that desired records can be compared with the records read.
+func WithNormalizationPolicy(policy NormalizationPolicy) Option {
+	return func(d *dns) {
+		d.normalization = &policy
+	}
+}
+
+func (d *dns) Normalize(record *RecordBody) *RecordBody {
+	if d.normalization == nil {
+		return NormalizationPolicy{}.Normalize(record)
+	}
+	return d.normalization.Normalize(record)
+}
+
+// Normalize returns a copy of the record with its name and rdata normalized according to the policy
+func (p NormalizationPolicy) Normalize(record *RecordBody) *RecordBody {
+	if record == nil {
+		return nil
+	}
+	normalized := *record
+	normalized.Name = p.normalizeName(record.Name)
+	normalized.Target = p.NormalizeRdata(record.Target, record.RecordType)
+	return &normalized
+}
+
+// NormalizeRdata returns the rdata of the given record type normalized according to the policy
+func (p NormalizationPolicy) NormalizeRdata(rData []string, recordType string) []string {
+	if rData == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(rData))
+	for _, value := range rData {
+		normalized = append(normalized, p.normalizeRdataValue(value, strings.ToUpper(recordType)))
+	}
+	return normalized
+}
+
+func (p NormalizationPolicy) normalizeName(name string) string {
+	name = strings.TrimSuffix(name, ".")
+	if p.LowercaseNames {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+func (p NormalizationPolicy) normalizeRdataValue(value, recordType string) string {
+	if recordType == "AAAA" {
+		return p.normalizeIPv6(value)
+	}
+
+	index, ok := rdataNameIndex[recordType]
+	if !ok || (!p.LowercaseNames && p.TrailingDot == TriStateUnset) {
+		return value
+	}
+	fields := strings.Fields(value)
+	if recordType == "SVCB" || recordType == "HTTPS" {
+		// params may contain quoted whitespace
+		var err error
+		if fields, err = splitSVCBFields(value); err != nil {
+			return value
+		}
+	}
+	if len(fields) <= index {
+		return value
+	}
+	fields[index] = p.normalizeRdataName(fields[index])
+	return strings.Join(fields, " ")
+}
+
+func (p NormalizationPolicy) normalizeRdataName(name string) string {
+	if p.LowercaseNames {
+		name = strings.ToLower(name)
+	}
+	if name == "." {
+		return name
+	}
+	switch p.TrailingDot {
+	case TriStateTrue:
+		return fqdn(name)
+	case TriStateFalse:
+		return strings.TrimSuffix(name, ".")
+	}
+	return name
+}
+
+func (p NormalizationPolicy) normalizeIPv6(value string) string {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil || ip.To4() != nil {
+		return value
+	}
+	switch p.IPv6Form {
+	case IPv6Expanded:
+		return FullIPv6(ip)
+	case IPv6Compressed:
+		return ip.String()
+	}
+	return value
+}
+
+// rdataNameIndex is the index of the domain name field in the rdata of the record types which have one
+var rdataNameIndex = map[string]int{
+	"CNAME": 0,
+	"NS":    0,
+	"PTR":   0,
+	"MX":    1,
+	"AFSDB": 1,
+	"SRV":   3,
+	"SVCB":  1,
+	"HTTPS": 1,
+}
+
+// normalizeRecordSets normalizes the record sets read from the API in place with the policy of the client, if any
+func (d *dns) normalizeRecordSets(recordSets []RecordSet) {
+	if d.normalization == nil {
+		return
+	}
+	for i, rs := range recordSets {
+		recordSets[i].Name = d.normalization.normalizeName(rs.Name)
+		recordSets[i].Rdata = d.normalization.NormalizeRdata(rs.Rdata, rs.Type)
+	}
+}
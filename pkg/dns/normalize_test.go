@@ -0,0 +1,161 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizationPolicy_Normalize(t *testing.T) {
+	type input struct {
+		recordType string
+		name       string
+		target     string
+	}
+	inputs := []input{
+		{recordType: "CNAME", name: "WWW.Example.com.", target: "Origin.Example.NET"},
+		{recordType: "MX", name: "example.com", target: "10  Mail.Example.com."},
+		{recordType: "SRV", name: "_sip._tcp.example.com", target: "10 60 5060 Big.Example.com"},
+		{recordType: "HTTPS", name: "example.com", target: `1  CDN.Example.net alpn=h2,h3 key65000="A  B"`},
+		{recordType: "HTTPS", name: "example.com", target: "1 . alpn=h2"},
+		{recordType: "AAAA", name: "example.com", target: "2001:DB8::1"},
+		{recordType: "TXT", name: "example.com", target: `"Hello  World."`},
+	}
+
+	lowercase := map[bool][]string{
+		false: {"Origin.Example.NET", "Mail.Example.com.", "Big.Example.com", "CDN.Example.net", "."},
+		true:  {"origin.example.net", "mail.example.com.", "big.example.com", "cdn.example.net", "."},
+	}
+	trailingDot := map[TriState]func(string) string{
+		TriStateUnset: func(name string) string { return name },
+		TriStateTrue:  fqdn,
+		TriStateFalse: func(name string) string {
+			if name == "." {
+				return name
+			}
+			return strings.TrimSuffix(name, ".")
+		},
+	}
+	ipv6 := map[IPv6Form]string{
+		"":             "2001:DB8::1",
+		IPv6Expanded:   "2001:0db8:0000:0000:0000:0000:0000:0001",
+		IPv6Compressed: "2001:db8::1",
+	}
+
+	for _, lower := range []bool{false, true} {
+		for _, dot := range []TriState{TriStateUnset, TriStateTrue, TriStateFalse} {
+			for _, form := range []IPv6Form{"", IPv6Expanded, IPv6Compressed} {
+				policy := NormalizationPolicy{LowercaseNames: lower, TrailingDot: dot, IPv6Form: form}
+				t.Run(fmt.Sprintf("lowercase=%t trailingDot=%d ipv6=%q", lower, dot, form), func(t *testing.T) {
+					names := lowercase[lower]
+					for i, name := range names {
+						names[i] = trailingDot[dot](name)
+					}
+					expectedTargets := []string{
+						names[0],
+						"10 " + names[1],
+						"10 60 5060 " + names[2],
+						"1 " + names[3] + ` alpn=h2,h3 key65000="A  B"`,
+						"1 " + names[4] + " alpn=h2",
+						ipv6[form],
+						`"Hello  World."`,
+					}
+					if !lower && dot == TriStateUnset {
+						// rdata with domain names is left as is, whitespace included
+						expectedTargets[1] = inputs[1].target
+						expectedTargets[3] = inputs[3].target
+					}
+
+					for i, in := range inputs {
+						record := &RecordBody{Name: in.name, RecordType: in.recordType, TTL: 300, Target: []string{in.target}}
+						normalized := policy.Normalize(record)
+						expectedName := strings.TrimSuffix(in.name, ".")
+						if lower {
+							expectedName = strings.ToLower(expectedName)
+						}
+						assert.Equal(t, &RecordBody{Name: expectedName, RecordType: in.recordType, TTL: 300, Target: []string{expectedTargets[i]}}, normalized, in.recordType)
+						// the input is not modified and normalization is idempotent
+						assert.Equal(t, in.target, record.Target[0])
+						assert.Equal(t, normalized, policy.Normalize(normalized))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestNormalizationPolicy_API(t *testing.T) {
+	client := Client(session.Must(session.New()))
+	for _, in := range [][]string{
+		{"CNAME", "www.example.net"},
+		{"MX", "10 mail.example.com", "20  backup.example.com."},
+		{"SRV", "10 60 5060 big.example.com"},
+		{"AAAA", "2001:db8::1"},
+		{"SVCB", "1  svc.example.net alpn=h2"},
+	} {
+		assert.Equal(t, client.ProcessRdata(context.Background(), in[1:], in[0]), APINormalizationPolicy.NormalizeRdata(in[1:], in[0]), in[0])
+	}
+}
+
+func TestDNS_WithNormalizationPolicy(t *testing.T) {
+	policy := NormalizationPolicy{LowercaseNames: true, TrailingDot: TriStateFalse, IPv6Form: IPv6Compressed}
+
+	t.Run("GetRecord", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"name": "WWW.example.com", "type": "CNAME", "ttl": 300, "rdata": ["Origin.Example.net."]}`))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithNormalizationPolicy(policy))
+		record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "CNAME")
+		require.NoError(t, err)
+		assert.Equal(t, &RecordBody{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"origin.example.net"}}, record)
+		assert.Equal(t, record, client.Normalize(&RecordBody{Name: "www.Example.com.", RecordType: "CNAME", TTL: 300, Target: []string{"origin.example.NET."}}))
+	})
+
+	t.Run("GetRdata", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"metadata": {"totalElements": 1}, "recordsets": [{"name": "www.example.com", "type": "AAAA", "ttl": 300, "rdata": ["2001:0db8:0000:0000:0000:0000:0000:0001"]}]}`))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithNormalizationPolicy(policy))
+		rdata, err := client.GetRdata(context.Background(), "example.com", "WWW.example.com.", "AAAA")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2001:db8::1"}, rdata)
+	})
+
+	t.Run("GetRecordSets", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"metadata": {"totalElements": 1}, "recordsets": [{"name": "example.com", "type": "MX", "ttl": 300, "rdata": ["10 Mail.example.com."]}]}`))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithNormalizationPolicy(policy))
+		result, err := client.GetRecordSets(context.Background(), "example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []RecordSet{{Name: "example.com", Type: "MX", TTL: 300, Rdata: []string{"10 mail.example.com"}}}, result.RecordSets)
+	})
+
+	t.Run("CreateRecord", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/config-dns/v2/zones/example.com/names/www.example.com/types/AAAA", r.URL.Path)
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			var sent RecordBody
+			require.NoError(t, json.Unmarshal(body, &sent))
+			assert.Equal(t, RecordBody{Name: "www.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}}, sent)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		client := mockAPIClient(t, mockServer, WithNormalizationPolicy(policy))
+		record := &RecordBody{Name: "WWW.example.com.", RecordType: "AAAA", TTL: 300, Target: []string{"2001:0DB8::0001"}}
+		require.NoError(t, client.CreateRecord(context.Background(), record, "example.com"))
+		assert.Equal(t, "WWW.example.com.", record.Name)
+	})
+}
@@ -0,0 +1,299 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Propagation checks query the nameservers directly with golang.org/x/net/dns/dnsmessage, which is already a dependency
+// of the module, rather than with the standard library resolver: the latter only supports a few record types, always
+// asks for recursion and follows CNAME chains, whereas the answer of each authoritative nameserver is needed as is.
+
+const (
+	// defaultPropagationPollInterval is used by WaitForRecordPropagation when the poll interval is not positive
+	defaultPropagationPollInterval = 5 * time.Second
+	// propagationQueryTimeout bounds a single DNS query of WaitForRecordPropagation
+	propagationQueryTimeout = 5 * time.Second
+)
+
+var (
+	// ErrRecordNotPropagated is returned by WaitForRecordPropagation when the context is done
+	// before all resolvers answer with the expected rdata
+	ErrRecordNotPropagated = errors.New("record not propagated")
+
+	propagationTypes = map[string]dnsmessage.Type{
+		"A":     dnsmessage.TypeA,
+		"AAAA":  dnsmessage.TypeAAAA,
+		"CNAME": dnsmessage.TypeCNAME,
+		"MX":    dnsmessage.TypeMX,
+		"NS":    dnsmessage.TypeNS,
+		"PTR":   dnsmessage.TypePTR,
+		"SRV":   dnsmessage.TypeSRV,
+		"TXT":   dnsmessage.TypeTXT,
+	}
+)
+
+func (d *dns) WaitForRecordPropagation(ctx context.Context, zone, name, recordType string, expected []string, resolvers []string, pollInterval time.Duration) error {
+	logger := d.Log(ctx)
+	logger.Debug("WaitForRecordPropagation")
+
+	recordType = strings.ToUpper(recordType)
+	qtype, ok := propagationTypes[recordType]
+	if !ok {
+		return fmt.Errorf("%w: %s cannot be checked for propagation", ErrUnsupportedRecordType, recordType)
+	}
+	qname, err := dnsmessage.NewName(canonicalName(name) + ".")
+	if err != nil {
+		return fmt.Errorf("%w: invalid name %q: %s", ErrBadRequest, name, err)
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultPropagationPollInterval
+	}
+
+	if len(resolvers) == 0 {
+		if resolvers, err = d.GetRdata(ctx, zone, zone, "NS"); err != nil {
+			return fmt.Errorf("WaitForRecordPropagation: fetching nameservers of %s: %w", zone, err)
+		}
+		if len(resolvers) == 0 {
+			return fmt.Errorf("%w: zone %s has no nameservers", ErrBadRequest, zone)
+		}
+	}
+
+	want := make([]string, 0, len(expected))
+	for _, rdata := range expected {
+		want = append(want, normalizePropagationRdata(recordType, rdata))
+	}
+	sort.Strings(want)
+
+	for {
+		var pending []string
+		for _, resolver := range resolvers {
+			got, err := queryRecord(ctx, resolverAddress(resolver), qname, qtype)
+			switch {
+			case err != nil:
+				pending = append(pending, fmt.Sprintf("%s: %s", resolver, err))
+			case !equalStrings(got, want):
+				pending = append(pending, fmt.Sprintf("%s: got [%s]", resolver, strings.Join(got, ", ")))
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		logger.Debugf("WaitForRecordPropagation: %s %s not propagated yet: %s", name, recordType, strings.Join(pending, "; "))
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s %s, want [%s]: %s: %w", ErrRecordNotPropagated, name, recordType,
+				strings.Join(want, ", "), strings.Join(pending, "; "), ctx.Err())
+		}
+	}
+}
+
+// resolverAddress returns the host:port address of the resolver, using port 53 when none is given
+func resolverAddress(resolver string) string {
+	if _, _, err := net.SplitHostPort(resolver); err == nil {
+		return resolver
+	}
+	return net.JoinHostPort(strings.TrimSuffix(resolver, "."), "53")
+}
+
+// queryRecord sends a non-recursive query to the resolver over UDP, retrying over TCP when the answer is truncated,
+// and returns the normalized rdata of the answers of the queried name and type, sorted
+func queryRecord(ctx context.Context, address string, name dnsmessage.Name, qtype dnsmessage.Type) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, propagationQueryTimeout)
+	defer cancel()
+
+	query, err := (&dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: uint16(rand.Uint32())},
+		Questions: []dnsmessage.Question{{Name: name, Type: qtype, Class: dnsmessage.ClassINET}},
+	}).Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := exchange(ctx, "udp", address, query)
+	if err == nil && resp.Truncated {
+		resp, err = exchange(ctx, "tcp", address, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.RCode != dnsmessage.RCodeSuccess && resp.RCode != dnsmessage.RCodeNameError {
+		return nil, fmt.Errorf("query failed: %s", resp.RCode)
+	}
+
+	answers := []string{}
+	for _, rr := range resp.Answers {
+		if rr.Header.Type != qtype || !strings.EqualFold(rr.Header.Name.String(), name.String()) {
+			continue
+		}
+		answers = append(answers, formatPropagationRdata(rr.Body))
+	}
+	sort.Strings(answers)
+	return answers, nil
+}
+
+func exchange(ctx context.Context, network, address string, query []byte) (*dnsmessage.Message, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf []byte
+	if network == "tcp" {
+		msg := make([]byte, 2+len(query))
+		binary.BigEndian.PutUint16(msg, uint16(len(query)))
+		copy(msg[2:], query)
+		if _, err := conn.Write(msg); err != nil {
+			return nil, err
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		buf = make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buf = make([]byte, 65535)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[:n]
+	}
+
+	var resp dnsmessage.Message
+	if err := resp.Unpack(buf); err != nil {
+		return nil, err
+	}
+	if resp.ID != binary.BigEndian.Uint16(query) {
+		return nil, fmt.Errorf("mismatched response ID")
+	}
+	return &resp, nil
+}
+
+// formatPropagationRdata returns the rdata of an answer in the form of normalizePropagationRdata
+func formatPropagationRdata(body dnsmessage.ResourceBody) string {
+	switch rr := body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(rr.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		return net.IP(rr.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		return strings.ToLower(rr.CNAME.String())
+	case *dnsmessage.MXResource:
+		return fmt.Sprintf("%d %s", rr.Pref, strings.ToLower(rr.MX.String()))
+	case *dnsmessage.NSResource:
+		return strings.ToLower(rr.NS.String())
+	case *dnsmessage.PTRResource:
+		return strings.ToLower(rr.PTR.String())
+	case *dnsmessage.SRVResource:
+		return fmt.Sprintf("%d %d %d %s", rr.Priority, rr.Weight, rr.Port, strings.ToLower(rr.Target.String()))
+	case *dnsmessage.TXTResource:
+		return strings.Join(rr.TXT, "")
+	}
+	return body.GoString()
+}
+
+// normalizePropagationRdata returns rdata in a form comparable with DNS answers: IP addresses in their shortest form,
+// domain names in lower case with a trailing dot and TXT strings unquoted and concatenated
+func normalizePropagationRdata(recordType, rdata string) string {
+	fqdn := func(name string) string {
+		return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+	}
+	fields := strings.Fields(rdata)
+
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(rdata); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS", "PTR":
+		return fqdn(rdata)
+	case "MX":
+		if len(fields) == 2 {
+			return fields[0] + " " + fqdn(fields[1])
+		}
+	case "SRV":
+		if len(fields) == 4 {
+			return strings.Join(fields[:3], " ") + " " + fqdn(fields[3])
+		}
+	case "TXT":
+		return unquoteTXT(rdata)
+	}
+	return rdata
+}
+
+// unquoteTXT concatenates the character strings of TXT rdata, e.g. `"part one" "part two"`, or returns it as is
+// when it is not quoted
+func unquoteTXT(rdata string) string {
+	rdata = strings.TrimSpace(rdata)
+	if !strings.HasPrefix(rdata, `"`) {
+		return rdata
+	}
+
+	var b strings.Builder
+	for rdata != "" {
+		rdata = strings.TrimLeft(rdata, " \t")
+		if !strings.HasPrefix(rdata, `"`) {
+			b.WriteString(rdata)
+			break
+		}
+		end := 1
+		for end < len(rdata) && rdata[end] != '"' {
+			if rdata[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(rdata) {
+			b.WriteString(rdata[1:])
+			break
+		}
+		s, err := strconv.Unquote(rdata[:end+1])
+		if err != nil {
+			s = rdata[1:end]
+		}
+		b.WriteString(s)
+		rdata = rdata[end+1:]
+	}
+	return b.String()
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
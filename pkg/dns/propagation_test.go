@@ -0,0 +1,204 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeNameserver answers queries over UDP with the resources returned by answer for the query count
+func fakeNameserver(t *testing.T, answer func(q dnsmessage.Question, count int32) []dnsmessage.Resource) (string, *int32) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	var count int32
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil {
+				continue
+			}
+			assert.False(t, query.RecursionDesired)
+			resp := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true},
+				Questions: query.Questions,
+				Answers:   answer(query.Questions[0], atomic.AddInt32(&count, 1)),
+			}
+			if len(resp.Answers) == 0 {
+				resp.RCode = dnsmessage.RCodeNameError
+			}
+			data, err := resp.Pack()
+			require.NoError(t, err)
+			_, _ = conn.WriteTo(data, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String(), &count
+}
+
+func TestDNS_WaitForRecordPropagation(t *testing.T) {
+	name := dnsmessage.MustNewName("www.example.com.")
+	a := func(ip string) dnsmessage.Resource {
+		var addr [4]byte
+		copy(addr[:], net.ParseIP(ip).To4())
+		return dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 300},
+			Body:   &dnsmessage.AResource{A: addr},
+		}
+	}
+	txt := func(segments ...string) dnsmessage.Resource {
+		return dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET, TTL: 300},
+			Body:   &dnsmessage.TXTResource{TXT: segments},
+		}
+	}
+
+	tests := map[string]struct {
+		recordType    string
+		expected      []string
+		answer        func(q dnsmessage.Question, count int32) []dnsmessage.Resource
+		timeout       time.Duration
+		minQueries    int32
+		withError     error
+		withCtxError  error
+		useZoneNSList bool
+	}{
+		"A propagated after a few queries": {
+			recordType: "A",
+			expected:   []string{"10.0.0.2", "10.0.0.1"},
+			answer: func(q dnsmessage.Question, count int32) []dnsmessage.Resource {
+				assert.Equal(t, dnsmessage.TypeA, q.Type)
+				if count < 3 {
+					return []dnsmessage.Resource{a("10.0.0.1")}
+				}
+				return []dnsmessage.Resource{a("10.0.0.1"), a("10.0.0.2")}
+			},
+			timeout:    time.Second,
+			minQueries: 3,
+		},
+		"record removed": {
+			recordType: "A",
+			answer: func(_ dnsmessage.Question, count int32) []dnsmessage.Resource {
+				if count < 2 {
+					return []dnsmessage.Resource{a("10.0.0.1")}
+				}
+				return nil
+			},
+			timeout:    time.Second,
+			minQueries: 2,
+		},
+		"TXT compared unquoted and concatenated": {
+			recordType: "TXT",
+			expected:   []string{`"v=spf1 " "-all"`},
+			answer: func(_ dnsmessage.Question, _ int32) []dnsmessage.Resource {
+				return []dnsmessage.Resource{txt("v=spf1 -all")}
+			},
+			timeout:    time.Second,
+			minQueries: 1,
+		},
+		"nameservers of the zone": {
+			recordType: "A",
+			expected:   []string{"10.0.0.1"},
+			answer: func(_ dnsmessage.Question, _ int32) []dnsmessage.Resource {
+				return []dnsmessage.Resource{a("10.0.0.1")}
+			},
+			timeout:       time.Second,
+			minQueries:    1,
+			useZoneNSList: true,
+		},
+		"not propagated before the context is done": {
+			recordType: "A",
+			expected:   []string{"10.0.0.2"},
+			answer: func(_ dnsmessage.Question, _ int32) []dnsmessage.Resource {
+				return []dnsmessage.Resource{a("10.0.0.1")}
+			},
+			timeout:      50 * time.Millisecond,
+			withError:    ErrRecordNotPropagated,
+			withCtxError: context.DeadlineExceeded,
+		},
+		"unsupported record type": {
+			recordType: "CAA",
+			timeout:    time.Second,
+			withError:  ErrUnsupportedRecordType,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			answer := test.answer
+			if answer == nil {
+				answer = func(_ dnsmessage.Question, _ int32) []dnsmessage.Resource { return nil }
+			}
+			address, count := fakeNameserver(t, answer)
+
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/recordsets?search=example.com&showAll=true&types=NS", r.URL.String())
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`
+{
+	"metadata": {"page": 1, "pageSize": 25, "showAll": true, "totalElements": 1},
+	"recordsets": [{"name": "example.com", "type": "NS", "ttl": 86400, "rdata": ["` + address + `"]}]
+}`))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+
+			resolvers := []string{address}
+			if test.useZoneNSList {
+				resolvers = nil
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			err := client.WaitForRecordPropagation(ctx, "example.com", "www.example.com", test.recordType, test.expected, resolvers, time.Millisecond)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				if test.withCtxError != nil {
+					assert.True(t, errors.Is(err, test.withCtxError), "want: %s; got: %s", test.withCtxError, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, atomic.LoadInt32(count), test.minQueries)
+		})
+	}
+}
+
+func TestNormalizePropagationRdata(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		rdata      string
+		expected   string
+	}{
+		"AAAA expanded":    {recordType: "AAAA", rdata: "2001:0db8:0000:0000:0000:0000:0000:0001", expected: "2001:db8::1"},
+		"CNAME":            {recordType: "CNAME", rdata: "WWW.Example.com", expected: "www.example.com."},
+		"MX":               {recordType: "MX", rdata: "10 mx.example.com.", expected: "10 mx.example.com."},
+		"SRV":              {recordType: "SRV", rdata: "10 20 443 target.example.com", expected: "10 20 443 target.example.com."},
+		"TXT quoted":       {recordType: "TXT", rdata: `"a \"quoted\" word" "and more"`, expected: `a "quoted" wordand more`},
+		"TXT not quoted":   {recordType: "TXT", rdata: "plain text", expected: "plain text"},
+		"invalid A as is":  {recordType: "A", rdata: "not-an-ip", expected: "not-an-ip"},
+		"MX missing field": {recordType: "MX", rdata: "mx.example.com", expected: "mx.example.com"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizePropagationRdata(test.recordType, test.rdata))
+		})
+	}
+}
@@ -2,43 +2,153 @@ package dns
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net"
 	"net/http"
+	"sort"
+	"strings"
 
 	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // Records contains operations available on a Record resource.
 type Records interface {
-	// GetRecordList retrieves recordset list based on type.
+	// GetRecordList retrieves recordset list based on name and type.
+	// A non-empty name lists the recordsets at that name only, compared case-insensitively and ignoring a trailing dot:
+	// the recordsets whose name merely contains it, which the search query also matches, are left out, and unless
+	// a single page is requested, totalElements is the number of recordsets listed. RecordListQueryArgs.Search
+	// lists all recordsets whose name contains the search term instead.
+	// An empty type lists recordsets of all types, otherwise the type is validated
+	// and may be a comma-joined list of types, e.g. "A,AAAA".
+	// The resulting query string is e.g. ?search=www.example.com&showAll=true&types=A%2CAAAA
+	// Optional RecordListQueryArgs set the sort order, a search filter and paging; the applied sort order
+	// is reported in the response metadata.
+	// Unless a single page is requested, the remaining pages are fetched when the response holds fewer recordsets
+	// than its totalElements, and ErrResultTruncated is returned if the complete list still cannot be listed.
 	//
 	// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-zone-recordsets
-	GetRecordList(context.Context, string, string, string) (*RecordSetResponse, error)
+	GetRecordList(context.Context, string, string, string, ...RecordListQueryArgs) (*RecordSetResponse, error)
 	// GetRdata retrieves record rdata, e.g. target.
+	// It returns ErrRecordNotFound when the record does not exist
+	// and an empty slice when the record exists with empty rdata.
 	GetRdata(context.Context, string, string, string) ([]string, error)
-	// ProcessRdata process rdata.
+	// ProcessRdata process rdata into the form returned by the API, so that it can be compared with API responses.
+	// AAAA addresses are fully expanded and LOC values padded. Domain names of CNAME, NS and PTR targets,
+	// MX exchanges, AFSDB hostnames and SRV targets always get a trailing dot, like in API responses,
+	// while the numeric fields preceding them, e.g. the MX preference, are kept as they are.
+	// SVCB and HTTPS target names get a trailing dot too, and their params are kept verbatim.
 	ProcessRdata(context.Context, []string, string) []string
+	// Normalize returns a copy of the record normalized with the NormalizationPolicy of the client (see
+	// WithNormalizationPolicy), the form in which the client returns records read from the API.
+	Normalize(record *RecordBody) *RecordBody
 	// ParseRData parses rdata. returning map.
 	ParseRData(context.Context, string, []string) map[string]interface{}
+	// RecordToMap returns the record as a map with the name, recordtype, ttl, active and target keys,
+	// target holding the rdata as is, extended with the fields parsed by ParseRData.
+	// When the record is not valid, it returns the map without the parsed fields together with
+	// an error wrapping ErrStructValidation.
+	RecordToMap(context.Context, *RecordBody) (map[string]interface{}, error)
+	// NewPTRRecord builds the PTR record pointing the reverse name of the IP address to the target and returns it
+	// with the reverse zone it belongs to, i.e. the /24 in-addr.arpa zone for IPv4 and the /64 ip6.arpa zone for IPv6.
+	NewPTRRecord(ctx context.Context, ip net.IP, target string, ttl int) (*RecordBody, string, error)
+	// DerivePTRRecords builds the PTR records of the addresses of the A and AAAA forward records with NewPTRRecord,
+	// grouped by reverse zone and sorted by name. Other record types and wildcard names are skipped. The PTR records
+	// get the TTL of their forward record. An address of several forward names is handled according to the
+	// PTRConflictPolicy of the optional PTROptions, PTRConflictSkip by default.
+	DerivePTRRecords(ctx context.Context, forwardRecords []*RecordBody, opts ...PTROptions) (map[string][]*RecordBody, error)
 	// GetRecord retrieves a recordset and returns as RecordBody.
 	//
 	// See:  https://techdocs.akamai.com/edge-dns/reference/get-zone-name-type
 	GetRecord(context.Context, string, string, string) (*RecordBody, error)
+	// GetRecordIfModified retrieves a recordset like GetRecord and returns it with the ETag of the response.
+	// When etag, e.g. returned by a previous call, is not empty, it is sent as If-None-Match and ErrNotModified
+	// is returned together with the ETag, without a record, when the recordset has not changed since.
+	//
+	// See:  https://techdocs.akamai.com/edge-dns/reference/get-zone-name-type
+	GetRecordIfModified(ctx context.Context, zone, name, recordType, etag string) (*RecordBody, string, error)
+	// RecordExists reports whether the recordset exists without reading it, with a HEAD request, or a GET request
+	// whose body is not decoded when HEAD is not allowed. It returns false and no error when the recordset
+	// is not found, and false and the error when the request fails otherwise.
+	//
+	// See:  https://techdocs.akamai.com/edge-dns/reference/get-zone-name-type
+	RecordExists(ctx context.Context, zone, name, recordType string) (bool, error)
+	// GetRecords retrieves the recordsets of the keys with GetRecord, with at most concurrency requests at a time,
+	// DefaultGetRecordsConcurrency when it is not positive. Duplicate keys are read once. When some of the
+	// recordsets cannot be read, it returns the ones read together with a *GetRecordsError holding the error of
+	// each key. With the NotFoundAsNil option, recordsets which do not exist are returned as nil records.
+	//
+	// See:  https://techdocs.akamai.com/edge-dns/reference/get-zone-name-type
+	GetRecords(ctx context.Context, zone string, keys []RecordKey, concurrency int, opts ...GetRecordsOptions) (map[RecordKey]*RecordBody, error)
+	// GetRecordParsed retrieves a recordset and returns it together with its rdata parsed by ParseRData.
+	// The parsed map always contains "target" and, depending on the record type, the following keys:
+	//   - AFSDB: subtype
+	//   - AKAMAITLC: answer_type, dns_name
+	//   - CERT: type_value or type_mnemonic, keytag, algorithm, certificate
+	//   - DNSKEY: flags, protocol, algorithm, key
+	//   - DS: keytag, digest_type, algorithm, digest
+	//   - HINFO: hardware, software
+	//   - HTTPS, SVCB: svc_priority, target_name, svc_params and, when the params are valid, svc_param_values
+	//     mapping each param key to its comma-separated values (see ParseSVCBRdata)
+	//   - NAPTR: order, preference, flagsnaptr, service, regexp, replacement
+	//   - NSEC3: flags, algorithm, iterations, salt, next_hashed_owner_name, type_bitmaps
+	//   - NSEC3PARAM: flags, algorithm, iterations, salt
+	//   - RP: mailbox, txt
+	//   - RRSIG: type_covered, algorithm, labels, original_ttl, expiration, inception, signer, keytag, signature
+	//   - SOA: name_server, email_address, serial, refresh, retry, expiry, nxdomain_ttl
+	//   - SRV: priority, weight, port when they are the same for all targets
+	//   - SSHFP: algorithm, fingerprint_type, fingerprint
+	//   - TLSA: usage, selector, match_type, certificate
+	//
+	// See:  https://techdocs.akamai.com/edge-dns/reference/get-zone-name-type
+	GetRecordParsed(context.Context, string, string, string) (*RecordBody, map[string]interface{}, error)
 	// CreateRecord creates recordset.
+	// CNAME records at the zone apex are rejected with ErrInvalidCNAME (see CheckApexCNAME).
+	// It returns ErrRecordAlreadyExists, wrapping the API error, when the recordset already exists,
+	// unless it matches the record and the client was created with WithCreateReconciliation.
+	// In dry-run mode (see WithDryRun) it returns a *DryRunError describing the request instead.
 	//
 	// See: https://techdocs.akamai.com/edge-dns/reference/post-zones-zone-names-name-types-type
 	CreateRecord(context.Context, *RecordBody, string, ...bool) error
+	// CreateOrUpdateRecord creates the recordset or replaces it when it already exists.
+	CreateOrUpdateRecord(context.Context, *RecordBody, string, ...bool) error
 	// DeleteRecord removes recordset.
 	//
 	// See: https://techdocs.akamai.com/edge-dns/reference/delete-zone-name-type
 	DeleteRecord(context.Context, *RecordBody, string, ...bool) error
 	// UpdateRecord replaces the recordset.
+	// CNAME records at the zone apex are rejected with ErrInvalidCNAME (see CheckApexCNAME).
 	//
 	// See: https://techdocs.akamai.com/edge-dns/reference/put-zones-zone-names-name-types-type
 	UpdateRecord(context.Context, *RecordBody, string, ...bool) error
+	// WithZoneLock acquires the record write lock of the zone once, according to the client write serialization,
+	// and runs the callback while holding it. CreateRecord, UpdateRecord and DeleteRecord calls made
+	// within the callback must pass recLock=false, otherwise they deadlock on the lock already held.
+	// The callback must not spawn concurrent writes to the same zone, since the lock only serializes
+	// it against other writers. In dry-run mode (see WithDryRun) the lock is not taken.
+	WithZoneLock(ctx context.Context, zone string, fn func() error) error
+	// DelegateSubzone delegates childName to the nameservers by creating its NS recordset in parentZone, together with
+	// A and AAAA glue records for the nameservers listed in glue, under a single zone lock (see WithZoneLock).
+	// Glue is required for nameservers in-bailiwick of the child, i.e. within childName, and it can only be created
+	// for nameservers within parentZone. Glue records are created before the NS recordset.
+	DelegateSubzone(ctx context.Context, parentZone, childName string, nameservers []string, glue map[string][]net.IP, ttl int) error
+	// WaitForRecordPropagation queries the resolvers, host or host:port addresses, or the nameservers of the zone
+	// when none are given, every pollInterval until each of them answers with the expected rdata, in any order.
+	// An empty expected waits for the record to be removed. Queries are non-recursive and sent directly over UDP,
+	// falling back to TCP for truncated answers. A, AAAA, CNAME, MX, NS, PTR, SRV and TXT records are supported.
+	// It returns an error wrapping ErrRecordNotPropagated and the context error, with the last answers, when the
+	// context is done first.
+	WaitForRecordPropagation(ctx context.Context, zone, name, recordType string, expected []string, resolvers []string, pollInterval time.Duration) error
 }
 
-// RecordBody contains request body for dns record
+// RecordBody contains request body for dns record.
+// TTL is in seconds, or TTLInheritZone to let the default TTL of the zone apply.
 type RecordBody struct {
 	Name       string   `json:"name,omitempty"`
 	RecordType string   `json:"type,omitempty"`
@@ -47,64 +157,182 @@ type RecordBody struct {
 	Target     []string `json:"rdata,omitempty"`
 }
 
+// PTROptions contains optional settings of DerivePTRRecords
+type PTROptions struct {
+	// Conflicts decides what to do with an address of several forward names
+	Conflicts PTRConflictPolicy
+}
+
+// PTRConflictPolicy decides how DerivePTRRecords handles an address of several forward names
+type PTRConflictPolicy string
+
+const (
+	// PTRConflictSkip creates no PTR record for the address, leaving the choice of the name to the caller
+	PTRConflictSkip PTRConflictPolicy = "skip"
+	// PTRConflictFirst points the PTR record to the first forward name of the address
+	PTRConflictFirst PTRConflictPolicy = "first"
+	// PTRConflictAll points the PTR record to every forward name of the address, in order
+	PTRConflictAll PTRConflictPolicy = "all"
+)
+
+// recordBodyPayload is the request body of record writes, see MarshalRecordBody
+type recordBodyPayload struct {
+	Name       string   `json:"name"`
+	RecordType string   `json:"type"`
+	TTL        *int     `json:"ttl,omitempty"`
+	Active     bool     `json:"active,omitempty"`
+	Target     []string `json:"rdata"`
+}
+
 var (
 	zoneRecordWriteLock sync.Mutex
 )
 
-// Validate validates RecordBody
-func (rec *RecordBody) Validate() error {
-	if len(rec.Name) < 1 {
-		return fmt.Errorf("RecordBody is missing Name")
+// MarshalRecordBody returns the JSON request body sent by CreateRecord and UpdateRecord for the record.
+// Unlike the json encoding of RecordBody, the name, type, ttl and rdata fields are always present: a TTL of 0 is sent
+// as "ttl":0, which the API rejects (Validate requires a TTL of at least 30) rather than replacing it with a default.
+// The only exception is a TTL of TTLInheritZone, for which the ttl field is omitted so that the default TTL of the zone
+// applies. A nil or empty Target is sent as "rdata":[], never null. Active is not part of the record set schema of the API
+// and is only sent when true. The body is the same for every record type, rdata being sent as given, e.g.
+//
+//	{"name":"www.example.com","type":"A","ttl":300,"rdata":["10.0.0.1","10.0.0.2"]}
+//	{"name":"example.com","type":"MX","ttl":300,"rdata":["10 mx1.example.com."]}
+//	{"name":"example.com","type":"TXT","ttl":300,"rdata":["\"v=spf1 -all\""]}
+func MarshalRecordBody(record *RecordBody) (io.Reader, error) {
+	if record == nil {
+		return nil, fmt.Errorf("%w: nil record", ErrBadRequest)
 	}
-	if len(rec.RecordType) < 1 {
-		return fmt.Errorf("RecordBody is missing RecordType")
+	return convertStructToReqBody(newRecordBodyPayload(record))
+}
+
+func newRecordBodyPayload(record *RecordBody) recordBodyPayload {
+	target := record.Target
+	if target == nil {
+		target = []string{}
 	}
-	if rec.TTL == 0 {
-		return fmt.Errorf("RecordBody is missing TTL")
+	payload := recordBodyPayload{
+		Name:       record.Name,
+		RecordType: record.RecordType,
+		Active:     record.Active,
+		Target:     target,
 	}
-	if rec.Target == nil || len(rec.Target) < 1 {
-		return fmt.Errorf("RecordBody is missing Target")
+	if record.TTL != TTLInheritZone {
+		ttl := record.TTL
+		payload.TTL = &ttl
 	}
+	return payload
+}
 
-	return nil
+// Validate validates RecordBody
+func (rec *RecordBody) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Name":       validation.Validate(rec.Name, validation.Required),
+		"RecordType": validation.Validate(rec.RecordType, validation.Required),
+		"TTL":        validation.Validate(rec.TTL, validation.When(rec.TTL != TTLInheritZone, recordTTLRules...)),
+		"Target":     validation.Validate(rec.Target, validation.Required, validation.By(validateTargets(rec.RecordType))),
+	})
 }
 
-// Eval option lock arg passed into writable endpoints. Default is true, e.g. lock
-func localLock(lockArg []bool) bool {
-	for _, lock := range lockArg {
-		// should only be one entry
-		return lock
-	}
+const (
+	// TTLInheritZone is the TTL of a RecordBody which has no TTL of its own and inherits the default TTL of its zone.
+	// Its ttl field is omitted from the request body of CreateRecord and UpdateRecord, while a TTL of 0 is sent as is
+	// and rejected by Validate, so that a forgotten TTL is not mistaken for a deliberate one.
+	TTLInheritZone = -1
 
-	return true
-}
+	minRecordTTL = 30
+	maxRecordTTL = math.MaxInt32
+)
 
-func (d *dns) CreateRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
-	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
-	// is required to be incremented for every subsequent update to a zone,
-	// so we have to save just one request at a time to ensure this is always
-	// incremented properly
+var recordTTLRules = []validation.Rule{validation.Required, validation.Min(minRecordTTL), validation.Max(maxRecordTTL)}
 
-	if localLock(recLock) {
-		zoneRecordWriteLock.Lock()
-		defer zoneRecordWriteLock.Unlock()
+// CheckApexCNAME returns ErrInvalidCNAME when the record is a CNAME at the apex of the zone,
+// where it would conflict with the SOA and NS records of the zone
+func CheckApexCNAME(zone string, record *RecordBody) error {
+	if record == nil || !strings.EqualFold(record.RecordType, "CNAME") {
+		return nil
+	}
+	if canonicalName(record.Name) == canonicalName(zone) {
+		return fmt.Errorf("%w: %s is the apex of zone %s and a CNAME cannot coexist with its SOA and NS records, "+
+			"use A/AAAA records or an AKAMAICDN (ALIAS/ANAME-like) record instead", ErrInvalidCNAME, record.Name, zone)
 	}
+	return nil
+}
+
+// validateTargets validates the number and format of targets which depend on the record type
+func validateTargets(recordType string) validation.RuleFunc {
+	return func(value interface{}) error {
+		targets, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected targets type %T", value)
+		}
+
+		recordType = strings.ToUpper(recordType)
+		if info, ok := recordTypes[recordType]; ok && !info.MultipleTargets && len(targets) != 1 {
+			return fmt.Errorf("%s record must have exactly one target, got %d", recordType, len(targets))
+		}
+
+		switch recordType {
+		case "SOA":
+			if fields := strings.Fields(targets[0]); len(fields) != 7 {
+				return fmt.Errorf("SOA target must consist of 7 fields, got %d", len(fields))
+			}
+		case "A":
+			for _, target := range targets {
+				if ip := net.ParseIP(target); ip == nil || ip.To4() == nil {
+					return fmt.Errorf("invalid IPv4 address %q", target)
+				}
+			}
+		case "AAAA":
+			for _, target := range targets {
+				if ip := net.ParseIP(target); ip == nil || ip.To4() != nil {
+					return fmt.Errorf("invalid IPv6 address %q", target)
+				}
+			}
+		case "SVCB", "HTTPS":
+			for _, target := range targets {
+				if _, err := ParseSVCBRdata(target); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
 
+func (d *dns) CreateRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
 	logger := d.Log(ctx)
 	logger.Debug("CreateRecord")
+	if d.normalization != nil {
+		record = d.normalization.Normalize(record)
+	}
 	logger.Debugf("DNS Lib Create Record: [%v]", record)
 	if err := record.Validate(); err != nil {
 		logger.Errorf("Record content not valid: %w", err)
 		return fmt.Errorf("CreateRecord content not valid. [%w]", err)
 	}
+	if err := CheckApexCNAME(zone, record); err != nil {
+		return err
+	}
+
+	postURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, record.Name, record.RecordType)
+	if d.dryRun {
+		return d.dryRunRequest(ctx, http.MethodPost, postURL, newRecordBodyPayload(record))
+	}
 
-	reqBody, err := convertStructToReqBody(record)
+	// This lock will restrict the concurrency of API calls
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
+	// is required to be incremented for every subsequent update to a zone,
+	// so we have to save just one request at a time to ensure this is always
+	// incremented properly. In dry-run mode the lock is not taken.
+	defer d.lockWrite(&zoneRecordWriteLock, zone, recLock...)()
+	defer d.cache.invalidateRecord(zone, record.Name, record.RecordType)
+
+	reqBody, err := MarshalRecordBody(record)
 	if err != nil {
 		return fmt.Errorf("failed to generate request body: %w", err)
 	}
 
-	postURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, record.Name, record.RecordType)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to create CreateRecord request: %w", err)
@@ -115,6 +343,22 @@ func (d *dns) CreateRecord(ctx context.Context, record *RecordBody, zone string,
 		return fmt.Errorf("CreateRecord request failed: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusConflict {
+		conflictErr := fmt.Errorf("%w: %w", ErrRecordAlreadyExists, d.Error(resp))
+		if d.reconcileCreates {
+			if err := d.reconcileCreate(ctx, zone, RecordSet{
+				Name:  record.Name,
+				Type:  record.RecordType,
+				TTL:   record.TTL,
+				Rdata: record.Target,
+			}); err != nil {
+				return fmt.Errorf("%w: %w", conflictErr, err)
+			}
+			return nil
+		}
+		return conflictErr
+	}
+
 	if resp.StatusCode != http.StatusCreated {
 		return d.Error(resp)
 	}
@@ -122,32 +366,52 @@ func (d *dns) CreateRecord(ctx context.Context, record *RecordBody, zone string,
 	return nil
 }
 
-func (d *dns) UpdateRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
-	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
-	// is required to be incremented for every subsequent update to a zone
-	// so we have to save just one request at a time to ensure this is always
-	// incremented properly
+func (d *dns) CreateOrUpdateRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
+	logger := d.Log(ctx)
+	logger.Debug("CreateOrUpdateRecord")
 
-	if localLock(recLock) {
-		zoneRecordWriteLock.Lock()
-		defer zoneRecordWriteLock.Unlock()
+	err := d.CreateRecord(ctx, record, zone, recLock...)
+	if errors.Is(err, ErrRecordAlreadyExists) {
+		logger.Debugf("Record %s %s already exists, updating it", record.Name, record.RecordType)
+		return d.UpdateRecord(ctx, record, zone, recLock...)
 	}
 
+	return err
+}
+
+func (d *dns) UpdateRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
 	logger := d.Log(ctx)
 	logger.Debug("UpdateRecord")
+	if d.normalization != nil {
+		record = d.normalization.Normalize(record)
+	}
 	logger.Debugf("DNS Lib Update Record: [%v]", record)
 	if err := record.Validate(); err != nil {
 		logger.Errorf("Record content not valid: %s", err.Error())
 		return fmt.Errorf("UpdateRecord content not valid. [%w]", err)
 	}
+	if err := CheckApexCNAME(zone, record); err != nil {
+		return err
+	}
+
+	putURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, record.Name, record.RecordType)
+	if d.dryRun {
+		return d.dryRunRequest(ctx, http.MethodPut, putURL, newRecordBodyPayload(record))
+	}
 
-	reqBody, err := convertStructToReqBody(record)
+	// This lock will restrict the concurrency of API calls
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
+	// is required to be incremented for every subsequent update to a zone
+	// so we have to save just one request at a time to ensure this is always
+	// incremented properly. In dry-run mode the lock is not taken.
+	defer d.lockWrite(&zoneRecordWriteLock, zone, recLock...)()
+	defer d.cache.invalidateRecord(zone, record.Name, record.RecordType)
+
+	reqBody, err := MarshalRecordBody(record)
 	if err != nil {
 		return fmt.Errorf("failed to generate request body: %w", err)
 	}
 
-	putURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, record.Name, record.RecordType)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to create UpdateRecord request: %w", err)
@@ -165,20 +429,117 @@ func (d *dns) UpdateRecord(ctx context.Context, record *RecordBody, zone string,
 	return nil
 }
 
-func (d *dns) DeleteRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
-	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
-	// is required to be incremented for every subsequent update to a zone
-	// so we have to save just one request at a time to ensure this is always
-	// incremented properly
+func (d *dns) WithZoneLock(ctx context.Context, zone string, fn func() error) error {
+	logger := d.Log(ctx)
+	logger.Debug("WithZoneLock")
 
-	if localLock(recLock) {
-		zoneRecordWriteLock.Lock()
-		defer zoneRecordWriteLock.Unlock()
+	if d.dryRun {
+		return fn()
 	}
 
+	defer d.lockWrite(&zoneRecordWriteLock, zone, true)()
+
+	return fn()
+}
+
+func (d *dns) DelegateSubzone(ctx context.Context, parentZone, childName string, nameservers []string, glue map[string][]net.IP, ttl int) error {
+	logger := d.Log(ctx)
+	logger.Debug("DelegateSubzone")
+
+	parent, child := canonicalName(parentZone), canonicalName(childName)
+	if child == parent || !inDomain(child, parent) {
+		return fmt.Errorf("%w: %q is not a subdomain of %q", ErrBadRequest, childName, parentZone)
+	}
+	if len(nameservers) == 0 {
+		return fmt.Errorf("%w: at least one nameserver is required", ErrBadRequest)
+	}
+
+	glueIPs := make(map[string][]net.IP, len(glue))
+	for name, ips := range glue {
+		name = canonicalName(name)
+		glueIPs[name] = append(glueIPs[name], ips...)
+	}
+
+	ns := &RecordBody{
+		Name:       child,
+		RecordType: "NS",
+		TTL:        ttl,
+	}
+	isNameserver := make(map[string]bool, len(nameservers))
+	for _, nameserver := range nameservers {
+		name := canonicalName(nameserver)
+		if inDomain(name, child) && len(glueIPs[name]) == 0 {
+			return fmt.Errorf("%w: glue is required for in-bailiwick nameserver %q", ErrBadRequest, nameserver)
+		}
+		isNameserver[name] = true
+		ns.Target = append(ns.Target, fqdn(name))
+	}
+
+	glueNames := make([]string, 0, len(glueIPs))
+	for name := range glueIPs {
+		if !isNameserver[name] {
+			return fmt.Errorf("%w: glue for %q which is not a nameserver of %q", ErrBadRequest, name, childName)
+		}
+		if !inDomain(name, parent) {
+			return fmt.Errorf("%w: glue for %q cannot be created outside of zone %q", ErrBadRequest, name, parentZone)
+		}
+		glueNames = append(glueNames, name)
+	}
+	sort.Strings(glueNames)
+
+	var records []*RecordBody
+	for _, name := range glueNames {
+		a := &RecordBody{Name: name, RecordType: "A", TTL: ttl}
+		aaaa := &RecordBody{Name: name, RecordType: "AAAA", TTL: ttl}
+		for _, ip := range glueIPs[name] {
+			if ipv4 := ip.To4(); ipv4 != nil {
+				a.Target = append(a.Target, ipv4.String())
+			} else if ip.To16() != nil {
+				aaaa.Target = append(aaaa.Target, ip.String())
+			} else {
+				return fmt.Errorf("%w: invalid glue IP address %q for %q", ErrBadRequest, ip.String(), name)
+			}
+		}
+		for _, rec := range []*RecordBody{a, aaaa} {
+			if len(rec.Target) > 0 {
+				records = append(records, rec)
+			}
+		}
+	}
+	records = append(records, ns)
+
+	for _, rec := range records {
+		if err := rec.Validate(); err != nil {
+			return fmt.Errorf("DelegateSubzone %s %s record not valid: %w", rec.Name, rec.RecordType, err)
+		}
+	}
+
+	return d.WithZoneLock(ctx, parentZone, func() error {
+		for _, rec := range records {
+			if err := d.CreateRecord(ctx, rec, parentZone, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// canonicalName returns the lower-cased domain name without a trailing dot
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// inDomain reports whether the canonical name equals or is a subdomain of the canonical domain
+func inDomain(name, domain string) bool {
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+func (d *dns) DeleteRecord(ctx context.Context, record *RecordBody, zone string, recLock ...bool) error {
 	logger := d.Log(ctx)
 	logger.Debug("DeleteRecord")
+	if d.normalization != nil {
+		record = d.normalization.Normalize(record)
+	}
 
 	if err := record.Validate(); err != nil {
 		logger.Errorf("Record content not valid: %w", err)
@@ -186,6 +547,18 @@ func (d *dns) DeleteRecord(ctx context.Context, record *RecordBody, zone string,
 	}
 
 	deleteURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, record.Name, record.RecordType)
+	if d.dryRun {
+		return d.dryRunRequest(ctx, http.MethodDelete, deleteURL, nil)
+	}
+
+	// This lock will restrict the concurrency of API calls
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
+	// is required to be incremented for every subsequent update to a zone
+	// so we have to save just one request at a time to ensure this is always
+	// incremented properly. In dry-run mode the lock is not taken.
+	defer d.lockWrite(&zoneRecordWriteLock, zone, recLock...)()
+	defer d.cache.invalidateRecord(zone, record.Name, record.RecordType)
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, deleteURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DeleteRecord request: %w", err)
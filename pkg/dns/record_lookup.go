@@ -7,11 +7,20 @@ import (
 
 	"encoding/hex"
 	"net"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-func fullIPv6(ip net.IP) string {
+// FullIPv6 returns the IP address in the expanded IPv6 form returned by the API, with all eight groups of four
+// hexadecimal digits, e.g. "2001:0db8:0000:0000:0000:0000:0000:0001" for 2001:db8::1. IPv4 addresses are returned in
+// their IPv4-mapped IPv6 form, e.g. "0000:0000:0000:0000:0000:ffff:0a00:0001", and invalid addresses as "".
+func FullIPv6(ip net.IP) string {
+	ip = ip.To16()
+	if ip == nil {
+		return ""
+	}
 
 	dst := make([]byte, hex.EncodedLen(len(ip)))
 	_ = hex.Encode(dst, ip)
@@ -35,7 +44,10 @@ func padValue(str string) string {
 	return fmt.Sprintf("%.2f", float)
 }
 
-func padCoordinates(str string) string {
+// PadCoordinates returns the LOC record rdata in the form returned by the API, with the altitude, size and precisions
+// written with two decimals, e.g. "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m" for
+// "52 22 23.000 N 4 53 32.000 E -2m 0m 10000m 10m". It returns "" when the rdata does not have all twelve fields.
+func PadCoordinates(str string) string {
 	s := strings.Split(str, " ")
 	if len(s) < 12 {
 		return ""
@@ -50,35 +62,318 @@ func (d *dns) GetRecord(ctx context.Context, zone, name, recordType string) (*Re
 	logger := d.Log(ctx)
 	logger.Debug("GetRecord")
 
+	if d.cache != nil {
+		return d.getCachedRecord(ctx, zone, name, recordType)
+	}
+	record, _, err := d.getRecord(ctx, zone, name, recordType, "")
+	return record, err
+}
+
+func (d *dns) GetRecordIfModified(ctx context.Context, zone, name, recordType, etag string) (*RecordBody, string, error) {
+	logger := d.Log(ctx)
+	logger.Debug("GetRecordIfModified")
+
+	return d.getRecord(ctx, zone, name, recordType, etag)
+}
+
+// getRecord retrieves a recordset, conditionally on it not matching etag when etag is not empty,
+// and returns it with the ETag of the response
+func (d *dns) getRecord(ctx context.Context, zone, name, recordType, etag string) (*RecordBody, string, error) {
 	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, name, recordType)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GetRecord request: %w", err)
+		return nil, "", fmt.Errorf("failed to create GetRecord request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
 
 	var result RecordBody
 	resp, err := d.Exec(req, &result)
 	if err != nil {
-		return nil, fmt.Errorf("GetRecord request failed: %w", err)
+		return nil, "", fmt.Errorf("GetRecord request failed: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		_ = resp.Body.Close()
+		if responseETag := resp.Header.Get("ETag"); responseETag != "" {
+			etag = responseETag
+		}
+		return nil, etag, ErrNotModified
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, d.Error(resp)
+		return nil, "", d.Error(resp)
 	}
 
-	return &result, nil
+	if d.normalization != nil {
+		return d.normalization.Normalize(&result), resp.Header.Get("ETag"), nil
+	}
+	return &result, resp.Header.Get("ETag"), nil
+}
+
+func (d *dns) RecordExists(ctx context.Context, zone, name, recordType string) (bool, error) {
+	logger := d.Log(ctx)
+	logger.Debug("RecordExists")
+
+	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", zone, name, recordType)
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, getURL, nil)
+		if err != nil {
+			return false, fmt.Errorf("failed to create RecordExists request: %w", err)
+		}
+
+		resp, err := d.Exec(req, nil)
+		if err != nil {
+			return false, fmt.Errorf("RecordExists request failed: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			_ = resp.Body.Close()
+			return true, nil
+		case http.StatusNotFound:
+			_ = resp.Body.Close()
+			return false, nil
+		case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			if method == http.MethodHead {
+				_ = resp.Body.Close()
+				logger.Debug("HEAD not allowed, checking the recordset with GET")
+				continue
+			}
+		}
+		return false, d.Error(resp)
+	}
+	return false, nil
+}
+
+// GetRecordParsed returns the recordset with its rdata parsed by ParseRData. Besides "target", the parsed map is keyed
+// by the rdata fields of the record type, as listed by SupportedRecordTypes; the DNS interface lists the keys per type
+func (d *dns) GetRecordParsed(ctx context.Context, zone, name, recordType string) (*RecordBody, map[string]interface{}, error) {
+	logger := d.Log(ctx)
+	logger.Debug("GetRecordParsed")
+
+	record, err := d.GetRecord(ctx, zone, name, recordType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return record, d.ParseRData(ctx, record.RecordType, record.Target), nil
+}
+
+// parseRecordTypes validates a single or comma-joined list of record types
+// and returns it upper-cased and comma-joined, as expected by the types query param
+func parseRecordTypes(recordType string) (string, error) {
+	var types []string
+	for _, t := range strings.Split(recordType, ",") {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if _, ok := supportedRecordTypes[t]; !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnsupportedRecordType, t)
+		}
+		types = append(types, t)
+	}
+
+	return strings.Join(types, ","), nil
+}
+
+func (d *dns) NewPTRRecord(ctx context.Context, ip net.IP, target string, ttl int) (*RecordBody, string, error) {
+	logger := d.Log(ctx)
+	logger.Debug("NewPTRRecord")
+
+	if target == "" {
+		return nil, "", fmt.Errorf("%w: PTR target is required", ErrBadRequest)
+	}
+
+	var labels []string
+	var zoneLabels int
+	var suffix string
+	if ipv4 := ip.To4(); ipv4 != nil {
+		for i := len(ipv4) - 1; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(ipv4[i])))
+		}
+		zoneLabels, suffix = 3, "in-addr.arpa"
+	} else if ipv6 := ip.To16(); ipv6 != nil {
+		nibbles := strings.ReplaceAll(FullIPv6(ipv6), ":", "")
+		for i := len(nibbles) - 1; i >= 0; i-- {
+			labels = append(labels, string(nibbles[i]))
+		}
+		zoneLabels, suffix = 16, "ip6.arpa"
+	} else {
+		return nil, "", fmt.Errorf("%w: invalid IP address %q", ErrBadRequest, ip.String())
+	}
+
+	name := strings.Join(append(labels, suffix), ".")
+	zone := strings.Join(append(labels[len(labels)-zoneLabels:], suffix), ".")
+
+	return &RecordBody{
+		Name:       name,
+		RecordType: "PTR",
+		TTL:        ttl,
+		Target:     []string{fqdn(target)},
+	}, zone, nil
+}
+
+func (d *dns) DerivePTRRecords(ctx context.Context, forwardRecords []*RecordBody, opts ...PTROptions) (map[string][]*RecordBody, error) {
+	logger := d.Log(ctx)
+	logger.Debug("DerivePTRRecords")
+
+	if len(opts) > 1 {
+		return nil, fmt.Errorf("invalid arguments DerivePTRRecords options")
+	}
+	conflicts := PTRConflictSkip
+	if len(opts) > 0 && opts[0].Conflicts != "" {
+		conflicts = opts[0].Conflicts
+	}
+	switch conflicts {
+	case PTRConflictSkip, PTRConflictFirst, PTRConflictAll:
+	default:
+		return nil, fmt.Errorf("%w: invalid PTR conflict policy %q", ErrBadRequest, conflicts)
+	}
+
+	type reverse struct {
+		record *RecordBody
+		zone   string
+	}
+	byName := make(map[string]*reverse)
+	var names []string
+	for _, rec := range forwardRecords {
+		if rec == nil {
+			return nil, fmt.Errorf("%w: nil record", ErrBadRequest)
+		}
+		recordType := strings.ToUpper(rec.RecordType)
+		if (recordType != "A" && recordType != "AAAA") || strings.HasPrefix(rec.Name, "*") {
+			continue
+		}
+		for _, target := range rec.Target {
+			ip := net.ParseIP(strings.TrimSpace(target))
+			if ip == nil || (recordType == "A") != (ip.To4() != nil) {
+				return nil, fmt.Errorf("%w: invalid %s rdata %q of %s", ErrBadRequest, recordType, target, rec.Name)
+			}
+			ptr, zone, err := d.NewPTRRecord(ctx, ip, rec.Name, rec.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("DerivePTRRecords: %s: %w", rec.Name, err)
+			}
+			existing, ok := byName[ptr.Name]
+			if !ok {
+				byName[ptr.Name] = &reverse{record: ptr, zone: zone}
+				names = append(names, ptr.Name)
+				continue
+			}
+			if !containsName(existing.record.Target, ptr.Target[0]) {
+				existing.record.Target = append(existing.record.Target, ptr.Target[0])
+			}
+		}
+	}
+
+	result := make(map[string][]*RecordBody)
+	for _, name := range names {
+		r := byName[name]
+		if len(r.record.Target) > 1 {
+			switch conflicts {
+			case PTRConflictSkip:
+				logger.Debugf("DerivePTRRecords: skipping %s of several names: %s", name, strings.Join(r.record.Target, ", "))
+				continue
+			case PTRConflictFirst:
+				r.record.Target = r.record.Target[:1]
+			}
+		}
+		result[r.zone] = append(result[r.zone], r.record)
+	}
+	for _, records := range result {
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].Name < records[j].Name
+		})
+	}
+
+	return result, nil
+}
+
+// containsName reports whether names contains name, compared case-insensitively
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validSortByFields contains the recordset fields GetRecordList can sort by, optionally prefixed with "-" for descending order
+var validSortByFields = map[string]struct{}{"name": {}, "type": {}}
+
+func parseSortBy(sortBy string) (string, error) {
+	fields := strings.Split(sortBy, ",")
+	for i, field := range fields {
+		field = strings.TrimSpace(field)
+		if _, ok := validSortByFields[strings.TrimPrefix(field, "-")]; !ok {
+			return "", fmt.Errorf("%w: invalid sortBy field %q, must be one of name, type, -name, -type", ErrBadRequest, field)
+		}
+		fields[i] = field
+	}
+	return strings.Join(fields, ","), nil
 }
 
-func (d *dns) GetRecordList(ctx context.Context, zone, _, recordType string) (*RecordSetResponse, error) {
+func (d *dns) GetRecordList(ctx context.Context, zone, name, recordType string, queryArgs ...RecordListQueryArgs) (*RecordSetResponse, error) {
 	logger := d.Log(ctx)
 	logger.Debug("GetRecordList")
 
-	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/recordsets?types=%s&showAll=true", zone, recordType)
+	if len(queryArgs) > 1 {
+		return nil, fmt.Errorf("invalid arguments GetRecordList QueryArgs")
+	}
+	var args RecordListQueryArgs
+	if len(queryArgs) > 0 {
+		args = queryArgs[0]
+	}
+	if name != "" && args.Search != "" {
+		return nil, fmt.Errorf("%w: name and Search cannot be used together", ErrBadRequest)
+	}
+
+	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/recordsets", zone)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GetRecordList request: %w", err)
 	}
 
+	q := req.URL.Query()
+	if name != "" {
+		q.Add("search", name)
+	}
+	if args.Search != "" {
+		q.Add("search", args.Search)
+	}
+	if args.Page > 0 {
+		q.Add("page", strconv.Itoa(args.Page))
+		if args.PageSize > 0 {
+			q.Add("pageSize", strconv.Itoa(args.PageSize))
+		}
+	} else {
+		q.Add("showAll", "true")
+	}
+	var sortBy string
+	if args.SortBy != "" {
+		if sortBy, err = parseSortBy(args.SortBy); err != nil {
+			return nil, fmt.Errorf("GetRecordList: %w", err)
+		}
+		q.Add("sortBy", sortBy)
+	}
+	if recordType != "" {
+		types, err := parseRecordTypes(recordType)
+		if err != nil {
+			return nil, fmt.Errorf("GetRecordList: %w", err)
+		}
+		q.Add("types", types)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	// the URL is read before Exec, which sets its host
+	listURL, generation := req.URL.String(), uint64(0)
+	if d.cache != nil {
+		if cached, ok := d.cache.getList(zone, listURL); ok {
+			return cached, nil
+		}
+		generation = d.cache.generation(zone)
+	}
+
 	var result RecordSetResponse
 	resp, err := d.Exec(req, &result)
 	if err != nil {
@@ -89,9 +384,84 @@ func (d *dns) GetRecordList(ctx context.Context, zone, _, recordType string) (*R
 		return nil, d.Error(resp)
 	}
 
+	if args.Page == 0 && result.Metadata.TotalElements > len(result.RecordSets) {
+		logger.Debugf("GetRecordList: %d of %d recordsets listed, fetching all pages", len(result.RecordSets), result.Metadata.TotalElements)
+		if err := d.getRecordListPages(ctx, req.URL, &result); err != nil {
+			return nil, fmt.Errorf("GetRecordList: %w", err)
+		}
+	}
+
+	if result.Metadata.SortBy == "" {
+		result.Metadata.SortBy = sortBy
+	}
+	d.normalizeRecordSets(result.RecordSets)
+	if name != "" {
+		result.RecordSets = filterRecordSetsByName(result.RecordSets, name)
+		if args.Page == 0 {
+			result.Metadata.TotalElements = len(result.RecordSets)
+		}
+	}
+	if d.cache != nil {
+		d.cache.storeList(zone, listURL, generation, &result)
+	}
+
 	return &result, nil
 }
 
+// filterRecordSetsByName returns the recordsets at the name, compared canonically, as the search query of
+// GetRecordList also matches the names containing it
+func filterRecordSetsByName(recordSets []RecordSet, name string) []RecordSet {
+	name = canonicalName(name)
+	filtered := recordSets[:0]
+	for _, rs := range recordSets {
+		if canonicalName(rs.Name) == name {
+			filtered = append(filtered, rs)
+		}
+	}
+	return filtered
+}
+
+// getRecordListPages replaces the recordsets of a truncated showAll listing with those of all pages of the same listing.
+// It returns ErrResultTruncated when the pages still hold fewer recordsets than the total reported by the last one.
+func (d *dns) getRecordListPages(ctx context.Context, listURL *url.URL, result *RecordSetResponse) error {
+	var recordSets []RecordSet
+	var metadata Metadata
+	for page := 1; ; page++ {
+		q := listURL.Query()
+		q.Del("showAll")
+		q.Set("page", strconv.Itoa(page))
+		q.Set("pageSize", strconv.Itoa(exportPageSize))
+		pageURL := *listURL
+		pageURL.RawQuery = q.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create GetRecordList request: %w", err)
+		}
+
+		var pageResult RecordSetResponse
+		resp, err := d.Exec(req, &pageResult)
+		if err != nil {
+			return fmt.Errorf("GetRecordList request failed: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return d.Error(resp)
+		}
+
+		recordSets = append(recordSets, pageResult.RecordSets...)
+		metadata = pageResult.Metadata
+		if len(pageResult.RecordSets) == 0 || page >= pageResult.Metadata.LastPage {
+			break
+		}
+	}
+
+	if len(recordSets) < metadata.TotalElements {
+		return fmt.Errorf("%w: %d of %d recordsets listed", ErrResultTruncated, len(recordSets), metadata.TotalElements)
+	}
+	result.RecordSets = recordSets
+	result.Metadata.TotalElements = metadata.TotalElements
+	return nil
+}
+
 func (d *dns) GetRdata(ctx context.Context, zone, name, recordType string) ([]string, error) {
 	logger := d.Log(ctx)
 	logger.Debug("GetrData")
@@ -101,23 +471,32 @@ func (d *dns) GetRdata(ctx context.Context, zone, name, recordType string) ([]st
 		return nil, err
 	}
 
+	// the AAAA addresses are already in the form of the normalization policy of the client, if it sets one
+	expandIPv6 := d.normalization == nil || d.normalization.IPv6Form == ""
 	var rData []string
 	for _, r := range records.RecordSets {
-		if r.Name == name {
+		if r.Name == name || (d.normalization != nil && canonicalName(r.Name) == canonicalName(name)) {
+			if rData == nil {
+				rData = make([]string, 0, len(r.Rdata))
+			}
 			for _, i := range r.Rdata {
 				str := i
 
-				if recordType == "AAAA" {
+				if recordType == "AAAA" && expandIPv6 {
 					addr := net.ParseIP(str)
-					result := fullIPv6(addr)
+					result := FullIPv6(addr)
 					str = result
 				} else if recordType == "LOC" {
-					str = padCoordinates(str)
+					str = PadCoordinates(str)
 				}
 				rData = append(rData, str)
 			}
 		}
 	}
+	if rData == nil {
+		return nil, fmt.Errorf("%w: name: %s, type: %s", ErrRecordNotFound, name, recordType)
+	}
+
 	return rData, nil
 }
 
@@ -128,12 +507,21 @@ func (d *dns) ProcessRdata(ctx context.Context, rData []string, rType string) []
 	var newRData []string
 	for _, i := range rData {
 		str := i
-		if rType == "AAAA" {
+		switch strings.ToUpper(rType) {
+		case "AAAA":
 			addr := net.ParseIP(str)
-			result := fullIPv6(addr)
+			result := FullIPv6(addr)
 			str = result
-		} else if rType == "LOC" {
-			str = padCoordinates(str)
+		case "LOC":
+			str = PadCoordinates(str)
+		case "CNAME", "NS", "PTR":
+			str = fqdn(strings.TrimSpace(str))
+		case "MX", "AFSDB":
+			str = normalizeRdataName(str, 1)
+		case "SRV":
+			str = normalizeRdataName(str, 3)
+		case "SVCB", "HTTPS":
+			str = normalizeSVCBRdata(str)
 		}
 		newRData = append(newRData, str)
 	}
@@ -141,6 +529,60 @@ func (d *dns) ProcessRdata(ctx context.Context, rData []string, rType string) []
 	return newRData
 }
 
+// fqdn returns the domain name with a trailing dot, matching the form in which the API returns domain names in rdata
+func fqdn(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// normalizeRdataName collapses whitespace between rdata fields and converts the domain name field at the given
+// index to fqdn form, leaving the numeric fields, e.g. the MX preference, untouched
+func normalizeRdataName(rData string, index int) string {
+	fields := strings.Fields(rData)
+	if len(fields) != index+1 {
+		return rData
+	}
+	for _, field := range fields[:index] {
+		if _, err := strconv.Atoi(field); err != nil {
+			return rData
+		}
+	}
+	fields[index] = fqdn(fields[index])
+	return strings.Join(fields, " ")
+}
+
+func (d *dns) RecordToMap(ctx context.Context, record *RecordBody) (map[string]interface{}, error) {
+	logger := d.Log(ctx)
+	logger.Debug("RecordToMap")
+
+	if record == nil {
+		return nil, fmt.Errorf("%w: record is required", ErrBadRequest)
+	}
+
+	recordMap := map[string]interface{}{
+		"name":       record.Name,
+		"recordtype": record.RecordType,
+		"ttl":        record.TTL,
+		"active":     record.Active,
+		"target":     record.Target,
+	}
+	if err := record.Validate(); err != nil {
+		logger.Errorf("Record content not valid: %s", err)
+		return recordMap, fmt.Errorf("%w: %s", ErrStructValidation, err)
+	}
+
+	for key, value := range d.ParseRData(ctx, record.RecordType, record.Target) {
+		if key == "target" {
+			continue
+		}
+		recordMap[key] = value
+	}
+
+	return recordMap, nil
+}
+
 func (d *dns) ParseRData(ctx context.Context, rType string, rData []string) map[string]interface{} {
 	logger := d.Log(ctx)
 	logger.Debug("ParserData")
@@ -256,18 +698,15 @@ func resolveAFSDBType(rData, newRData []string, fieldMap map[string]interface{})
 func resolveDNSKEYType(rData []string, fieldMap map[string]interface{}) {
 	for _, rContent := range rData {
 		parts := strings.Split(rContent, " ")
+		// has to be at least four fields.
+		if len(parts) < 4 {
+			break
+		}
 		fieldMap["flags"], _ = strconv.Atoi(parts[0])
 		fieldMap["protocol"], _ = strconv.Atoi(parts[1])
 		fieldMap["algorithm"], _ = strconv.Atoi(parts[2])
-		key := parts[3]
 		// key can have whitespace
-		if len(parts) > 4 {
-			i := 4
-			for i < len(parts) {
-				key += " " + parts[i]
-			}
-		}
-		fieldMap["key"] = key
+		fieldMap["key"] = strings.Join(parts[3:], " ")
 		break
 	}
 }
@@ -283,26 +722,37 @@ func resolveSVCBType(rData []string, fieldMap map[string]interface{}) {
 		fieldMap["target_name"] = parts[1]
 		if len(parts) > 2 {
 			fieldMap["svc_params"] = parts[2]
+			resolveSvcParamValues(rContent, fieldMap)
 		}
 		break
 	}
 }
 
+// resolveSvcParamValues adds the values of the SvcParams of valid SVCB and HTTPS rdata, keyed by param key
+func resolveSvcParamValues(rData string, fieldMap map[string]interface{}) {
+	record, err := ParseSVCBRdata(rData)
+	if err != nil {
+		return
+	}
+	values := make(map[string][]string, len(record.Params))
+	for _, param := range record.Params {
+		values[param.Key] = param.Values()
+	}
+	fieldMap["svc_param_values"] = values
+}
+
 func resolveDSType(rData []string, fieldMap map[string]interface{}) {
 	for _, rContent := range rData {
 		parts := strings.Split(rContent, " ")
+		// has to be at least four fields.
+		if len(parts) < 4 {
+			break
+		}
 		fieldMap["keytag"], _ = strconv.Atoi(parts[0])
 		fieldMap["digest_type"], _ = strconv.Atoi(parts[2])
 		fieldMap["algorithm"], _ = strconv.Atoi(parts[1])
-		dig := parts[3]
 		// digest can have whitespace
-		if len(parts) > 4 {
-			i := 4
-			for i < len(parts) {
-				dig += " " + parts[i]
-			}
-		}
-		fieldMap["digest"] = dig
+		fieldMap["digest"] = strings.Join(parts[3:], " ")
 		break
 	}
 }
@@ -365,6 +815,10 @@ func resolveRPType(rData []string, fieldMap map[string]interface{}) {
 func resolveRRSIGType(rData []string, fieldMap map[string]interface{}) {
 	for _, rContent := range rData {
 		parts := strings.Split(rContent, " ")
+		// has to be at least nine fields.
+		if len(parts) < 9 {
+			break
+		}
 		fieldMap["type_covered"] = parts[0]
 		fieldMap["algorithm"], _ = strconv.Atoi(parts[1])
 		fieldMap["labels"], _ = strconv.Atoi(parts[2])
@@ -373,15 +827,8 @@ func resolveRRSIGType(rData []string, fieldMap map[string]interface{}) {
 		fieldMap["inception"] = parts[5]
 		fieldMap["signer"] = parts[7]
 		fieldMap["keytag"], _ = strconv.Atoi(parts[6])
-		sig := parts[8]
 		// sig can have whitespace
-		if len(parts) > 9 {
-			i := 9
-			for i < len(parts) {
-				sig += " " + parts[i]
-			}
-		}
-		fieldMap["signature"] = sig
+		fieldMap["signature"] = strings.Join(parts[8:], " ")
 		break
 	}
 }
@@ -472,7 +919,7 @@ func resolveAAAAType(rData, newRData []string, fieldMap map[string]interface{})
 	for _, i := range rData {
 		str := i
 		addr := net.ParseIP(str)
-		result := fullIPv6(addr)
+		result := FullIPv6(addr)
 		str = result
 		newRData = append(newRData, str)
 	}
@@ -482,7 +929,7 @@ func resolveAAAAType(rData, newRData []string, fieldMap map[string]interface{})
 func resolveLOCType(rData, newRData []string, fieldMap map[string]interface{}) {
 	for _, i := range rData {
 		str := i
-		str = padCoordinates(str)
+		str = PadCoordinates(str)
 		newRData = append(newRData, str)
 	}
 	fieldMap["target"] = newRData
@@ -526,6 +973,7 @@ func resolveHTTPSType(rData []string, fieldMap map[string]interface{}) {
 		fieldMap["target_name"] = parts[1]
 		if len(parts) > 2 {
 			fieldMap["svc_params"] = parts[2]
+			resolveSvcParamValues(rContent, fieldMap)
 		}
 		break
 	}
@@ -2,7 +2,10 @@ package dns
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -90,11 +93,232 @@ func TestDNS_GetRecord(t *testing.T) {
 	}
 }
 
+func TestDNS_GetRecordIfModified(t *testing.T) {
+	tests := map[string]struct {
+		etag                string
+		responseStatus      int
+		responseHeader      http.Header
+		responseBody        string
+		expectedIfNoneMatch string
+		expectedResponse    *RecordBody
+		expectedETag        string
+		withError           error
+	}{
+		"200 OK without etag": {
+			responseStatus: http.StatusOK,
+			responseHeader: http.Header{"ETag": {`"v1"`}},
+			responseBody:   `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2"]}`,
+			expectedResponse: &RecordBody{
+				Name:       "www.example.com",
+				RecordType: "A",
+				TTL:        300,
+				Target:     []string{"10.0.0.2"},
+			},
+			expectedETag: `"v1"`,
+		},
+		"200 OK modified": {
+			etag:                `"v1"`,
+			responseStatus:      http.StatusOK,
+			responseHeader:      http.Header{"ETag": {`"v2"`}},
+			responseBody:        `{"name": "www.example.com", "type": "A", "ttl": 600, "rdata": ["10.0.0.2"]}`,
+			expectedIfNoneMatch: `"v1"`,
+			expectedResponse: &RecordBody{
+				Name:       "www.example.com",
+				RecordType: "A",
+				TTL:        600,
+				Target:     []string{"10.0.0.2"},
+			},
+			expectedETag: `"v2"`,
+		},
+		"304 not modified": {
+			etag:                `"v1"`,
+			responseStatus:      http.StatusNotModified,
+			expectedIfNoneMatch: `"v1"`,
+			expectedETag:        `"v1"`,
+			withError:           ErrNotModified,
+		},
+		"404 not found": {
+			etag:                `"v1"`,
+			responseStatus:      http.StatusNotFound,
+			responseBody:        `{"type": "not_found", "title": "Not Found", "status": 404}`,
+			expectedIfNoneMatch: `"v1"`,
+			withError:           ErrRecordNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/names/www.example.com/types/A", r.URL.String())
+				assert.Equal(t, test.expectedIfNoneMatch, r.Header.Get("If-None-Match"))
+				for k, v := range test.responseHeader {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, etag, err := client.GetRecordIfModified(context.Background(), "example.com", "www.example.com", "A", test.etag)
+			assert.Equal(t, test.expectedETag, etag)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDNS_RecordExists(t *testing.T) {
+	const path = "/config-dns/v2/zones/example.com/names/www.example.com/types/A"
+	tests := map[string]struct {
+		responseStatus  map[string]int
+		expectedMethods []string
+		expectedResult  bool
+		withStatusCode  int
+	}{
+		"200 OK": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusOK},
+			expectedMethods: []string{http.MethodHead},
+			expectedResult:  true,
+		},
+		"404 Not Found": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusNotFound},
+			expectedMethods: []string{http.MethodHead},
+			expectedResult:  false,
+		},
+		"500 internal server error": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusInternalServerError},
+			expectedMethods: []string{http.MethodHead},
+			withStatusCode:  http.StatusInternalServerError,
+		},
+		"403 forbidden": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusForbidden},
+			expectedMethods: []string{http.MethodHead},
+			withStatusCode:  http.StatusForbidden,
+		},
+		"HEAD not allowed, 200 OK": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusMethodNotAllowed, http.MethodGet: http.StatusOK},
+			expectedMethods: []string{http.MethodHead, http.MethodGet},
+			expectedResult:  true,
+		},
+		"HEAD not allowed, 404 Not Found": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusMethodNotAllowed, http.MethodGet: http.StatusNotFound},
+			expectedMethods: []string{http.MethodHead, http.MethodGet},
+			expectedResult:  false,
+		},
+		"HEAD not allowed, 500 internal server error": {
+			responseStatus:  map[string]int{http.MethodHead: http.StatusMethodNotAllowed, http.MethodGet: http.StatusInternalServerError},
+			expectedMethods: []string{http.MethodHead, http.MethodGet},
+			withStatusCode:  http.StatusInternalServerError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, path, r.URL.String())
+				methods = append(methods, r.Method)
+				w.WriteHeader(test.responseStatus[r.Method])
+				if r.Method == http.MethodGet {
+					_, err := w.Write([]byte(`{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2"]}`))
+					assert.NoError(t, err)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.RecordExists(context.Background(), "example.com", "www.example.com", "A")
+			assert.Equal(t, test.expectedMethods, methods)
+			if test.withStatusCode != 0 {
+				var apiErr *Error
+				require.True(t, errors.As(err, &apiErr), "want: *Error; got: %s", err)
+				assert.Equal(t, test.withStatusCode, apiErr.StatusCode)
+				assert.False(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestDNS_GetRecordParsed(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedResponse *RecordBody
+		expectedParsed   map[string]interface{}
+		withError        error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody: `
+			{
+				"name": "_sip._tcp.example.com",
+				"type": "SRV",
+				"ttl": 300,
+				"rdata": [
+					"10 60 5060 big.example.com.",
+					"10 60 5060 small.example.com."
+				]
+			}`,
+			expectedResponse: &RecordBody{
+				Name:       "_sip._tcp.example.com",
+				RecordType: "SRV",
+				TTL:        300,
+				Target:     []string{"10 60 5060 big.example.com.", "10 60 5060 small.example.com."},
+			},
+			expectedParsed: map[string]interface{}{
+				"port":     5060,
+				"priority": 10,
+				"weight":   60,
+				"target":   []string{"big.example.com.", "small.example.com."},
+			},
+		},
+		"404 not found": {
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+	"title": "Not Found",
+	"detail": "RecordSet _sip._tcp.example.com SRV not found",
+	"status": 404
+}`,
+			withError: ErrRecordNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/names/_sip._tcp.example.com/types/SRV", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			record, parsed, err := client.GetRecordParsed(context.Background(), "example.com", "_sip._tcp.example.com", "SRV")
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, record)
+			assert.Equal(t, test.expectedParsed, parsed)
+		})
+	}
+}
+
 func TestDNS_GetRecordList(t *testing.T) {
 	tests := map[string]struct {
 		zone             string
 		name             string
 		recordType       string
+		queryArgs        []RecordListQueryArgs
 		responseStatus   int
 		responseBody     string
 		expectedPath     string
@@ -111,7 +335,7 @@ func TestDNS_GetRecordList(t *testing.T) {
         "zone": "example.com",
         "page": 1,
         "pageSize": 25,
-        "totalElements": 2,
+        "totalElements": 1,
         "types": [
             "A"
         ]
@@ -135,7 +359,7 @@ func TestDNS_GetRecordList(t *testing.T) {
 					Page:          1,
 					PageSize:      25,
 					ShowAll:       false,
-					TotalElements: 2,
+					TotalElements: 1,
 				},
 				RecordSets: []RecordSet{
 					{
@@ -147,6 +371,137 @@ func TestDNS_GetRecordList(t *testing.T) {
 				},
 			},
 		},
+		"200 OK, no type filter": {
+			zone:           "example.com",
+			name:           "www.example.com",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"metadata": {
+        "page": 1,
+        "pageSize": 25,
+        "totalElements": 1
+    },
+    "recordsets": [
+        {
+            "name": "www.example.com",
+            "type": "TXT",
+            "ttl": 300,
+            "rdata": [
+                "\"some text\""
+            ]
+        }
+    ]
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true",
+			expectedResponse: &RecordSetResponse{
+				Metadata: Metadata{
+					Page:          1,
+					PageSize:      25,
+					TotalElements: 1,
+				},
+				RecordSets: []RecordSet{
+					{
+						Name:  "www.example.com",
+						Type:  "TXT",
+						TTL:   300,
+						Rdata: []string{"\"some text\""},
+					},
+				},
+			},
+		},
+		"200 OK, other names matching the search excluded": {
+			zone:           "example.com",
+			name:           "www.example.com.",
+			recordType:     "A",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"metadata": {
+        "showAll": true,
+        "totalElements": 3
+    },
+    "recordsets": [
+        {"name": "foo.www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.1"]},
+        {"name": "WWW.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2"]},
+        {"name": "www.example.com.au.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.3"]}
+    ]
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/recordsets?search=www.example.com.&showAll=true&types=A",
+			expectedResponse: &RecordSetResponse{
+				Metadata: Metadata{
+					ShowAll:       true,
+					TotalElements: 1,
+				},
+				RecordSets: []RecordSet{
+					{
+						Name:  "WWW.example.com",
+						Type:  "A",
+						TTL:   300,
+						Rdata: []string{"10.0.0.2"},
+					},
+				},
+			},
+		},
+		"200 OK, multiple types": {
+			zone:           "example.com",
+			name:           "www.example.com",
+			recordType:     "a, aaaa",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"metadata": {
+        "page": 1,
+        "pageSize": 25,
+        "totalElements": 2
+    },
+    "recordsets": [
+        {
+            "name": "www.example.com",
+            "type": "A",
+            "ttl": 300,
+            "rdata": [
+                "10.0.0.2"
+            ]
+        },
+        {
+            "name": "www.example.com",
+            "type": "AAAA",
+            "ttl": 300,
+            "rdata": [
+                "2001:db8::1"
+            ]
+        }
+    ]
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=A%2CAAAA",
+			expectedResponse: &RecordSetResponse{
+				Metadata: Metadata{
+					Page:          1,
+					PageSize:      25,
+					TotalElements: 2,
+				},
+				RecordSets: []RecordSet{
+					{
+						Name:  "www.example.com",
+						Type:  "A",
+						TTL:   300,
+						Rdata: []string{"10.0.0.2"},
+					},
+					{
+						Name:  "www.example.com",
+						Type:  "AAAA",
+						TTL:   300,
+						Rdata: []string{"2001:db8::1"},
+					},
+				},
+			},
+		},
+		"unsupported type": {
+			zone:       "example.com",
+			recordType: "A,AAA",
+			withError:  ErrUnsupportedRecordType,
+		},
 		"500 internal server error": {
 			zone:           "example.com",
 			recordType:     "A",
@@ -166,6 +521,64 @@ func TestDNS_GetRecordList(t *testing.T) {
 				StatusCode: http.StatusInternalServerError,
 			},
 		},
+		"200 OK, sorted and paged search": {
+			zone:       "example.com",
+			recordType: "A",
+			queryArgs: []RecordListQueryArgs{{
+				SortBy:   "-name, type",
+				Search:   "www",
+				Page:     2,
+				PageSize: 10,
+			}},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"metadata": {
+		"zone": "example.com",
+		"page": 2,
+		"pageSize": 10,
+		"lastPage": 3,
+		"totalElements": 21
+	},
+	"recordsets": [
+		{
+			"name": "www.example.com",
+			"type": "A",
+			"ttl": 300,
+			"rdata": ["10.0.0.2"]
+		}
+	]
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/recordsets?page=2&pageSize=10&search=www&sortBy=-name%2Ctype&types=A",
+			expectedResponse: &RecordSetResponse{
+				Metadata: Metadata{
+					LastPage:      3,
+					Page:          2,
+					PageSize:      10,
+					TotalElements: 21,
+					SortBy:        "-name,type",
+				},
+				RecordSets: []RecordSet{
+					{
+						Name:  "www.example.com",
+						Type:  "A",
+						TTL:   300,
+						Rdata: []string{"10.0.0.2"},
+					},
+				},
+			},
+		},
+		"invalid sortBy field": {
+			zone:      "example.com",
+			queryArgs: []RecordListQueryArgs{{SortBy: "ttl"}},
+			withError: ErrBadRequest,
+		},
+		"name and search together": {
+			zone:      "example.com",
+			name:      "www.example.com",
+			queryArgs: []RecordListQueryArgs{{Search: "www"}},
+			withError: ErrBadRequest,
+		},
 	}
 
 	for name, test := range tests {
@@ -178,7 +591,7 @@ func TestDNS_GetRecordList(t *testing.T) {
 				assert.NoError(t, err)
 			}))
 			client := mockAPIClient(t, mockServer)
-			result, err := client.GetRecordList(context.Background(), test.zone, test.name, test.recordType)
+			result, err := client.GetRecordList(context.Background(), test.zone, test.name, test.recordType, test.queryArgs...)
 			if test.withError != nil {
 				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
 				return
@@ -189,6 +602,95 @@ func TestDNS_GetRecordList(t *testing.T) {
 	}
 }
 
+func TestDNS_GetRecordListTruncated(t *testing.T) {
+	recordSet := func(name string) string {
+		return `{"name": "` + name + `", "type": "A", "ttl": 300, "rdata": ["10.0.0.1"]}`
+	}
+	tests := map[string]struct {
+		queryArgs        []RecordListQueryArgs
+		responses        map[string]string
+		expectedRequests []string
+		expectedNames    []string
+		withError        error
+	}{
+		"truncated list completed with all pages": {
+			responses: map[string]string{
+				"showAll=true&types=A": `{
+	"metadata": {"showAll": true, "totalElements": 3},
+	"recordsets": [` + recordSet("a.example.com") + `, ` + recordSet("b.example.com") + `]
+}`,
+				"page=1&pageSize=500&types=A": `{
+	"metadata": {"page": 1, "pageSize": 2, "lastPage": 2, "totalElements": 3},
+	"recordsets": [` + recordSet("a.example.com") + `, ` + recordSet("b.example.com") + `]
+}`,
+				"page=2&pageSize=500&types=A": `{
+	"metadata": {"page": 2, "pageSize": 2, "lastPage": 2, "totalElements": 3},
+	"recordsets": [` + recordSet("c.example.com") + `]
+}`,
+			},
+			expectedRequests: []string{"showAll=true&types=A", "page=1&pageSize=500&types=A", "page=2&pageSize=500&types=A"},
+			expectedNames:    []string{"a.example.com", "b.example.com", "c.example.com"},
+		},
+		"pages still truncated": {
+			responses: map[string]string{
+				"showAll=true&types=A": `{
+	"metadata": {"showAll": true, "totalElements": 3},
+	"recordsets": [` + recordSet("a.example.com") + `]
+}`,
+				"page=1&pageSize=500&types=A": `{
+	"metadata": {"page": 1, "pageSize": 500, "lastPage": 1, "totalElements": 3},
+	"recordsets": [` + recordSet("a.example.com") + `]
+}`,
+			},
+			expectedRequests: []string{"showAll=true&types=A", "page=1&pageSize=500&types=A"},
+			withError:        ErrResultTruncated,
+		},
+		"single page requested": {
+			queryArgs: []RecordListQueryArgs{{Page: 1, PageSize: 1}},
+			responses: map[string]string{
+				"page=1&pageSize=1&types=A": `{
+	"metadata": {"page": 1, "pageSize": 1, "lastPage": 3, "totalElements": 3},
+	"recordsets": [` + recordSet("a.example.com") + `]
+}`,
+			},
+			expectedRequests: []string{"page=1&pageSize=1&types=A"},
+			expectedNames:    []string{"a.example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/recordsets", r.URL.Path)
+				requests = append(requests, r.URL.RawQuery)
+				body, ok := test.responses[r.URL.RawQuery]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetRecordList(context.Background(), "example.com", "", "A", test.queryArgs...)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, rs := range result.RecordSets {
+				names = append(names, rs.Name)
+			}
+			assert.Equal(t, test.expectedNames, names)
+			assert.Equal(t, 3, result.Metadata.TotalElements)
+		})
+	}
+}
+
 func TestDNS_GetRdata(t *testing.T) {
 	tests := map[string]struct {
 		zone             string
@@ -227,7 +729,7 @@ func TestDNS_GetRdata(t *testing.T) {
 		}
     ]
 }`,
-			expectedPath:     "/config-dns/v2/zones/example.com/recordsets?showAll=true&types=AAAA",
+			expectedPath:     "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=AAAA",
 			expectedResponse: []string{"2001:0db8:85a3:0000:0000:8a2e:0370:7334"},
 		},
 		"loc test": {
@@ -257,9 +759,72 @@ func TestDNS_GetRdata(t *testing.T) {
         }
     ]
 }`,
-			expectedPath:     "/config-dns/v2/zones/example.com/recordsets?showAll=true&types=LOC",
+			expectedPath:     "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=LOC",
 			expectedResponse: []string{"52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m"},
 		},
+		"200 OK, empty rdata": {
+			zone:           "example.com",
+			name:           "www.example.com",
+			recordType:     "TXT",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"metadata": {
+        "page": 1,
+        "pageSize": 25,
+        "totalElements": 1
+    },
+    "recordsets": [
+        {
+            "name": "www.example.com",
+            "type": "TXT",
+            "ttl": 300,
+            "rdata": []
+        }
+    ]
+}`,
+			expectedPath:     "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=TXT",
+			expectedResponse: []string{},
+		},
+		"200 OK, record not found": {
+			zone:           "example.com",
+			name:           "www.example.com",
+			recordType:     "TXT",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"metadata": {
+        "page": 1,
+        "pageSize": 25,
+        "totalElements": 1
+    },
+    "recordsets": [
+        {
+            "name": "www2.www.example.com",
+            "type": "TXT",
+            "ttl": 300,
+            "rdata": ["\"text\""]
+        }
+    ]
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=TXT",
+			withError:    ErrRecordNotFound,
+		},
+		"404 not found": {
+			zone:           "example.com",
+			name:           "www.example.com",
+			recordType:     "TXT",
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+    "title": "Not Found",
+    "detail": "The requested zone does not exist",
+    "status": 404
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=TXT",
+			withError:    ErrRecordNotFound,
+		},
 		"500 internal server error": {
 			zone:           "example.com",
 			recordType:     "A",
@@ -314,6 +879,93 @@ func TestDNS_TestRdata(t *testing.T) {
 	assert.Equal(t, []string{"52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m"}, out)
 }
 
+func TestFullIPv6(t *testing.T) {
+	tests := map[string]struct {
+		ip       net.IP
+		expected string
+	}{
+		"compressed IPv6": {
+			ip:       net.ParseIP("2001:db8::1"),
+			expected: "2001:0db8:0000:0000:0000:0000:0000:0001",
+		},
+		"expanded IPv6": {
+			ip:       net.ParseIP("2001:0db8:85a3:0000:0000:8a2e:0370:7334"),
+			expected: "2001:0db8:85a3:0000:0000:8a2e:0370:7334",
+		},
+		"unspecified address": {
+			ip:       net.IPv6zero,
+			expected: "0000:0000:0000:0000:0000:0000:0000:0000",
+		},
+		"IPv4 address": {
+			ip:       net.ParseIP("10.0.0.1"),
+			expected: "0000:0000:0000:0000:0000:ffff:0a00:0001",
+		},
+		"4-byte IPv4 address": {
+			ip:       net.ParseIP("10.0.0.1").To4(),
+			expected: "0000:0000:0000:0000:0000:ffff:0a00:0001",
+		},
+		"invalid address": {
+			ip:       net.ParseIP("not an address"),
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FullIPv6(test.ip))
+		})
+	}
+}
+
+func TestPadCoordinates(t *testing.T) {
+	tests := map[string]struct {
+		rdata    string
+		expected string
+	}{
+		"integer values": {
+			rdata:    "52 22 23.000 N 4 53 32.000 E -2m 0m 10000m 10m",
+			expected: "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m",
+		},
+		"already padded": {
+			rdata:    "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m",
+			expected: "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m",
+		},
+		"values without unit": {
+			rdata:    "51 30 12.748 N 0 7 39.611 W 0.5 1 2 3",
+			expected: "51 30 12.748 N 0 7 39.611 W 0.50m 1.00m 2.00m 3.00m",
+		},
+		"missing fields": {
+			rdata:    "52 22 23.000 N 4 53 32.000 E -2m",
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PadCoordinates(test.rdata))
+		})
+	}
+}
+
+func TestDNS_ProcessRdataGolden(t *testing.T) {
+	client := Client(session.Must(session.New()))
+
+	data, err := ioutil.ReadFile("testdata/TestDNS_ProcessRdata.golden.json")
+	require.NoError(t, err)
+	var golden map[string]struct {
+		Input []string `json:"input"`
+		API   []string `json:"api"`
+	}
+	require.NoError(t, json.Unmarshal(data, &golden))
+
+	for recordType, test := range golden {
+		t.Run(recordType, func(t *testing.T) {
+			assert.Equal(t, test.API, client.ProcessRdata(context.Background(), test.Input, recordType))
+			assert.Equal(t, test.API, client.ProcessRdata(context.Background(), test.API, recordType))
+		})
+	}
+}
+
 func TestDNS_ParseRData(t *testing.T) {
 	client := Client(session.Must(session.New()))
 
@@ -347,6 +999,94 @@ func TestDNS_ParseRData(t *testing.T) {
 				"svc_priority": 3,
 				"target_name":  "https.example.com.",
 				"svc_params":   "alpn=bar port=8080",
+				"svc_param_values": map[string][]string{
+					"alpn": {"bar"},
+					"port": {"8080"},
+				},
+			},
+		},
+		"HTTPS with list params": {
+			rType: "HTTPS",
+			rdata: []string{"1 . alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2 no-default-alpn"},
+			expect: map[string]interface{}{
+				"target":       []string{},
+				"svc_priority": 1,
+				"target_name":  ".",
+				"svc_params":   "alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2 no-default-alpn",
+				"svc_param_values": map[string][]string{
+					"alpn":            {"h2", "h3"},
+					"ipv4hint":        {"192.0.2.1", "192.0.2.2"},
+					"no-default-alpn": nil,
+				},
+			},
+		},
+		"SVCB with invalid params": {
+			rType: "SVCB",
+			rdata: []string{"1 svc.example.com. port=http"},
+			expect: map[string]interface{}{
+				"target":       []string{},
+				"svc_priority": 1,
+				"target_name":  "svc.example.com.",
+				"svc_params":   "port=http",
+			},
+		},
+		"DNSKEY with whitespace in key": {
+			rType: "DNSKEY",
+			rdata: []string{"257 3 8 AwEAAaet idLzsKWU t4swWR8y"},
+			expect: map[string]interface{}{
+				"target":    []string{},
+				"flags":     257,
+				"protocol":  3,
+				"algorithm": 8,
+				"key":       "AwEAAaet idLzsKWU t4swWR8y",
+			},
+		},
+		"DNSKEY with too few fields": {
+			rType: "DNSKEY",
+			rdata: []string{"257 3 8"},
+			expect: map[string]interface{}{
+				"target": []string{},
+			},
+		},
+		"DS with whitespace in digest": {
+			rType: "DS",
+			rdata: []string{"30336 8 2 0BB5D4D8 DE6A6AB2 E9DB8F5A"},
+			expect: map[string]interface{}{
+				"target":      []string{},
+				"keytag":      30336,
+				"algorithm":   8,
+				"digest_type": 2,
+				"digest":      "0BB5D4D8 DE6A6AB2 E9DB8F5A",
+			},
+		},
+		"DS with too few fields": {
+			rType: "DS",
+			rdata: []string{"30336 8"},
+			expect: map[string]interface{}{
+				"target": []string{},
+			},
+		},
+		"RRSIG with whitespace in signature": {
+			rType: "RRSIG",
+			rdata: []string{"A 8 2 300 20240101000000 20231201000000 30336 example.com. oJB1W6WN Gv+ldvQ3 WDG0MQkg"},
+			expect: map[string]interface{}{
+				"target":       []string{},
+				"type_covered": "A",
+				"algorithm":    8,
+				"labels":       2,
+				"original_ttl": 300,
+				"expiration":   "20240101000000",
+				"inception":    "20231201000000",
+				"keytag":       30336,
+				"signer":       "example.com.",
+				"signature":    "oJB1W6WN Gv+ldvQ3 WDG0MQkg",
+			},
+		},
+		"RRSIG with too few fields": {
+			rType: "RRSIG",
+			rdata: []string{"A 8 2 300"},
+			expect: map[string]interface{}{
+				"target": []string{},
 			},
 		},
 		"SRV with default values": {
@@ -376,3 +1116,248 @@ func TestDNS_ParseRData(t *testing.T) {
 		})
 	}
 }
+
+func TestDNS_RecordToMap(t *testing.T) {
+	client := Client(session.Must(session.New()))
+
+	tests := map[string]struct {
+		record    *RecordBody
+		expect    map[string]interface{}
+		withError error
+	}{
+		"valid SOA record": {
+			record: &RecordBody{
+				Name:       "example.com",
+				RecordType: "SOA",
+				TTL:        86400,
+				Active:     true,
+				Target:     []string{"a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"},
+			},
+			expect: map[string]interface{}{
+				"name":          "example.com",
+				"recordtype":    "SOA",
+				"ttl":           86400,
+				"active":        true,
+				"target":        []string{"a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"},
+				"name_server":   "a1-1.akam.net.",
+				"email_address": "hostmaster.example.com.",
+				"serial":        2024010101,
+				"refresh":       3600,
+				"retry":         600,
+				"expiry":        604800,
+				"nxdomain_ttl":  300,
+			},
+		},
+		"valid AFSDB record": {
+			record: &RecordBody{
+				Name:       "example.com",
+				RecordType: "AFSDB",
+				TTL:        300,
+				Target:     []string{"1 bar.com."},
+			},
+			expect: map[string]interface{}{
+				"name":       "example.com",
+				"recordtype": "AFSDB",
+				"ttl":        300,
+				"active":     false,
+				"target":     []string{"1 bar.com."},
+				"subtype":    1,
+			},
+		},
+		"invalid record": {
+			record: &RecordBody{
+				Name:       "example.com",
+				RecordType: "SOA",
+				TTL:        86400,
+				Target:     []string{"a1-1.akam.net."},
+			},
+			expect: map[string]interface{}{
+				"name":       "example.com",
+				"recordtype": "SOA",
+				"ttl":        86400,
+				"active":     false,
+				"target":     []string{"a1-1.akam.net."},
+			},
+			withError: ErrStructValidation,
+		},
+		"missing record": {
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := client.RecordToMap(context.Background(), test.record)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expect, out)
+		})
+	}
+}
+
+func TestDNS_NewPTRRecord(t *testing.T) {
+	client := Client(session.Must(session.New()))
+
+	tests := map[string]struct {
+		ip             net.IP
+		target         string
+		ttl            int
+		expectedRecord *RecordBody
+		expectedZone   string
+		withError      error
+	}{
+		"IPv4 address": {
+			ip:     net.ParseIP("192.0.2.10"),
+			target: "host.example.com",
+			ttl:    300,
+			expectedRecord: &RecordBody{
+				Name:       "10.2.0.192.in-addr.arpa",
+				RecordType: "PTR",
+				TTL:        300,
+				Target:     []string{"host.example.com."},
+			},
+			expectedZone: "2.0.192.in-addr.arpa",
+		},
+		"IPv6 address": {
+			ip:     net.ParseIP("2001:db8::567:89ab"),
+			target: "host.example.com.",
+			ttl:    600,
+			expectedRecord: &RecordBody{
+				Name:       "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+				RecordType: "PTR",
+				TTL:        600,
+				Target:     []string{"host.example.com."},
+			},
+			expectedZone: "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		"invalid IP address": {
+			ip:        net.IP{1, 2, 3},
+			target:    "host.example.com",
+			ttl:       300,
+			withError: ErrBadRequest,
+		},
+		"missing target": {
+			ip:        net.ParseIP("192.0.2.10"),
+			ttl:       300,
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			record, zone, err := client.NewPTRRecord(context.Background(), test.ip, test.target, test.ttl)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRecord, record)
+			assert.Equal(t, test.expectedZone, zone)
+		})
+	}
+}
+
+func TestDNS_DerivePTRRecords(t *testing.T) {
+	client := Client(session.Must(session.New()))
+	forward := []*RecordBody{
+		{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.10", "198.51.100.7"}},
+		{Name: "api.example.com", RecordType: "A", TTL: 600, Target: []string{"192.0.2.10"}},
+		{Name: "mail.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.2"}},
+		{Name: "www.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+		{Name: "*.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.99"}},
+		{Name: "example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mail.example.com."}},
+	}
+	ipv6Name := "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"
+	ipv6Zone := "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"
+
+	tests := map[string]struct {
+		records          []*RecordBody
+		opts             []PTROptions
+		expectedResponse map[string][]*RecordBody
+		withError        error
+	}{
+		"conflicting addresses are skipped by default": {
+			records: forward,
+			expectedResponse: map[string][]*RecordBody{
+				"2.0.192.in-addr.arpa": {
+					{Name: "2.2.0.192.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"mail.example.com."}},
+				},
+				"100.51.198.in-addr.arpa": {
+					{Name: "7.100.51.198.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+				},
+				ipv6Zone: {
+					{Name: ipv6Name, RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+				},
+			},
+		},
+		"first name of conflicting addresses": {
+			records: forward,
+			opts:    []PTROptions{{Conflicts: PTRConflictFirst}},
+			expectedResponse: map[string][]*RecordBody{
+				"2.0.192.in-addr.arpa": {
+					{Name: "10.2.0.192.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+					{Name: "2.2.0.192.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"mail.example.com."}},
+				},
+				"100.51.198.in-addr.arpa": {
+					{Name: "7.100.51.198.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+				},
+				ipv6Zone: {
+					{Name: ipv6Name, RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+				},
+			},
+		},
+		"all names of conflicting addresses": {
+			records: forward[:2],
+			opts:    []PTROptions{{Conflicts: PTRConflictAll}},
+			expectedResponse: map[string][]*RecordBody{
+				"2.0.192.in-addr.arpa": {
+					{Name: "10.2.0.192.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"www.example.com.", "api.example.com."}},
+				},
+				"100.51.198.in-addr.arpa": {
+					{Name: "7.100.51.198.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+				},
+			},
+		},
+		"duplicate forward records are not conflicts": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.10"}},
+				{Name: "WWW.example.com", RecordType: "a", TTL: 300, Target: []string{"192.0.2.10"}},
+			},
+			expectedResponse: map[string][]*RecordBody{
+				"2.0.192.in-addr.arpa": {
+					{Name: "10.2.0.192.in-addr.arpa", RecordType: "PTR", TTL: 300, Target: []string{"www.example.com."}},
+				},
+			},
+		},
+		"no address records": {
+			records:          forward[5:],
+			expectedResponse: map[string][]*RecordBody{},
+		},
+		"IPv6 address in A record": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"2001:db8::1"}},
+			},
+			withError: ErrBadRequest,
+		},
+		"invalid conflict policy": {
+			records:   forward,
+			opts:      []PTROptions{{Conflicts: "random"}},
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := client.DerivePTRRecords(context.Background(), test.records, test.opts...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
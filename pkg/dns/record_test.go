@@ -3,9 +3,14 @@ package dns
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -62,6 +67,66 @@ func TestDNS_CreateRecord(t *testing.T) {
 				StatusCode: http.StatusInternalServerError,
 			},
 		},
+		"409 conflict": {
+			body: RecordBody{
+				Name:       "www.example.com",
+				RecordType: "A",
+				TTL:        300,
+				Target:     []string{"10.0.0.2", "10.0.0.3"},
+			},
+			responseStatus: http.StatusConflict,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/conflict",
+	"title": "Conflict",
+	"detail": "RecordSet www.example.com A already exists",
+	"status": 409
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/names/www.example.com/types/A",
+			withError:    ErrRecordAlreadyExists,
+		},
+		"409 conflict wraps API error": {
+			body: RecordBody{
+				Name:       "www.example.com",
+				RecordType: "A",
+				TTL:        300,
+				Target:     []string{"10.0.0.2", "10.0.0.3"},
+			},
+			responseStatus: http.StatusConflict,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/conflict",
+	"title": "Conflict",
+	"detail": "RecordSet www.example.com A already exists",
+	"status": 409
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/names/www.example.com/types/A",
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/authoritative-dns/conflict",
+				Title:      "Conflict",
+				Detail:     "RecordSet www.example.com A already exists",
+				StatusCode: http.StatusConflict,
+			},
+		},
+		"CNAME at zone apex": {
+			body: RecordBody{
+				Name:       "example.com",
+				RecordType: "CNAME",
+				TTL:        300,
+				Target:     []string{"www.example.net."},
+			},
+			withError: ErrInvalidCNAME,
+		},
+		"CNAME below zone apex": {
+			body: RecordBody{
+				Name:       "www.example.com",
+				RecordType: "CNAME",
+				TTL:        300,
+				Target:     []string{"www.example.net."},
+			},
+			responseStatus: http.StatusCreated,
+			expectedPath:   "/config-dns/v2/zones/example.com/names/www.example.com/types/CNAME",
+		},
 	}
 
 	for name, test := range tests {
@@ -85,6 +150,229 @@ func TestDNS_CreateRecord(t *testing.T) {
 	}
 }
 
+func TestDNS_CreateRecordReconciliation(t *testing.T) {
+	const recordPath = "/config-dns/v2/zones/example.com/names/www.example.com/types/A"
+	conflict := `{"type": "https://problems.luna.akamaiapis.net/authoritative-dns/conflict", "title": "Conflict", "status": 409}`
+	record := RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.3", "10.0.0.2"}}
+
+	tests := map[string]struct {
+		reconcile        bool
+		inheritTTL       bool
+		existingStatus   int
+		existingBody     string
+		expectedRequests []string
+		withError        error
+	}{
+		"matching record set": {
+			reconcile:        true,
+			existingStatus:   http.StatusOK,
+			existingBody:     `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2", "10.0.0.3"]}`,
+			expectedRequests: []string{"POST " + recordPath, "GET " + recordPath},
+		},
+		"different rdata": {
+			reconcile:        true,
+			existingStatus:   http.StatusOK,
+			existingBody:     `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2"]}`,
+			expectedRequests: []string{"POST " + recordPath, "GET " + recordPath},
+			withError:        ErrRecordAlreadyExists,
+		},
+		"different TTL": {
+			reconcile:        true,
+			existingStatus:   http.StatusOK,
+			existingBody:     `{"name": "www.example.com", "type": "A", "ttl": 600, "rdata": ["10.0.0.2", "10.0.0.3"]}`,
+			expectedRequests: []string{"POST " + recordPath, "GET " + recordPath},
+			withError:        ErrRecordAlreadyExists,
+		},
+		"inherited TTL matches any TTL": {
+			reconcile:        true,
+			inheritTTL:       true,
+			existingStatus:   http.StatusOK,
+			existingBody:     `{"name": "www.example.com", "type": "A", "ttl": 600, "rdata": ["10.0.0.2", "10.0.0.3"]}`,
+			expectedRequests: []string{"POST " + recordPath, "GET " + recordPath},
+		},
+		"record set gone": {
+			reconcile:        true,
+			existingStatus:   http.StatusNotFound,
+			existingBody:     `{"title": "Not Found", "status": 404}`,
+			expectedRequests: []string{"POST " + recordPath, "GET " + recordPath},
+			withError:        ErrRecordAlreadyExists,
+		},
+		"reconciliation disabled": {
+			expectedRequests: []string{"POST " + recordPath},
+			withError:        ErrRecordAlreadyExists,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusConflict)
+					_, err := w.Write([]byte(conflict))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(test.existingStatus)
+				_, err := w.Write([]byte(test.existingBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, WithCreateReconciliation(test.reconcile))
+			record := record
+			if test.inheritTTL {
+				record.TTL = TTLInheritZone
+			}
+			err := client.CreateRecord(context.Background(), &record, "example.com")
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckApexCNAME(t *testing.T) {
+	tests := map[string]struct {
+		zone      string
+		record    *RecordBody
+		withError error
+	}{
+		"CNAME at zone apex": {
+			zone:      "example.com",
+			record:    &RecordBody{Name: "example.com", RecordType: "CNAME"},
+			withError: ErrInvalidCNAME,
+		},
+		"CNAME at zone apex, different case and trailing dot": {
+			zone:      "Example.com.",
+			record:    &RecordBody{Name: "example.COM", RecordType: "cname"},
+			withError: ErrInvalidCNAME,
+		},
+		"CNAME below zone apex": {
+			zone:   "example.com",
+			record: &RecordBody{Name: "www.example.com", RecordType: "CNAME"},
+		},
+		"A record at zone apex": {
+			zone:   "example.com",
+			record: &RecordBody{Name: "example.com", RecordType: "A"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckApexCNAME(test.zone, test.record)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Contains(t, err.Error(), "AKAMAICDN")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDNS_CreateOrUpdateRecord(t *testing.T) {
+	tests := map[string]struct {
+		createStatus    int
+		createBody      string
+		updateStatus    int
+		updateBody      string
+		expectedMethods []string
+		withError       error
+	}{
+		"201 created": {
+			createStatus:    http.StatusCreated,
+			expectedMethods: []string{http.MethodPost},
+		},
+		"409 conflict, falls back to update": {
+			createStatus: http.StatusConflict,
+			createBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/conflict",
+	"title": "Conflict",
+	"detail": "RecordSet www.example.com A already exists",
+	"status": 409
+}`,
+			updateStatus:    http.StatusOK,
+			expectedMethods: []string{http.MethodPost, http.MethodPut},
+		},
+		"409 conflict, update fails": {
+			createStatus: http.StatusConflict,
+			createBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/conflict",
+	"title": "Conflict",
+	"detail": "RecordSet www.example.com A already exists",
+	"status": 409
+}`,
+			updateStatus: http.StatusInternalServerError,
+			updateBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error updating recordset",
+	"status": 500
+}`,
+			expectedMethods: []string{http.MethodPost, http.MethodPut},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error updating recordset",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"500 internal server error, no update": {
+			createStatus: http.StatusInternalServerError,
+			createBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error creating recordset",
+	"status": 500
+}`,
+			expectedMethods: []string{http.MethodPost},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating recordset",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/names/www.example.com/types/A", r.URL.String())
+				methods = append(methods, r.Method)
+				status, body := test.createStatus, test.createBody
+				if r.Method == http.MethodPut {
+					status, body = test.updateStatus, test.updateBody
+				}
+				w.WriteHeader(status)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.CreateOrUpdateRecord(context.Background(), &RecordBody{
+				Name:       "www.example.com",
+				RecordType: "A",
+				TTL:        300,
+				Target:     []string{"10.0.0.2", "10.0.0.3"},
+			}, "example.com")
+			assert.Equal(t, test.expectedMethods, methods)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestDNS_UpdateRecord(t *testing.T) {
 	tests := map[string]struct {
 		body           RecordBody
@@ -223,3 +511,539 @@ func TestDNS_DeleteRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestDNS_WriteSerialization(t *testing.T) {
+	tests := map[string]struct {
+		options             []Option
+		zones               []string
+		recLock             []bool
+		expectedMaxInFlight int32
+	}{
+		"None issues concurrent writes": {
+			options:             []Option{WithWriteSerialization(None)},
+			zones:               []string{"example.com", "example.com"},
+			expectedMaxInFlight: 2,
+		},
+		"PerZone serializes writes to the same zone": {
+			zones:               []string{"example.com", "example.com"},
+			expectedMaxInFlight: 1,
+		},
+		"PerZone issues concurrent writes to different zones": {
+			zones:               []string{"example.com", "example.net"},
+			expectedMaxInFlight: 2,
+		},
+		"PerZone serializes writes to differently spelled names of the same zone": {
+			zones:               []string{"example.com", "example.com.", "Example.com"},
+			expectedMaxInFlight: 1,
+		},
+		"Global serializes writes to different zones": {
+			options:             []Option{WithWriteSerialization(Global)},
+			zones:               []string{"example.com", "example.net"},
+			expectedMaxInFlight: 1,
+		},
+		"recLock overrides client serialization": {
+			options:             []Option{WithWriteSerialization(Global)},
+			zones:               []string{"example.com", "example.com"},
+			recLock:             []bool{false},
+			expectedMaxInFlight: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			bothInFlight := make(chan struct{})
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&inFlight, 1)
+				if n == 2 {
+					close(bothInFlight)
+				}
+				for {
+					m := atomic.LoadInt32(&maxInFlight)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+						break
+					}
+				}
+				select {
+				case <-bothInFlight:
+				case <-time.After(200 * time.Millisecond):
+				}
+				atomic.AddInt32(&inFlight, -1)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+
+			var wg sync.WaitGroup
+			for _, zone := range test.zones {
+				wg.Add(1)
+				go func(zone string) {
+					defer wg.Done()
+					err := client.CreateRecord(context.Background(), &RecordBody{
+						Name:       "www." + canonicalName(zone),
+						RecordType: "A",
+						TTL:        300,
+						Target:     []string{"10.0.0.2"},
+					}, zone, test.recLock...)
+					assert.NoError(t, err)
+				}(zone)
+			}
+			wg.Wait()
+
+			assert.Equal(t, test.expectedMaxInFlight, atomic.LoadInt32(&maxInFlight))
+		})
+	}
+}
+
+func TestDNS_WithZoneLock(t *testing.T) {
+	tests := map[string]struct {
+		options   []Option
+		zone      string
+		withError error
+	}{
+		"PerZone serializes other writers to the zone": {
+			zone: "example.com",
+		},
+		"Global serializes other writers": {
+			options: []Option{WithWriteSerialization(Global)},
+			zone:    "example.com",
+		},
+		"None still takes the zone lock": {
+			options: []Option{WithWriteSerialization(None)},
+			zone:    "example.com",
+		},
+		"callback error is returned": {
+			zone:      "example.com",
+			withError: errors.New("oops"),
+		},
+	}
+
+	newRecord := func(name string) *RecordBody {
+		return &RecordBody{
+			Name:       name,
+			RecordType: "A",
+			TTL:        300,
+			Target:     []string{"10.0.0.2"},
+		}
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var order []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				order = append(order, r.URL.Path)
+				mu.Unlock()
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+
+			started := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				<-started
+				err := client.CreateRecord(context.Background(), newRecord("other.example.com"), test.zone, true)
+				assert.NoError(t, err)
+			}()
+
+			err := client.WithZoneLock(context.Background(), test.zone, func() error {
+				if err := client.CreateRecord(context.Background(), newRecord("a.example.com"), test.zone, false); err != nil {
+					return err
+				}
+				close(started)
+				time.Sleep(50 * time.Millisecond)
+				if err := client.CreateRecord(context.Background(), newRecord("b.example.com"), test.zone, false); err != nil {
+					return err
+				}
+				return test.withError
+			})
+			<-done
+
+			if test.withError != nil {
+				assert.Equal(t, test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, []string{
+				"/config-dns/v2/zones/example.com/names/a.example.com/types/A",
+				"/config-dns/v2/zones/example.com/names/b.example.com/types/A",
+				"/config-dns/v2/zones/example.com/names/other.example.com/types/A",
+			}, order)
+		})
+	}
+}
+
+func TestDNS_DelegateSubzone(t *testing.T) {
+	tests := map[string]struct {
+		childName        string
+		nameservers      []string
+		glue             map[string][]net.IP
+		responseStatuses map[string]int
+		expectedRequests []string
+		expectedBodies   []string
+		withError        error
+	}{
+		"NS and glue records": {
+			childName:   "Sub.Example.com.",
+			nameservers: []string{"ns1.sub.example.com", "ns2.example.com.", "a1-1.akam.net"},
+			glue: map[string][]net.IP{
+				"ns1.sub.example.com.": {net.ParseIP("10.0.0.1"), net.ParseIP("2001:db8::1")},
+				"ns2.example.com":      {net.ParseIP("10.0.0.2")},
+			},
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/names/ns1.sub.example.com/types/A",
+				"/config-dns/v2/zones/example.com/names/ns1.sub.example.com/types/AAAA",
+				"/config-dns/v2/zones/example.com/names/ns2.example.com/types/A",
+				"/config-dns/v2/zones/example.com/names/sub.example.com/types/NS",
+			},
+			expectedBodies: []string{
+				`{"name":"ns1.sub.example.com","type":"A","ttl":300,"rdata":["10.0.0.1"]}`,
+				`{"name":"ns1.sub.example.com","type":"AAAA","ttl":300,"rdata":["2001:db8::1"]}`,
+				`{"name":"ns2.example.com","type":"A","ttl":300,"rdata":["10.0.0.2"]}`,
+				`{"name":"sub.example.com","type":"NS","ttl":300,"rdata":["ns1.sub.example.com.","ns2.example.com.","a1-1.akam.net."]}`,
+			},
+		},
+		"NS records only": {
+			childName:   "sub.example.com",
+			nameservers: []string{"a1-1.akam.net", "a2-2.akam.net"},
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/names/sub.example.com/types/NS",
+			},
+			expectedBodies: []string{
+				`{"name":"sub.example.com","type":"NS","ttl":300,"rdata":["a1-1.akam.net.","a2-2.akam.net."]}`,
+			},
+		},
+		"stops at the first failed record": {
+			childName:   "sub.example.com",
+			nameservers: []string{"ns1.sub.example.com"},
+			glue: map[string][]net.IP{
+				"ns1.sub.example.com": {net.ParseIP("10.0.0.1")},
+			},
+			responseStatuses: map[string]int{
+				"/config-dns/v2/zones/example.com/names/ns1.sub.example.com/types/A": http.StatusConflict,
+			},
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/names/ns1.sub.example.com/types/A",
+			},
+			withError: ErrRecordAlreadyExists,
+		},
+		"missing glue for in-bailiwick nameserver": {
+			childName:   "sub.example.com",
+			nameservers: []string{"ns1.sub.example.com", "a1-1.akam.net"},
+			withError:   ErrBadRequest,
+		},
+		"glue for a name which is not a nameserver": {
+			childName:   "sub.example.com",
+			nameservers: []string{"a1-1.akam.net"},
+			glue: map[string][]net.IP{
+				"ns1.sub.example.com": {net.ParseIP("10.0.0.1")},
+			},
+			withError: ErrBadRequest,
+		},
+		"glue outside of the parent zone": {
+			childName:   "sub.example.com",
+			nameservers: []string{"ns1.example.net"},
+			glue: map[string][]net.IP{
+				"ns1.example.net": {net.ParseIP("10.0.0.1")},
+			},
+			withError: ErrBadRequest,
+		},
+		"child is not a subdomain of the parent": {
+			childName:   "sub.example.net",
+			nameservers: []string{"a1-1.akam.net"},
+			withError:   ErrBadRequest,
+		},
+		"no nameservers": {
+			childName: "sub.example.com",
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests, bodies []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				requests = append(requests, r.URL.Path)
+				bodies = append(bodies, string(body))
+				if status, ok := test.responseStatuses[r.URL.Path]; ok {
+					w.WriteHeader(status)
+					_, err = w.Write([]byte(`{"type": "conflict", "title": "Conflict", "status": 409}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+
+			err := client.DelegateSubzone(context.Background(), "example.com", test.childName, test.nameservers, test.glue, 300)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBodies, bodies)
+		})
+	}
+}
+
+func TestDNS_DryRun(t *testing.T) {
+	record := &RecordBody{
+		Name:       "www.example.com",
+		RecordType: "A",
+		TTL:        300,
+		Target:     []string{"10.0.0.2"},
+	}
+	tests := map[string]struct {
+		call      func(DNS) error
+		expected  *DryRunError
+		withError bool
+	}{
+		"CreateRecord": {
+			call: func(client DNS) error {
+				return client.CreateRecord(context.Background(), record, "example.com")
+			},
+			expected: &DryRunError{
+				Method: http.MethodPost,
+				URL:    "/config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				Body:   []byte(`{"name":"www.example.com","type":"A","ttl":300,"rdata":["10.0.0.2"]}`),
+			},
+		},
+		"UpdateRecord": {
+			call: func(client DNS) error {
+				return client.UpdateRecord(context.Background(), record, "example.com")
+			},
+			expected: &DryRunError{
+				Method: http.MethodPut,
+				URL:    "/config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				Body:   []byte(`{"name":"www.example.com","type":"A","ttl":300,"rdata":["10.0.0.2"]}`),
+			},
+		},
+		"DeleteRecord": {
+			call: func(client DNS) error {
+				return client.DeleteRecord(context.Background(), record, "example.com")
+			},
+			expected: &DryRunError{
+				Method: http.MethodDelete,
+				URL:    "/config-dns/v2/zones/example.com/names/www.example.com/types/A",
+			},
+		},
+		"CreateOrUpdateRecord": {
+			call: func(client DNS) error {
+				return client.CreateOrUpdateRecord(context.Background(), record, "example.com")
+			},
+			expected: &DryRunError{
+				Method: http.MethodPost,
+				URL:    "/config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				Body:   []byte(`{"name":"www.example.com","type":"A","ttl":300,"rdata":["10.0.0.2"]}`),
+			},
+		},
+		"validation error": {
+			call: func(client DNS) error {
+				return client.CreateRecord(context.Background(), &RecordBody{Name: "www.example.com"}, "example.com")
+			},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, WithDryRun(true))
+
+			// hold the zone lock to verify that dry runs do not take it
+			l, _ := zoneLocks.LoadOrStore("example.com", &sync.Mutex{})
+			l.(*sync.Mutex).Lock()
+			defer l.(*sync.Mutex).Unlock()
+
+			done := make(chan error)
+			go func() {
+				done <- test.call(client)
+			}()
+			var err error
+			select {
+			case err = <-done:
+			case <-time.After(time.Second):
+				t.Fatal("dry run blocked on the write lock")
+			}
+
+			if test.withError {
+				assert.Error(t, err)
+				assert.False(t, errors.Is(err, ErrDryRun), "validation errors must not be reported as dry runs")
+				return
+			}
+			assert.True(t, errors.Is(err, ErrDryRun), "want: %s; got: %s", ErrDryRun, err)
+			var dryRunErr *DryRunError
+			require.True(t, errors.As(err, &dryRunErr))
+			assert.Equal(t, test.expected, dryRunErr)
+		})
+	}
+}
+
+func TestMarshalRecordBody(t *testing.T) {
+	tests := map[string]struct {
+		record       *RecordBody
+		expectedBody string
+		withError    error
+	}{
+		"A record": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+			expectedBody: `{"name":"www.example.com","type":"A","ttl":300,"rdata":["10.0.0.1","10.0.0.2"]}`,
+		},
+		"AAAA record": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+			expectedBody: `{"name":"www.example.com","type":"AAAA","ttl":300,"rdata":["2001:db8::1"]}`,
+		},
+		"CNAME record": {
+			record:       &RecordBody{Name: "api.example.com", RecordType: "CNAME", TTL: 600, Target: []string{"www.example.com."}},
+			expectedBody: `{"name":"api.example.com","type":"CNAME","ttl":600,"rdata":["www.example.com."]}`,
+		},
+		"MX record": {
+			record:       &RecordBody{Name: "example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx1.example.com."}},
+			expectedBody: `{"name":"example.com","type":"MX","ttl":300,"rdata":["10 mx1.example.com."]}`,
+		},
+		"TXT record": {
+			record:       &RecordBody{Name: "example.com", RecordType: "TXT", TTL: 300, Target: []string{`"v=spf1 -all"`}},
+			expectedBody: `{"name":"example.com","type":"TXT","ttl":300,"rdata":["\"v=spf1 -all\""]}`,
+		},
+		"SRV record": {
+			record:       &RecordBody{Name: "_sip._tcp.example.com", RecordType: "SRV", TTL: 300, Target: []string{"10 60 5060 sip.example.com."}},
+			expectedBody: `{"name":"_sip._tcp.example.com","type":"SRV","ttl":300,"rdata":["10 60 5060 sip.example.com."]}`,
+		},
+		"active record": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Active: true, Target: []string{"10.0.0.1"}},
+			expectedBody: `{"name":"www.example.com","type":"A","ttl":300,"active":true,"rdata":["10.0.0.1"]}`,
+		},
+		"zero TTL is sent": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "A", Target: []string{"10.0.0.1"}},
+			expectedBody: `{"name":"www.example.com","type":"A","ttl":0,"rdata":["10.0.0.1"]}`,
+		},
+		"inherited TTL is omitted": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "A", TTL: TTLInheritZone, Target: []string{"10.0.0.1"}},
+			expectedBody: `{"name":"www.example.com","type":"A","rdata":["10.0.0.1"]}`,
+		},
+		"nil target is an empty list": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300},
+			expectedBody: `{"name":"www.example.com","type":"A","ttl":300,"rdata":[]}`,
+		},
+		"empty target is an empty list": {
+			record:       &RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{}},
+			expectedBody: `{"name":"www.example.com","type":"A","ttl":300,"rdata":[]}`,
+		},
+		"nil record": {
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := MarshalRecordBody(test.record)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			data, err := ioutil.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBody, string(data))
+		})
+	}
+}
+
+func TestRecordBody_Validate(t *testing.T) {
+	tests := map[string]struct {
+		record    RecordBody
+		withError string
+	}{
+		"valid A record": {
+			record: RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+		},
+		"valid AAAA record": {
+			record: RecordBody{Name: "www.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+		},
+		"valid CNAME record": {
+			record: RecordBody{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"origin.example.net."}},
+		},
+		"valid SOA record": {
+			record: RecordBody{Name: "example.com", RecordType: "SOA", TTL: 86400, Target: []string{"a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"}},
+		},
+		"valid MX record": {
+			record: RecordBody{Name: "example.com", RecordType: "MX", TTL: 2147483647, Target: []string{"10 mail.example.com.", "20 backup.example.com."}},
+		},
+		"valid HTTPS record": {
+			record: RecordBody{Name: "example.com", RecordType: "HTTPS", TTL: 300, Target: []string{"1 . alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2"}},
+		},
+		"missing fields": {
+			record:    RecordBody{},
+			withError: "Name: cannot be blank\nRecordType: cannot be blank\nTTL: cannot be blank\nTarget: cannot be blank",
+		},
+		"inherited TTL": {
+			record: RecordBody{Name: "www.example.com", RecordType: "A", TTL: TTLInheritZone, Target: []string{"10.0.0.1"}},
+		},
+		"zero TTL": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "A", Target: []string{"10.0.0.1"}},
+			withError: "TTL: cannot be blank",
+		},
+		"negative TTL": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "A", TTL: -2, Target: []string{"10.0.0.1"}},
+			withError: "TTL: must be no less than 30",
+		},
+		"TTL too low": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "A", TTL: 29, Target: []string{"10.0.0.1"}},
+			withError: "TTL: must be no less than 30",
+		},
+		"TTL too high": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "A", TTL: 2147483648, Target: []string{"10.0.0.1"}},
+			withError: "TTL: must be no greater than 2147483647",
+		},
+		"invalid A target": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "2001:db8::1"}},
+			withError: `Target: invalid IPv4 address "2001:db8::1"`,
+		},
+		"invalid AAAA target": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"10.0.0.1"}},
+			withError: `Target: invalid IPv6 address "10.0.0.1"`,
+		},
+		"invalid HTTPS target": {
+			record:    RecordBody{Name: "example.com", RecordType: "HTTPS", TTL: 300, Target: []string{"1 . alpn=h2 ipv4hint=2001:db8::1"}},
+			withError: `Target: invalid SVCB record: ipv4hint: invalid IPv4 address "2001:db8::1"`,
+		},
+		"CNAME with multiple targets": {
+			record:    RecordBody{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"a.example.net.", "b.example.net."}},
+			withError: "Target: CNAME record must have exactly one target, got 2",
+		},
+		"SOA with multiple targets": {
+			record: RecordBody{Name: "example.com", RecordType: "SOA", TTL: 86400, Target: []string{
+				"a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300",
+				"a2-2.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300",
+			}},
+			withError: "Target: SOA record must have exactly one target, got 2",
+		},
+		"SOA with missing fields": {
+			record:    RecordBody{Name: "example.com", RecordType: "SOA", TTL: 86400, Target: []string{"a1-1.akam.net. hostmaster.example.com. 2024010101"}},
+			withError: "Target: SOA target must consist of 7 fields, got 3",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.record.Validate()
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.withError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultGetRecordsConcurrency is the number of concurrent requests of GetRecords when no concurrency is given
+const DefaultGetRecordsConcurrency = 4
+
+type (
+	// RecordKey identifies a recordset of a zone by its name and type
+	RecordKey struct {
+		Name       string
+		RecordType string
+	}
+
+	// GetRecordsOptions contains optional settings of GetRecords
+	GetRecordsOptions struct {
+		// NotFoundAsNil returns a nil record for the recordsets which do not exist instead of an error
+		NotFoundAsNil bool
+	}
+
+	// GetRecordsError is returned by GetRecords when some of the recordsets could not be read
+	GetRecordsError struct {
+		// Errors maps the keys of the recordsets which could not be read to the error of their GetRecord request
+		Errors map[RecordKey]error
+	}
+)
+
+func (e *GetRecordsError) Error() string {
+	keys := make([]RecordKey, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sortRecordKeys(keys)
+
+	msgs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s %s: %s", key.Name, key.RecordType, e.Errors[key]))
+	}
+	return fmt.Sprintf("failed to get %d recordsets: %s", len(keys), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed GetRecord requests, so that errors.Is and errors.As match any of them
+func (e *GetRecordsError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (d *dns) GetRecords(ctx context.Context, zone string, keys []RecordKey, concurrency int, opts ...GetRecordsOptions) (map[RecordKey]*RecordBody, error) {
+	logger := d.Log(ctx)
+	logger.Debug("GetRecords")
+
+	if len(opts) > 1 {
+		return nil, fmt.Errorf("invalid arguments GetRecords options")
+	}
+	var options GetRecordsOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultGetRecordsConcurrency
+	}
+
+	pending := make(chan RecordKey)
+	go func() {
+		defer close(pending)
+		seen := make(map[RecordKey]struct{}, len(keys))
+		for _, key := range keys {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			pending <- key
+		}
+	}()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		records = make(map[RecordKey]*RecordBody, len(keys))
+		errs    = make(map[RecordKey]error)
+	)
+	for i := 0; i < concurrency && i < len(keys); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range pending {
+				record, err := d.GetRecord(ctx, zone, key.Name, key.RecordType)
+				if err != nil && options.NotFoundAsNil && errors.Is(err, ErrRecordNotFound) {
+					record, err = nil, nil
+				}
+				mu.Lock()
+				if err != nil {
+					errs[key] = err
+				} else {
+					records[key] = record
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return records, &GetRecordsError{Errors: errs}
+	}
+	return records, nil
+}
+
+// sortRecordKeys sorts keys by name and type
+func sortRecordKeys(keys []RecordKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Name != keys[j].Name {
+			return keys[i].Name < keys[j].Name
+		}
+		return keys[i].RecordType < keys[j].RecordType
+	})
+}
@@ -0,0 +1,142 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_GetRecords(t *testing.T) {
+	recordResponse := func(name string) string {
+		return fmt.Sprintf(`{"name": "%s", "type": "A", "ttl": 300, "rdata": ["10.0.0.1"]}`, name)
+	}
+	errorBodies := map[int]string{
+		http.StatusNotFound:            `{"type": "not_found", "title": "Not Found", "status": 404}`,
+		http.StatusInternalServerError: `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`,
+	}
+
+	tests := map[string]struct {
+		keys             []RecordKey
+		opts             []GetRecordsOptions
+		responses        map[string]int
+		expectedRequests int
+		expectedRecords  map[RecordKey]*RecordBody
+		withErrors       map[RecordKey]error
+	}{
+		"all found, duplicates read once": {
+			keys: []RecordKey{
+				{Name: "a.example.com", RecordType: "A"},
+				{Name: "b.example.com", RecordType: "A"},
+				{Name: "a.example.com", RecordType: "A"},
+			},
+			expectedRequests: 2,
+			expectedRecords: map[RecordKey]*RecordBody{
+				{Name: "a.example.com", RecordType: "A"}: {Name: "a.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "b.example.com", RecordType: "A"}: {Name: "b.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+			},
+		},
+		"not found as nil": {
+			keys: []RecordKey{
+				{Name: "a.example.com", RecordType: "A"},
+				{Name: "missing.example.com", RecordType: "A"},
+			},
+			opts:             []GetRecordsOptions{{NotFoundAsNil: true}},
+			responses:        map[string]int{"missing.example.com": http.StatusNotFound},
+			expectedRequests: 2,
+			expectedRecords: map[RecordKey]*RecordBody{
+				{Name: "a.example.com", RecordType: "A"}:       {Name: "a.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "missing.example.com", RecordType: "A"}: nil,
+			},
+		},
+		"not found and server error": {
+			keys: []RecordKey{
+				{Name: "a.example.com", RecordType: "A"},
+				{Name: "missing.example.com", RecordType: "A"},
+				{Name: "error.example.com", RecordType: "A"},
+			},
+			responses:        map[string]int{"missing.example.com": http.StatusNotFound, "error.example.com": http.StatusInternalServerError},
+			expectedRequests: 3,
+			expectedRecords: map[RecordKey]*RecordBody{
+				{Name: "a.example.com", RecordType: "A"}: {Name: "a.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+			},
+			withErrors: map[RecordKey]error{
+				{Name: "missing.example.com", RecordType: "A"}: ErrRecordNotFound,
+				{Name: "error.example.com", RecordType: "A"}:   &Error{Type: "internal_error", Title: "Internal Server Error", StatusCode: http.StatusInternalServerError},
+			},
+		},
+		"no keys": {
+			expectedRecords: map[RecordKey]*RecordBody{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				recordName := strings.Split(strings.TrimPrefix(r.URL.Path, "/config-dns/v2/zones/example.com/names/"), "/")[0]
+				if status, ok := test.responses[recordName]; ok {
+					w.WriteHeader(status)
+					_, err := w.Write([]byte(errorBodies[status]))
+					assert.NoError(t, err)
+					return
+				}
+				_, err := w.Write([]byte(recordResponse(recordName)))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			records, err := client.GetRecords(context.Background(), "example.com", test.keys, 2, test.opts...)
+			assert.Equal(t, test.expectedRequests, int(requests))
+			assert.Equal(t, test.expectedRecords, records)
+			if test.withErrors == nil {
+				require.NoError(t, err)
+				return
+			}
+			var recordsErr *GetRecordsError
+			require.True(t, errors.As(err, &recordsErr), "want: *GetRecordsError; got: %s", err)
+			require.Len(t, recordsErr.Errors, len(test.withErrors))
+			for key, want := range test.withErrors {
+				assert.True(t, errors.Is(recordsErr.Errors[key], want), "want: %s; got: %s", want, recordsErr.Errors[key])
+				assert.True(t, errors.Is(err, want))
+			}
+		})
+	}
+}
+
+func TestDNS_GetRecordsConcurrency(t *testing.T) {
+	const concurrency = 3
+	var inFlight, maxInFlight int32
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		_, err := w.Write([]byte(`{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.1"]}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	var keys []RecordKey
+	for i := 0; i < 20; i++ {
+		keys = append(keys, RecordKey{Name: fmt.Sprintf("host%d.example.com", i), RecordType: "A"})
+	}
+	records, err := client.GetRecords(context.Background(), "example.com", keys, concurrency)
+	require.NoError(t, err)
+	assert.Len(t, records, len(keys))
+	assert.LessOrEqual(t, int(maxInFlight), concurrency)
+	assert.Greater(t, int(maxInFlight), 1)
+}
@@ -0,0 +1,198 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// recordCache caches the recordsets read by GetRecord and GetRecordList for a TTL. Writes through the client
+	// invalidate the affected entries, and bump the generation of the zone so that reads in flight during a write
+	// do not store what they read.
+	recordCache struct {
+		ttl time.Duration
+		now func() time.Time
+
+		mu          sync.Mutex
+		records     map[recordCacheKey]*cachedRecord
+		lists       map[string]map[string]*cachedRecordList
+		generations map[string]uint64
+	}
+
+	recordCacheKey struct {
+		zone       string
+		name       string
+		recordType string
+	}
+
+	cachedRecord struct {
+		record  *RecordBody
+		etag    string
+		expires time.Time
+	}
+
+	cachedRecordList struct {
+		result  *RecordSetResponse
+		expires time.Time
+	}
+)
+
+// WithCache makes the client cache the recordsets read by GetRecord and GetRecordList for the given TTL.
+// Expired GetRecord entries are revalidated with their ETag, so that unchanged recordsets are not transferred again.
+// CreateRecord, UpdateRecord and DeleteRecord invalidate the cached recordset and the cached lists of its zone,
+// and bulk writes such as UpdateRecordSets, SubmitChangeList or PostMasterZoneFile invalidate the whole zone.
+// Writes made by other clients are only seen once the entries expire. The cache is disabled when ttl is not positive.
+func WithCache(ttl time.Duration) Option {
+	return func(d *dns) {
+		if ttl <= 0 {
+			d.cache = nil
+			return
+		}
+		d.cache = &recordCache{
+			ttl:         ttl,
+			now:         time.Now,
+			records:     make(map[recordCacheKey]*cachedRecord),
+			lists:       make(map[string]map[string]*cachedRecordList),
+			generations: make(map[string]uint64),
+		}
+	}
+}
+
+// getCachedRecord returns the recordset from the cache, revalidating an expired entry with its ETag,
+// or gets it and caches it
+func (d *dns) getCachedRecord(ctx context.Context, zone, name, recordType string) (*RecordBody, error) {
+	key := newRecordCacheKey(zone, name, recordType)
+	generation := d.cache.generation(zone)
+	cached, etag, fresh := d.cache.getRecord(key)
+	if fresh {
+		return cached, nil
+	}
+
+	record, responseETag, err := d.getRecord(ctx, zone, name, recordType, etag)
+	if errors.Is(err, ErrNotModified) && cached != nil {
+		d.cache.storeRecord(key, generation, cached, responseETag)
+		return cached, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.cache.storeRecord(key, generation, record, responseETag)
+	return record, nil
+}
+
+func newRecordCacheKey(zone, name, recordType string) recordCacheKey {
+	return recordCacheKey{
+		zone:       canonicalCacheName(zone),
+		name:       canonicalCacheName(name),
+		recordType: strings.ToUpper(recordType),
+	}
+}
+
+func canonicalCacheName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// generation returns the current generation of the zone, to be passed to the store methods
+func (c *recordCache) generation(zone string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[canonicalCacheName(zone)]
+}
+
+// getRecord returns a copy of the cached recordset, and whether it is still fresh. An expired entry is returned
+// with its ETag for revalidation.
+func (c *recordCache) getRecord(key recordCacheKey) (*RecordBody, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.records[key]
+	if !ok {
+		return nil, "", false
+	}
+	return copyRecordBody(entry.record), entry.etag, c.now().Before(entry.expires)
+}
+
+// storeRecord caches a copy of the recordset unless the zone was written since generation was read
+func (c *recordCache) storeRecord(key recordCacheKey, generation uint64, record *RecordBody, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[key.zone] != generation {
+		return
+	}
+	c.records[key] = &cachedRecord{record: copyRecordBody(record), etag: etag, expires: c.now().Add(c.ttl)}
+}
+
+// getList returns a copy of the cached fresh recordset list of the zone for the request URL
+func (c *recordCache) getList(zone, listURL string) (*RecordSetResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.lists[canonicalCacheName(zone)][listURL]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	return copyRecordSetResponse(entry.result), true
+}
+
+// storeList caches a copy of the recordset list unless the zone was written since generation was read
+func (c *recordCache) storeList(zone, listURL string, generation uint64, result *RecordSetResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	zone = canonicalCacheName(zone)
+	if c.generations[zone] != generation {
+		return
+	}
+	if c.lists[zone] == nil {
+		c.lists[zone] = make(map[string]*cachedRecordList)
+	}
+	c.lists[zone][listURL] = &cachedRecordList{result: copyRecordSetResponse(result), expires: c.now().Add(c.ttl)}
+}
+
+// invalidateRecord removes the cached recordset and the cached lists of its zone.
+// It is a no-op on a nil cache, i.e. a client without WithCache.
+func (c *recordCache) invalidateRecord(zone, name, recordType string) {
+	if c == nil {
+		return
+	}
+	key := newRecordCacheKey(zone, name, recordType)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[key.zone]++
+	delete(c.records, key)
+	delete(c.lists, key.zone)
+}
+
+// invalidateZone removes all cached recordsets and lists of the zone.
+// It is a no-op on a nil cache, i.e. a client without WithCache.
+func (c *recordCache) invalidateZone(zone string) {
+	if c == nil {
+		return
+	}
+	zone = canonicalCacheName(zone)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[zone]++
+	for key := range c.records {
+		if key.zone == zone {
+			delete(c.records, key)
+		}
+	}
+	delete(c.lists, zone)
+}
+
+func copyRecordBody(record *RecordBody) *RecordBody {
+	copied := *record
+	copied.Target = append([]string(nil), record.Target...)
+	return &copied
+}
+
+func copyRecordSetResponse(result *RecordSetResponse) *RecordSetResponse {
+	copied := *result
+	copied.RecordSets = make([]RecordSet, len(result.RecordSets))
+	for i, rs := range result.RecordSets {
+		rs.Rdata = append([]string(nil), rs.Rdata...)
+		copied.RecordSets[i] = rs
+	}
+	return &copied
+}
@@ -0,0 +1,196 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_WithCache(t *testing.T) {
+	www := func(target string) *RecordBody {
+		return &RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{target}}
+	}
+
+	tests := map[string]struct {
+		run              func(t *testing.T, client DNS, advance func(time.Duration))
+		expectedRequests []string
+	}{
+		"GetRecord cache hit": {
+			run: func(t *testing.T, client DNS, _ func(time.Duration)) {
+				for i := 0; i < 2; i++ {
+					record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+					require.NoError(t, err)
+					assert.Equal(t, www("10.0.0.1"), record)
+				}
+			},
+			expectedRequests: []string{"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A"},
+		},
+		"GetRecord revalidated after TTL": {
+			run: func(t *testing.T, client DNS, advance func(time.Duration)) {
+				for i := 0; i < 3; i++ {
+					record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+					require.NoError(t, err)
+					assert.Equal(t, www("10.0.0.1"), record)
+					advance(2 * time.Minute)
+				}
+			},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A If-None-Match: etag-10.0.0.1",
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A If-None-Match: etag-10.0.0.1",
+			},
+		},
+		"returned records are copies": {
+			run: func(t *testing.T, client DNS, _ func(time.Duration)) {
+				record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				record.Target[0] = "10.9.9.9"
+				record, err = client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				assert.Equal(t, www("10.0.0.1"), record)
+			},
+			expectedRequests: []string{"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A"},
+		},
+		"GetRecordList cache hit and TTL expiry": {
+			run: func(t *testing.T, client DNS, advance func(time.Duration)) {
+				for i := 0; i < 2; i++ {
+					list, err := client.GetRecordList(context.Background(), "example.com", "www.example.com", "A")
+					require.NoError(t, err)
+					assert.Equal(t, []string{"10.0.0.1"}, list.RecordSets[0].Rdata)
+				}
+				advance(2 * time.Minute)
+				_, err := client.GetRecordList(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+			},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=A",
+				"GET /config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=A",
+			},
+		},
+		"UpdateRecord invalidates the record and the lists of the zone": {
+			run: func(t *testing.T, client DNS, _ func(time.Duration)) {
+				_, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				_, err = client.GetRecordList(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+
+				require.NoError(t, client.UpdateRecord(context.Background(), www("10.0.0.2"), "example.com"))
+
+				record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				assert.Equal(t, www("10.0.0.2"), record)
+				list, err := client.GetRecordList(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"10.0.0.2"}, list.RecordSets[0].Rdata)
+			},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"GET /config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=A",
+				"PUT /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"GET /config-dns/v2/zones/example.com/recordsets?search=www.example.com&showAll=true&types=A",
+			},
+		},
+		"UpdateRecordSets invalidates the zone": {
+			run: func(t *testing.T, client DNS, _ func(time.Duration)) {
+				_, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+
+				require.NoError(t, client.UpdateRecordSets(context.Background(), &RecordSets{RecordSets: []RecordSet{
+					{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.3"}},
+				}}, "example.com"))
+
+				record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				assert.Equal(t, www("10.0.0.3"), record)
+			},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"PUT /config-dns/v2/zones/example.com/recordsets",
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+			},
+		},
+		"writes to another zone keep the cache": {
+			run: func(t *testing.T, client DNS, _ func(time.Duration)) {
+				_, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+
+				require.NoError(t, client.UpdateRecord(context.Background(), &RecordBody{
+					Name: "www.example.net", RecordType: "A", TTL: 300, Target: []string{"10.0.0.4"},
+				}, "example.net"))
+
+				record, err := client.GetRecord(context.Background(), "example.com", "www.example.com", "A")
+				require.NoError(t, err)
+				assert.Equal(t, www("10.0.0.1"), record)
+			},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"PUT /config-dns/v2/zones/example.net/names/www.example.net/types/A",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			target := "10.0.0.1"
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				request := r.Method + " " + r.URL.String()
+				if etag := r.Header.Get("If-None-Match"); etag != "" {
+					request += " If-None-Match: " + etag
+				}
+				requests = append(requests, request)
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/config-dns/v2/zones/example.com/recordsets":
+					w.Header().Set("Content-Type", "application/json")
+					_, err := fmt.Fprintf(w, `{"metadata": {"showAll": true, "totalElements": 1}, "recordsets": [{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": [%q]}]}`, target)
+					assert.NoError(t, err)
+				case r.Method == http.MethodGet:
+					etag := "etag-" + target
+					w.Header().Set("ETag", etag)
+					if r.Header.Get("If-None-Match") == etag {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_, err := fmt.Fprintf(w, `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": [%q]}`, target)
+					assert.NoError(t, err)
+				case r.Method == http.MethodPut && r.URL.Path == "/config-dns/v2/zones/example.com/recordsets":
+					var body RecordSets
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					target = body.RecordSets[0].Rdata[0]
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodPut:
+					var body RecordBody
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					if r.URL.Path == "/config-dns/v2/zones/example.com/names/www.example.com/types/A" {
+						target = body.Target[0]
+					}
+					w.WriteHeader(http.StatusOK)
+				default:
+					t.Errorf("unexpected request: %s", request)
+				}
+			}))
+			defer mockServer.Close()
+
+			client := mockAPIClient(t, mockServer, WithCache(time.Minute))
+			now := time.Now()
+			client.(*dns).cache.now = func() time.Time { return now }
+
+			test.run(t, client, func(d time.Duration) { now = now.Add(d) })
+			assert.Equal(t, test.expectedRequests, requests)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// ImportFormat is the format of the records read by ImportRecords
+	ImportFormat string
+
+	// ImportedRecord is a record of the generic import format, a list of these records in JSON or YAML.
+	// Records of the same name and type are collapsed into a single RecordBody.
+	ImportedRecord struct {
+		Name   string   `json:"name" yaml:"name"`
+		Type   string   `json:"type" yaml:"type"`
+		TTL    int      `json:"ttl" yaml:"ttl"`
+		Values []string `json:"values" yaml:"values"`
+	}
+)
+
+const (
+	// ImportFormatJSON is a JSON array of ImportedRecord
+	ImportFormatJSON ImportFormat = "json"
+	// ImportFormatYAML is a YAML sequence of ImportedRecord
+	ImportFormatYAML ImportFormat = "yaml"
+)
+
+func (d *dns) ImportRecords(ctx context.Context, format ImportFormat, r io.Reader) ([]*RecordBody, error) {
+	logger := d.Log(ctx)
+	logger.Debug("ImportRecords")
+
+	records, err := importRecords(format, r)
+	if err != nil {
+		return nil, fmt.Errorf("ImportRecords: %w", err)
+	}
+
+	return records, nil
+}
+
+func importRecords(format ImportFormat, r io.Reader) ([]*RecordBody, error) {
+	var imported []ImportedRecord
+	switch format {
+	case ImportFormatJSON:
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&imported); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidImport, err)
+		}
+	case ImportFormatYAML:
+		decoder := yaml.NewDecoder(r)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&imported); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidImport, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+	}
+
+	var records []*RecordBody
+	index := make(map[recordKey]*RecordBody)
+	for i, rec := range imported {
+		name := strings.TrimSuffix(strings.TrimSpace(rec.Name), ".")
+		recordType := strings.ToUpper(strings.TrimSpace(rec.Type))
+		if name == "" {
+			return nil, fmt.Errorf("%w: record %d has no name", ErrInvalidImport, i)
+		}
+		if recordType == "" {
+			return nil, fmt.Errorf("%w: record %d (%s) has no type", ErrInvalidImport, i, name)
+		}
+		if _, ok := supportedRecordTypes[recordType]; !ok {
+			return nil, fmt.Errorf("%w: record %d (%s): %s", ErrUnsupportedRecordType, i, name, recordType)
+		}
+		if len(rec.Values) == 0 {
+			return nil, fmt.Errorf("%w: record %d (%s %s) has no values", ErrInvalidImport, i, name, recordType)
+		}
+
+		key := recordKey{name: strings.ToLower(name), recordType: recordType}
+		values := normalizeImportedValues(recordType, rec.Values)
+		if record, ok := index[key]; ok {
+			if record.TTL != rec.TTL {
+				return nil, fmt.Errorf("%w: record %d: TTL %d of %s %s differs from TTL %d of the same record set",
+					ErrInvalidImport, i, rec.TTL, name, recordType, record.TTL)
+			}
+			record.Target = appendMissingTargets(record.Target, values)
+			continue
+		}
+		record := &RecordBody{
+			Name:       name,
+			RecordType: recordType,
+			TTL:        rec.TTL,
+			Target:     appendMissingTargets(nil, values),
+		}
+		index[key] = record
+		records = append(records, record)
+	}
+
+	for _, record := range records {
+		if err := record.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s %s: %s", ErrInvalidImport, record.Name, record.RecordType, err)
+		}
+	}
+
+	return records, nil
+}
+
+// normalizeImportedValues converts the values of a record to the form in which the API returns them: absolute domain
+// names with a trailing dot, expanded IPv6 addresses and quoted TXT and SPF strings
+func normalizeImportedValues(recordType string, values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if (recordType == "TXT" || recordType == "SPF") && !isQuoted(value) {
+			value = quoteString(value)
+		}
+		normalized = append(normalized, value)
+	}
+	return APINormalizationPolicy.NormalizeRdata(normalized, recordType)
+}
+
+// appendMissingTargets appends the values which are not among the targets yet
+func appendMissingTargets(targets, values []string) []string {
+	for _, value := range values {
+		found := false
+		for _, target := range targets {
+			if target == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			targets = append(targets, value)
+		}
+	}
+	return targets
+}
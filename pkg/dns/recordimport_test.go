@@ -0,0 +1,131 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_ImportRecords(t *testing.T) {
+	fixture := func(name string) func() (io.Reader, error) {
+		return func() (io.Reader, error) {
+			return os.Open("testdata/TestDNS_ImportRecords/" + name)
+		}
+	}
+	content := func(s string) func() (io.Reader, error) {
+		return func() (io.Reader, error) {
+			return strings.NewReader(s), nil
+		}
+	}
+	expectedFixtureRecords := []*RecordBody{
+		{Name: "example.com", RecordType: "NS", TTL: 86400, Target: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+		{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "example.com", RecordType: "MX", TTL: 3600, Target: []string{"10 mail.example.com."}},
+		{Name: "example.com", RecordType: "TXT", TTL: 300, Target: []string{`"v=spf1 include:_spf.example.com -all"`, `"already quoted"`}},
+		{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:0db8:0000:0000:0000:0000:0000:0001"}},
+		{Name: "docs.example.com", RecordType: "CNAME", TTL: 600, Target: []string{"docs.example.net."}},
+	}
+
+	tests := map[string]struct {
+		format    ImportFormat
+		input     func() (io.Reader, error)
+		expected  []*RecordBody
+		withError error
+	}{
+		"JSON": {
+			format:   ImportFormatJSON,
+			input:    fixture("records.json"),
+			expected: expectedFixtureRecords,
+		},
+		"YAML": {
+			format:   ImportFormatYAML,
+			input:    fixture("records.yaml"),
+			expected: expectedFixtureRecords,
+		},
+		"empty YAML": {
+			format: ImportFormatYAML,
+			input:  content(""),
+		},
+		"unsupported format": {
+			format:    "csv",
+			input:     content("name,type,ttl,values\n"),
+			withError: ErrInvalidImport,
+		},
+		"malformed JSON": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"name": "www.example.com"`),
+			withError: ErrInvalidImport,
+		},
+		"unknown field": {
+			format:    ImportFormatYAML,
+			input:     content("- name: www.example.com\n  type: A\n  ttl: 300\n  value: 10.0.0.1\n"),
+			withError: ErrInvalidImport,
+		},
+		"missing name": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"type": "A", "ttl": 300, "values": ["10.0.0.1"]}]`),
+			withError: ErrInvalidImport,
+		},
+		"missing values": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"name": "www.example.com", "type": "A", "ttl": 300}]`),
+			withError: ErrInvalidImport,
+		},
+		"unsupported record type": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"name": "www.example.com", "type": "WKS", "ttl": 300, "values": ["10.0.0.1 TCP 25"]}]`),
+			withError: ErrUnsupportedRecordType,
+		},
+		"conflicting TTLs in record set": {
+			format: ImportFormatJSON,
+			input: content(`[{"name": "www.example.com", "type": "A", "ttl": 300, "values": ["10.0.0.1"]},
+				{"name": "www.example.com", "type": "A", "ttl": 600, "values": ["10.0.0.2"]}]`),
+			withError: ErrInvalidImport,
+		},
+		"TTL below minimum": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"name": "www.example.com", "type": "A", "ttl": 10, "values": ["10.0.0.1"]}]`),
+			withError: ErrInvalidImport,
+		},
+		"invalid target": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"name": "www.example.com", "type": "A", "ttl": 300, "values": ["2001:db8::1"]}]`),
+			withError: ErrInvalidImport,
+		},
+		"multiple CNAME targets": {
+			format:    ImportFormatJSON,
+			input:     content(`[{"name": "docs.example.com", "type": "CNAME", "ttl": 300, "values": ["a.example.net", "b.example.net"]}]`),
+			withError: ErrInvalidImport,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			client := mockAPIClient(t, mockServer)
+			input, err := test.input()
+			require.NoError(t, err)
+			if closer, ok := input.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			result, err := client.ImportRecords(context.Background(), test.format, input)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
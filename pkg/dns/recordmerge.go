@@ -0,0 +1,89 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// MergeRecordSetsOptions contains optional settings of MergeRecordSets
+	MergeRecordSetsOptions struct {
+		// TTLConflict is the policy applied to records of the same recordset with different TTLs,
+		// TTLConflictMax by default
+		TTLConflict TTLConflictPolicy
+	}
+
+	// TTLConflictPolicy defines the TTL of a recordset merged from records with different TTLs
+	TTLConflictPolicy int
+)
+
+const (
+	// TTLConflictMax keeps the highest TTL of the records
+	TTLConflictMax TTLConflictPolicy = iota
+	// TTLConflictMin keeps the lowest TTL of the records
+	TTLConflictMin
+	// TTLConflictFirst keeps the TTL of the first record
+	TTLConflictFirst
+	// TTLConflictStrict fails with ErrTTLConflict
+	TTLConflictStrict
+)
+
+// MergeRecordSets coalesces the records of the same name and type, e.g. assembled from several sources, into a single
+// recordset with the union of their targets, as the API rejects the creation of a recordset which already exists.
+// Names are compared regardless of case and trailing dot, and targets in the canonical form of RecordTargetDiff, so
+// that cosmetic differences do not duplicate targets. The merged recordsets are returned in the order of the first
+// record of each, with the name, type and targets in the form they were first given. The records are not modified.
+func MergeRecordSets(records []*RecordBody, opts ...MergeRecordSetsOptions) ([]*RecordBody, error) {
+	if len(opts) > 1 {
+		return nil, fmt.Errorf("%w: invalid arguments MergeRecordSets options", ErrBadRequest)
+	}
+	var options MergeRecordSetsOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	var merged []*RecordBody
+	index := make(map[RecordKey]*RecordBody, len(records))
+	for i, record := range records {
+		if record == nil {
+			return nil, fmt.Errorf("%w: record %d is nil", ErrBadRequest, i)
+		}
+		key := RecordKey{Name: canonicalName(record.Name), RecordType: strings.ToUpper(record.RecordType)}
+		recordSet, ok := index[key]
+		if !ok {
+			recordSet = copyRecordBody(record)
+			recordSet.Target = recordTargetUnion(recordSet.RecordType, nil, record.Target)
+			index[key] = recordSet
+			merged = append(merged, recordSet)
+			continue
+		}
+
+		if record.TTL != recordSet.TTL {
+			switch options.TTLConflict {
+			case TTLConflictStrict:
+				return nil, fmt.Errorf("%w: record %d: TTL %d of %s %s differs from TTL %d of the same recordset",
+					ErrTTLConflict, i, record.TTL, record.Name, record.RecordType, recordSet.TTL)
+			case TTLConflictMax:
+				recordSet.TTL = max(recordSet.TTL, record.TTL)
+			case TTLConflictMin:
+				recordSet.TTL = min(recordSet.TTL, record.TTL)
+			}
+		}
+		recordSet.Active = recordSet.Active || record.Active
+		recordSet.Target = recordTargetUnion(recordSet.RecordType, recordSet.Target, record.Target)
+	}
+
+	return merged, nil
+}
+
+// recordTargetUnion returns the targets followed by the targets of added which are not among them yet, compared in the
+// canonical form of RecordTargetDiff. Targets repeated in added are only added once.
+func recordTargetUnion(recordType string, targets, added []string) []string {
+	union := append([]string(nil), targets...)
+	for _, target := range added {
+		if missing, _ := RecordTargetDiff(recordType, union, []string{target}); len(missing) > 0 {
+			union = append(union, target)
+		}
+	}
+	return union
+}
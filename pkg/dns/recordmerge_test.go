@@ -0,0 +1,121 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRecordSets(t *testing.T) {
+	tests := map[string]struct {
+		records   []*RecordBody
+		options   []MergeRecordSetsOptions
+		expected  []*RecordBody
+		withError error
+	}{
+		"target union": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+				{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx1.example.com."}},
+				{Name: "WWW.example.com.", RecordType: "a", TTL: 300, Target: []string{"10.0.0.2", "10.0.0.3", "10.0.0.3"}},
+				{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 MX1.example.com", "20 mx2.example.com."}},
+			},
+			expected: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+				{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx1.example.com.", "20 mx2.example.com."}},
+			},
+		},
+		"same name with different types kept apart": {
+			records: []*RecordBody{
+				{Name: "api.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+				{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:0db8:0000:0000:0000:0000:0000:0001"}},
+			},
+			expected: []*RecordBody{
+				{Name: "api.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+			},
+		},
+		"TTL conflict keeps the highest TTL by default": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "www.example.com", RecordType: "A", TTL: 3600, Target: []string{"10.0.0.2"}},
+				{Name: "www.example.com", RecordType: "A", TTL: 600, Target: []string{"10.0.0.3"}},
+			},
+			expected: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 3600, Target: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+			},
+		},
+		"TTL conflict keeps the lowest TTL": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "www.example.com", RecordType: "A", TTL: 60, Target: []string{"10.0.0.2"}},
+			},
+			options: []MergeRecordSetsOptions{{TTLConflict: TTLConflictMin}},
+			expected: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 60, Target: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		"TTL conflict keeps the first TTL": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "www.example.com", RecordType: "A", TTL: 3600, Target: []string{"10.0.0.2"}},
+			},
+			options: []MergeRecordSetsOptions{{TTLConflict: TTLConflictFirst}},
+			expected: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		"strict TTL conflict": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "www.example.com", RecordType: "A", TTL: 3600, Target: []string{"10.0.0.2"}},
+			},
+			options:   []MergeRecordSetsOptions{{TTLConflict: TTLConflictStrict}},
+			withError: ErrTTLConflict,
+		},
+		"strict without conflict": {
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.2"}},
+			},
+			options: []MergeRecordSetsOptions{{TTLConflict: TTLConflictStrict}},
+			expected: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		"nil record": {
+			records:   []*RecordBody{nil},
+			withError: ErrBadRequest,
+		},
+		"too many options": {
+			options:   []MergeRecordSetsOptions{{}, {}},
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var originals []RecordBody
+			for _, record := range test.records {
+				if record != nil {
+					originals = append(originals, *copyRecordBody(record))
+				}
+			}
+
+			result, err := MergeRecordSets(test.records, test.options...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+
+			for i, record := range test.records {
+				assert.Equal(t, originals[i], *record, "record %d was modified", i)
+			}
+		})
+	}
+}
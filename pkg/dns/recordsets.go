@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -22,13 +24,23 @@ type Recordsets interface {
 	// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-zone-recordsets
 	GetRecordSets(context.Context, string, ...RecordSetQueryArgs) (*RecordSetResponse, error)
 	// CreateRecordSets creates multiple record sets.
+	// It returns ErrInvalidCNAME when a CNAME is at the zone apex or shares its name with other record sets.
+	// A 409 Conflict is treated as success when all the record sets exist as requested
+	// and the client was created with WithCreateReconciliation.
 	//
 	// See: https://techdocs.akamai.com/edge-dns/reference/post-zones-zone-recordsets
 	CreateRecordSets(context.Context, *RecordSets, string, ...bool) error
 	// UpdateRecordSets replaces list of record sets.
+	// It returns ErrInvalidCNAME when a CNAME is at the zone apex or shares its name with other record sets.
 	//
 	// See: https://techdocs.akamai.com/edge-dns/reference/put-zones-zone-recordsets
 	UpdateRecordSets(context.Context, *RecordSets, string, ...bool) error
+	// CheckZoneCapacity returns an error wrapping ErrZoneCapacityExceeded when adding the given number of record
+	// sets to the zone, e.g. len(plan.Creates) of a ZonePlan, would exceed the record set limit of the client
+	// (see WithZoneRecordLimit), so that a bulk import fails before its first write rather than halfway through.
+	// The current number of record sets is read from the totalElements of a one-element page of GetRecordSets.
+	// A warning is logged when the zone would be filled beyond 90% of the limit. Without a limit, it does nothing.
+	CheckZoneCapacity(ctx context.Context, zone string, additional int) error
 }
 
 // RecordSetQueryArgs contains query parameters for recordset request
@@ -41,6 +53,18 @@ type RecordSetQueryArgs struct {
 	Types    string
 }
 
+// RecordListQueryArgs contains optional query parameters of GetRecordList
+type RecordListQueryArgs struct {
+	// SortBy is a comma-separated list of name and type fields, prefixed with "-" for descending order, e.g. "-name,type"
+	SortBy string
+	// Search filters recordsets by a name substring, it cannot be combined with the name argument of GetRecordList
+	Search string
+	// Page requests a single page of recordsets instead of all of them
+	Page int
+	// PageSize is the number of recordsets per page, used with Page
+	PageSize int
+}
+
 // RecordSets Struct. Used for Create and Update record sets. Contains a list of RecordSet objects
 type RecordSets struct {
 	RecordSets []RecordSet `json:"recordsets"`
@@ -56,11 +80,12 @@ type RecordSet struct {
 
 // Metadata contains metadata of RecordSet response
 type Metadata struct {
-	LastPage      int  `json:"lastPage"`
-	Page          int  `json:"page"`
-	PageSize      int  `json:"pageSize"`
-	ShowAll       bool `json:"showAll"`
-	TotalElements int  `json:"totalElements"`
+	LastPage      int    `json:"lastPage"`
+	Page          int    `json:"page"`
+	PageSize      int    `json:"pageSize"`
+	ShowAll       bool   `json:"showAll"`
+	TotalElements int    `json:"totalElements"`
+	SortBy        string `json:"sortBy,omitempty"`
 }
 
 // RecordSetResponse contains a response with a list of record sets
@@ -88,10 +113,58 @@ func (rs *RecordSets) Validate() error {
 	return nil
 }
 
+// checkCNAMEs returns ErrInvalidCNAME when one of the record sets is a CNAME at the zone apex
+// or a CNAME sharing its name with record sets of other types
+func (rs *RecordSets) checkCNAMEs(zone string) error {
+	types := make(map[string][]string)
+	var names []string
+	for _, rec := range rs.RecordSets {
+		name := canonicalName(rec.Name)
+		if _, ok := types[name]; !ok {
+			names = append(names, name)
+		}
+		types[name] = append(types[name], strings.ToUpper(rec.Type))
+	}
+
+	var conflicts []string
+	for _, name := range names {
+		hasCNAME := false
+		for _, recordType := range types[name] {
+			if recordType == "CNAME" {
+				hasCNAME = true
+			}
+		}
+		if !hasCNAME {
+			continue
+		}
+		if err := CheckApexCNAME(zone, &RecordBody{Name: name, RecordType: "CNAME"}); err != nil {
+			return err
+		}
+		if len(types[name]) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", name, strings.Join(types[name], ", ")))
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: a CNAME cannot coexist with other record sets at the same name: %s",
+			ErrInvalidCNAME, strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
 func (d *dns) GetRecordSets(ctx context.Context, zone string, queryArgs ...RecordSetQueryArgs) (*RecordSetResponse, error) {
 	logger := d.Log(ctx)
 	logger.Debug("GetRecordSets")
 
+	result, err := d.getRecordSets(ctx, zone, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	d.normalizeRecordSets(result.RecordSets)
+	return result, nil
+}
+
+// getRecordSets lists record sets in the form returned by the API, regardless of the normalization policy of the client
+func (d *dns) getRecordSets(ctx context.Context, zone string, queryArgs ...RecordSetQueryArgs) (*RecordSetResponse, error) {
 	if len(queryArgs) > 1 {
 		return nil, fmt.Errorf("invalid arguments GetRecordSets QueryArgs")
 	}
@@ -137,17 +210,48 @@ func (d *dns) GetRecordSets(ctx context.Context, zone string, queryArgs ...Recor
 	return &result, nil
 }
 
+// zoneCapacityWarningPercent is the share of the zone record set limit beyond which CheckZoneCapacity logs a warning
+const zoneCapacityWarningPercent = 90
+
+func (d *dns) CheckZoneCapacity(ctx context.Context, zone string, additional int) error {
+	logger := d.Log(ctx)
+	logger.Debug("CheckZoneCapacity")
+
+	if zone == "" {
+		return fmt.Errorf("%w: zone is required", ErrBadRequest)
+	}
+	if additional < 0 {
+		return fmt.Errorf("%w: additional record sets must not be negative, got %d", ErrBadRequest, additional)
+	}
+	if d.zoneRecordLimit <= 0 {
+		return nil
+	}
+
+	result, err := d.getRecordSets(ctx, zone, RecordSetQueryArgs{Page: 1, PageSize: 1})
+	if err != nil {
+		return fmt.Errorf("counting record sets of zone %s: %w", zone, err)
+	}
+	current := result.Metadata.TotalElements
+	total := current + additional
+	if total > d.zoneRecordLimit {
+		return fmt.Errorf("%w: zone %s has %d record sets, adding %d would exceed the limit of %d",
+			ErrZoneCapacityExceeded, zone, current, additional, d.zoneRecordLimit)
+	}
+	if total*100 > d.zoneRecordLimit*zoneCapacityWarningPercent {
+		logger.Warnf("zone %s will have %d of at most %d record sets", zone, total, d.zoneRecordLimit)
+	}
+	return nil
+}
+
 func (d *dns) CreateRecordSets(ctx context.Context, recordSets *RecordSets, zone string, recLock ...bool) error {
 	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
 	// is required to be incremented for every subsequent update to a zone
 	// so we have to save just one request at a time to ensure this is always
 	// incremented properly
 
-	if localLock(recLock) {
-		zoneRecordSetsWriteLock.Lock()
-		defer zoneRecordSetsWriteLock.Unlock()
-	}
+	defer d.lockWrite(&zoneRecordSetsWriteLock, zone, recLock...)()
+	defer d.cache.invalidateZone(zone)
 
 	logger := d.Log(ctx)
 	logger.Debug("CreateRecordSets")
@@ -155,6 +259,9 @@ func (d *dns) CreateRecordSets(ctx context.Context, recordSets *RecordSets, zone
 	if err := recordSets.Validate(); err != nil {
 		return err
 	}
+	if err := recordSets.checkCNAMEs(zone); err != nil {
+		return err
+	}
 
 	reqBody, err := convertStructToReqBody(recordSets)
 	if err != nil {
@@ -172,6 +279,16 @@ func (d *dns) CreateRecordSets(ctx context.Context, recordSets *RecordSets, zone
 		return fmt.Errorf("CreateRecordsets request failed: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusConflict && d.reconcileCreates {
+		conflictErr := d.Error(resp)
+		for _, rs := range recordSets.RecordSets {
+			if err := d.reconcileCreate(ctx, zone, rs); err != nil {
+				return fmt.Errorf("%w: %w", conflictErr, err)
+			}
+		}
+		return nil
+	}
+
 	if resp.StatusCode != http.StatusNoContent {
 		return d.Error(resp)
 	}
@@ -181,15 +298,13 @@ func (d *dns) CreateRecordSets(ctx context.Context, recordSets *RecordSets, zone
 
 func (d *dns) UpdateRecordSets(ctx context.Context, recordSets *RecordSets, zone string, recLock ...bool) error {
 	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
 	// is required to be incremented for every subsequent update to a zone
 	// so we have to save just one request at a time to ensure this is always
 	// incremented properly
 
-	if localLock(recLock) {
-		zoneRecordSetsWriteLock.Lock()
-		defer zoneRecordSetsWriteLock.Unlock()
-	}
+	defer d.lockWrite(&zoneRecordSetsWriteLock, zone, recLock...)()
+	defer d.cache.invalidateZone(zone)
 
 	logger := d.Log(ctx)
 	logger.Debug("UpdateRecordsets")
@@ -197,6 +312,9 @@ func (d *dns) UpdateRecordSets(ctx context.Context, recordSets *RecordSets, zone
 	if err := recordSets.Validate(); err != nil {
 		return err
 	}
+	if err := recordSets.checkCNAMEs(zone); err != nil {
+		return err
+	}
 
 	reqBody, err := convertStructToReqBody(recordSets)
 	if err != nil {
@@ -220,3 +338,19 @@ func (d *dns) UpdateRecordSets(ctx context.Context, recordSets *RecordSets, zone
 
 	return nil
 }
+
+// reconcileCreate returns nil when the record set exists with the TTL and rdata of rs, so that a create which failed
+// with 409 Conflict, e.g. on the retry of a create whose response was lost, can be treated as a success.
+// A TTL of TTLInheritZone matches the TTL of the existing record set, whatever the default TTL of the zone.
+func (d *dns) reconcileCreate(ctx context.Context, zone string, rs RecordSet) error {
+	existing, err := d.GetRecord(ctx, zone, rs.Name, rs.Type)
+	if err != nil {
+		return fmt.Errorf("reconciling %s %s: %w", rs.Name, rs.Type, err)
+	}
+	rdata := d.ProcessRdata(ctx, rs.Rdata, rs.Type)
+	if (rs.TTL != TTLInheritZone && existing.TTL != rs.TTL) || !reflect.DeepEqual(sortedRdata(d.ProcessRdata(ctx, existing.Target, rs.Type)), sortedRdata(rdata)) {
+		return fmt.Errorf("existing %s %s record set differs from the requested one", rs.Name, rs.Type)
+	}
+	d.Log(ctx).Debugf("%s %s record set already exists as requested", rs.Name, rs.Type)
+	return nil
+}
@@ -3,10 +3,14 @@ package dns
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -168,6 +172,26 @@ func TestDNS_CreateRecordSets(t *testing.T) {
 				StatusCode: http.StatusInternalServerError,
 			},
 		},
+		"CNAME at zone apex": {
+			zone: "example.com",
+			sets: &RecordSets{
+				[]RecordSet{
+					{Name: "example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.net."}},
+				},
+			},
+			withError: ErrInvalidCNAME,
+		},
+		"CNAME coexisting with other record types": {
+			zone: "example.com",
+			sets: &RecordSets{
+				[]RecordSet{
+					{Name: "www.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.net."}},
+					{Name: "www.example.com.", Type: "TXT", TTL: 300, Rdata: []string{`"text"`}},
+					{Name: "api.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"api.example.net."}},
+				},
+			},
+			withError: ErrInvalidCNAME,
+		},
 	}
 
 	for name, test := range tests {
@@ -192,6 +216,69 @@ func TestDNS_CreateRecordSets(t *testing.T) {
 	}
 }
 
+func TestDNS_CreateRecordSetsReconciliation(t *testing.T) {
+	sets := &RecordSets{[]RecordSet{
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.2"}},
+		{Name: "example.com", Type: "MX", TTL: 300, Rdata: []string{"10 mail.example.com"}},
+	}}
+
+	tests := map[string]struct {
+		existing         map[string]string
+		expectedRequests []string
+		withError        error
+	}{
+		"all record sets match": {
+			existing: map[string]string{
+				"/config-dns/v2/zones/example.com/names/www.example.com/types/A": `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2"]}`,
+				"/config-dns/v2/zones/example.com/names/example.com/types/MX":    `{"name": "example.com", "type": "MX", "ttl": 300, "rdata": ["10 mail.example.com."]}`,
+			},
+			expectedRequests: []string{
+				"POST /config-dns/v2/zones/example.com/recordsets",
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"GET /config-dns/v2/zones/example.com/names/example.com/types/MX",
+			},
+		},
+		"record set differs": {
+			existing: map[string]string{
+				"/config-dns/v2/zones/example.com/names/www.example.com/types/A": `{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2"]}`,
+				"/config-dns/v2/zones/example.com/names/example.com/types/MX":    `{"name": "example.com", "type": "MX", "ttl": 300, "rdata": ["20 other.example.com."]}`,
+			},
+			expectedRequests: []string{
+				"POST /config-dns/v2/zones/example.com/recordsets",
+				"GET /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+				"GET /config-dns/v2/zones/example.com/names/example.com/types/MX",
+			},
+			withError: &Error{Title: "Conflict", StatusCode: http.StatusConflict},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusConflict)
+					_, err := w.Write([]byte(`{"title": "Conflict", "status": 409}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.existing[r.URL.Path]))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, WithCreateReconciliation(true))
+			err := client.CreateRecordSets(context.Background(), sets, "example.com")
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestDNS_UpdateRecordSets(t *testing.T) {
 	tests := map[string]struct {
 		zone             string
@@ -274,3 +361,113 @@ func TestDNS_UpdateRecordSets(t *testing.T) {
 		})
 	}
 }
+
+func TestDNS_CheckZoneCapacity(t *testing.T) {
+	tests := map[string]struct {
+		zone             string
+		additional       int
+		limit            int
+		totalElements    int
+		responseStatus   int
+		expectedRequests []string
+		expectedWarning  string
+		withError        error
+	}{
+		"within limit": {
+			zone:             "example.com",
+			additional:       100,
+			limit:            10000,
+			totalElements:    5000,
+			responseStatus:   http.StatusOK,
+			expectedRequests: []string{"/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=1&showAll=false"},
+		},
+		"near limit": {
+			zone:             "example.com",
+			additional:       100,
+			limit:            10000,
+			totalElements:    9800,
+			responseStatus:   http.StatusOK,
+			expectedRequests: []string{"/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=1&showAll=false"},
+			expectedWarning:  "zone example.com will have 9900 of at most 10000 record sets",
+		},
+		"exactly at limit": {
+			zone:             "example.com",
+			additional:       200,
+			limit:            10000,
+			totalElements:    9800,
+			responseStatus:   http.StatusOK,
+			expectedRequests: []string{"/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=1&showAll=false"},
+			expectedWarning:  "zone example.com will have 10000 of at most 10000 record sets",
+		},
+		"limit exceeded": {
+			zone:             "example.com",
+			additional:       500,
+			limit:            10000,
+			totalElements:    9800,
+			responseStatus:   http.StatusOK,
+			expectedRequests: []string{"/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=1&showAll=false"},
+			withError:        ErrZoneCapacityExceeded,
+		},
+		"no limit": {
+			zone:          "example.com",
+			additional:    500,
+			totalElements: 9800,
+		},
+		"negative additional": {
+			zone:       "example.com",
+			additional: -1,
+			limit:      10000,
+			withError:  ErrBadRequest,
+		},
+		"missing zone": {
+			additional: 1,
+			limit:      10000,
+			withError:  ErrBadRequest,
+		},
+		"zone not found": {
+			zone:             "example.com",
+			additional:       1,
+			limit:            10000,
+			responseStatus:   http.StatusNotFound,
+			expectedRequests: []string{"/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=1&showAll=false"},
+			withError:        &Error{StatusCode: http.StatusNotFound},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.responseStatus)
+				if test.responseStatus != http.StatusOK {
+					_, err := w.Write([]byte(`{"type": "https://problems.luna.akamaiapis.net/authoritative-dns/zone-not-found", "title": "Not Found", "status": 404}`))
+					assert.NoError(t, err)
+					return
+				}
+				_, err := fmt.Fprintf(w, `{"metadata": {"page": 1, "pageSize": 1, "totalElements": %d}, "recordsets": [{"name": "example.com", "type": "SOA", "ttl": 86400, "rdata": ["a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"]}]}`, test.totalElements)
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, WithZoneRecordLimit(test.limit))
+
+			handler := memory.New()
+			ctx := session.ContextWithOptions(context.Background(),
+				session.WithContextLog(&log.Logger{Handler: handler, Level: log.WarnLevel}))
+			err := client.CheckZoneCapacity(ctx, test.zone, test.additional)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.expectedWarning == "" {
+				assert.Empty(t, handler.Entries)
+				return
+			}
+			require.Len(t, handler.Entries, 1)
+			assert.Equal(t, test.expectedWarning, handler.Entries[0].Message)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package dns
+
+import "sort"
+
+// RecordTypeInfo describes a record type supported by Edge DNS and the fields of its rdata
+type RecordTypeInfo struct {
+	// Type is the record type, e.g. "MX"
+	Type string
+	// RequiredFields are the whitespace separated fields of each rdata value, in order. The fields of the types
+	// ParseRData parses are named after the keys of its map, the rdata of CERT records starting with a type returned
+	// as type_value or type_mnemonic.
+	RequiredFields []string
+	// OptionalFields are the fields which may follow the required fields
+	OptionalFields []string
+	// MultipleTargets reports whether a recordset of the type may have several rdata values
+	MultipleTargets bool
+	// Example is a valid rdata value of the type
+	Example string
+}
+
+// recordTypes are the record types supported by Edge DNS, keyed by type
+var recordTypes = map[string]RecordTypeInfo{
+	"A": {
+		RequiredFields:  []string{"address"},
+		MultipleTargets: true,
+		Example:         "192.0.2.1",
+	},
+	"AAAA": {
+		RequiredFields:  []string{"address"},
+		MultipleTargets: true,
+		Example:         "2001:db8::1",
+	},
+	"AFSDB": {
+		RequiredFields:  []string{"subtype", "target"},
+		MultipleTargets: true,
+		Example:         "1 afsdb.example.com.",
+	},
+	"AKAMAICDN": {
+		RequiredFields: []string{"target"},
+		Example:        "www.example.com.edgekey.net",
+	},
+	"AKAMAITLC": {
+		RequiredFields: []string{"answer_type", "dns_name"},
+		Example:        "DUAL a1.w10.akamai.net.",
+	},
+	"CAA": {
+		RequiredFields:  []string{"flags", "tag", "value"},
+		MultipleTargets: true,
+		Example:         `0 issue "letsencrypt.org"`,
+	},
+	"CERT": {
+		RequiredFields:  []string{"type", "keytag", "algorithm", "certificate"},
+		MultipleTargets: true,
+		Example:         "PGP 0 0 MIGfMA0GCSqGSIb3DQEBAQUAA4GN",
+	},
+	"CNAME": {
+		RequiredFields: []string{"target"},
+		Example:        "www.example.net.",
+	},
+	"DNSKEY": {
+		RequiredFields:  []string{"flags", "protocol", "algorithm", "key"},
+		MultipleTargets: true,
+		Example:         "257 3 8 AwEAAaetidLzsKWUt4sw WR8yu0wPHPiUi8LU",
+	},
+	"DS": {
+		RequiredFields:  []string{"keytag", "algorithm", "digest_type", "digest"},
+		MultipleTargets: true,
+		Example:         "30336 8 2 0BB5D4D8DE6A6AB2E9DB8F5AF2C26DBD 3B3D0C3B0A4E5F6A7B8C9D0E1F2A3B4C",
+	},
+	"HINFO": {
+		RequiredFields:  []string{"hardware", "software"},
+		MultipleTargets: true,
+		Example:         "INTEL-386 UNIX",
+	},
+	"HTTPS": {
+		RequiredFields:  []string{"svc_priority", "target_name"},
+		OptionalFields:  []string{"svc_params"},
+		MultipleTargets: true,
+		Example:         "1 . alpn=h2,h3",
+	},
+	"LOC": {
+		RequiredFields: []string{
+			"lat_degrees", "lat_minutes", "lat_seconds", "lat_direction",
+			"long_degrees", "long_minutes", "long_seconds", "long_direction",
+			"altitude", "size", "horiz_precision", "vert_precision",
+		},
+		MultipleTargets: true,
+		Example:         "51 30 12.748 N 0 7 39.611 W 0.00m 0.00m 0.00m 0.00m",
+	},
+	"MX": {
+		RequiredFields:  []string{"priority", "target"},
+		MultipleTargets: true,
+		Example:         "10 mail.example.com.",
+	},
+	"NAPTR": {
+		RequiredFields:  []string{"order", "preference", "flagsnaptr", "service", "regexp", "replacement"},
+		MultipleTargets: true,
+		Example:         `100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com.`,
+	},
+	"NS": {
+		RequiredFields:  []string{"target"},
+		MultipleTargets: true,
+		Example:         "a1-1.akam.net.",
+	},
+	"NSEC3": {
+		RequiredFields:  []string{"algorithm", "flags", "iterations", "salt", "next_hashed_owner_name", "type_bitmaps"},
+		MultipleTargets: true,
+		Example:         "1 0 1 AABBCCDD 2VPTU5TIMAMQTTGL4LUU9KG21E0AOR3S A",
+	},
+	"NSEC3PARAM": {
+		RequiredFields:  []string{"algorithm", "flags", "iterations", "salt"},
+		MultipleTargets: true,
+		Example:         "1 0 1 AABBCCDD",
+	},
+	"PTR": {
+		RequiredFields:  []string{"target"},
+		MultipleTargets: true,
+		Example:         "www.example.com.",
+	},
+	"RP": {
+		RequiredFields:  []string{"mailbox", "txt"},
+		MultipleTargets: true,
+		Example:         "admin.example.com. info.example.com.",
+	},
+	"RRSIG": {
+		RequiredFields: []string{
+			"type_covered", "algorithm", "labels", "original_ttl", "expiration", "inception", "keytag", "signer", "signature",
+		},
+		MultipleTargets: true,
+		Example:         "A 8 2 300 20240101000000 20231201000000 30336 example.com. oJB1W6WNGv+ldvQ3 WDG0MQkg5IEhjRip",
+	},
+	"SOA": {
+		RequiredFields: []string{"name_server", "email_address", "serial", "refresh", "retry", "expiry", "nxdomain_ttl"},
+		Example:        "a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300",
+	},
+	"SPF": {
+		RequiredFields:  []string{"text"},
+		MultipleTargets: true,
+		Example:         `"v=spf1 -all"`,
+	},
+	"SRV": {
+		RequiredFields:  []string{"priority", "weight", "port", "target"},
+		MultipleTargets: true,
+		Example:         "10 60 5060 sip.example.com.",
+	},
+	"SSHFP": {
+		RequiredFields:  []string{"algorithm", "fingerprint_type", "fingerprint"},
+		MultipleTargets: true,
+		Example:         "1 1 123456789ABCDEF67890123456789ABCDEF67890",
+	},
+	"SVCB": {
+		RequiredFields:  []string{"svc_priority", "target_name"},
+		OptionalFields:  []string{"svc_params"},
+		MultipleTargets: true,
+		Example:         "1 svc.example.com. port=8443",
+	},
+	"TLSA": {
+		RequiredFields:  []string{"usage", "selector", "match_type", "certificate"},
+		MultipleTargets: true,
+		Example:         "3 1 1 0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6",
+	},
+	"TXT": {
+		RequiredFields:  []string{"text"},
+		MultipleTargets: true,
+		Example:         `"hello world"`,
+	},
+	"ZONEMD": {
+		RequiredFields:  []string{"serial", "scheme", "hash_algorithm", "digest"},
+		MultipleTargets: true,
+		Example:         "2024010101 1 1 FEBE3D4CE2EC2FFA4BA99D46CD69D6D29711E55217057BEE7EB1A7B641A47BA7FED2DD5B97AE499FAFA4F22C6BD647DE",
+	},
+}
+
+// supportedRecordTypes contains the record types accepted by the Edge DNS API
+var supportedRecordTypes = func() map[string]struct{} {
+	types := make(map[string]struct{}, len(recordTypes))
+	for recordType := range recordTypes {
+		types[recordType] = struct{}{}
+	}
+	return types
+}()
+
+// SupportedRecordTypes returns the record types supported by Edge DNS, sorted by type, with the fields of their rdata,
+// e.g. to build forms or validators. RecordBody.Validate and ParseRData handle the same types.
+func SupportedRecordTypes() []RecordTypeInfo {
+	infos := make([]RecordTypeInfo, 0, len(recordTypes))
+	for recordType, info := range recordTypes {
+		info.Type = recordType
+		info.RequiredFields = append([]string(nil), info.RequiredFields...)
+		info.OptionalFields = append([]string(nil), info.OptionalFields...)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Type < infos[j].Type
+	})
+	return infos
+}
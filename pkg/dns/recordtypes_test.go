@@ -0,0 +1,70 @@
+package dns
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportedRecordTypes(t *testing.T) {
+	client := Client(session.Must(session.New()))
+	// parsedFields maps the keys of the map returned by ParseRData which are not named after an rdata field
+	parsedFields := map[string]string{
+		"type_value":       "type",
+		"type_mnemonic":    "type",
+		"svc_param_values": "svc_params",
+	}
+
+	infos := SupportedRecordTypes()
+	require.Len(t, infos, len(supportedRecordTypes))
+	assert.True(t, sort.SliceIsSorted(infos, func(i, j int) bool { return infos[i].Type < infos[j].Type }))
+
+	for _, info := range infos {
+		t.Run(info.Type, func(t *testing.T) {
+			require.Contains(t, supportedRecordTypes, info.Type)
+			require.NotEmpty(t, info.RequiredFields)
+
+			record := &RecordBody{Name: "www.example.com", RecordType: info.Type, TTL: 300, Target: []string{info.Example}}
+			assert.NoError(t, record.Validate())
+			if !info.MultipleTargets {
+				record.Target = []string{info.Example, info.Example}
+				assert.Error(t, record.Validate())
+			}
+
+			parsed := client.ParseRData(context.Background(), info.Type, []string{info.Example})
+			require.Contains(t, parsed, "target")
+			fields := append(append([]string{"target"}, info.RequiredFields...), info.OptionalFields...)
+			var parsedAny bool
+			for key := range parsed {
+				if field, ok := parsedFields[key]; ok {
+					key = field
+				}
+				assert.Contains(t, fields, key, "ParseRData returned a field which is not described")
+				parsedAny = parsedAny || key != "target"
+			}
+			if !parsedAny {
+				return
+			}
+			for _, field := range info.RequiredFields {
+				if field == "target" {
+					continue
+				}
+				found := false
+				for key := range parsed {
+					found = found || key == field || parsedFields[key] == field
+				}
+				assert.True(t, found, "required field %s is not returned by ParseRData", field)
+			}
+		})
+	}
+}
+
+func TestSupportedRecordTypes_ReturnsCopies(t *testing.T) {
+	infos := SupportedRecordTypes()
+	infos[0].RequiredFields[0] = "modified"
+	assert.NotEqual(t, "modified", SupportedRecordTypes()[0].RequiredFields[0])
+}
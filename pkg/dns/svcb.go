@@ -0,0 +1,302 @@
+package dns
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+type (
+	// SVCBRecord is the parsed rdata of a SVCB or HTTPS record (RFC 9460). HTTPS records are SVCB records
+	// bound to the HTTPS scheme, used e.g. to alias the apex of a zone to a CDN hostname or to publish ECH keys.
+	SVCBRecord struct {
+		// Priority is 0 for AliasMode records, which must not have params, and the priority for ServiceMode records
+		Priority int
+		// Target is the target name, "." meaning the owner name for ServiceMode records
+		Target string
+		// Params are the SvcParams of a ServiceMode record, in the order of the rdata
+		Params []SvcParam
+	}
+
+	// HTTPSRecord is the parsed rdata of an HTTPS record, which has the same format as a SVCB record
+	HTTPSRecord = SVCBRecord
+
+	// SvcParam is a SvcParam of a SVCB or HTTPS record, e.g. alpn=h2,h3
+	SvcParam struct {
+		// Key is the lowercase key, one of the SvcParamKey* constants or keyNNNNN
+		Key string
+		// Value is the unquoted value, empty for keys without value such as no-default-alpn
+		Value string
+	}
+)
+
+// SvcParam keys defined by RFC 9460, other keys are written keyNNNNN
+const (
+	SvcParamKeyMandatory     = "mandatory"
+	SvcParamKeyALPN          = "alpn"
+	SvcParamKeyNoDefaultALPN = "no-default-alpn"
+	SvcParamKeyPort          = "port"
+	SvcParamKeyIPv4Hint      = "ipv4hint"
+	SvcParamKeyECH           = "ech"
+	SvcParamKeyIPv6Hint      = "ipv6hint"
+)
+
+// svcParamKeyNumbers maps the named SvcParam keys to their number, so that e.g. alpn and key1 are the same key
+var svcParamKeyNumbers = map[string]int{
+	SvcParamKeyMandatory:     0,
+	SvcParamKeyALPN:          1,
+	SvcParamKeyNoDefaultALPN: 2,
+	SvcParamKeyPort:          3,
+	SvcParamKeyIPv4Hint:      4,
+	SvcParamKeyECH:           5,
+	SvcParamKeyIPv6Hint:      6,
+}
+
+// ParseSVCBRdata parses and validates the rdata of a SVCB or HTTPS record,
+// e.g. `1 . alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2`. It returns an error wrapping ErrInvalidSVCBRecord
+// when the priority or target are missing, a param key is unknown or repeated, or a param value is invalid.
+func ParseSVCBRdata(rData string) (*SVCBRecord, error) {
+	fields, err := splitSVCBFields(rData)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("%w: %q: priority and target are required", ErrInvalidSVCBRecord, rData)
+	}
+
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid priority %q", ErrInvalidSVCBRecord, fields[0])
+	}
+	record := &SVCBRecord{
+		Priority: int(priority),
+		Target:   fields[1],
+	}
+	for _, field := range fields[2:] {
+		key, value, _ := strings.Cut(field, "=")
+		record.Params = append(record.Params, SvcParam{
+			Key:   strings.ToLower(key),
+			Value: unquoteSvcParamValue(value),
+		})
+	}
+
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Validate checks the priority and the SvcParams of the record, it returns an error wrapping ErrInvalidSVCBRecord
+func (r *SVCBRecord) Validate() error {
+	if r.Priority < 0 || r.Priority > 65535 {
+		return fmt.Errorf("%w: priority %d out of range", ErrInvalidSVCBRecord, r.Priority)
+	}
+	if r.Target == "" {
+		return fmt.Errorf("%w: target is required", ErrInvalidSVCBRecord)
+	}
+	if r.Priority == 0 && len(r.Params) > 0 {
+		return fmt.Errorf("%w: AliasMode record (priority 0) must not have params", ErrInvalidSVCBRecord)
+	}
+
+	keys := make(map[int]string, len(r.Params))
+	for _, param := range r.Params {
+		number, ok := svcParamKeyNumber(param.Key)
+		if !ok {
+			return fmt.Errorf("%w: unknown param key %q", ErrInvalidSVCBRecord, param.Key)
+		}
+		if other, ok := keys[number]; ok {
+			return fmt.Errorf("%w: duplicate param key %q (%q)", ErrInvalidSVCBRecord, param.Key, other)
+		}
+		keys[number] = param.Key
+		if err := param.validate(); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidSVCBRecord, param.Key, err)
+		}
+	}
+
+	if _, ok := keys[svcParamKeyNumbers[SvcParamKeyNoDefaultALPN]]; ok {
+		if _, ok := keys[svcParamKeyNumbers[SvcParamKeyALPN]]; !ok {
+			return fmt.Errorf("%w: %s requires %s", ErrInvalidSVCBRecord, SvcParamKeyNoDefaultALPN, SvcParamKeyALPN)
+		}
+	}
+	if mandatory, ok := r.Param(SvcParamKeyMandatory); ok {
+		for _, key := range mandatory.Values() {
+			number, _ := svcParamKeyNumber(key)
+			if _, ok := keys[number]; !ok {
+				return fmt.Errorf("%w: mandatory key %q is missing", ErrInvalidSVCBRecord, key)
+			}
+		}
+	}
+	return nil
+}
+
+// Param returns the param with the given key, if any
+func (r *SVCBRecord) Param(key string) (SvcParam, bool) {
+	for _, param := range r.Params {
+		if param.Key == strings.ToLower(key) {
+			return param, true
+		}
+	}
+	return SvcParam{}, false
+}
+
+// String returns the record in rdata presentation format, which ParseSVCBRdata parses back to the same record
+func (r *SVCBRecord) String() string {
+	fields := []string{strconv.Itoa(r.Priority), r.Target}
+	for _, param := range r.Params {
+		fields = append(fields, param.String())
+	}
+	return strings.Join(fields, " ")
+}
+
+// Values returns the comma-separated values of list params such as alpn, ipv4hint, ipv6hint and mandatory.
+// Commas escaped with a backslash, e.g. in alpn ids, do not separate values.
+func (p SvcParam) Values() []string {
+	if p.Value == "" {
+		return nil
+	}
+	var values []string
+	var value strings.Builder
+	for i := 0; i < len(p.Value); i++ {
+		switch c := p.Value[i]; {
+		case c == '\\' && i+1 < len(p.Value):
+			value.WriteByte(p.Value[i+1])
+			i++
+		case c == ',':
+			values = append(values, value.String())
+			value.Reset()
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return append(values, value.String())
+}
+
+// String returns the param in presentation format, quoting the value when it contains whitespace
+func (p SvcParam) String() string {
+	switch {
+	case p.Value == "":
+		return p.Key
+	case strings.ContainsAny(p.Value, " \t\""):
+		return p.Key + `="` + strings.ReplaceAll(p.Value, `"`, `\"`) + `"`
+	}
+	return p.Key + "=" + p.Value
+}
+
+func (p SvcParam) validate() error {
+	values := p.Values()
+	switch p.Key {
+	case SvcParamKeyNoDefaultALPN:
+		if p.Value != "" {
+			return fmt.Errorf("must not have a value")
+		}
+		return nil
+	case SvcParamKeyPort:
+		if _, err := strconv.ParseUint(p.Value, 10, 16); err != nil {
+			return fmt.Errorf("invalid port %q", p.Value)
+		}
+		return nil
+	case SvcParamKeyECH:
+		if _, err := base64.StdEncoding.DecodeString(p.Value); err != nil || p.Value == "" {
+			return fmt.Errorf("invalid base64 ECHConfigList")
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(p.Key, "key") && len(values) == 0 {
+		return fmt.Errorf("value is required")
+	}
+	for _, value := range values {
+		switch p.Key {
+		case SvcParamKeyMandatory:
+			if _, ok := svcParamKeyNumber(value); !ok || value == SvcParamKeyMandatory {
+				return fmt.Errorf("invalid key %q", value)
+			}
+		case SvcParamKeyALPN:
+			if value == "" {
+				return fmt.Errorf("empty protocol id")
+			}
+		case SvcParamKeyIPv4Hint:
+			if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("invalid IPv4 address %q", value)
+			}
+		case SvcParamKeyIPv6Hint:
+			if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+				return fmt.Errorf("invalid IPv6 address %q", value)
+			}
+		}
+	}
+	return nil
+}
+
+// svcParamKeyNumber returns the number of a named or keyNNNNN param key
+func svcParamKeyNumber(key string) (int, bool) {
+	if number, ok := svcParamKeyNumbers[key]; ok {
+		return number, true
+	}
+	digits := strings.TrimPrefix(key, "key")
+	if digits == key || digits == "" {
+		return 0, false
+	}
+	number, err := strconv.ParseUint(digits, 10, 16)
+	if err != nil || number == 65535 {
+		return 0, false
+	}
+	return int(number), true
+}
+
+// splitSVCBFields splits the rdata on whitespace, except inside double-quoted param values, which are kept verbatim
+func splitSVCBFields(rData string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+	for i := 0; i < len(rData); i++ {
+		c := rData[i]
+		switch {
+		case c == '\\' && i+1 < len(rData):
+			field.WriteByte(c)
+			field.WriteByte(rData[i+1])
+			i++
+			continue
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ' ' || c == '\t'):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteByte(c)
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: %q: unterminated quoted value", ErrInvalidSVCBRecord, rData)
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// unquoteSvcParamValue removes the quotes around a param value, unescaping quotes within it
+func unquoteSvcParamValue(value string) string {
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return value
+	}
+	return strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+}
+
+// normalizeSVCBRdata converts the target of SVCB and HTTPS rdata to fqdn form and collapses whitespace between
+// fields, leaving the params verbatim. Rdata which cannot be split in fields is returned as is.
+func normalizeSVCBRdata(rData string) string {
+	fields, err := splitSVCBFields(rData)
+	if err != nil || len(fields) < 2 {
+		return rData
+	}
+	if _, err := strconv.Atoi(fields[0]); err != nil {
+		return rData
+	}
+	fields[1] = fqdn(fields[1])
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,160 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSVCBRdata(t *testing.T) {
+	tests := map[string]struct {
+		rData     string
+		expected  *SVCBRecord
+		canonical string
+		withError string
+	}{
+		"AliasMode": {
+			rData:     "0 cdn.example.net.",
+			expected:  &SVCBRecord{Priority: 0, Target: "cdn.example.net."},
+			canonical: "0 cdn.example.net.",
+		},
+		"alpn and ipv4hint lists": {
+			rData: "1 . alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2",
+			expected: &SVCBRecord{Priority: 1, Target: ".", Params: []SvcParam{
+				{Key: "alpn", Value: "h2,h3"},
+				{Key: "ipv4hint", Value: "192.0.2.1,192.0.2.2"},
+			}},
+			canonical: "1 . alpn=h2,h3 ipv4hint=192.0.2.1,192.0.2.2",
+		},
+		"all keys": {
+			rData: `16 svc.example.net. mandatory=alpn,port alpn="h2,h3" no-default-alpn port=8443 ech=AEj+DQBE ipv6hint=2001:db8::1 key65000="a b"`,
+			expected: &SVCBRecord{Priority: 16, Target: "svc.example.net.", Params: []SvcParam{
+				{Key: "mandatory", Value: "alpn,port"},
+				{Key: "alpn", Value: "h2,h3"},
+				{Key: "no-default-alpn"},
+				{Key: "port", Value: "8443"},
+				{Key: "ech", Value: "AEj+DQBE"},
+				{Key: "ipv6hint", Value: "2001:db8::1"},
+				{Key: "key65000", Value: "a b"},
+			}},
+			canonical: `16 svc.example.net. mandatory=alpn,port alpn=h2,h3 no-default-alpn port=8443 ech=AEj+DQBE ipv6hint=2001:db8::1 key65000="a b"`,
+		},
+		"uppercase keys and extra whitespace": {
+			rData: "1  svc.example.net.   ALPN=h3",
+			expected: &SVCBRecord{Priority: 1, Target: "svc.example.net.", Params: []SvcParam{
+				{Key: "alpn", Value: "h3"},
+			}},
+			canonical: "1 svc.example.net. alpn=h3",
+		},
+		"missing target": {
+			rData:     "1",
+			withError: `invalid SVCB record: "1": priority and target are required`,
+		},
+		"invalid priority": {
+			rData:     "65536 .",
+			withError: `invalid SVCB record: invalid priority "65536"`,
+		},
+		"AliasMode with params": {
+			rData:     "0 cdn.example.net. alpn=h2",
+			withError: "invalid SVCB record: AliasMode record (priority 0) must not have params",
+		},
+		"unknown key": {
+			rData:     "1 . foo=bar",
+			withError: `invalid SVCB record: unknown param key "foo"`,
+		},
+		"key out of range": {
+			rData:     "1 . key65535=bar",
+			withError: `invalid SVCB record: unknown param key "key65535"`,
+		},
+		"duplicate key": {
+			rData:     "1 . alpn=h2 key1=h3",
+			withError: `invalid SVCB record: duplicate param key "key1" ("alpn")`,
+		},
+		"invalid port": {
+			rData:     "1 . port=70000",
+			withError: `invalid SVCB record: port: invalid port "70000"`,
+		},
+		"invalid ipv4hint": {
+			rData:     "1 . ipv4hint=192.0.2.1,2001:db8::1",
+			withError: `invalid SVCB record: ipv4hint: invalid IPv4 address "2001:db8::1"`,
+		},
+		"invalid ipv6hint": {
+			rData:     "1 . ipv6hint=192.0.2.1",
+			withError: `invalid SVCB record: ipv6hint: invalid IPv6 address "192.0.2.1"`,
+		},
+		"empty alpn": {
+			rData:     "1 . alpn=",
+			withError: "invalid SVCB record: alpn: value is required",
+		},
+		"no-default-alpn with value": {
+			rData:     "1 . alpn=h2 no-default-alpn=h3",
+			withError: "invalid SVCB record: no-default-alpn: must not have a value",
+		},
+		"no-default-alpn without alpn": {
+			rData:     "1 . no-default-alpn",
+			withError: "invalid SVCB record: no-default-alpn requires alpn",
+		},
+		"invalid ech": {
+			rData:     "1 . ech=not-base64!",
+			withError: "invalid SVCB record: ech: invalid base64 ECHConfigList",
+		},
+		"missing mandatory key": {
+			rData:     "1 . mandatory=port alpn=h2",
+			withError: `invalid SVCB record: mandatory key "port" is missing`,
+		},
+		"unterminated quote": {
+			rData:     `1 . alpn="h2`,
+			withError: `invalid SVCB record: "1 . alpn=\"h2": unterminated quoted value`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			record, err := ParseSVCBRdata(test.rData)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidSVCBRecord), "want: %s; got: %s", ErrInvalidSVCBRecord, err)
+				assert.Equal(t, test.withError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, record)
+			assert.Equal(t, test.canonical, record.String())
+
+			roundTrip, err := ParseSVCBRdata(record.String())
+			require.NoError(t, err)
+			assert.Equal(t, record, roundTrip)
+		})
+	}
+}
+
+func TestSvcParam_Values(t *testing.T) {
+	tests := map[string]struct {
+		param    SvcParam
+		expected []string
+	}{
+		"no value": {
+			param: SvcParam{Key: "no-default-alpn"},
+		},
+		"single value": {
+			param:    SvcParam{Key: "port", Value: "443"},
+			expected: []string{"443"},
+		},
+		"list": {
+			param:    SvcParam{Key: "alpn", Value: "h2,h3"},
+			expected: []string{"h2", "h3"},
+		},
+		"escaped comma": {
+			param:    SvcParam{Key: "alpn", Value: `h2\,x,h3`},
+			expected: []string{"h2,x", "h3"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.param.Values())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package dns
+
+// targetDiffPolicy is the canonical form in which RecordTargetDiff compares targets
+var targetDiffPolicy = NormalizationPolicy{
+	LowercaseNames: true,
+	TrailingDot:    TriStateTrue,
+	IPv6Form:       IPv6Expanded,
+}
+
+// TargetDiff returns the targets of new which are not in old, and the targets of old which are not in new, each in
+// the order of its slice. The order of the targets in the record set does not matter, as the API does not keep it,
+// while each target is compared as a whole: the preference of an MX target, the priority and weight of an SRV target
+// or the order of the strings of a TXT target are significant, so changing them removes the old target and adds the
+// new one. A target repeated in new more times than in old is added as many more times, and conversely.
+func TargetDiff(old, new []string) (added, removed []string) {
+	return targetDiff(old, new, func(target string) string { return target })
+}
+
+// RecordTargetDiff is TargetDiff for the targets of the record type, which are compared in a canonical form so that
+// cosmetic differences, e.g. the case and trailing dot of domain names or the form of IPv6 addresses, are not changes.
+// The targets returned are in the form of old and new.
+func RecordTargetDiff(recordType string, old, new []string) (added, removed []string) {
+	return targetDiff(old, new, func(target string) string {
+		return targetDiffPolicy.NormalizeRdata([]string{target}, recordType)[0]
+	})
+}
+
+func targetDiff(old, new []string, canonical func(string) string) (added, removed []string) {
+	return missingTargets(new, old, canonical), missingTargets(old, new, canonical)
+}
+
+// missingTargets returns the targets of from which are not in to, counting repeated targets
+func missingTargets(from, to []string, canonical func(string) string) []string {
+	count := make(map[string]int, len(to))
+	for _, target := range to {
+		count[canonical(target)]++
+	}
+	var missing []string
+	for _, target := range from {
+		key := canonical(target)
+		if count[key] > 0 {
+			count[key]--
+			continue
+		}
+		missing = append(missing, target)
+	}
+	return missing
+}
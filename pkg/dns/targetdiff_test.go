@@ -0,0 +1,133 @@
+package dns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetDiff(t *testing.T) {
+	tests := map[string]struct {
+		recordType       string
+		old              []string
+		new              []string
+		expectedAdded    []string
+		expectedRemoved  []string
+		canonicalAdded   []string
+		canonicalRemoved []string
+	}{
+		"A, one target replaced": {
+			recordType:       "A",
+			old:              []string{"1.1.1.1", "2.2.2.2"},
+			new:              []string{"2.2.2.2", "3.3.3.3"},
+			expectedAdded:    []string{"3.3.3.3"},
+			expectedRemoved:  []string{"1.1.1.1"},
+			canonicalAdded:   []string{"3.3.3.3"},
+			canonicalRemoved: []string{"1.1.1.1"},
+		},
+		"A, reordered": {
+			recordType: "A",
+			old:        []string{"1.1.1.1", "2.2.2.2"},
+			new:        []string{"2.2.2.2", "1.1.1.1"},
+		},
+		"A, repeated target": {
+			recordType:     "A",
+			old:            []string{"1.1.1.1"},
+			new:            []string{"1.1.1.1", "1.1.1.1"},
+			expectedAdded:  []string{"1.1.1.1"},
+			canonicalAdded: []string{"1.1.1.1"},
+		},
+		"AAAA, compressed and expanded forms": {
+			recordType:      "AAAA",
+			old:             []string{"2001:0db8:0000:0000:0000:0000:0000:0001"},
+			new:             []string{"2001:db8::1"},
+			expectedAdded:   []string{"2001:db8::1"},
+			expectedRemoved: []string{"2001:0db8:0000:0000:0000:0000:0000:0001"},
+		},
+		"CNAME, case and trailing dot": {
+			recordType:      "CNAME",
+			old:             []string{"www.example.com."},
+			new:             []string{"WWW.example.com"},
+			expectedAdded:   []string{"WWW.example.com"},
+			expectedRemoved: []string{"www.example.com."},
+		},
+		"MX, reordered": {
+			recordType: "MX",
+			old:        []string{"10 mx1.example.com.", "20 mx2.example.com."},
+			new:        []string{"20 mx2.example.com.", "10 mx1.example.com."},
+		},
+		"MX, preferences swapped": {
+			recordType:       "MX",
+			old:              []string{"10 mx1.example.com.", "20 mx2.example.com."},
+			new:              []string{"10 mx2.example.com.", "20 mx1.example.com."},
+			expectedAdded:    []string{"10 mx2.example.com.", "20 mx1.example.com."},
+			expectedRemoved:  []string{"10 mx1.example.com.", "20 mx2.example.com."},
+			canonicalAdded:   []string{"10 mx2.example.com.", "20 mx1.example.com."},
+			canonicalRemoved: []string{"10 mx1.example.com.", "20 mx2.example.com."},
+		},
+		"SRV, weight changed": {
+			recordType:       "SRV",
+			old:              []string{"10 60 5060 sip1.example.com.", "10 40 5060 sip2.example.com."},
+			new:              []string{"10 50 5060 sip1.example.com.", "10 40 5060 SIP2.example.com"},
+			expectedAdded:    []string{"10 50 5060 sip1.example.com.", "10 40 5060 SIP2.example.com"},
+			expectedRemoved:  []string{"10 60 5060 sip1.example.com.", "10 40 5060 sip2.example.com."},
+			canonicalAdded:   []string{"10 50 5060 sip1.example.com."},
+			canonicalRemoved: []string{"10 60 5060 sip1.example.com."},
+		},
+		"TXT, strings of a target reordered": {
+			recordType:       "TXT",
+			old:              []string{`"v=spf1" "-all"`, `"site-verification=abc"`},
+			new:              []string{`"site-verification=abc"`, `"-all" "v=spf1"`},
+			expectedAdded:    []string{`"-all" "v=spf1"`},
+			expectedRemoved:  []string{`"v=spf1" "-all"`},
+			canonicalAdded:   []string{`"-all" "v=spf1"`},
+			canonicalRemoved: []string{`"v=spf1" "-all"`},
+		},
+		"new record set": {
+			recordType:     "NS",
+			new:            []string{"ns1.example.net."},
+			expectedAdded:  []string{"ns1.example.net."},
+			canonicalAdded: []string{"ns1.example.net."},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			added, removed := TargetDiff(test.old, test.new)
+			assert.Equal(t, test.expectedAdded, added)
+			assert.Equal(t, test.expectedRemoved, removed)
+
+			added, removed = RecordTargetDiff(test.recordType, test.old, test.new)
+			assert.Equal(t, test.canonicalAdded, added)
+			assert.Equal(t, test.canonicalRemoved, removed)
+		})
+	}
+}
+
+func TestDNS_PlanZone_LogsTargetDiff(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(zonePlanRecordSets))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	handler := memory.New()
+	ctx := session.ContextWithOptions(context.Background(),
+		session.WithContextLog(&log.Logger{Handler: handler, Level: log.InfoLevel}))
+	_, err := client.PlanZone(ctx, "example.com", []*RecordBody{
+		{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.2", "10.0.0.3"}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, handler.Entries, 1)
+	assert.Equal(t, log.InfoLevel, handler.Entries[0].Level)
+	assert.Equal(t, `Update www.example.com A: TTL 300 -> 300, targets added ["10.0.0.3"], removed ["10.0.0.1"]`, handler.Entries[0].Message)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 
 	"reflect"
 	"strings"
@@ -31,14 +32,17 @@ type (
 		// See: https://techdocs.akamai.com/edge-dns/reference/post-keys-bulk-update
 		TSIGKeyBulkUpdate(context.Context, *TSIGKeyBulkPost) error
 		// GetTSIGKey retrieves a TSIG key for zone.
+		// It returns ErrTSIGKeyNotFound when the zone has no key.
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-zone-key
 		GetTSIGKey(context.Context, string) (*TSIGKeyResponse, error)
 		// DeleteTSIGKey deletes TSIG key for zone.
+		// It returns ErrTSIGKeyNotFound when the zone has no key.
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/delete-zones-zone-key
 		DeleteTSIGKey(context.Context, string) error
 		// UpdateTSIGKey updates TSIG key for zone.
+		// The key is validated first, see TSIGKey.Validate.
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/put-zones-zone-key
 		UpdateTSIGKey(context.Context, *TSIGKey, string) error
@@ -91,15 +95,27 @@ type (
 	}
 )
 
-// Validate validates TSIGKey
+// TSIGAlgorithms contains the HMAC algorithms supported for TSIG keys
+var TSIGAlgorithms = []string{"hmac-md5.sig-alg.reg.int", "hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"}
+
+// Validate validates TSIGKey: the algorithm must be one of TSIGAlgorithms and the secret must be base64 encoded
 func (key *TSIGKey) Validate() error {
 	return validation.Errors{
-		"Name":      validation.Validate(key.Name, validation.Required),
-		"Algorithm": validation.Validate(key.Algorithm, validation.Required),
-		"Secret":    validation.Validate(key.Secret, validation.Required),
+		"Name": validation.Validate(key.Name, validation.Required),
+		"Algorithm": validation.Validate(strings.ToLower(key.Algorithm), validation.Required,
+			validation.In(stringsToInterfaces(TSIGAlgorithms)...).Error(fmt.Sprintf("must be one of %s", strings.Join(TSIGAlgorithms, ", ")))),
+		"Secret": validation.Validate(key.Secret, validation.Required, is.Base64),
 	}.Filter()
 }
 
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
+
 // Validate validates TSIGKeyBulkPost
 func (bulk *TSIGKeyBulkPost) Validate() error {
 	return validation.Errors{
@@ -283,6 +299,9 @@ func (d *dns) GetTSIGKey(ctx context.Context, zone string) (*TSIGKeyResponse, er
 		return nil, fmt.Errorf("GetTsigKey request failed: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: zone %s: %w", ErrTSIGKeyNotFound, zone, d.Error(resp))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, d.Error(resp)
 	}
@@ -305,6 +324,9 @@ func (d *dns) DeleteTSIGKey(ctx context.Context, zone string) error {
 		return fmt.Errorf("DeleteTsigKey request failed: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: zone %s: %w", ErrTSIGKeyNotFound, zone, d.Error(resp))
+	}
 	if resp.StatusCode != http.StatusNoContent {
 		return d.Error(resp)
 	}
@@ -317,7 +339,7 @@ func (d *dns) UpdateTSIGKey(ctx context.Context, tsigKey *TSIGKey, zone string)
 	logger.Debug("UpdateTSIGKey")
 
 	if err := tsigKey.Validate(); err != nil {
-		return err
+		return fmt.Errorf("%w: %s", ErrStructValidation, err)
 	}
 
 	reqBody, err := convertStructToReqBody(tsigKey)
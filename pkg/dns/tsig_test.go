@@ -341,6 +341,19 @@ func TestDNS_GetTSIGKey(t *testing.T) {
 				ZoneCount: 7,
 			},
 		},
+		"404 zone without key": {
+			zone:           "example.com",
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+    "title": "Not Found",
+    "detail": "Zone example.com has no TSIG key",
+    "status": 404
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/key",
+			withError:    ErrTSIGKeyNotFound,
+		},
 		"500 internal server error": {
 			zone:           "example.com",
 			responseStatus: http.StatusInternalServerError,
@@ -396,6 +409,19 @@ func TestDNS_DeleteTSIGKey(t *testing.T) {
 			responseStatus: http.StatusNoContent,
 			expectedPath:   "/config-dns/v2/zones/example.com/key",
 		},
+		"404 zone without key": {
+			zone:           "example.com",
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/not-found",
+    "title": "Not Found",
+    "detail": "Zone example.com has no TSIG key",
+    "status": 404
+}`,
+			expectedPath: "/config-dns/v2/zones/example.com/key",
+			withError:    ErrTSIGKeyNotFound,
+		},
 		"500 internal server error": {
 			zone:           "example.com",
 			responseStatus: http.StatusInternalServerError,
@@ -458,6 +484,24 @@ func TestDNS_UpdateTSIGKey(t *testing.T) {
 			responseStatus: http.StatusNoContent,
 			expectedPath:   "/config-dns/v2/zones/example.com/key",
 		},
+		"unsupported algorithm": {
+			key: TSIGKey{
+				Name:      "example.com.akamai.com.",
+				Algorithm: "hmac-sha3",
+				Secret:    "Ok1qR5IW1ajVka5cHPEJQIXfLyx5V3PSkFBROAzOn21JumDq6nIpoj6H8rfj5Uo+Ok55ZWQ0Wgrf302fDscHLw==",
+			},
+			zone:      "example.com",
+			withError: ErrStructValidation,
+		},
+		"secret not base64": {
+			key: TSIGKey{
+				Name:      "example.com.akamai.com.",
+				Algorithm: "HMAC-SHA256",
+				Secret:    "not base64!",
+			},
+			zone:      "example.com",
+			withError: ErrStructValidation,
+		},
 		"500 internal server error": {
 			key: TSIGKey{
 				Name:      "example.com.akamai.com.",
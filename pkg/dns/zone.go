@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -41,6 +42,22 @@ type (
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-zone-zone-file
 		GetMasterZoneFile(context.Context, string) (string, error)
+		// ExportZone retrieves all recordsets of the zone, following pagination, and renders them as BIND master file text.
+		// The output starts with $ORIGIN and $TTL directives followed by the SOA record, the apex NS records
+		// and the remaining records ordered by name and type. Names inside the zone are written relative to the origin.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-zone-recordsets
+		ExportZone(context.Context, string) (string, error)
+		// ParseMasterFile parses BIND master file content into record bodies ready to be used with CreateRecordSets.
+		// Relative names, including the domain names in the rdata of CNAME, NS, PTR, MX, SRV, AFSDB and SOA records,
+		// are resolved against the origin, which can be changed with $ORIGIN, and records owned by names outside the
+		// origin zone are rejected. Records without an explicit TTL get the defaultTTL, which can be changed with $TTL.
+		// Records of the same name and type are collapsed into a single RecordBody.
+		ParseMasterFile(ctx context.Context, origin, defaultTTL, content string) ([]*RecordBody, error)
+		// ImportRecords reads records exported from another DNS provider in a generic JSON or YAML format, a list of
+		// ImportedRecord, into validated record bodies ready to be used with CreateRecordSets. Records of the same name
+		// and type are collapsed into a single RecordBody, and their values are normalized to the form the API returns.
+		ImportRecords(ctx context.Context, format ImportFormat, r io.Reader) ([]*RecordBody, error)
 		// PostMasterZoneFile updates master zone file.
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/post-zones-zone-zone-file
@@ -61,6 +78,11 @@ type (
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/put-zone
 		UpdateZone(context.Context, *ZoneCreate, ZoneQueryString) error
+		// FindZoneForName returns the candidate zone containing the name, picking the longest matching zone
+		// when several candidates match, e.g. sub.example.com over example.com for www.sub.example.com.
+		// Names and zones are compared case-insensitively, with or without a trailing dot.
+		// It returns ErrNoMatchingZone when none of the candidates contain the name.
+		FindZoneForName(ctx context.Context, name string, candidateZones []string) (string, error)
 		// GetZoneNames retrieves a list of a zone's record names.
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/get-zone-names
@@ -81,6 +103,10 @@ type (
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-create-requests-requestid
 		GetBulkZoneCreateStatus(context.Context, string) (*BulkStatusResponse, error)
+		// WaitForBulkZoneCreate polls the status of a bulk create request every pollInterval
+		// (DefaultBulkZonePollInterval when not positive) until it completes and returns the per-zone result.
+		// Polling stops with the context error when the context is canceled.
+		WaitForBulkZoneCreate(ctx context.Context, requestID string, pollInterval time.Duration) (*BulkCreateResultResponse, error)
 		//GetBulkZoneDeleteStatus retrieves submit request status.
 		//
 		// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-delete-requests-requestid
@@ -374,6 +400,7 @@ func (d *dns) GetMasterZoneFile(ctx context.Context, zone string) (string, error
 func (d *dns) PostMasterZoneFile(ctx context.Context, zone string, fileData string) error {
 	logger := d.Log(ctx)
 	logger.Debug("PostMasterZoneFile")
+	defer d.cache.invalidateZone(zone)
 
 	mtResp := ""
 	pmzfURL := fmt.Sprintf("/config-dns/v2/zones/%s/zone-file", zone)
@@ -399,13 +426,12 @@ func (d *dns) PostMasterZoneFile(ctx context.Context, zone string, fileData stri
 
 func (d *dns) CreateZone(ctx context.Context, zone *ZoneCreate, zoneQueryString ZoneQueryString, clearConn ...bool) error {
 	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
 	// is required to be incremented for every subsequent update to a zone,
 	// so we have to save just one request at a time to ensure this is always
 	// incremented properly
 
-	zoneWriteLock.Lock()
-	defer zoneWriteLock.Unlock()
+	defer d.lockWrite(&zoneWriteLock, zone.Zone)()
 
 	logger := d.Log(ctx)
 	logger.Debug("Zone Create")
@@ -457,13 +483,12 @@ func (d *dns) CreateZone(ctx context.Context, zone *ZoneCreate, zoneQueryString
 
 func (d *dns) SaveChangelist(ctx context.Context, zone *ZoneCreate) error {
 	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
 	// is required to be incremented for every subsequent update to a zone
 	// so we have to save just one request at a time to ensure this is always
 	// incremented properly
 
-	zoneWriteLock.Lock()
-	defer zoneWriteLock.Unlock()
+	defer d.lockWrite(&zoneWriteLock, zone.Zone)()
 
 	logger := d.Log(ctx)
 	logger.Debug("SaveChangeList")
@@ -493,13 +518,13 @@ func (d *dns) SaveChangelist(ctx context.Context, zone *ZoneCreate) error {
 
 func (d *dns) SubmitChangelist(ctx context.Context, zone *ZoneCreate) error {
 	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
 	// is required to be incremented for every subsequent update to a zone
 	// so we have to save just one request at a time to ensure this is always
 	// incremented properly
 
-	zoneWriteLock.Lock()
-	defer zoneWriteLock.Unlock()
+	defer d.lockWrite(&zoneWriteLock, zone.Zone)()
+	defer d.cache.invalidateZone(zone.Zone)
 
 	logger := d.Log(ctx)
 	logger.Debug("SubmitChangeList")
@@ -529,13 +554,12 @@ func (d *dns) SubmitChangelist(ctx context.Context, zone *ZoneCreate) error {
 
 func (d *dns) UpdateZone(ctx context.Context, zone *ZoneCreate, _ ZoneQueryString) error {
 	// This lock will restrict the concurrency of API calls
-	// to 1 save request at a time. This is needed for the Soa.Serial value which
+	// to 1 save request at a time (see WithWriteSerialization). This is needed for the Soa.Serial value which
 	// is required to be incremented for every subsequent update to a zone
 	// so we have to save just one request at a time to ensure this is always
 	// incremented properly
 
-	zoneWriteLock.Lock()
-	defer zoneWriteLock.Unlock()
+	defer d.lockWrite(&zoneWriteLock, zone.Zone)()
 
 	logger := d.Log(ctx)
 	logger.Debug("Zone Update")
@@ -606,7 +630,8 @@ func filterZoneCreate(zone *ZoneCreate) map[string]interface{} {
 	return filteredZone
 }
 
-// ValidateZone validates ZoneCreate Object
+// ValidateZone validates ZoneCreate Object: the type must be PRIMARY, SECONDARY or ALIAS,
+// secondary zones require the IP addresses of their masters and ALIAS zones a target
 func ValidateZone(zone *ZoneCreate) error {
 	if len(zone.Zone) == 0 {
 		return fmt.Errorf("Zone name is required")
@@ -640,10 +665,42 @@ func ValidateZone(zone *ZoneCreate) error {
 	if zone.Masters != nil && len(zone.Masters) > 0 && zType == "PRIMARY" {
 		return fmt.Errorf("Masters is invalid for Primary zone type")
 	}
+	if zType == "SECONDARY" {
+		if len(zone.Masters) == 0 {
+			return fmt.Errorf("Masters is required for Secondary zone type")
+		}
+		for _, master := range zone.Masters {
+			if net.ParseIP(master) == nil {
+				return fmt.Errorf("Masters must be IP addresses, got %q", master)
+			}
+		}
+	}
 
 	return nil
 }
 
+func (d *dns) FindZoneForName(ctx context.Context, name string, candidateZones []string) (string, error) {
+	logger := d.Log(ctx)
+	logger.Debug("FindZoneForName")
+
+	name = canonicalName(name)
+	match := ""
+	for _, zone := range candidateZones {
+		normalized := canonicalName(zone)
+		if normalized == "" || !inDomain(name, normalized) {
+			continue
+		}
+		if len(normalized) > len(canonicalName(match)) {
+			match = zone
+		}
+	}
+	if match == "" {
+		return "", fmt.Errorf("%w: %s", ErrNoMatchingZone, name)
+	}
+
+	return match, nil
+}
+
 func (d *dns) GetZoneNames(ctx context.Context, zone string) (*ZoneNamesResponse, error) {
 	logger := d.Log(ctx)
 	logger.Debug("GetZoneNames")
@@ -711,6 +711,7 @@ func TestDNS_CreateZone(t *testing.T) {
 				Zone:       "example.com",
 				ContractID: "1-2ABCDE",
 				Type:       "secondary",
+				Masters:    []string{"1.2.3.4"},
 			},
 			responseStatus: http.StatusInternalServerError,
 			responseBody: `
@@ -921,6 +922,7 @@ func TestDNS_UpdateZone(t *testing.T) {
 				Zone:       "example.com",
 				ContractID: "1-2ABCDE",
 				Type:       "secondary",
+				Masters:    []string{"1.2.3.4"},
 			},
 			responseStatus: http.StatusInternalServerError,
 			responseBody: `
@@ -1098,6 +1100,36 @@ func TestDNS_GetZoneNameTypes(t *testing.T) {
 	}
 }
 
+func Test_ValidateZone(t *testing.T) {
+	tests := map[string]ZoneCreate{
+		"primary": {
+			Zone: "example.com",
+			Type: "PRIMARY",
+		},
+		"secondary": {
+			Zone:    "example.com",
+			Type:    "secondary",
+			Masters: []string{"1.2.3.4", "2001:db8::1"},
+			TSIGKey: &TSIGKey{
+				Name:      "example.com.akamai.com.",
+				Algorithm: "hmac-sha512",
+				Secret:    "Ok1qR5IW1ajVka5cHPEJQIXfLyx5V3PSkFBROAzOn21JumDq6nIpoj6H8rfj5Uo+Ok55ZWQ0Wgrf302fDscHLw==",
+			},
+		},
+		"alias": {
+			Zone:   "example.com",
+			Type:   "ALIAS",
+			Target: "example.net",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, ValidateZone(&test))
+		})
+	}
+}
+
 func Test_ValidateZoneErrors(t *testing.T) {
 	tests := map[string]ZoneCreate{
 		"empty zone": {},
@@ -1146,6 +1178,21 @@ func Test_ValidateZoneErrors(t *testing.T) {
 			Type:    "PRIMARY",
 			Masters: []string{"foo"},
 		},
+		"secondary no masters": {
+			Zone: "example.com",
+			Type: "SECONDARY",
+		},
+		"secondary master not an IP": {
+			Zone:    "example.com",
+			Type:    "SECONDARY",
+			Masters: []string{"1.2.3.4", "master.example.com"},
+		},
+		"secondary target": {
+			Zone:    "example.com",
+			Type:    "SECONDARY",
+			Masters: []string{"1.2.3.4"},
+			Target:  "example.net",
+		},
 	}
 
 	for name, test := range tests {
@@ -1155,3 +1202,51 @@ func Test_ValidateZoneErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestDNS_FindZoneForName(t *testing.T) {
+	client := Client(session.Must(session.New()))
+	candidates := []string{"example.com", "sub.example.com.", "example.net", "ample.com"}
+
+	tests := map[string]struct {
+		name         string
+		expectedZone string
+		withError    error
+	}{
+		"name in parent zone": {
+			name:         "www.example.com",
+			expectedZone: "example.com",
+		},
+		"name in overlapping sub zone": {
+			name:         "www.sub.example.com",
+			expectedZone: "sub.example.com.",
+		},
+		"apex of sub zone": {
+			name:         "sub.example.com",
+			expectedZone: "sub.example.com.",
+		},
+		"apex with trailing dot and mixed case": {
+			name:         "Example.COM.",
+			expectedZone: "example.com",
+		},
+		"label suffix is not a zone match": {
+			name:      "www.notexample.net",
+			withError: ErrNoMatchingZone,
+		},
+		"no matching zone": {
+			name:      "www.example.org",
+			withError: ErrNoMatchingZone,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			zone, err := client.FindZoneForName(context.Background(), test.name, candidates)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedZone, zone)
+		})
+	}
+}
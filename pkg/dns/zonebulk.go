@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 )
 
+// DefaultBulkZonePollInterval is the interval WaitForBulkZoneCreate polls the request status at when no interval is given
+const DefaultBulkZonePollInterval = 10 * time.Second
+
 // BulkZonesCreate contains a list of one or more new Zones to create
 type BulkZonesCreate struct {
 	Zones []*ZoneCreate `json:"zones"`
@@ -196,3 +200,39 @@ func (d *dns) DeleteBulkZones(ctx context.Context, zonesList *ZoneNameListRespon
 
 	return &result, nil
 }
+
+func (d *dns) WaitForBulkZoneCreate(ctx context.Context, requestID string, pollInterval time.Duration) (*BulkCreateResultResponse, error) {
+	logger := d.Log(ctx)
+	logger.Debug("WaitForBulkZoneCreate")
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultBulkZonePollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := d.GetBulkZoneCreateStatus(ctx, requestID)
+		if err != nil {
+			return nil, fmt.Errorf("WaitForBulkZoneCreate: %w", err)
+		}
+		if status.IsComplete {
+			break
+		}
+		logger.Debugf("Bulk zone create request %s: %d of %d zones processed", requestID,
+			status.SuccessCount+status.FailureCount, status.ZonesSubmitted)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("WaitForBulkZoneCreate: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	result, err := d.GetBulkZoneCreateResult(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("WaitForBulkZoneCreate: %w", err)
+	}
+
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -473,3 +474,116 @@ func TestDNS_DeleteBulkZones(t *testing.T) {
 		})
 	}
 }
+
+func TestDNS_WaitForBulkZoneCreate(t *testing.T) {
+	incomplete := `
+{
+	"requestId": "15bc138f-8d82-451b-80b7-a56b88ffc474",
+	"zonesSubmitted": 2,
+	"successCount": 1,
+	"failureCount": 0,
+	"isComplete": false,
+	"expirationDate": "2020-10-28T17:10:04.515792Z"
+}`
+	complete := `
+{
+	"requestId": "15bc138f-8d82-451b-80b7-a56b88ffc474",
+	"zonesSubmitted": 2,
+	"successCount": 1,
+	"failureCount": 1,
+	"isComplete": true,
+	"expirationDate": "2020-10-28T17:10:04.515792Z"
+}`
+
+	tests := map[string]struct {
+		statusResponses []string
+		statusCode      int
+		timeout         time.Duration
+		expectedResult  *BulkCreateResultResponse
+		withError       error
+	}{
+		"completes after polling": {
+			statusResponses: []string{incomplete, incomplete, complete},
+			statusCode:      http.StatusOK,
+			timeout:         time.Second,
+			expectedResult: &BulkCreateResultResponse{
+				RequestID:                "15bc138f-8d82-451b-80b7-a56b88ffc474",
+				SuccessfullyCreatedZones: []string{"one.testbulk.net"},
+				FailedZones: []*BulkFailedZone{
+					{
+						Zone:          "two.testbulk.net",
+						FailureReason: "ZONE_ALREADY_EXISTS",
+					},
+				},
+			},
+		},
+		"context deadline stops polling": {
+			statusResponses: []string{incomplete},
+			statusCode:      http.StatusOK,
+			timeout:         20 * time.Millisecond,
+			withError:       context.DeadlineExceeded,
+		},
+		"500 internal server error": {
+			statusResponses: []string{`
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error fetching request status",
+	"status": 500
+}`},
+			statusCode: http.StatusInternalServerError,
+			timeout:    time.Second,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching request status",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			polls := 0
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				if r.URL.String() == "/config-dns/v2/zones/create-requests/15bc138f-8d82-451b-80b7-a56b88ffc474/result" {
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(`
+{
+	"requestId": "15bc138f-8d82-451b-80b7-a56b88ffc474",
+	"successfullyCreatedZones": ["one.testbulk.net"],
+	"failedZones": [
+		{
+			"zone": "two.testbulk.net",
+			"failureReason": "ZONE_ALREADY_EXISTS"
+		}
+	]
+}`))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, "/config-dns/v2/zones/create-requests/15bc138f-8d82-451b-80b7-a56b88ffc474", r.URL.String())
+				response := test.statusResponses[len(test.statusResponses)-1]
+				if polls < len(test.statusResponses) {
+					response = test.statusResponses[polls]
+				}
+				polls++
+				w.WriteHeader(test.statusCode)
+				_, err := w.Write([]byte(response))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			result, err := client.WaitForBulkZoneCreate(ctx, "15bc138f-8d82-451b-80b7-a56b88ffc474", time.Millisecond)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			assert.Equal(t, len(test.statusResponses), polls)
+		})
+	}
+}
@@ -0,0 +1,445 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// exportPageSize is the number of recordsets requested per page by ExportZone
+const exportPageSize = 500
+
+func (d *dns) ExportZone(ctx context.Context, zone string) (string, error) {
+	logger := d.Log(ctx)
+	logger.Debug("ExportZone")
+
+	var recordSets []RecordSet
+	for page := 1; ; page++ {
+		resp, err := d.getRecordSets(ctx, zone, RecordSetQueryArgs{Page: page, PageSize: exportPageSize})
+		if err != nil {
+			return "", fmt.Errorf("ExportZone: %w", err)
+		}
+		recordSets = append(recordSets, resp.RecordSets...)
+		if len(resp.RecordSets) == 0 || page >= resp.Metadata.LastPage {
+			break
+		}
+	}
+
+	return formatMasterFile(zone, recordSets), nil
+}
+
+type (
+	// masterFileEntry is a logical master file line, with parenthesized continuation lines joined
+	masterFileEntry struct {
+		line       int
+		tokens     []string
+		blankOwner bool
+	}
+
+	recordKey struct {
+		name       string
+		recordType string
+	}
+)
+
+var recordClasses = map[string]struct{}{"IN": {}, "CH": {}, "CS": {}, "HS": {}}
+
+func (d *dns) ParseMasterFile(ctx context.Context, origin, defaultTTL, content string) ([]*RecordBody, error) {
+	logger := d.Log(ctx)
+	logger.Debug("ParseMasterFile")
+
+	records, err := parseMasterFile(origin, defaultTTL, content)
+	if err != nil {
+		return nil, fmt.Errorf("ParseMasterFile: %w", err)
+	}
+
+	return records, nil
+}
+
+// rdataNameFields lists the indexes of the domain name fields of the rdata of record types, which master files may
+// write relative to the origin
+var rdataNameFields = map[string][]int{
+	"AFSDB": {1},
+	"CNAME": {0},
+	"MX":    {1},
+	"NS":    {0},
+	"PTR":   {0},
+	"SOA":   {0, 1},
+	"SRV":   {3},
+}
+
+func parseMasterFile(origin, defaultTTL, content string) ([]*RecordBody, error) {
+	origin = strings.TrimSuffix(origin, ".")
+	zone := strings.ToLower(origin)
+	ttl := 0
+	if defaultTTL != "" {
+		var err error
+		if ttl, err = parseTTL(defaultTTL); err != nil {
+			return nil, fmt.Errorf("%w: invalid default TTL %q", ErrInvalidMasterFile, defaultTTL)
+		}
+	}
+
+	entries, err := splitMasterFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*RecordBody
+	index := make(map[recordKey]*RecordBody)
+	owner := ""
+	for _, entry := range entries {
+		tokens := entry.tokens
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("%w: line %d: $ORIGIN requires a single domain name", ErrInvalidMasterFile, entry.line)
+			}
+			origin = absoluteName(tokens[1], origin)
+			continue
+		case "$TTL":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("%w: line %d: $TTL requires a single value", ErrInvalidMasterFile, entry.line)
+			}
+			if ttl, err = parseTTL(tokens[1]); err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid TTL %q", ErrInvalidMasterFile, entry.line, tokens[1])
+			}
+			continue
+		}
+		if strings.HasPrefix(tokens[0], "$") {
+			return nil, fmt.Errorf("%w: line %d: unsupported directive %s", ErrInvalidMasterFile, entry.line, tokens[0])
+		}
+
+		if !entry.blankOwner {
+			owner = absoluteName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("%w: line %d: record has no owner name", ErrInvalidMasterFile, entry.line)
+		}
+		if !inZone(owner, zone) {
+			return nil, fmt.Errorf("%w: line %d: owner %s is outside the zone %s", ErrInvalidMasterFile, entry.line, owner, zone)
+		}
+
+		recordTTL, recordType := -1, ""
+		for len(tokens) > 0 && recordType == "" {
+			token := tokens[0]
+			tokens = tokens[1:]
+			if _, ok := recordClasses[strings.ToUpper(token)]; ok {
+				continue
+			}
+			if value, err := parseTTL(token); err == nil && recordTTL < 0 {
+				recordTTL = value
+				continue
+			}
+			recordType = strings.ToUpper(token)
+		}
+		if recordType == "" {
+			return nil, fmt.Errorf("%w: line %d: missing record type", ErrInvalidMasterFile, entry.line)
+		}
+		if _, ok := supportedRecordTypes[recordType]; !ok {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrUnsupportedRecordType, entry.line, recordType)
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("%w: line %d: %s record has no rdata", ErrInvalidMasterFile, entry.line, recordType)
+		}
+		if recordTTL < 0 {
+			if ttl == 0 {
+				return nil, fmt.Errorf("%w: line %d: record has no TTL and no default TTL is set", ErrInvalidMasterFile, entry.line)
+			}
+			recordTTL = ttl
+		}
+
+		key := recordKey{name: strings.ToLower(owner), recordType: recordType}
+		rdata := strings.Join(qualifyRdataNames(recordType, tokens, origin), " ")
+		if record, ok := index[key]; ok {
+			if record.TTL != recordTTL {
+				return nil, fmt.Errorf("%w: line %d: TTL %d of %s %s differs from TTL %d of the same record set",
+					ErrInvalidMasterFile, entry.line, recordTTL, owner, recordType, record.TTL)
+			}
+			record.Target = append(record.Target, rdata)
+			continue
+		}
+		record := &RecordBody{
+			Name:       owner,
+			RecordType: recordType,
+			TTL:        recordTTL,
+			Target:     []string{rdata},
+		}
+		index[key] = record
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// splitMasterFile splits master file content into logical entries, dropping comments and blank lines
+// and joining lines enclosed in parentheses
+func splitMasterFile(content string) ([]masterFileEntry, error) {
+	var entries []masterFileEntry
+	var current *masterFileEntry
+	depth := 0
+	for i, line := range strings.Split(content, "\n") {
+		if depth == 0 {
+			current = &masterFileEntry{
+				line:       i + 1,
+				blankOwner: strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"),
+			}
+		}
+
+		var token strings.Builder
+		flush := func() {
+			if token.Len() > 0 {
+				current.tokens = append(current.tokens, token.String())
+				token.Reset()
+			}
+		}
+		inQuotes, escaped := false, false
+	scan:
+		for _, r := range line {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inQuotes = !inQuotes
+			case inQuotes:
+			case r == ';':
+				break scan
+			case r == '(':
+				flush()
+				depth++
+				continue
+			case r == ')':
+				flush()
+				if depth == 0 {
+					return nil, fmt.Errorf("%w: line %d: unbalanced parentheses", ErrInvalidMasterFile, i+1)
+				}
+				depth--
+				continue
+			case r == ' ' || r == '\t' || r == '\r':
+				flush()
+				continue
+			}
+			token.WriteRune(r)
+		}
+		if inQuotes {
+			return nil, fmt.Errorf("%w: line %d: unterminated quoted string", ErrInvalidMasterFile, i+1)
+		}
+		flush()
+
+		if depth == 0 && len(current.tokens) > 0 {
+			entries = append(entries, *current)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: line %d: unbalanced parentheses", ErrInvalidMasterFile, current.line)
+	}
+
+	return entries, nil
+}
+
+// inZone reports whether the owner name is the zone or a name under it. Any name is in the zone when it is empty.
+func inZone(owner, zone string) bool {
+	owner = strings.ToLower(owner)
+	return zone == "" || owner == zone || strings.HasSuffix(owner, "."+zone)
+}
+
+// qualifyRdataNames returns the rdata fields with the domain names of the record type, e.g. the exchange of an MX
+// record, resolved against the origin in the fully qualified form returned by the API, e.g. "mail.example.com."
+// for "mail" or "@" for "example.com.". Rdata with an unexpected number of fields is returned as is.
+func qualifyRdataNames(recordType string, fields []string, origin string) []string {
+	indexes, ok := rdataNameFields[recordType]
+	if !ok || len(fields) <= indexes[len(indexes)-1] {
+		return fields
+	}
+	qualified := append([]string(nil), fields...)
+	for _, i := range indexes {
+		if name := absoluteName(fields[i], origin); name != "" {
+			qualified[i] = name + "."
+		}
+	}
+	return qualified
+}
+
+// absoluteName resolves the master file name against the origin and returns it without the trailing dot
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case origin == "":
+		return name
+	}
+	return name + "." + origin
+}
+
+// parseTTL parses a TTL given in seconds or in BIND notation, e.g. 1h30m
+func parseTTL(value string) (int, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("negative TTL")
+		}
+		return seconds, nil
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, number := 0, ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+		multiplier, ok := units[c|0x20]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, err
+		}
+		total += n * multiplier
+		number = ""
+	}
+	if number != "" || value == "" {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+
+	return total, nil
+}
+
+// formatMasterFile renders the recordsets of the zone as BIND master file text.
+// The SOA record comes first, followed by the apex NS records and the remaining
+// records ordered by name and type, so that the output is stable across exports.
+func formatMasterFile(zone string, recordSets []RecordSet) string {
+	origin := strings.TrimSuffix(zone, ".")
+	sorted := make([]RecordSet, len(recordSets))
+	copy(sorted, recordSets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := recordRank(sorted[i], origin), recordRank(sorted[j], origin)
+		if ri != rj {
+			return ri < rj
+		}
+		ni, nj := strings.ToLower(sorted[i].Name), strings.ToLower(sorted[j].Name)
+		if ni != nj {
+			return ni < nj
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", origin)
+	for _, rs := range sorted {
+		if strings.EqualFold(rs.Type, "SOA") {
+			fmt.Fprintf(&b, "$TTL %d\n", rs.TTL)
+			break
+		}
+	}
+	for _, rs := range sorted {
+		name := relativeName(rs.Name, origin)
+		recordType := strings.ToUpper(rs.Type)
+		for _, rdata := range rs.Rdata {
+			fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", name, rs.TTL, recordType, formatRdata(recordType, rdata))
+		}
+	}
+
+	return b.String()
+}
+
+// recordRank orders the SOA record first, apex NS records second and everything else after them
+func recordRank(rs RecordSet, origin string) int {
+	apex := strings.EqualFold(strings.TrimSuffix(rs.Name, "."), origin)
+	switch {
+	case apex && strings.EqualFold(rs.Type, "SOA"):
+		return 0
+	case apex && strings.EqualFold(rs.Type, "NS"):
+		return 1
+	case apex:
+		return 2
+	}
+	return 3
+}
+
+// relativeName returns the name relative to the origin, "@" for the origin itself
+// and the fully qualified name with a trailing dot for names outside the origin
+func relativeName(name, origin string) string {
+	name = strings.TrimSuffix(name, ".")
+	if strings.EqualFold(name, origin) {
+		return "@"
+	}
+	if suffix := "." + origin; len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+	return name + "."
+}
+
+// formatRdata formats rdata of the given type, spreading SOA and multi-string TXT records over several lines
+func formatRdata(recordType, rdata string) string {
+	switch recordType {
+	case "SOA":
+		fields := splitRdataFields(rdata)
+		if len(fields) != 7 {
+			return rdata
+		}
+		labels := []string{"serial", "refresh", "retry", "expire", "minimum"}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s %s (", fields[0], fields[1])
+		for i, label := range labels {
+			fmt.Fprintf(&b, "\n\t\t\t%s ; %s", fields[i+2], label)
+		}
+		b.WriteString(" )")
+		return b.String()
+	case "TXT", "SPF":
+		chunks := splitRdataFields(rdata)
+		for i, chunk := range chunks {
+			if !isQuoted(chunk) {
+				chunks[i] = quoteString(chunk)
+			}
+		}
+		if len(chunks) == 1 {
+			return chunks[0]
+		}
+		return "( " + strings.Join(chunks, "\n\t\t\t") + " )"
+	}
+	return rdata
+}
+
+// splitRdataFields splits rdata on whitespace, keeping quoted strings (including the quotes) intact
+func splitRdataFields(rdata string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes, escaped := false, false
+	for _, r := range rdata {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`)
+}
+
+func quoteString(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
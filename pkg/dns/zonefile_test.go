@@ -0,0 +1,244 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_ExportZone(t *testing.T) {
+	tests := map[string]struct {
+		zone             string
+		responseStatus   int
+		responseBodies   map[string]string
+		expectedResponse string
+		withError        error
+	}{
+		"200 OK, multiple pages": {
+			zone:           "example.com",
+			responseStatus: http.StatusOK,
+			responseBodies: map[string]string{
+				"1": `
+{
+	"metadata": {"page": 1, "pageSize": 500, "lastPage": 2, "totalElements": 5},
+	"recordsets": [
+		{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.1", "10.0.0.2"]},
+		{"name": "example.com", "type": "NS", "ttl": 86400, "rdata": ["a1-1.akam.net.", "a2-2.akam.net."]},
+		{"name": "example.com", "type": "TXT", "ttl": 300, "rdata": ["\"v=spf1 -all\"", "\"part one\" \"part two\""]}
+	]
+}`,
+				"2": `
+{
+	"metadata": {"page": 2, "pageSize": 500, "lastPage": 2, "totalElements": 5},
+	"recordsets": [
+		{"name": "example.com", "type": "SOA", "ttl": 86400, "rdata": ["a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"]},
+		{"name": "mail.example.net", "type": "CNAME", "ttl": 600, "rdata": ["mx.example.net."]}
+	]
+}`,
+			},
+			expectedResponse: `$ORIGIN example.com.
+$TTL 86400
+@	86400	IN	SOA	a1-1.akam.net. hostmaster.example.com. (
+			2024010101 ; serial
+			3600 ; refresh
+			600 ; retry
+			604800 ; expire
+			300 ; minimum )
+@	86400	IN	NS	a1-1.akam.net.
+@	86400	IN	NS	a2-2.akam.net.
+@	300	IN	TXT	"v=spf1 -all"
+@	300	IN	TXT	( "part one"
+			"part two" )
+mail.example.net.	600	IN	CNAME	mx.example.net.
+www	300	IN	A	10.0.0.1
+www	300	IN	A	10.0.0.2
+`,
+		},
+		"500 internal server error": {
+			zone:           "example.com",
+			responseStatus: http.StatusInternalServerError,
+			responseBodies: map[string]string{
+				"1": `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error fetching recordsets",
+	"status": 500
+}`,
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching recordsets",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/recordsets", r.URL.Path)
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "500", r.URL.Query().Get("pageSize"))
+				body, ok := test.responseBodies[r.URL.Query().Get("page")]
+				require.True(t, ok, "unexpected page requested: %s", r.URL.Query().Get("page"))
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ExportZone(context.Background(), test.zone)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDNS_ParseMasterFile(t *testing.T) {
+	tests := map[string]struct {
+		origin     string
+		defaultTTL string
+		content    string
+		expected   []*RecordBody
+		withError  error
+	}{
+		"directives, relative names and collapsed record sets": {
+			origin: "example.com.",
+			content: `$TTL 1h
+$ORIGIN example.com.
+@	IN	SOA	a1-1.akam.net. hostmaster.example.com. (
+			2024010101 ; serial
+			3600       ; refresh
+			600        ; retry
+			604800     ; expire
+			300 )      ; minimum
+	86400	IN	NS	a1-1.akam.net.
+	86400	IN	NS	a2-2.akam.net.
+; web servers
+www		300	IN	A	10.0.0.1
+www		300	IN	A	10.0.0.2 ; second server
+@		IN	TXT	"v=spf1 include:_spf.example.com -all"
+@		IN	TXT	( "part one; with semicolon"
+			"part two" )
+mail.example.com.	600	CNAME	mx.example.net.
+$ORIGIN sub.example.com.
+api	IN	300	AAAA	2001:db8::1
+`,
+			expected: []*RecordBody{
+				{Name: "example.com", RecordType: "SOA", TTL: 3600, Target: []string{"a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"}},
+				{Name: "example.com", RecordType: "NS", TTL: 86400, Target: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+				{Name: "example.com", RecordType: "TXT", TTL: 3600, Target: []string{`"v=spf1 include:_spf.example.com -all"`, `"part one; with semicolon" "part two"`}},
+				{Name: "mail.example.com", RecordType: "CNAME", TTL: 600, Target: []string{"mx.example.net."}},
+				{Name: "api.sub.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+			},
+		},
+		"relative and @ rdata names": {
+			origin: "example.com",
+			content: `$TTL 300
+@	SOA	ns1 hostmaster ( 1 3600 600 604800 300 )
+@	NS	ns1
+@	NS	ns2.example.net.
+@	MX	10 mail
+@	MX	20 @
+www	CNAME	@
+ftp	CNAME	www
+_sip._tcp	SRV	10 60 5060 sip
+@	AFSDB	1 afsdb
+$ORIGIN 2.0.192.in-addr.arpa.example.com.
+1	PTR	host.example.com.
+2	PTR	host2
+`,
+			expected: []*RecordBody{
+				{Name: "example.com", RecordType: "SOA", TTL: 300, Target: []string{"ns1.example.com. hostmaster.example.com. 1 3600 600 604800 300"}},
+				{Name: "example.com", RecordType: "NS", TTL: 300, Target: []string{"ns1.example.com.", "ns2.example.net."}},
+				{Name: "example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mail.example.com.", "20 example.com."}},
+				{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"example.com."}},
+				{Name: "ftp.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"www.example.com."}},
+				{Name: "_sip._tcp.example.com", RecordType: "SRV", TTL: 300, Target: []string{"10 60 5060 sip.example.com."}},
+				{Name: "example.com", RecordType: "AFSDB", TTL: 300, Target: []string{"1 afsdb.example.com."}},
+				{Name: "1.2.0.192.in-addr.arpa.example.com", RecordType: "PTR", TTL: 300, Target: []string{"host.example.com."}},
+				{Name: "2.2.0.192.in-addr.arpa.example.com", RecordType: "PTR", TTL: 300, Target: []string{"host2.2.0.192.in-addr.arpa.example.com."}},
+			},
+		},
+		"owner outside the zone": {
+			origin:    "example.com",
+			content:   "mail.example.net. 300 CNAME mx.example.net.\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"owner outside the zone after $ORIGIN": {
+			origin:    "example.com",
+			content:   "$ORIGIN example.net.\nwww 300 A 10.0.0.1\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"owner with the zone as suffix of its last label": {
+			origin:    "example.com",
+			content:   "notexample.com. 300 A 10.0.0.1\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"default TTL": {
+			origin:     "example.com",
+			defaultTTL: "300",
+			content:    "www A 10.0.0.1\n",
+			expected: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+			},
+		},
+		"missing TTL": {
+			origin:    "example.com",
+			content:   "www A 10.0.0.1\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"conflicting TTLs in record set": {
+			origin:    "example.com",
+			content:   "www 300 A 10.0.0.1\nwww 600 A 10.0.0.2\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"unbalanced parentheses": {
+			origin:    "example.com",
+			content:   "@ 300 SOA a1-1.akam.net. hostmaster.example.com. ( 1 2 3 4 5\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"unterminated quoted string": {
+			origin:    "example.com",
+			content:   "@ 300 TXT \"no end\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"unsupported directive": {
+			origin:    "example.com",
+			content:   "$INCLUDE other.zone\n",
+			withError: ErrInvalidMasterFile,
+		},
+		"unsupported record type": {
+			origin:    "example.com",
+			content:   "www 300 WKS 10.0.0.1 TCP 25\n",
+			withError: ErrUnsupportedRecordType,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ParseMasterFile(context.Background(), test.origin, test.defaultTTL, test.content)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
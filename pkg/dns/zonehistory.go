@@ -0,0 +1,163 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// ZoneHistory contains operations auditing the changes of a zone
+	ZoneHistory interface {
+		// GetZoneHistory returns the versions of the zone last modified at or after since, newest first, or all of them
+		// when since is the zero time. Each version is a change of the zone, with the user who made it when the API
+		// reports it, and the serial of its SOA record. A zone without versions has an empty history.
+		//
+		// See: https://techdocs.akamai.com/edge-dns/reference/get-zones-zone-versions
+		GetZoneHistory(ctx context.Context, zone string, since time.Time) ([]ZoneChange, error)
+	}
+
+	// ZoneChange describes a version of a zone
+	ZoneChange struct {
+		VersionID          string
+		ActivationState    string
+		LastModifiedBy     string
+		LastModifiedDate   time.Time
+		LastActivationDate time.Time
+		// Serial is the serial of the SOA record of the version, 0 if the version has no SOA record
+		Serial uint32
+	}
+
+	// zoneVersion is a zone version as returned by the API
+	zoneVersion struct {
+		VersionID          string `json:"versionId"`
+		ActivationState    string `json:"activationState"`
+		LastModifiedBy     string `json:"lastModifiedBy"`
+		LastModifiedDate   string `json:"lastModifiedDate"`
+		LastActivationDate string `json:"lastActivationDate"`
+	}
+
+	// zoneVersionsResponse contains a page of zone versions
+	zoneVersionsResponse struct {
+		Metadata struct {
+			TotalElements int `json:"totalElements"`
+		} `json:"metadata"`
+		Versions []zoneVersion `json:"versions"`
+	}
+)
+
+func (d *dns) GetZoneHistory(ctx context.Context, zone string, since time.Time) ([]ZoneChange, error) {
+	logger := d.Log(ctx)
+	logger.Debug("GetZoneHistory")
+
+	if zone == "" {
+		return nil, fmt.Errorf("%w: zone is required", ErrBadRequest)
+	}
+
+	versions, err := d.getZoneVersions(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("GetZoneHistory: %w", err)
+	}
+
+	changes := make([]ZoneChange, 0, len(versions))
+	for _, v := range versions {
+		change := ZoneChange{
+			VersionID:          v.VersionID,
+			ActivationState:    v.ActivationState,
+			LastModifiedBy:     v.LastModifiedBy,
+			LastModifiedDate:   parseZoneVersionDate(v.LastModifiedDate),
+			LastActivationDate: parseZoneVersionDate(v.LastActivationDate),
+		}
+		if !since.IsZero() && change.LastModifiedDate.Before(since) {
+			continue
+		}
+		if change.Serial, err = d.getZoneVersionSerial(ctx, zone, v.VersionID); err != nil {
+			return nil, fmt.Errorf("GetZoneHistory: version %s: %w", v.VersionID, err)
+		}
+		changes = append(changes, change)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].LastModifiedDate.After(changes[j].LastModifiedDate)
+	})
+
+	return changes, nil
+}
+
+// getZoneVersions lists all versions of the zone, page by page
+func (d *dns) getZoneVersions(ctx context.Context, zone string) ([]zoneVersion, error) {
+	var versions []zoneVersion
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Add("page", strconv.Itoa(page))
+		q.Add("pageSize", strconv.Itoa(exportPageSize))
+		getURL := fmt.Sprintf("/config-dns/v2/zones/%s/versions?%s", zone, q.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GetZoneVersions request: %w", err)
+		}
+
+		var result zoneVersionsResponse
+		resp, err := d.Exec(req, &result)
+		if err != nil {
+			return nil, fmt.Errorf("GetZoneVersions request failed: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, d.Error(resp)
+		}
+
+		versions = append(versions, result.Versions...)
+		if len(result.Versions) == 0 || len(versions) >= result.Metadata.TotalElements {
+			return versions, nil
+		}
+	}
+}
+
+// getZoneVersionSerial returns the serial of the SOA record of the zone version, 0 if it has none
+func (d *dns) getZoneVersionSerial(ctx context.Context, zone, versionID string) (uint32, error) {
+	getURL := fmt.Sprintf("/config-dns/v2/zones/%s/versions/%s/recordsets?types=SOA", zone, versionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create GetZoneVersionRecordsets request: %w", err)
+	}
+
+	var result RecordSetResponse
+	resp, err := d.Exec(req, &result)
+	if err != nil {
+		return 0, fmt.Errorf("GetZoneVersionRecordsets request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, d.Error(resp)
+	}
+
+	for _, rs := range result.RecordSets {
+		if !strings.EqualFold(rs.Type, "SOA") || len(rs.Rdata) == 0 {
+			continue
+		}
+		// SOA rdata is "mname rname serial refresh retry expire minimum"
+		fields := strings.Fields(rs.Rdata[0])
+		if len(fields) < 3 {
+			continue
+		}
+		serial, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid SOA serial %q: %s", fields[2], err)
+		}
+		return uint32(serial), nil
+	}
+	return 0, nil
+}
+
+// parseZoneVersionDate parses the dates of zone versions, returning the zero time for missing or invalid dates
+func parseZoneVersionDate(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
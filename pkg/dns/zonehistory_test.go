@@ -0,0 +1,181 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_GetZoneHistory(t *testing.T) {
+	versions := `
+{
+	"metadata": {"zone": "example.com", "page": 1, "pageSize": 500, "totalElements": 3},
+	"versions": [
+		{
+			"versionId": "a2",
+			"activationState": "PENDING",
+			"lastModifiedBy": "jdoe",
+			"lastModifiedDate": "2024-03-02T10:00:00Z"
+		},
+		{
+			"versionId": "a3",
+			"activationState": "ACTIVE",
+			"lastModifiedBy": "asmith",
+			"lastModifiedDate": "2024-03-03T10:00:00Z",
+			"lastActivationDate": "2024-03-03T10:05:00Z"
+		},
+		{
+			"versionId": "a1",
+			"activationState": "INACTIVE",
+			"lastModifiedDate": "2024-03-01T10:00:00Z",
+			"lastActivationDate": "2024-03-01T10:05:00Z"
+		}
+	]
+}`
+	soa := func(serial string) string {
+		return `{"metadata": {"page": 1, "pageSize": 25, "totalElements": 1}, "recordsets": [{"name": "example.com", "type": "SOA", "ttl": 86400, "rdata": ["a1-1.akam.net. hostmaster.example.com. ` + serial + ` 3600 600 604800 300"]}]}`
+	}
+
+	tests := map[string]struct {
+		since            time.Time
+		responses        map[string]string
+		responseStatus   int
+		expectedRequests []string
+		expectedResponse []ZoneChange
+		withError        error
+	}{
+		"all versions": {
+			responses: map[string]string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500":     versions,
+				"/config-dns/v2/zones/example.com/versions/a1/recordsets?types=SOA": soa("2024030101"),
+				"/config-dns/v2/zones/example.com/versions/a2/recordsets?types=SOA": soa("2024030201"),
+				"/config-dns/v2/zones/example.com/versions/a3/recordsets?types=SOA": `{"metadata": {"totalElements": 0}, "recordsets": []}`,
+			},
+			responseStatus: http.StatusOK,
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500",
+				"/config-dns/v2/zones/example.com/versions/a2/recordsets?types=SOA",
+				"/config-dns/v2/zones/example.com/versions/a3/recordsets?types=SOA",
+				"/config-dns/v2/zones/example.com/versions/a1/recordsets?types=SOA",
+			},
+			expectedResponse: []ZoneChange{
+				{
+					VersionID:          "a3",
+					ActivationState:    "ACTIVE",
+					LastModifiedBy:     "asmith",
+					LastModifiedDate:   time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC),
+					LastActivationDate: time.Date(2024, time.March, 3, 10, 5, 0, 0, time.UTC),
+				},
+				{
+					VersionID:        "a2",
+					ActivationState:  "PENDING",
+					LastModifiedBy:   "jdoe",
+					LastModifiedDate: time.Date(2024, time.March, 2, 10, 0, 0, 0, time.UTC),
+					Serial:           2024030201,
+				},
+				{
+					VersionID:          "a1",
+					ActivationState:    "INACTIVE",
+					LastModifiedDate:   time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+					LastActivationDate: time.Date(2024, time.March, 1, 10, 5, 0, 0, time.UTC),
+					Serial:             2024030101,
+				},
+			},
+		},
+		"since": {
+			since: time.Date(2024, time.March, 2, 10, 0, 0, 0, time.UTC),
+			responses: map[string]string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500":     versions,
+				"/config-dns/v2/zones/example.com/versions/a2/recordsets?types=SOA": soa("2024030201"),
+				"/config-dns/v2/zones/example.com/versions/a3/recordsets?types=SOA": soa("2024030301"),
+			},
+			responseStatus: http.StatusOK,
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500",
+				"/config-dns/v2/zones/example.com/versions/a2/recordsets?types=SOA",
+				"/config-dns/v2/zones/example.com/versions/a3/recordsets?types=SOA",
+			},
+			expectedResponse: []ZoneChange{
+				{
+					VersionID:          "a3",
+					ActivationState:    "ACTIVE",
+					LastModifiedBy:     "asmith",
+					LastModifiedDate:   time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC),
+					LastActivationDate: time.Date(2024, time.March, 3, 10, 5, 0, 0, time.UTC),
+					Serial:             2024030301,
+				},
+				{
+					VersionID:        "a2",
+					ActivationState:  "PENDING",
+					LastModifiedBy:   "jdoe",
+					LastModifiedDate: time.Date(2024, time.March, 2, 10, 0, 0, 0, time.UTC),
+					Serial:           2024030201,
+				},
+			},
+		},
+		"no history": {
+			responses: map[string]string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500": `{"metadata": {"zone": "example.com", "page": 1, "pageSize": 500, "totalElements": 0}, "versions": []}`,
+			},
+			responseStatus: http.StatusOK,
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500",
+			},
+			expectedResponse: []ZoneChange{},
+		},
+		"404 zone not found": {
+			responses: map[string]string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500": `
+{
+	"type": "https://problems.luna.akamaiapis.net/authoritative-dns/notFound",
+	"title": "Not Found",
+	"detail": "Zone example.com does not exist",
+	"status": 404
+}`,
+			},
+			responseStatus: http.StatusNotFound,
+			expectedRequests: []string{
+				"/config-dns/v2/zones/example.com/versions?page=1&pageSize=500",
+			},
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/authoritative-dns/notFound",
+				Title:      "Not Found",
+				Detail:     "Zone example.com does not exist",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				requests = append(requests, r.URL.String())
+				body, ok := test.responses[r.URL.String()]
+				if !assert.True(t, ok, "unexpected request %s", r.URL) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetZoneHistory(context.Background(), "example.com", test.since)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// ZonePlans contains operations reconciling the records of a zone with a desired state.
+	ZonePlans interface {
+		// PlanZone compares the desired records with the live record sets of the zone and returns the creates,
+		// updates and deletes needed to reconcile them, ordered by name and type. Record sets are matched by name
+		// and type, names are compared case-insensitively and rdata is compared in the form returned by the API
+		// (see ProcessRdata), regardless of its order. The SOA and NS record sets at the zone apex, which are often
+		// managed by Akamai, and record sets matching the optional ZonePlanOptions are left out of the plan.
+		// The targets added and removed by each update (see TargetDiff) are logged at info level.
+		PlanZone(ctx context.Context, zone string, desired []*RecordBody, opts ...ZonePlanOptions) (*ZonePlan, error)
+		// ApplyZonePlan performs the operations of the plan under a single zone lock (see WithZoneLock),
+		// creates first, then updates and deletes last, and stops at the first failing operation.
+		// Plans deleting more than ZonePlan.MaxDeletionPercent of the record sets of the zone are refused with
+		// ErrMassDeletionBlocked unless ZonePlan.AllowMassDeletion is set.
+		ApplyZonePlan(ctx context.Context, plan *ZonePlan) error
+		// ApplyZonePlansThrottled applies the plans of several zones in batches of ThrottleOptions.BatchSize operations,
+		// in the order of ApplyZonePlan, each under the zone lock, pausing ThrottleOptions.BatchDelay between the batches
+		// of a zone. Up to ThrottleOptions.Concurrency zones are applied at a time. A zone stops at its first failing
+		// operation while the other zones go on, and the returned error joins the errors of the failed zones.
+		// ThrottleOptions.Progress is called after each batch.
+		ApplyZonePlansThrottled(ctx context.Context, plans []*ZonePlan, opts ThrottleOptions) error
+		// SyncRecordSets reconciles the record sets of the zone with the desired records: it fetches the live record
+		// sets, plans the changes like PlanZone and applies them like ApplyZonePlan, all under a single zone lock so
+		// that no other write of this client interleaves. Record sets absent from desired are only deleted when
+		// SyncOptions.Prune is set, and the sync is refused with ErrMassDeletionBlocked when they are more than
+		// SyncOptions.MaxDeletionPercent of the record sets of the zone, e.g. because desired is empty by mistake,
+		// unless SyncOptions.AllowMassDeletion is set. The returned report lists the changes made, including those made before a failure.
+		SyncRecordSets(ctx context.Context, zone string, desired []*RecordBody, opts SyncOptions) (*SyncReport, error)
+	}
+
+	// ZonePlanOptions contains optional filters of PlanZone
+	ZonePlanOptions struct {
+		// IgnoreTypes lists record types left out of the plan, e.g. "TXT"
+		IgnoreTypes []string
+		// IgnoreNames lists fully qualified record names left out of the plan, e.g. "_acme-challenge.example.com"
+		IgnoreNames []string
+	}
+
+	// SyncOptions contains the options of SyncRecordSets
+	SyncOptions struct {
+		// Prune deletes the record sets of the zone which are absent from the desired records
+		Prune bool
+		// ProtectedTypes lists record types which are never created, updated or deleted, e.g. "TXT".
+		// The SOA and NS record sets at the zone apex are always protected.
+		ProtectedTypes []string
+		// ProtectedNames lists fully qualified record names which are never created, updated or deleted
+		ProtectedNames []string
+		// MaxDeletionPercent is the share of the record sets of the zone which may be deleted,
+		// DefaultMaxDeletionPercent when not positive
+		MaxDeletionPercent int
+		// AllowMassDeletion deletes the record sets absent from desired regardless of MaxDeletionPercent
+		AllowMassDeletion bool
+	}
+
+	// SyncReport contains the changes made by SyncRecordSets
+	SyncReport struct {
+		Zone    string
+		Created []*RecordBody
+		Updated []*RecordBody
+		Deleted []*RecordBody
+	}
+
+	// ZonePlan contains the operations reconciling the records of a zone with a desired state
+	ZonePlan struct {
+		Zone    string
+		Creates []*RecordBody
+		Updates []*RecordBody
+		Deletes []*RecordBody
+		// LiveRecordSets is the number of record sets of the zone the plan was computed against, left out ones excluded.
+		// PlanZone sets it; plans without it are not checked for mass deletion.
+		LiveRecordSets int
+		// MaxDeletionPercent is the share of LiveRecordSets the plan may delete, DefaultMaxDeletionPercent when not positive
+		MaxDeletionPercent int
+		// AllowMassDeletion applies the plan regardless of MaxDeletionPercent
+		AllowMassDeletion bool
+	}
+)
+
+// DefaultMaxDeletionPercent is the share of the record sets of a zone which a zone plan may delete by default
+const DefaultMaxDeletionPercent = 50
+
+// Empty reports whether the plan has no operations, i.e. the zone is already in the desired state
+func (p *ZonePlan) Empty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// checkMassDeletion returns an error wrapping ErrMassDeletionBlocked when the plan deletes more than
+// MaxDeletionPercent of the live record sets, unless AllowMassDeletion is set
+func (p *ZonePlan) checkMassDeletion() error {
+	if p.AllowMassDeletion || p.LiveRecordSets == 0 || len(p.Deletes) == 0 {
+		return nil
+	}
+	maxPercent := p.MaxDeletionPercent
+	if maxPercent <= 0 {
+		maxPercent = DefaultMaxDeletionPercent
+	}
+	if len(p.Deletes)*100 > p.LiveRecordSets*maxPercent {
+		return fmt.Errorf("%w: %d of %d record sets deleted, more than %d%%",
+			ErrMassDeletionBlocked, len(p.Deletes), p.LiveRecordSets, maxPercent)
+	}
+	return nil
+}
+
+func (d *dns) PlanZone(ctx context.Context, zone string, desired []*RecordBody, opts ...ZonePlanOptions) (*ZonePlan, error) {
+	logger := d.Log(ctx)
+	logger.Debug("PlanZone")
+
+	if len(opts) > 1 {
+		return nil, fmt.Errorf("invalid arguments PlanZone options")
+	}
+	if zone == "" {
+		return nil, fmt.Errorf("%w: zone is required", ErrBadRequest)
+	}
+
+	ignored := func(name, recordType string) bool {
+		name, recordType = canonicalName(name), strings.ToUpper(recordType)
+		if name == canonicalName(zone) && (recordType == "SOA" || recordType == "NS") {
+			return true
+		}
+		if len(opts) == 0 {
+			return false
+		}
+		for _, t := range opts[0].IgnoreTypes {
+			if strings.ToUpper(t) == recordType {
+				return true
+			}
+		}
+		for _, n := range opts[0].IgnoreNames {
+			if canonicalName(n) == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	desiredByKey := make(map[recordKey]*RecordBody, len(desired))
+	var desiredKeys []recordKey
+	for _, rec := range desired {
+		if rec == nil {
+			return nil, fmt.Errorf("%w: nil record", ErrBadRequest)
+		}
+		if err := rec.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s %s: %s", ErrStructValidation, rec.Name, rec.RecordType, err)
+		}
+		if ignored(rec.Name, rec.RecordType) {
+			continue
+		}
+		k := recordKey{name: canonicalName(rec.Name), recordType: strings.ToUpper(rec.RecordType)}
+		if _, ok := desiredByKey[k]; ok {
+			return nil, fmt.Errorf("%w: duplicate record %s %s", ErrStructValidation, rec.Name, rec.RecordType)
+		}
+		desiredByKey[k] = rec
+		desiredKeys = append(desiredKeys, k)
+	}
+
+	live, err := d.getAllRecordSets(ctx, fmt.Sprintf("/config-dns/v2/zones/%s/recordsets", zone))
+	if err != nil {
+		return nil, fmt.Errorf("PlanZone: %w", err)
+	}
+
+	plan := &ZonePlan{Zone: zone}
+	liveKeys := make(map[recordKey]struct{}, len(live))
+	for _, rs := range live {
+		if ignored(rs.Name, rs.Type) {
+			continue
+		}
+		k := recordKey{name: canonicalName(rs.Name), recordType: strings.ToUpper(rs.Type)}
+		liveKeys[k] = struct{}{}
+		plan.LiveRecordSets++
+		rec, ok := desiredByKey[k]
+		if !ok {
+			plan.Deletes = append(plan.Deletes, &RecordBody{Name: rs.Name, RecordType: rs.Type, TTL: rs.TTL, Target: rs.Rdata})
+			continue
+		}
+		target := d.ProcessRdata(ctx, rec.Target, k.recordType)
+		if rec.TTL != rs.TTL || !reflect.DeepEqual(sortedRdata(target), sortedRdata(rs.Rdata)) {
+			added, removed := TargetDiff(rs.Rdata, target)
+			logger.Infof("Update %s %s: TTL %d -> %d, targets added %q, removed %q", rs.Name, rs.Type, rs.TTL, rec.TTL, added, removed)
+			plan.Updates = append(plan.Updates, rec)
+		}
+	}
+	for _, k := range desiredKeys {
+		if _, ok := liveKeys[k]; !ok {
+			plan.Creates = append(plan.Creates, desiredByKey[k])
+		}
+	}
+
+	for _, records := range [][]*RecordBody{plan.Creates, plan.Updates, plan.Deletes} {
+		sort.SliceStable(records, func(i, j int) bool {
+			ni, nj := canonicalName(records[i].Name), canonicalName(records[j].Name)
+			if ni != nj {
+				return ni < nj
+			}
+			return strings.ToUpper(records[i].RecordType) < strings.ToUpper(records[j].RecordType)
+		})
+	}
+
+	return plan, nil
+}
+
+func (d *dns) ApplyZonePlan(ctx context.Context, plan *ZonePlan) error {
+	logger := d.Log(ctx)
+	logger.Debug("ApplyZonePlan")
+
+	if plan == nil || plan.Zone == "" {
+		return fmt.Errorf("%w: plan with a zone is required", ErrBadRequest)
+	}
+	if err := plan.checkMassDeletion(); err != nil {
+		return fmt.Errorf("ApplyZonePlan: %w", err)
+	}
+
+	return d.WithZoneLock(ctx, plan.Zone, func() error {
+		if err := d.applyZonePlan(ctx, plan, &SyncReport{}); err != nil {
+			return fmt.Errorf("ApplyZonePlan: %w", err)
+		}
+		return nil
+	})
+}
+
+func (d *dns) SyncRecordSets(ctx context.Context, zone string, desired []*RecordBody, opts SyncOptions) (*SyncReport, error) {
+	logger := d.Log(ctx)
+	logger.Debug("SyncRecordSets")
+
+	if zone == "" {
+		return nil, fmt.Errorf("%w: zone is required", ErrBadRequest)
+	}
+
+	report := &SyncReport{Zone: zone}
+	err := d.WithZoneLock(ctx, zone, func() error {
+		plan, err := d.PlanZone(ctx, zone, desired, ZonePlanOptions{
+			IgnoreTypes: opts.ProtectedTypes,
+			IgnoreNames: opts.ProtectedNames,
+		})
+		if err != nil {
+			return err
+		}
+		if !opts.Prune {
+			plan.Deletes = nil
+		}
+		plan.MaxDeletionPercent = opts.MaxDeletionPercent
+		plan.AllowMassDeletion = opts.AllowMassDeletion
+		if err := plan.checkMassDeletion(); err != nil {
+			return err
+		}
+		return d.applyZonePlan(ctx, plan, report)
+	})
+	if err != nil {
+		return report, fmt.Errorf("SyncRecordSets: %w", err)
+	}
+
+	return report, nil
+}
+
+// applyZonePlan performs the operations of the plan, recording the successful ones in report.
+// The caller must hold the zone lock.
+func (d *dns) applyZonePlan(ctx context.Context, plan *ZonePlan, report *SyncReport) error {
+	for _, rec := range plan.Creates {
+		if err := d.CreateRecord(ctx, rec, plan.Zone, false); err != nil {
+			return fmt.Errorf("creating %s %s: %w", rec.Name, rec.RecordType, err)
+		}
+		report.Created = append(report.Created, rec)
+	}
+	for _, rec := range plan.Updates {
+		if err := d.UpdateRecord(ctx, rec, plan.Zone, false); err != nil {
+			return fmt.Errorf("updating %s %s: %w", rec.Name, rec.RecordType, err)
+		}
+		report.Updated = append(report.Updated, rec)
+	}
+	for _, rec := range plan.Deletes {
+		if err := d.DeleteRecord(ctx, rec, plan.Zone, false); err != nil {
+			return fmt.Errorf("deleting %s %s: %w", rec.Name, rec.RecordType, err)
+		}
+		report.Deleted = append(report.Deleted, rec)
+	}
+	return nil
+}
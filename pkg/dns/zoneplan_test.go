@@ -0,0 +1,406 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const zonePlanRecordSets = `
+{
+	"metadata": {"page": 1, "pageSize": 500, "lastPage": 1, "totalElements": 7},
+	"recordsets": [
+		{"name": "example.com", "type": "SOA", "ttl": 86400, "rdata": ["a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"]},
+		{"name": "example.com", "type": "NS", "ttl": 86400, "rdata": ["a1-1.akam.net.", "a2-2.akam.net."]},
+		{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.1", "10.0.0.2"]},
+		{"name": "mail.example.com", "type": "MX", "ttl": 300, "rdata": ["10 mx.example.com."]},
+		{"name": "old.example.com", "type": "CNAME", "ttl": 300, "rdata": ["www.example.com."]},
+		{"name": "example.com", "type": "TXT", "ttl": 300, "rdata": ["\"google-site-verification=abc\""]},
+		{"name": "_acme-challenge.example.com", "type": "TXT", "ttl": 60, "rdata": ["\"token\""]}
+	]
+}`
+
+func TestDNS_PlanZone(t *testing.T) {
+	tests := map[string]struct {
+		desired          []*RecordBody
+		opts             []ZonePlanOptions
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ZonePlan
+		withError        error
+	}{
+		"creates, updates and deletes": {
+			desired: []*RecordBody{
+				{Name: "WWW.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.2", "10.0.0.1"}},
+				{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx.example.com"}},
+				{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+				{Name: "example.com", RecordType: "TXT", TTL: 600, Target: []string{`"google-site-verification=abc"`}},
+				{Name: "example.com", RecordType: "NS", TTL: 300, Target: []string{"ns1.example.net."}},
+			},
+			opts:           []ZonePlanOptions{{IgnoreNames: []string{"_acme-challenge.example.com."}}},
+			responseStatus: http.StatusOK,
+			responseBody:   zonePlanRecordSets,
+			expectedResponse: &ZonePlan{
+				Zone: "example.com",
+				Creates: []*RecordBody{
+					{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+				},
+				Updates: []*RecordBody{
+					{Name: "example.com", RecordType: "TXT", TTL: 600, Target: []string{`"google-site-verification=abc"`}},
+				},
+				Deletes: []*RecordBody{
+					{Name: "old.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"www.example.com."}},
+				},
+				LiveRecordSets: 4,
+			},
+		},
+		"ignored types": {
+			desired: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+				{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx.example.com."}},
+				{Name: "old.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"www.example.com."}},
+			},
+			opts:           []ZonePlanOptions{{IgnoreTypes: []string{"txt"}}},
+			responseStatus: http.StatusOK,
+			responseBody:   zonePlanRecordSets,
+			expectedResponse: &ZonePlan{
+				Zone:           "example.com",
+				LiveRecordSets: 3,
+			},
+		},
+		"duplicate desired record": {
+			desired: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+				{Name: "www.example.com.", RecordType: "a", TTL: 300, Target: []string{"10.0.0.2"}},
+			},
+			withError: ErrStructValidation,
+		},
+		"invalid desired record": {
+			desired: []*RecordBody{
+				{Name: "www.example.com", RecordType: "A", TTL: 300},
+			},
+			withError: ErrStructValidation,
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error fetching recordsets",
+	"status": 500
+}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching recordsets",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.PlanZone(context.Background(), "example.com", test.desired, test.opts...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDNS_ApplyZonePlan(t *testing.T) {
+	plan := &ZonePlan{
+		Zone: "example.com",
+		Creates: []*RecordBody{
+			{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+		},
+		Updates: []*RecordBody{
+			{Name: "example.com", RecordType: "TXT", TTL: 600, Target: []string{`"v=spf1 -all"`}},
+		},
+		Deletes: []*RecordBody{
+			{Name: "old.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"www.example.com."}},
+		},
+	}
+
+	tests := map[string]struct {
+		plan             *ZonePlan
+		failingRequest   string
+		expectedRequests []string
+		withError        error
+	}{
+		"creates, updates then deletes": {
+			plan: plan,
+			expectedRequests: []string{
+				"POST /config-dns/v2/zones/example.com/names/api.example.com/types/AAAA",
+				"PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+				"DELETE /config-dns/v2/zones/example.com/names/old.example.com/types/CNAME",
+			},
+		},
+		"stops at the first failure": {
+			plan:           plan,
+			failingRequest: "PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+			expectedRequests: []string{
+				"POST /config-dns/v2/zones/example.com/names/api.example.com/types/AAAA",
+				"PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error updating record",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"mass deletion blocked": {
+			plan: &ZonePlan{
+				Zone:           "example.com",
+				Deletes:        plan.Deletes,
+				LiveRecordSets: 1,
+			},
+			withError: ErrMassDeletionBlocked,
+		},
+		"mass deletion allowed": {
+			plan: &ZonePlan{
+				Zone:              "example.com",
+				Deletes:           plan.Deletes,
+				LiveRecordSets:    1,
+				AllowMassDeletion: true,
+			},
+			expectedRequests: []string{
+				"DELETE /config-dns/v2/zones/example.com/names/old.example.com/types/CNAME",
+			},
+		},
+		"deletion within the max percent": {
+			plan: &ZonePlan{
+				Zone:               "example.com",
+				Deletes:            plan.Deletes,
+				LiveRecordSets:     4,
+				MaxDeletionPercent: 25,
+			},
+			expectedRequests: []string{
+				"DELETE /config-dns/v2/zones/example.com/names/old.example.com/types/CNAME",
+			},
+		},
+		"missing plan": {
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request := r.Method + " " + r.URL.String()
+				mu.Lock()
+				requests = append(requests, request)
+				mu.Unlock()
+				if request == test.failingRequest {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "detail": "Error updating record", "status": 500}`))
+					assert.NoError(t, err)
+					return
+				}
+				switch r.Method {
+				case http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+				case http.MethodPut:
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+
+			err := client.ApplyZonePlan(context.Background(), test.plan)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, test.expectedRequests, requests)
+		})
+	}
+}
+
+func TestDNS_SyncRecordSets(t *testing.T) {
+	desired := []*RecordBody{
+		{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx.example.com."}},
+		{Name: "api.example.com", RecordType: "AAAA", TTL: 300, Target: []string{"2001:db8::1"}},
+		{Name: "example.com", RecordType: "TXT", TTL: 600, Target: []string{`"v=spf1 -all"`}},
+	}
+
+	tests := map[string]struct {
+		desired          []*RecordBody
+		opts             SyncOptions
+		failingRequest   string
+		expectedRequests []string
+		expectedReport   *SyncReport
+		withError        error
+	}{
+		"without prune": {
+			opts: SyncOptions{ProtectedNames: []string{"_acme-challenge.example.com"}},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+				"POST /config-dns/v2/zones/example.com/names/api.example.com/types/AAAA",
+				"PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+			},
+			expectedReport: &SyncReport{
+				Zone:    "example.com",
+				Created: []*RecordBody{desired[2]},
+				Updated: []*RecordBody{desired[3]},
+			},
+		},
+		"with prune": {
+			opts: SyncOptions{Prune: true, ProtectedNames: []string{"_acme-challenge.example.com"}},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+				"POST /config-dns/v2/zones/example.com/names/api.example.com/types/AAAA",
+				"PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+				"DELETE /config-dns/v2/zones/example.com/names/old.example.com/types/CNAME",
+			},
+			expectedReport: &SyncReport{
+				Zone:    "example.com",
+				Created: []*RecordBody{desired[2]},
+				Updated: []*RecordBody{desired[3]},
+				Deleted: []*RecordBody{
+					{Name: "old.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"www.example.com."}},
+				},
+			},
+		},
+		"protected types": {
+			opts: SyncOptions{Prune: true, ProtectedTypes: []string{"txt", "cname"}},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+				"POST /config-dns/v2/zones/example.com/names/api.example.com/types/AAAA",
+			},
+			expectedReport: &SyncReport{
+				Zone:    "example.com",
+				Created: []*RecordBody{desired[2]},
+			},
+		},
+		"empty desired set blocked": {
+			desired: []*RecordBody{},
+			opts:    SyncOptions{Prune: true, ProtectedNames: []string{"_acme-challenge.example.com"}},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+			},
+			expectedReport: &SyncReport{Zone: "example.com"},
+			withError:      ErrMassDeletionBlocked,
+		},
+		"empty desired set allowed": {
+			desired: []*RecordBody{},
+			opts:    SyncOptions{Prune: true, ProtectedNames: []string{"_acme-challenge.example.com"}, AllowMassDeletion: true},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+				"DELETE /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+				"DELETE /config-dns/v2/zones/example.com/names/mail.example.com/types/MX",
+				"DELETE /config-dns/v2/zones/example.com/names/old.example.com/types/CNAME",
+				"DELETE /config-dns/v2/zones/example.com/names/www.example.com/types/A",
+			},
+			expectedReport: &SyncReport{
+				Zone: "example.com",
+				Deleted: []*RecordBody{
+					{Name: "example.com", RecordType: "TXT", TTL: 300, Target: []string{`"google-site-verification=abc"`}},
+					{Name: "mail.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mx.example.com."}},
+					{Name: "old.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"www.example.com."}},
+					{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1", "10.0.0.2"}},
+				},
+			},
+		},
+		"empty desired set without prune": {
+			desired: []*RecordBody{},
+			opts:    SyncOptions{ProtectedNames: []string{"_acme-challenge.example.com"}},
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+			},
+			expectedReport: &SyncReport{Zone: "example.com"},
+		},
+		"partial failure": {
+			opts:           SyncOptions{Prune: true, ProtectedNames: []string{"_acme-challenge.example.com"}},
+			failingRequest: "PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+			expectedRequests: []string{
+				"GET /config-dns/v2/zones/example.com/recordsets?page=1&pageSize=500",
+				"POST /config-dns/v2/zones/example.com/names/api.example.com/types/AAAA",
+				"PUT /config-dns/v2/zones/example.com/names/example.com/types/TXT",
+			},
+			expectedReport: &SyncReport{
+				Zone:    "example.com",
+				Created: []*RecordBody{desired[2]},
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error updating record",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request := r.Method + " " + r.URL.String()
+				mu.Lock()
+				requests = append(requests, request)
+				mu.Unlock()
+				if request == test.failingRequest {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "detail": "Error updating record", "status": 500}`))
+					assert.NoError(t, err)
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(zonePlanRecordSets))
+					assert.NoError(t, err)
+				case http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+				case http.MethodPut:
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+
+			records := desired
+			if test.desired != nil {
+				records = test.desired
+			}
+			report, err := client.SyncRecordSets(context.Background(), "example.com", records, test.opts)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedReport, report)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, test.expectedRequests, requests)
+		})
+	}
+}
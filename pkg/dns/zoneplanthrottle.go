@@ -0,0 +1,154 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultThrottleBatchSize is the number of operations per batch of ApplyZonePlansThrottled when no size is given
+const DefaultThrottleBatchSize = 100
+
+type (
+	// ThrottleOptions contains the settings of ApplyZonePlansThrottled
+	ThrottleOptions struct {
+		// BatchSize is the number of operations applied under one zone lock, DefaultThrottleBatchSize when not positive
+		BatchSize int
+		// BatchDelay is the pause between the batches of a zone
+		BatchDelay time.Duration
+		// Concurrency is the number of zones applied at a time, 1 when not positive
+		Concurrency int
+		// Progress, when set, is called after each batch applied. Calls are serialized, also across zones.
+		Progress func(ApplyProgress)
+	}
+
+	// ApplyProgress reports the operations of a zone plan applied so far
+	ApplyProgress struct {
+		Zone string
+		// Batch is the number of the batch just applied, from 1 to Batches
+		Batch   int
+		Batches int
+		// Applied is the number of operations applied so far, out of Total
+		Applied int
+		Total   int
+	}
+)
+
+func (d *dns) ApplyZonePlansThrottled(ctx context.Context, plans []*ZonePlan, opts ThrottleOptions) error {
+	logger := d.Log(ctx)
+	logger.Debug("ApplyZonePlansThrottled")
+
+	for _, plan := range plans {
+		if plan == nil || plan.Zone == "" {
+			return fmt.Errorf("%w: plans with a zone are required", ErrBadRequest)
+		}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultThrottleBatchSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if progress := opts.Progress; progress != nil {
+		var mu sync.Mutex
+		opts.Progress = func(p ApplyProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress(p)
+		}
+	}
+
+	pending := make(chan *ZonePlan)
+	go func() {
+		defer close(pending)
+		for _, plan := range plans {
+			pending <- plan
+		}
+	}()
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	for i := 0; i < opts.Concurrency && i < len(plans); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for plan := range pending {
+				if err := d.applyZonePlanThrottled(ctx, plan, opts); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("zone %s: %w", plan.Zone, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("ApplyZonePlansThrottled: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// applyZonePlanThrottled applies the operations of the plan in batches, each under the zone lock
+func (d *dns) applyZonePlanThrottled(ctx context.Context, plan *ZonePlan, opts ThrottleOptions) error {
+	if err := plan.checkMassDeletion(); err != nil {
+		return err
+	}
+	batches := planBatches(plan, opts.BatchSize)
+	total := len(plan.Creates) + len(plan.Updates) + len(plan.Deletes)
+	applied := 0
+	for i, batch := range batches {
+		if i > 0 && opts.BatchDelay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.BatchDelay):
+			}
+		}
+		err := d.WithZoneLock(ctx, plan.Zone, func() error {
+			return d.applyZonePlan(ctx, batch, &SyncReport{})
+		})
+		if err != nil {
+			return fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+		applied += len(batch.Creates) + len(batch.Updates) + len(batch.Deletes)
+		if opts.Progress != nil {
+			opts.Progress(ApplyProgress{Zone: plan.Zone, Batch: i + 1, Batches: len(batches), Applied: applied, Total: total})
+		}
+	}
+	return nil
+}
+
+// planBatches splits the operations of the plan, in the order of ApplyZonePlan, into plans of at most size operations
+func planBatches(plan *ZonePlan, size int) []*ZonePlan {
+	var batches []*ZonePlan
+	batch := &ZonePlan{Zone: plan.Zone}
+	count := 0
+	add := func(field *[]*RecordBody, rec *RecordBody) {
+		*field = append(*field, rec)
+		count++
+		if count == size {
+			batches = append(batches, batch)
+			batch = &ZonePlan{Zone: plan.Zone}
+			count = 0
+		}
+	}
+	for _, rec := range plan.Creates {
+		add(&batch.Creates, rec)
+	}
+	for _, rec := range plan.Updates {
+		add(&batch.Updates, rec)
+	}
+	for _, rec := range plan.Deletes {
+		add(&batch.Deletes, rec)
+	}
+	if count > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS_ApplyZonePlansThrottled(t *testing.T) {
+	newPlan := func(zone string, creates, updates, deletes int) *ZonePlan {
+		plan := &ZonePlan{Zone: zone}
+		for i := 0; i < creates; i++ {
+			plan.Creates = append(plan.Creates, &RecordBody{Name: fmt.Sprintf("c%d.%s", i, zone), RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}})
+		}
+		for i := 0; i < updates; i++ {
+			plan.Updates = append(plan.Updates, &RecordBody{Name: fmt.Sprintf("u%d.%s", i, zone), RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}})
+		}
+		for i := 0; i < deletes; i++ {
+			plan.Deletes = append(plan.Deletes, &RecordBody{Name: fmt.Sprintf("d%d.%s", i, zone), RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}})
+		}
+		return plan
+	}
+	path := func(method, zone, name string) string {
+		return fmt.Sprintf("%s /config-dns/v2/zones/%s/names/%s.%s/types/A", method, zone, name, zone)
+	}
+
+	tests := map[string]struct {
+		plans            []*ZonePlan
+		opts             ThrottleOptions
+		failingRequest   string
+		expectedRequests []string
+		expectedProgress []ApplyProgress
+		withError        error
+	}{
+		"batches in plan order": {
+			plans: []*ZonePlan{newPlan("example.com", 4, 2, 1)},
+			opts:  ThrottleOptions{BatchSize: 3, BatchDelay: time.Millisecond},
+			expectedRequests: []string{
+				path(http.MethodPost, "example.com", "c0"),
+				path(http.MethodPost, "example.com", "c1"),
+				path(http.MethodPost, "example.com", "c2"),
+				path(http.MethodPost, "example.com", "c3"),
+				path(http.MethodPut, "example.com", "u0"),
+				path(http.MethodPut, "example.com", "u1"),
+				path(http.MethodDelete, "example.com", "d0"),
+			},
+			expectedProgress: []ApplyProgress{
+				{Zone: "example.com", Batch: 1, Batches: 3, Applied: 3, Total: 7},
+				{Zone: "example.com", Batch: 2, Batches: 3, Applied: 6, Total: 7},
+				{Zone: "example.com", Batch: 3, Batches: 3, Applied: 7, Total: 7},
+			},
+		},
+		"zones one after the other": {
+			plans: []*ZonePlan{newPlan("example.com", 2, 0, 0), newPlan("example.net", 0, 0, 1)},
+			opts:  ThrottleOptions{BatchSize: 2},
+			expectedRequests: []string{
+				path(http.MethodPost, "example.com", "c0"),
+				path(http.MethodPost, "example.com", "c1"),
+				path(http.MethodDelete, "example.net", "d0"),
+			},
+			expectedProgress: []ApplyProgress{
+				{Zone: "example.com", Batch: 1, Batches: 1, Applied: 2, Total: 2},
+				{Zone: "example.net", Batch: 1, Batches: 1, Applied: 1, Total: 1},
+			},
+		},
+		"failing zone stops, other zones go on": {
+			plans:          []*ZonePlan{newPlan("example.com", 3, 0, 0), newPlan("example.net", 1, 0, 0)},
+			opts:           ThrottleOptions{BatchSize: 2},
+			failingRequest: path(http.MethodPost, "example.com", "c1"),
+			expectedRequests: []string{
+				path(http.MethodPost, "example.com", "c0"),
+				path(http.MethodPost, "example.com", "c1"),
+				path(http.MethodPost, "example.net", "c0"),
+			},
+			expectedProgress: []ApplyProgress{
+				{Zone: "example.net", Batch: 1, Batches: 1, Applied: 1, Total: 1},
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating record",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"missing zone": {
+			plans:     []*ZonePlan{{}},
+			withError: ErrBadRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request := r.Method + " " + r.URL.String()
+				mu.Lock()
+				requests = append(requests, request)
+				mu.Unlock()
+				if request == test.failingRequest {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "detail": "Error creating record", "status": 500}`))
+					assert.NoError(t, err)
+					return
+				}
+				switch r.Method {
+				case http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+				case http.MethodPut:
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+
+			var progress []ApplyProgress
+			test.opts.Progress = func(p ApplyProgress) {
+				progress = append(progress, p)
+			}
+			err := client.ApplyZonePlansThrottled(context.Background(), test.plans, test.opts)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, test.expectedRequests, requests)
+			assert.Equal(t, test.expectedProgress, progress)
+		})
+	}
+}
+
+func TestDNS_ApplyZonePlansThrottledConcurrency(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		zones       = make(map[string]int)
+		maxInFlight int
+	)
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		zones[r.URL.Path]++
+		if len(zones) > maxInFlight {
+			maxInFlight = len(zones)
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		if zones[r.URL.Path]--; zones[r.URL.Path] == 0 {
+			delete(zones, r.URL.Path)
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	var plans []*ZonePlan
+	for i := 0; i < 6; i++ {
+		zone := fmt.Sprintf("example%d.com", i)
+		plans = append(plans, &ZonePlan{Zone: zone, Creates: []*RecordBody{
+			{Name: "www." + zone, RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+			{Name: "api." + zone, RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}},
+		}})
+	}
+	require.NoError(t, client.ApplyZonePlansThrottled(context.Background(), plans, ThrottleOptions{BatchSize: 1, Concurrency: 2}))
+	assert.LessOrEqual(t, maxInFlight, 2)
+}
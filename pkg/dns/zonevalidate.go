@@ -0,0 +1,123 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ValidateZoneRecords checks that the records of a desired zone are consistent with each other, before applying them
+// with e.g. SyncRecordSets. It enforces the rules which Validate cannot check on a single record:
+//   - all names are within the zone
+//   - a name with a CNAME has no other record types, except DNSSEC RRSIG and NSEC records
+//   - each name and type pair appears once, as a single record set
+//   - the apex of the zone has NS records
+//   - MX exchanges are not names of CNAME records of the set
+//
+// The returned error wraps ErrStructValidation and lists the problems of each record by its index and field.
+func ValidateZoneRecords(zone string, records []*RecordBody) error {
+	if zone == "" {
+		return fmt.Errorf("%w: zone is required", ErrBadRequest)
+	}
+	apex := canonicalName(zone)
+
+	problems := make(map[int]map[string][]string)
+	addProblem := func(i int, field, format string, args ...interface{}) {
+		if problems[i] == nil {
+			problems[i] = make(map[string][]string)
+		}
+		problems[i][field] = append(problems[i][field], fmt.Sprintf(format, args...))
+	}
+
+	seen := make(map[recordKey]int, len(records))
+	types := make(map[string][]string)
+	cnames := make(map[string]bool)
+	for i, rec := range records {
+		if rec == nil {
+			addProblem(i, "Name", "record is nil")
+			continue
+		}
+		name, recordType := canonicalName(rec.Name), strings.ToUpper(rec.RecordType)
+		if !inDomain(name, apex) {
+			addProblem(i, "Name", "%s is not within zone %s", rec.Name, zone)
+		}
+
+		key := recordKey{name: name, recordType: recordType}
+		if first, ok := seen[key]; ok {
+			addProblem(i, "RecordType", "duplicate %s record set for %s, first defined by record %d", recordType, rec.Name, first)
+			continue
+		}
+		seen[key] = i
+		types[name] = append(types[name], recordType)
+		if recordType == "CNAME" {
+			cnames[name] = true
+		}
+	}
+
+	for i, rec := range records {
+		if rec == nil {
+			continue
+		}
+		name, recordType := canonicalName(rec.Name), strings.ToUpper(rec.RecordType)
+		switch recordType {
+		case "CNAME":
+			if seen[recordKey{name: name, recordType: recordType}] != i {
+				continue
+			}
+			if others := cnameConflicts(types[name]); len(others) > 0 {
+				addProblem(i, "RecordType", "CNAME cannot coexist with %s records at %s", strings.Join(others, ", "), rec.Name)
+			}
+		case "MX":
+			for _, target := range rec.Target {
+				fields := strings.Fields(target)
+				if len(fields) != 2 {
+					continue
+				}
+				if exchange := canonicalName(fields[1]); cnames[exchange] {
+					addProblem(i, "Target", "MX exchange %s is a CNAME", fields[1])
+				}
+			}
+		}
+	}
+
+	errs := validation.Errors{}
+	if _, ok := seen[recordKey{name: apex, recordType: "NS"}]; !ok {
+		errs["Apex"] = fmt.Errorf("zone %s has no NS records at its apex", zone)
+	}
+	if len(problems) > 0 {
+		recordErrs := make(validation.Errors, len(problems))
+		for i, fields := range problems {
+			fieldErrs := make(validation.Errors, len(fields))
+			for field, messages := range fields {
+				fieldErrs[field] = errors.New(strings.Join(messages, "; "))
+			}
+			recordErrs[strconv.Itoa(i)] = fieldErrs
+		}
+		errs["Records"] = recordErrs
+	}
+
+	if err := edgegriderr.ParseValidationErrors(errs); err != nil {
+		return fmt.Errorf("%w: %s", ErrStructValidation, err)
+	}
+	return nil
+}
+
+// cnameConflicts returns the sorted record types, other than CNAME and the DNSSEC types allowed alongside it,
+// defined at a name with a CNAME
+func cnameConflicts(types []string) []string {
+	var others []string
+	for _, recordType := range types {
+		switch recordType {
+		case "CNAME", "RRSIG", "NSEC":
+			continue
+		}
+		others = append(others, recordType)
+	}
+	sort.Strings(others)
+	return others
+}
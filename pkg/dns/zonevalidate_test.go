@@ -0,0 +1,177 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateZoneRecords(t *testing.T) {
+	soa := &RecordBody{Name: "example.com", RecordType: "SOA", TTL: 86400, Target: []string{"a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"}}
+	ns := &RecordBody{Name: "example.com", RecordType: "NS", TTL: 86400, Target: []string{"a1-1.akam.net.", "a2-2.akam.net."}}
+
+	tests := map[string]struct {
+		zone      string
+		records   []*RecordBody
+		withError string
+	}{
+		"valid zone": {
+			zone: "example.com",
+			records: []*RecordBody{
+				soa,
+				ns,
+				{Name: "example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+				{Name: "example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mail.example.com."}},
+				{Name: "mail.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.2"}},
+				{Name: "www.example.com.", RecordType: "CNAME", TTL: 300, Target: []string{"example.com."}},
+				{Name: "sub.example.com", RecordType: "NS", TTL: 300, Target: []string{"ns1.example.net."}},
+			},
+		},
+		"zone name with trailing dot and mixed case": {
+			zone: "Example.COM.",
+			records: []*RecordBody{
+				{Name: "EXAMPLE.com", RecordType: "ns", TTL: 86400, Target: []string{"a1-1.akam.net."}},
+				{Name: "www.Example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+			},
+		},
+		"CNAME with DNSSEC records": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"example.net."}},
+				{Name: "www.example.com", RecordType: "RRSIG", TTL: 300, Target: []string{"CNAME 13 3 300 20240101000000 20231201000000 12345 example.com. c2ln"}},
+				{Name: "www.example.com", RecordType: "NSEC", TTL: 300, Target: []string{"x.example.com. CNAME RRSIG NSEC"}},
+			},
+		},
+		"missing zone": {
+			records:   []*RecordBody{ns},
+			withError: "missing argument: zone is required",
+		},
+		"empty zone": {
+			zone:      "example.com",
+			withError: "struct validation: Apex: zone example.com has no NS records at its apex",
+		},
+		"apex without NS": {
+			zone: "example.com",
+			records: []*RecordBody{
+				soa,
+				{Name: "sub.example.com", RecordType: "NS", TTL: 300, Target: []string{"ns1.example.net."}},
+			},
+			withError: "struct validation: Apex: zone example.com has no NS records at its apex",
+		},
+		"name outside of zone": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "www.example.net", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+				{Name: "badexample.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+			},
+			withError: "struct validation: Records[1]: {\n" +
+				"\tName: www.example.net is not within zone example.com\n" +
+				"}\n" +
+				"Records[2]: {\n" +
+				"\tName: badexample.com is not within zone example.com\n" +
+				"}",
+		},
+		"CNAME with other types": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "www.example.com", RecordType: "TXT", TTL: 300, Target: []string{`"hello"`}},
+				{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"example.net."}},
+				{Name: "WWW.example.com.", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+			},
+			withError: "struct validation: Records[2]: {\n" +
+				"\tRecordType: CNAME cannot coexist with A, TXT records at www.example.com\n" +
+				"}",
+		},
+		"CNAME at apex": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "example.com", RecordType: "CNAME", TTL: 300, Target: []string{"example.net."}},
+			},
+			withError: "struct validation: Records[1]: {\n" +
+				"\tRecordType: CNAME cannot coexist with NS records at example.com\n" +
+				"}",
+		},
+		"duplicate record sets": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+				{Name: "www.example.com.", RecordType: "a", TTL: 300, Target: []string{"192.0.2.2"}},
+				{Name: "example.com", RecordType: "NS", TTL: 300, Target: []string{"a3-3.akam.net."}},
+			},
+			withError: "struct validation: Records[2]: {\n" +
+				"\tRecordType: duplicate A record set for www.example.com., first defined by record 1\n" +
+				"}\n" +
+				"Records[3]: {\n" +
+				"\tRecordType: duplicate NS record set for example.com, first defined by record 0\n" +
+				"}",
+		},
+		"duplicate CNAME": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"a.example.net."}},
+				{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"b.example.net."}},
+			},
+			withError: "struct validation: Records[2]: {\n" +
+				"\tRecordType: duplicate CNAME record set for www.example.com, first defined by record 1\n" +
+				"}",
+		},
+		"MX exchange is a CNAME": {
+			zone: "example.com",
+			records: []*RecordBody{
+				ns,
+				{Name: "example.com", RecordType: "MX", TTL: 300, Target: []string{"10 mail.example.com.", "20 backup.example.com", "30 mx.example.net."}},
+				{Name: "mail.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"mx.example.net."}},
+				{Name: "backup.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"mx.example.net."}},
+			},
+			withError: "struct validation: Records[1]: {\n" +
+				"\tTarget: MX exchange mail.example.com. is a CNAME; MX exchange backup.example.com is a CNAME\n" +
+				"}",
+		},
+		"nil record": {
+			zone:      "example.com",
+			records:   []*RecordBody{ns, nil},
+			withError: "struct validation: Records[1]: {\n\tName: record is nil\n}",
+		},
+		"multiple problems": {
+			zone: "example.com",
+			records: []*RecordBody{
+				{Name: "www.example.com", RecordType: "CNAME", TTL: 300, Target: []string{"example.net."}},
+				{Name: "www.example.com", RecordType: "MX", TTL: 300, Target: []string{"10 www.example.com."}},
+				{Name: "ftp.example.org", RecordType: "A", TTL: 300, Target: []string{"192.0.2.1"}},
+			},
+			withError: "struct validation: Apex: zone example.com has no NS records at its apex\n" +
+				"Records[0]: {\n" +
+				"\tRecordType: CNAME cannot coexist with MX records at www.example.com\n" +
+				"}\n" +
+				"Records[1]: {\n" +
+				"\tTarget: MX exchange www.example.com. is a CNAME\n" +
+				"}\n" +
+				"Records[2]: {\n" +
+				"\tName: ftp.example.org is not within zone example.com\n" +
+				"}",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateZoneRecords(test.zone, test.records)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.withError, err.Error())
+				if test.zone != "" {
+					assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -37,6 +37,9 @@ var (
 	ErrHostContainsSlashAtTheEnd = errors.New("host must not contain '/' at the end")
 )
 
+// requiredEnvOptions are the env vars, without the AKAMAI_{SECTION} prefix, which FromEnv requires
+var requiredEnvOptions = []string{"HOST", "CLIENT_TOKEN", "CLIENT_SECRET", "ACCESS_TOKEN"}
+
 type (
 	// Config struct provides all the necessary fields to
 	// create authorization header, debug is optional
@@ -74,7 +77,7 @@ func New(opts ...Option) (*Config, error) {
 	if c.env {
 		if err := c.FromEnv(c.section); err == nil {
 			return c, nil
-		} else if !errors.Is(err, ErrRequiredOptionEnv) {
+		} else if !errors.Is(err, ErrRequiredOptionEnv) || anyEnvExists(envPrefix(c.section), requiredEnvOptions) {
 			return nil, err
 		}
 	}
@@ -111,7 +114,8 @@ func WithSection(section string) Option {
 }
 
 // WithEnv sets the option to try to the environment vars to populate the config
-// If loading from the env fails, will fallback to .edgerc
+// If none of the required env vars are set, will fallback to .edgerc. If only some of them are set,
+// New returns an error wrapping ErrRequiredOptionEnv
 func WithEnv(env bool) Option {
 	return func(c *Config) {
 		c.env = env
@@ -165,25 +169,20 @@ func (c *Config) FromFile(file string, section string) error {
 // You can define multiple configurations by prefixing with the section name specified, e.g.
 // passing "ccu" will cause it to look for AKAMAI_CCU_HOST, etc.
 //
-// If AKAMAI_{SECTION} does not exist, it will fall back to just AKAMAI_.
+// If none of the required AKAMAI_{SECTION} variables exist, it will fall back to just AKAMAI_.
+// When any of the required variables are missing, the returned error wraps ErrRequiredOptionEnv
+// and lists all of them.
 func (c *Config) FromEnv(section string) error {
-	var (
-		requiredOptions = []string{"HOST", "CLIENT_TOKEN", "CLIENT_SECRET", "ACCESS_TOKEN"}
-		prefix          string
-	)
+	prefix := envPrefix(section)
 
-	prefix = "AKAMAI"
-
-	if section != DefaultSection {
-		prefix = "AKAMAI_" + strings.ToUpper(section)
-	}
-
-	for _, opt := range requiredOptions {
+	var missing []string
+	for _, opt := range requiredEnvOptions {
 		optKey := fmt.Sprintf("%s_%s", prefix, opt)
 
 		val, ok := os.LookupEnv(optKey)
 		if !ok {
-			return fmt.Errorf("%w: %q", ErrRequiredOptionEnv, optKey)
+			missing = append(missing, strconv.Quote(optKey))
+			continue
 		}
 		switch {
 		case opt == "HOST":
@@ -196,6 +195,9 @@ func (c *Config) FromEnv(section string) error {
 			c.AccessToken = val
 		}
 	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrRequiredOptionEnv, strings.Join(missing, ", "))
+	}
 
 	val := os.Getenv(fmt.Sprintf("%s_%s", prefix, "MAX_BODY"))
 	if i, err := strconv.Atoi(val); err == nil {
@@ -214,6 +216,27 @@ func (c *Config) FromEnv(section string) error {
 	return nil
 }
 
+// envPrefix returns the prefix of the env vars of the section, falling back to AKAMAI
+// when none of the required AKAMAI_{SECTION} variables exist
+func envPrefix(section string) string {
+	if section != DefaultSection {
+		prefix := "AKAMAI_" + strings.ToUpper(section)
+		if anyEnvExists(prefix, requiredEnvOptions) {
+			return prefix
+		}
+	}
+	return "AKAMAI"
+}
+
+func anyEnvExists(prefix string, options []string) bool {
+	for _, opt := range options {
+		if _, ok := os.LookupEnv(fmt.Sprintf("%s_%s", prefix, opt)); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // Timestamp returns an edgegrid timestamp from the time
 func Timestamp(t time.Time) string {
 	local := time.FixedZone("GMT", 0)
@@ -75,10 +75,11 @@ func TestConfig_FromFile(t *testing.T) {
 
 func TestConfig_FromEnv(t *testing.T) {
 	tests := map[string]struct {
-		section   string
-		envs      map[string]string
-		expected  Config
-		withError error
+		section      string
+		envs         map[string]string
+		expected     Config
+		withError    error
+		errorMessage string
 	}{
 		"default section, valid envs, default max body": {
 			section: "default",
@@ -148,6 +149,39 @@ func TestConfig_FromEnv(t *testing.T) {
 				MaxBody:      131072,
 			},
 		},
+		"custom section, falls back to default variables": {
+			section: "test",
+			envs: map[string]string{
+				"AKAMAI_HOST":          "test-host",
+				"AKAMAI_CLIENT_TOKEN":  "test-client-token",
+				"AKAMAI_CLIENT_SECRET": "test-client-secret",
+				"AKAMAI_ACCESS_TOKEN":  "test-access-token",
+			},
+			expected: Config{
+				Host:         "test-host",
+				ClientToken:  "test-client-token",
+				ClientSecret: "test-client-secret",
+				AccessToken:  "test-access-token",
+				MaxBody:      131072,
+			},
+		},
+		"custom section, partial section variables do not fall back": {
+			section: "test",
+			envs: map[string]string{
+				"AKAMAI_TEST_HOST":     "test-host",
+				"AKAMAI_HOST":          "test-host",
+				"AKAMAI_CLIENT_TOKEN":  "test-client-token",
+				"AKAMAI_CLIENT_SECRET": "test-client-secret",
+				"AKAMAI_ACCESS_TOKEN":  "test-access-token",
+			},
+			withError:    ErrRequiredOptionEnv,
+			errorMessage: `required option is missing from env: "AKAMAI_TEST_CLIENT_TOKEN", "AKAMAI_TEST_CLIENT_SECRET", "AKAMAI_TEST_ACCESS_TOKEN"`,
+		},
+		"default section, all variables missing": {
+			section:      "default",
+			withError:    ErrRequiredOptionEnv,
+			errorMessage: `required option is missing from env: "AKAMAI_HOST", "AKAMAI_CLIENT_TOKEN", "AKAMAI_CLIENT_SECRET", "AKAMAI_ACCESS_TOKEN"`,
+		},
 		"custom section, missing host": {
 			section: "test",
 			envs: map[string]string{
@@ -199,6 +233,9 @@ func TestConfig_FromEnv(t *testing.T) {
 			err := cfg.FromEnv(test.section)
 			if test.withError != nil {
 				assert.True(t, errors.Is(err, test.withError), "want: %v; got: %v", test.withError, err)
+				if test.errorMessage != "" {
+					assert.EqualError(t, err, test.errorMessage)
+				}
 				return
 			}
 			require.NoError(t, err)
@@ -207,6 +244,89 @@ func TestConfig_FromEnv(t *testing.T) {
 	}
 }
 
+func TestNew_WithEnv(t *testing.T) {
+	tests := map[string]struct {
+		envs     map[string]string
+		expected Config
+	}{
+		"env variables take precedence over edgerc": {
+			envs: map[string]string{
+				"AKAMAI_TEST_HOST":          "env-host",
+				"AKAMAI_TEST_CLIENT_TOKEN":  "env-client-token",
+				"AKAMAI_TEST_CLIENT_SECRET": "env-client-secret",
+				"AKAMAI_TEST_ACCESS_TOKEN":  "env-access-token",
+			},
+			expected: Config{
+				Host:         "env-host",
+				ClientToken:  "env-client-token",
+				ClientSecret: "env-client-secret",
+				AccessToken:  "env-access-token",
+				MaxBody:      131072,
+			},
+		},
+		"falls back to edgerc when env variables are absent": {
+			expected: Config{
+				Host:         "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
+				ClientToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+				AccessToken:  "xxxx-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+				MaxBody:      131072,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.envs {
+				require.NoError(t, os.Setenv(k, v))
+			}
+			defer func() {
+				for k := range test.envs {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}()
+			cfg, err := New(WithEnv(true), WithFile("test/edgerc"), WithSection("test"))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected.Host, cfg.Host)
+			assert.Equal(t, test.expected.ClientToken, cfg.ClientToken)
+			assert.Equal(t, test.expected.ClientSecret, cfg.ClientSecret)
+			assert.Equal(t, test.expected.AccessToken, cfg.AccessToken)
+		})
+	}
+}
+
+func TestNew_WithEnvPartiallySet(t *testing.T) {
+	tests := map[string]struct {
+		envs map[string]string
+	}{
+		"section env variables partially set": {
+			envs: map[string]string{
+				"AKAMAI_TEST_HOST":         "env-host",
+				"AKAMAI_TEST_CLIENT_TOKEN": "env-client-token",
+			},
+		},
+		"default env variables partially set": {
+			envs: map[string]string{
+				"AKAMAI_HOST":         "env-host",
+				"AKAMAI_ACCESS_TOKEN": "env-access-token",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.envs {
+				require.NoError(t, os.Setenv(k, v))
+			}
+			defer func() {
+				for k := range test.envs {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}()
+			_, err := New(WithEnv(true), WithFile("test/edgerc"), WithSection("test"))
+			assert.True(t, errors.Is(err, ErrRequiredOptionEnv), "want: %s; got: %s", ErrRequiredOptionEnv, err)
+		})
+	}
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := map[string]struct {
 		fileName        string
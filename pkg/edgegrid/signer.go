@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sort"
@@ -22,6 +23,12 @@ type (
 		CheckRequestLimit(requestLimit int)
 	}
 
+	// TimestampSigner is implemented by signers which can sign a request with a given timestamp
+	// instead of the current time, e.g. to correct a skewed local clock
+	TimestampSigner interface {
+		SignRequestAt(r *http.Request, t time.Time)
+	}
+
 	authHeader struct {
 		authType    string
 		clientToken string
@@ -43,6 +50,11 @@ var (
 
 // SignRequest adds a signed authorization header to the http request
 func (c Config) SignRequest(r *http.Request) {
+	c.SignRequestAt(r, time.Now())
+}
+
+// SignRequestAt adds an authorization header to the http request signed with the timestamp t
+func (c Config) SignRequestAt(r *http.Request, t time.Time) {
 	if r.URL.Host == "" {
 		r.URL.Host = c.Host
 	}
@@ -50,7 +62,7 @@ func (c Config) SignRequest(r *http.Request) {
 		r.URL.Scheme = "https"
 	}
 	r.URL.RawQuery = c.addAccountSwitchKey(r)
-	r.Header.Set("Authorization", c.createAuthHeader(r).String())
+	r.Header.Set("Authorization", c.createAuthHeader(r, t).String())
 }
 
 // CheckRequestLimit waits if necessary to ensure that OpenAPI's request limit is not exceeded
@@ -63,8 +75,8 @@ func (c Config) CheckRequestLimit(limit int) {
 	}
 }
 
-func (c Config) createAuthHeader(r *http.Request) authHeader {
-	timestamp := Timestamp(time.Now())
+func (c Config) createAuthHeader(r *http.Request, t time.Time) authHeader {
+	timestamp := Timestamp(t)
 
 	auth := authHeader{
 		authType:    authType,
@@ -119,30 +131,46 @@ func canonicalizeHeaders(requestHeaders http.Header, headersToSign []string) str
 // The size of the POST body must be less than or equal to the value specified by the service.
 // Any request that does not meet this criteria SHOULD be rejected during the signing process,
 // as the request will be rejected by EdgeGrid.
+//
+// The body is hashed from a fresh reader obtained with r.GetBody, so r.Body is not consumed and only
+// the first maxBody bytes are read. A body without GetBody is buffered once (see bufferBody).
 func createContentHash(r *http.Request, maxBody int) string {
-	var (
-		contentHash  string
-		preparedBody string
-		bodyBytes    []byte
-	)
+	if r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
 
-	if r.Body != nil {
-		bodyBytes, _ = ioutil.ReadAll(r.Body)
-		r.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-		preparedBody = string(bodyBytes)
+	if r.GetBody == nil {
+		bufferBody(r)
 	}
 
-	if r.Method == http.MethodPost && len(preparedBody) > 0 {
-		if len(preparedBody) > maxBody {
-			preparedBody = preparedBody[0:maxBody]
-		}
+	if r.Method != http.MethodPost {
+		return ""
+	}
 
-		sum := sha256.Sum256([]byte(preparedBody))
+	body, err := r.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
 
-		contentHash = base64.StdEncoding.EncodeToString(sum[:])
+	hash := sha256.New()
+	n, _ := io.Copy(hash, io.LimitReader(body, int64(maxBody)))
+	if n == 0 {
+		return ""
 	}
 
-	return contentHash
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+// bufferBody reads the request body once and sets r.Body and r.GetBody to readers of the buffer,
+// so that the same bytes are hashed, sent, and replayed on redirects or retries without being read again.
+func bufferBody(r *http.Request) {
+	data, _ := ioutil.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
 }
 
 func (a authHeader) String() string {
@@ -1,7 +1,11 @@
 package edgegrid
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
@@ -40,7 +44,7 @@ func TestConfig_createAuthHeader(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			res := test.config.createAuthHeader(test.request)
+			res := test.config.createAuthHeader(test.request, time.Now())
 			assert.Equal(t, test.expected.authType, res.authType)
 			assert.Equal(t, test.expected.accessToken, res.accessToken)
 			assert.Equal(t, test.expected.clientToken, res.clientToken)
@@ -128,6 +132,39 @@ func TestCreateContentHash(t *testing.T) {
 	}
 }
 
+func TestCreateContentHash_LargeBody(t *testing.T) {
+	body := bytes.Repeat([]byte("0123456789abcdef"), 4*1024*1024/16)
+	maxBodySum := sha256.Sum256(body[:MaxBodySize])
+	expectedHash := base64.StdEncoding.EncodeToString(maxBodySum[:])
+
+	tests := map[string]io.Reader{
+		"seekable body":     bytes.NewReader(body),
+		"non-seekable body": io.MultiReader(bytes.NewReader(body)),
+	}
+
+	for name, reader := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "", reader)
+			require.NoError(t, err)
+
+			// signing twice, e.g. on a retry, hashes the same bytes
+			assert.Equal(t, expectedHash, createContentHash(req, MaxBodySize))
+			assert.Equal(t, expectedHash, createContentHash(req, MaxBodySize))
+
+			require.NotNil(t, req.GetBody)
+			sent, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(body, sent), "body was consumed by signing")
+
+			replayed, err := req.GetBody()
+			require.NoError(t, err)
+			replayedBody, err := ioutil.ReadAll(replayed)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(body, replayedBody), "body cannot be replayed")
+		})
+	}
+}
+
 func TestAuthHeader_String(t *testing.T) {
 	tests := map[string]struct {
 		given    authHeader
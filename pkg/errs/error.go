@@ -17,6 +17,16 @@ func UnescapeContent(content string) string {
 	return content
 }
 
+// Preview returns the content, e.g. a response body which is not a valid API error, truncated to limit bytes
+// with "..." appended when it is longer, and unescaped with UnescapeContent
+func Preview(content []byte, limit int) string {
+	preview := string(content)
+	if limit > 0 && len(content) > limit {
+		preview = string(content[:limit]) + "..."
+	}
+	return UnescapeContent(preview)
+}
+
 func isHTML(data string) bool {
 	_, err := html.Parse(strings.NewReader(data))
 	return err == nil
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // ASMaps contains operations available on a ASmap resource.
@@ -50,18 +54,67 @@ type (
 	}
 )
 
+// reservedASNumbers contains inclusive ranges of AS numbers which are reserved
+// for documentation, private use or otherwise (RFC 6996, RFC 7300, RFC 5398, RFC 6793)
+var reservedASNumbers = [][2]int64{
+	{23456, 23456},
+	{64496, 65551},
+	{65552, 131071},
+	{4200000000, 4294967295},
+}
+
 // Validate validates ASMap
 func (a *ASMap) Validate() error {
-	if len(a.Name) < 1 {
-		return fmt.Errorf("ASMap is missing Name")
+	return a.validate(nil)
+}
+
+// validate validates ASMap, checking also that assignments reference the given datacenters
+// unless datacenters is nil
+func (a *ASMap) validate(datacenters map[int]struct{}) error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Name":              validation.Validate(a.Name, validation.Required),
+		"DefaultDatacenter": validation.Validate(a.DefaultDatacenter, validation.NotNil),
+		"Assignments": validation.Validate(a.Assignments, validation.Each(validation.By(func(value interface{}) error {
+			as, ok := value.(ASAssignment)
+			if !ok {
+				return fmt.Errorf("unexpected assignment type %T", value)
+			}
+			return validation.Errors{
+				"DatacenterID": validation.Validate(as.DatacenterID, datacenterExists(datacenters)),
+				"ASNumbers":    validation.Validate(as.ASNumbers, validation.By(validateASNumbers)),
+			}.Filter()
+		}))),
+	})
+}
+
+// validateASNumbers checks that value contains AS numbers in range 1-4294967295,
+// which are not reserved
+func validateASNumbers(value interface{}) error {
+	numbers, ok := value.([]int64)
+	if !ok {
+		return fmt.Errorf("type %T is invalid, must be []int64", value)
 	}
-	if a.DefaultDatacenter == nil {
-		return fmt.Errorf("ASMap is missing DefaultDatacenter")
+	var invalid []string
+	for _, number := range numbers {
+		if number < 1 || number > 4294967295 || isReservedASNumber(number) {
+			invalid = append(invalid, fmt.Sprint(number))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid or reserved AS numbers: %s", strings.Join(invalid, ", "))
 	}
-
 	return nil
 }
 
+func isReservedASNumber(number int64) bool {
+	for _, r := range reservedASNumbers {
+		if number >= r[0] && number <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *gtm) ListASMaps(ctx context.Context, domainName string) ([]*ASMap, error) {
 	logger := g.Log(ctx)
 	logger.Debug("ListASMaps")
@@ -114,14 +167,14 @@ func (g *gtm) CreateASMap(ctx context.Context, asMap *ASMap, domainName string)
 	logger := g.Log(ctx)
 	logger.Debug("CreateASMap")
 
-	return asMap.save(ctx, g, domainName)
+	return asMap.save(ctx, g, domainName, nil)
 }
 
 func (g *gtm) UpdateASMap(ctx context.Context, asMap *ASMap, domainName string) (*ResponseStatus, error) {
 	logger := g.Log(ctx)
 	logger.Debug("UpdateASMap")
 
-	stat, err := asMap.save(ctx, g, domainName)
+	stat, err := asMap.save(ctx, g, domainName, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -129,8 +182,8 @@ func (g *gtm) UpdateASMap(ctx context.Context, asMap *ASMap, domainName string)
 }
 
 // save AsMap in given domain. Common path for Create and Update.
-func (a *ASMap) save(ctx context.Context, g *gtm, domainName string) (*ASMapResponse, error) {
-	if err := a.Validate(); err != nil {
+func (a *ASMap) save(ctx context.Context, g *gtm, domainName string, datacenters map[int]struct{}) (*ASMapResponse, error) {
+	if err := g.validateDatacenters(ctx, domainName, datacenters, a.validate); err != nil {
 		return nil, fmt.Errorf("ASMap validation failed. %w", err)
 	}
 
@@ -236,6 +236,9 @@ func TestGTM_CreateASMap(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
@@ -324,6 +327,9 @@ func TestGTM_UpdateASMap(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
@@ -432,3 +438,65 @@ func TestGTM_DeleteASMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGTM_CreateASMapAssignmentsValidation(t *testing.T) {
+	tests := map[string]struct {
+		asMap         *ASMap
+		expectedLists int
+		expectedError []string
+	}{
+		"32-bit AS number is valid, out of range and reserved AS numbers are not": {
+			asMap: &ASMap{
+				Name:              "The North",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*ASAssignment{
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 3134},
+						ASNumbers:      []int64{16702, 396982},
+					},
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 3133},
+						ASNumbers:      []int64{0, 4294967296, 64512, 23456},
+					},
+				},
+			},
+			expectedError: []string{
+				"Assignments[1]: {\n\tASNumbers: invalid or reserved AS numbers: 0, 4294967296, 64512, 23456\n}",
+			},
+		},
+		"unknown datacenter": {
+			asMap: &ASMap{
+				Name:              "The North",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*ASAssignment{
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 9999},
+						ASNumbers:      []int64{16625},
+					},
+				},
+			},
+			expectedLists: 1,
+			expectedError: []string{"DatacenterID: datacenter 9999 does not exist in the domain"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lists int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					lists++
+					return
+				}
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			client := mockAPIClient(t, mockServer)
+			_, err := client.CreateASMap(context.Background(), test.asMap, "example.akadns.net")
+			require.Error(t, err)
+			assert.Equal(t, test.expectedLists, lists)
+			for _, expected := range test.expectedError {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
@@ -3,7 +3,12 @@ package gtm
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // CIDRMaps contains operations available on a CIDR map resource.
@@ -51,13 +56,43 @@ type CIDRMapList struct {
 
 // Validate validates CIDRMap
 func (c *CIDRMap) Validate() error {
-	if len(c.Name) < 1 {
-		return fmt.Errorf("CIDRMap is missing Name")
+	return c.validate(nil)
+}
+
+// validate validates CIDRMap, checking also that assignments reference the given datacenters
+// unless datacenters is nil
+func (c *CIDRMap) validate(datacenters map[int]struct{}) error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Name":              validation.Validate(c.Name, validation.Required),
+		"DefaultDatacenter": validation.Validate(c.DefaultDatacenter, validation.NotNil),
+		"Assignments": validation.Validate(c.Assignments, validation.Each(validation.By(func(value interface{}) error {
+			a, ok := value.(CIDRAssignment)
+			if !ok {
+				return fmt.Errorf("unexpected assignment type %T", value)
+			}
+			return validation.Errors{
+				"DatacenterID": validation.Validate(a.DatacenterID, datacenterExists(datacenters)),
+				"Blocks":       validation.Validate(a.Blocks, validation.By(validateCIDRBlocks)),
+			}.Filter()
+		}))),
+	})
+}
+
+// validateCIDRBlocks checks that value contains CIDR blocks or single IP addresses
+func validateCIDRBlocks(value interface{}) error {
+	blocks, ok := value.([]string)
+	if !ok {
+		return fmt.Errorf("type %T is invalid, must be []string", value)
 	}
-	if c.DefaultDatacenter == nil {
-		return fmt.Errorf("CIDRMap is missing DefaultDatacenter")
+	var invalid []string
+	for _, block := range blocks {
+		if _, _, err := net.ParseCIDR(block); err != nil && net.ParseIP(block) == nil {
+			invalid = append(invalid, fmt.Sprintf("%q", block))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid CIDR blocks: %s", strings.Join(invalid, ", "))
 	}
-
 	return nil
 }
 
@@ -113,14 +148,14 @@ func (g *gtm) CreateCIDRMap(ctx context.Context, cidr *CIDRMap, domainName strin
 	logger := g.Log(ctx)
 	logger.Debug("CreateCIDRMap")
 
-	return cidr.save(ctx, g, domainName)
+	return cidr.save(ctx, g, domainName, nil)
 }
 
 func (g *gtm) UpdateCIDRMap(ctx context.Context, cidr *CIDRMap, domainName string) (*ResponseStatus, error) {
 	logger := g.Log(ctx)
 	logger.Debug("UpdateCIDRMap")
 
-	stat, err := cidr.save(ctx, g, domainName)
+	stat, err := cidr.save(ctx, g, domainName, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -128,11 +163,19 @@ func (g *gtm) UpdateCIDRMap(ctx context.Context, cidr *CIDRMap, domainName strin
 }
 
 // Save CIDRMap in given domain. Common path for Create and Update.
-func (c *CIDRMap) save(ctx context.Context, g *gtm, domainName string) (*CIDRMapResponse, error) {
-
-	if err := c.Validate(); err != nil {
+func (c *CIDRMap) save(ctx context.Context, g *gtm, domainName string, datacenters map[int]struct{}) (*CIDRMapResponse, error) {
+	if err := g.validateDatacenters(ctx, domainName, datacenters, c.validate); err != nil {
 		return nil, fmt.Errorf("CIDRMap validation failed. %w", err)
 	}
+	if g.checkCIDROverlaps {
+		domainMaps, err := g.ListCIDRMaps(ctx, domainName)
+		if err != nil {
+			return nil, fmt.Errorf("CIDRMap validation failed. %w", err)
+		}
+		if err := validateCIDROverlaps(c, domainMaps); err != nil {
+			return nil, fmt.Errorf("CIDRMap validation failed. %w", err)
+		}
+	}
 
 	putURL := fmt.Sprintf("/config-gtm/v1/domains/%s/cidr-maps/%s", domainName, c.Name)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
@@ -236,6 +236,9 @@ func TestGTM_CreateCIDRMap(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
@@ -324,6 +327,9 @@ func TestGTM_UpdateCIDRMap(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
@@ -432,3 +438,221 @@ func TestGTM_DeleteCIDRMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGTM_CreateCIDRMapAssignmentsValidation(t *testing.T) {
+	tests := map[string]struct {
+		cidrMap       *CIDRMap
+		expectedLists int
+		expectedError []string
+	}{
+		"invalid blocks are reported before datacenters are listed": {
+			cidrMap: &CIDRMap{
+				Name:              "The North",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*CIDRAssignment{
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 3134},
+						Blocks:         []string{"1.3.5.9", "1.2.3.0/24", "2001:db8::/32"},
+					},
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 9999},
+						Blocks:         []string{"1.2.4.0/33"},
+					},
+				},
+			},
+			expectedError: []string{
+				`Blocks: invalid CIDR blocks: "1.2.4.0/33"`,
+			},
+		},
+		"unknown datacenter": {
+			cidrMap: &CIDRMap{
+				Name:              "The North",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*CIDRAssignment{
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 9999},
+						Blocks:         []string{"1.2.4.0/24"},
+					},
+				},
+			},
+			expectedLists: 1,
+			expectedError: []string{
+				"DatacenterID: datacenter 9999 does not exist in the domain",
+			},
+		},
+		"missing default datacenter": {
+			cidrMap: &CIDRMap{
+				Name: "The North",
+			},
+			expectedError: []string{"DefaultDatacenter: is required"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lists int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					lists++
+					return
+				}
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			client := mockAPIClient(t, mockServer)
+			_, err := client.CreateCIDRMap(context.Background(), test.cidrMap, "example.akadns.net")
+			require.Error(t, err)
+			assert.Equal(t, test.expectedLists, lists)
+			for _, expected := range test.expectedError {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestDetectCIDROverlaps(t *testing.T) {
+	cidrMap := func(name string, assignments ...*CIDRAssignment) *CIDRMap {
+		return &CIDRMap{Name: name, DefaultDatacenter: &DatacenterBase{DatacenterID: 5400}, Assignments: assignments}
+	}
+	assignment := func(datacenterID int, blocks ...string) *CIDRAssignment {
+		return &CIDRAssignment{DatacenterBase: DatacenterBase{DatacenterID: datacenterID}, Blocks: blocks}
+	}
+
+	tests := map[string]struct {
+		maps     []*CIDRMap
+		expected []CIDROverlap
+	}{
+		"/24 nested in a /16 of another map": {
+			maps: []*CIDRMap{
+				cidrMap("north", assignment(3131, "10.1.0.0/16", "192.0.2.0/24")),
+				cidrMap("south", assignment(3132, "10.2.0.0/16"), assignment(3133, "10.1.2.0/24")),
+			},
+			expected: []CIDROverlap{
+				{
+					Block:      CIDRBlockAssignment{MapName: "north", DatacenterID: 3131, Block: "10.1.0.0/16"},
+					OtherBlock: CIDRBlockAssignment{MapName: "south", DatacenterID: 3133, Block: "10.1.2.0/24"},
+				},
+			},
+		},
+		"same block in two maps": {
+			maps: []*CIDRMap{
+				cidrMap("north", assignment(3131, "10.1.2.0/24")),
+				cidrMap("south", assignment(3131, "10.1.2.0/24")),
+			},
+			expected: []CIDROverlap{
+				{
+					Block:      CIDRBlockAssignment{MapName: "north", DatacenterID: 3131, Block: "10.1.2.0/24"},
+					OtherBlock: CIDRBlockAssignment{MapName: "south", DatacenterID: 3131, Block: "10.1.2.0/24"},
+				},
+			},
+		},
+		"single address in a block of another map": {
+			maps: []*CIDRMap{
+				cidrMap("north", assignment(3131, "2001:db8::/32")),
+				cidrMap("south", assignment(3132, "2001:db8::1", "2001:db9::1")),
+			},
+			expected: []CIDROverlap{
+				{
+					Block:      CIDRBlockAssignment{MapName: "north", DatacenterID: 3131, Block: "2001:db8::/32"},
+					OtherBlock: CIDRBlockAssignment{MapName: "south", DatacenterID: 3132, Block: "2001:db8::1"},
+				},
+			},
+		},
+		"same block assigned to two datacenters of a map": {
+			maps: []*CIDRMap{
+				cidrMap("north", assignment(3131, "10.1.2.0/24"), assignment(3132, "10.1.2.0/24")),
+			},
+			expected: []CIDROverlap{
+				{
+					Block:      CIDRBlockAssignment{MapName: "north", DatacenterID: 3131, Block: "10.1.2.0/24"},
+					OtherBlock: CIDRBlockAssignment{MapName: "north", DatacenterID: 3132, Block: "10.1.2.0/24"},
+				},
+			},
+		},
+		"nested blocks of a map": {
+			maps: []*CIDRMap{
+				cidrMap("north", assignment(3131, "10.1.0.0/16"), assignment(3132, "10.1.2.0/24")),
+			},
+		},
+		"adjacent blocks and invalid blocks": {
+			maps: []*CIDRMap{
+				cidrMap("north", assignment(3131, "10.1.0.0/16", "not-a-block")),
+				cidrMap("south", assignment(3132, "10.2.0.0/16", "not-a-block")),
+				nil,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DetectCIDROverlaps(test.maps))
+		})
+	}
+}
+
+func TestGTM_UpdateCIDRMapOverlapCheck(t *testing.T) {
+	domainMaps := `{"items": [
+		{"name": "The North", "defaultDatacenter": {"datacenterId": 5400}, "assignments": [{"datacenterId": 3131, "blocks": ["10.0.0.0/8"]}]},
+		{"name": "The South", "defaultDatacenter": {"datacenterId": 5400}, "assignments": [{"datacenterId": 3132, "blocks": ["10.1.0.0/16"]}]}
+	]}`
+
+	tests := map[string]struct {
+		options   []Option
+		blocks    []string
+		expectPut bool
+		withError []string
+	}{
+		"overlap rejected": {
+			options:   []Option{WithCIDROverlapCheck(true)},
+			blocks:    []string{"10.1.2.0/24"},
+			withError: []string{ErrCIDROverlap.Error(), "block 10.1.2.0/24 of CIDR map The North (datacenter 3133)", "block 10.1.0.0/16 of CIDR map The South"},
+		},
+		"blocks replaced by the update are not compared": {
+			options:   []Option{WithCIDROverlapCheck(true)},
+			blocks:    []string{"192.0.2.0/24"},
+			expectPut: true,
+		},
+		"check disabled by default": {
+			blocks:    []string{"10.1.2.0/24"},
+			expectPut: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			put := false
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/config-gtm/v1/domains/example.akadns.net/cidr-maps":
+					_, err := w.Write([]byte(domainMaps))
+					assert.NoError(t, err)
+				case r.Method == http.MethodPut && r.URL.Path == "/config-gtm/v1/domains/example.akadns.net/cidr-maps/The North":
+					put = true
+					_, err := w.Write([]byte(`{"resource": {"name": "The North"}, "status": {"changeId": "93a48b86-4fc3-4a5f-9ca2-036835034cc6"}}`))
+					assert.NoError(t, err)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+				}
+			}))
+			client := mockAPIClient(t, mockServer, test.options...)
+			_, err := client.UpdateCIDRMap(context.Background(), &CIDRMap{
+				Name:              "The North",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*CIDRAssignment{
+					{DatacenterBase: DatacenterBase{DatacenterID: 3133}, Blocks: test.blocks},
+				},
+			}, "example.akadns.net")
+			assert.Equal(t, test.expectPut, put)
+			if len(test.withError) > 0 {
+				assert.True(t, errors.Is(err, ErrCIDROverlap), "want: %s; got: %s", ErrCIDROverlap, err)
+				for _, msg := range test.withError {
+					assert.ErrorContains(t, err, msg)
+				}
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
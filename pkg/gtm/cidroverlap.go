@@ -0,0 +1,128 @@
+package gtm
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+type (
+	// CIDROverlap is a pair of overlapping CIDR blocks which make the routing of the addresses they share ambiguous:
+	// blocks of different CIDR maps, or the same block assigned to different datacenters of a CIDR map
+	CIDROverlap struct {
+		Block      CIDRBlockAssignment
+		OtherBlock CIDRBlockAssignment
+	}
+
+	// CIDRBlockAssignment is a CIDR block assigned to a datacenter by a CIDR map
+	CIDRBlockAssignment struct {
+		MapName      string
+		DatacenterID int
+		Nickname     string
+		Block        string
+	}
+
+	// cidrBlock is a parsed CIDR block assignment
+	cidrBlock struct {
+		CIDRBlockAssignment
+		network *net.IPNet
+	}
+)
+
+// String returns a description of the overlap
+func (o CIDROverlap) String() string {
+	return fmt.Sprintf("%s overlaps %s", o.Block, o.OtherBlock)
+}
+
+// String returns a description of the assignment
+func (a CIDRBlockAssignment) String() string {
+	return fmt.Sprintf("block %s of CIDR map %s (datacenter %d)", a.Block, a.MapName, a.DatacenterID)
+}
+
+// DetectCIDROverlaps returns the CIDR blocks of the maps, e.g. the CIDR maps of a domain listed by ListCIDRMaps,
+// which overlap a block of another map, e.g. 10.1.0.0/16 and 10.1.2.0/24, or which are assigned to several
+// datacenters of the same map. Blocks nested in a block of the same map are not reported, as the most specific
+// block routes their addresses. Single IP addresses are blocks of one address, and invalid blocks are ignored.
+// The overlaps are returned in the order of the maps, assignments and blocks.
+func DetectCIDROverlaps(maps []*CIDRMap) []CIDROverlap {
+	var blocks []cidrBlock
+	for _, m := range maps {
+		if m == nil {
+			continue
+		}
+		for _, assignment := range m.Assignments {
+			if assignment == nil {
+				continue
+			}
+			for _, block := range assignment.Blocks {
+				network := parseCIDRBlock(block)
+				if network == nil {
+					continue
+				}
+				blocks = append(blocks, cidrBlock{
+					CIDRBlockAssignment: CIDRBlockAssignment{
+						MapName:      m.Name,
+						DatacenterID: assignment.DatacenterID,
+						Nickname:     assignment.Nickname,
+						Block:        block,
+					},
+					network: network,
+				})
+			}
+		}
+	}
+
+	var overlaps []CIDROverlap
+	for i, block := range blocks {
+		for _, other := range blocks[i+1:] {
+			if block.conflicts(other) {
+				overlaps = append(overlaps, CIDROverlap{Block: block.CIDRBlockAssignment, OtherBlock: other.CIDRBlockAssignment})
+			}
+		}
+	}
+	return overlaps
+}
+
+// conflicts reports whether the blocks overlap across maps, or are the same block of different datacenters of a map
+func (b cidrBlock) conflicts(other cidrBlock) bool {
+	if b.MapName != other.MapName {
+		return b.network.Contains(other.network.IP) || other.network.Contains(b.network.IP)
+	}
+	return b.DatacenterID != other.DatacenterID && b.network.String() == other.network.String()
+}
+
+// parseCIDRBlock parses a CIDR block or a single IP address, returning nil when the block is invalid
+func parseCIDRBlock(block string) *net.IPNet {
+	if _, network, err := net.ParseCIDR(block); err == nil {
+		return network
+	}
+	ip := net.ParseIP(block)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+// validateCIDROverlaps checks that the CIDR map does not overlap the other CIDR maps of the domain
+func validateCIDROverlaps(c *CIDRMap, domainMaps []*CIDRMap) error {
+	maps := []*CIDRMap{c}
+	for _, m := range domainMaps {
+		if m != nil && m.Name != c.Name {
+			maps = append(maps, m)
+		}
+	}
+
+	var overlaps []string
+	for _, overlap := range DetectCIDROverlaps(maps) {
+		if overlap.Block.MapName == c.Name || overlap.OtherBlock.MapName == c.Name {
+			overlaps = append(overlaps, overlap.String())
+		}
+	}
+	if len(overlaps) > 0 {
+		return fmt.Errorf("%w: %s", ErrCIDROverlap, strings.Join(overlaps, "; "))
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package gtm
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // default schema version
@@ -97,3 +100,54 @@ type DatacenterBase struct {
 	Nickname     string `json:"nickname,omitempty"`
 	DatacenterID int    `json:"datacenterId"`
 }
+
+// datacenterIDs retrieves IDs of the datacenters existing in the domain
+func (g *gtm) datacenterIDs(ctx context.Context, domainName string) (map[int]struct{}, error) {
+	datacenters, err := g.ListDatacenters(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int]struct{}, len(datacenters))
+	for _, dc := range datacenters {
+		ids[dc.DatacenterID] = struct{}{}
+	}
+	return ids, nil
+}
+
+// validateDatacenters runs the validation of an object, first without checking the datacenters it references,
+// then, unless domain validation is disabled, checking that they exist in the domain. The datacenters of the domain
+// are only listed when datacenters is nil
+func (g *gtm) validateDatacenters(ctx context.Context, domainName string, datacenters map[int]struct{}, validate func(map[int]struct{}) error) error {
+	if err := validate(nil); err != nil {
+		return err
+	}
+	if g.skipDomainValidation {
+		return nil
+	}
+	if datacenters == nil {
+		var err error
+		if datacenters, err = g.datacenterIDs(ctx, domainName); err != nil {
+			return err
+		}
+	}
+	return validate(datacenters)
+}
+
+// datacenterExists returns a rule checking that a datacenter ID is one of the given datacenters.
+// Any datacenter ID is accepted when datacenters is nil
+func datacenterExists(datacenters map[int]struct{}) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		if datacenters == nil {
+			return nil
+		}
+		id, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("datacenter ID must be an int")
+		}
+		if _, ok := datacenters[id]; !ok {
+			return fmt.Errorf("datacenter %d does not exist in the domain", id)
+		}
+		return nil
+	})
+}
@@ -36,6 +36,10 @@ type Datacenters interface {
 	CreateIPv4DefaultDatacenter(context.Context, string) (*Datacenter, error)
 	// CreateIPv6DefaultDatacenter creates Default Datacenter for IPv6 Selector.
 	CreateIPv6DefaultDatacenter(context.Context, string) (*Datacenter, error)
+	// CreateDefaultDatacenter creates the default datacenter of the given type in the domain, with its well-known ID
+	// (see DefaultDCType.DatacenterID), and returns it. It returns the existing datacenter when the domain already
+	// has it, so it can be called before every map or property referencing it.
+	CreateDefaultDatacenter(ctx context.Context, domain string, dcType DefaultDCType) (*Datacenter, error)
 }
 
 // Datacenter represents a GTM datacenter
@@ -147,31 +151,96 @@ var (
 	Ipv6DefaultDC = 5402
 )
 
+// DefaultDCType is the type of a default datacenter, a virtual datacenter with a well-known ID which GTM creates on
+// request rather than one standing for a location of servers
+type DefaultDCType string
+
+const (
+	// DefaultDCMaps is the default datacenter of geographic, CIDR and AS maps (MapDefaultDC), to which the clients
+	// matched by no assignment of a map, i.e. "all other" clients, are mapped
+	DefaultDCMaps DefaultDCType = "maps"
+	// DefaultDCIPv4 is the datacenter of the IPv4 version selector (Ipv4DefaultDC)
+	DefaultDCIPv4 DefaultDCType = "ipv4"
+	// DefaultDCIPv6 is the datacenter of the IPv6 version selector (Ipv6DefaultDC)
+	DefaultDCIPv6 DefaultDCType = "ipv6"
+)
+
+// DatacenterID returns the well-known ID of the default datacenter type, or 0 for an unknown type
+func (t DefaultDCType) DatacenterID() int {
+	switch t {
+	case DefaultDCMaps:
+		return MapDefaultDC
+	case DefaultDCIPv4:
+		return Ipv4DefaultDC
+	case DefaultDCIPv6:
+		return Ipv6DefaultDC
+	}
+	return 0
+}
+
+// path returns the path segment of the datacenters endpoint creating the default datacenter type
+func (t DefaultDCType) path() string {
+	switch t {
+	case DefaultDCMaps:
+		return "default-datacenter-for-maps"
+	case DefaultDCIPv4:
+		return "datacenter-for-ip-version-selector-ipv4"
+	case DefaultDCIPv6:
+		return "datacenter-for-ip-version-selector-ipv6"
+	}
+	return ""
+}
+
+// DefaultDatacenterType returns the type of the default datacenter with the given ID,
+// and false when the ID is not the one of a default datacenter
+func DefaultDatacenterType(datacenterID int) (DefaultDCType, bool) {
+	for _, t := range []DefaultDCType{DefaultDCMaps, DefaultDCIPv4, DefaultDCIPv6} {
+		if t.DatacenterID() == datacenterID {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// IsDefaultDatacenter reports whether the datacenter ID is the well-known ID of a default datacenter
+func IsDefaultDatacenter(datacenterID int) bool {
+	_, ok := DefaultDatacenterType(datacenterID)
+	return ok
+}
+
 func (g *gtm) CreateMapsDefaultDatacenter(ctx context.Context, domainName string) (*Datacenter, error) {
 	logger := g.Log(ctx)
 	logger.Debug("CreateMapsDefaultDatacenter")
 
-	return createDefaultDC(ctx, g, MapDefaultDC, domainName)
+	return createDefaultDC(ctx, g, DefaultDCMaps, domainName)
 }
 
 func (g *gtm) CreateIPv4DefaultDatacenter(ctx context.Context, domainName string) (*Datacenter, error) {
 	logger := g.Log(ctx)
 	logger.Debug("CreateIPv4DefaultDatacenter")
 
-	return createDefaultDC(ctx, g, Ipv4DefaultDC, domainName)
+	return createDefaultDC(ctx, g, DefaultDCIPv4, domainName)
 }
 
 func (g *gtm) CreateIPv6DefaultDatacenter(ctx context.Context, domainName string) (*Datacenter, error) {
 	logger := g.Log(ctx)
 	logger.Debug("CreateIPv6DefaultDatacenter")
 
-	return createDefaultDC(ctx, g, Ipv6DefaultDC, domainName)
+	return createDefaultDC(ctx, g, DefaultDCIPv6, domainName)
 }
 
-// createDefaultDC is worker function used to create Default Datacenter identified id in the specified domain.
-func createDefaultDC(ctx context.Context, g *gtm, defaultID int, domainName string) (*Datacenter, error) {
-	if defaultID != MapDefaultDC && defaultID != Ipv4DefaultDC && defaultID != Ipv6DefaultDC {
-		return nil, fmt.Errorf("invalid default datacenter id provided for creation")
+func (g *gtm) CreateDefaultDatacenter(ctx context.Context, domainName string, dcType DefaultDCType) (*Datacenter, error) {
+	logger := g.Log(ctx)
+	logger.Debug("CreateDefaultDatacenter")
+
+	return createDefaultDC(ctx, g, dcType, domainName)
+}
+
+// createDefaultDC is worker function used to create Default Datacenter of the given type in the specified domain.
+func createDefaultDC(ctx context.Context, g *gtm, dcType DefaultDCType, domainName string) (*Datacenter, error) {
+	defaultID := dcType.DatacenterID()
+	if defaultID == 0 {
+		return nil, fmt.Errorf("invalid default datacenter type %q provided for creation", dcType)
 	}
 	// check if already exists
 	dc, err := g.GetDatacenter(ctx, defaultID, domainName)
@@ -183,15 +252,7 @@ func createDefaultDC(ctx context.Context, g *gtm, defaultID int, domainName stri
 		return nil, err
 	}
 
-	defaultURL := fmt.Sprintf("/config-gtm/v1/domains/%s/datacenters/", domainName)
-	switch defaultID {
-	case MapDefaultDC:
-		defaultURL += "default-datacenter-for-maps"
-	case Ipv4DefaultDC:
-		defaultURL += "datacenter-for-ip-version-selector-ipv4"
-	case Ipv6DefaultDC:
-		defaultURL += "datacenter-for-ip-version-selector-ipv6"
-	}
+	defaultURL := fmt.Sprintf("/config-gtm/v1/domains/%s/datacenters/%s", domainName, dcType.path())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, defaultURL, nil)
 	if err != nil {
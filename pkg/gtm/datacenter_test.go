@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -510,6 +511,113 @@ func TestGTM_CreateIPv6DefaultDatacenter(t *testing.T) {
 	}
 }
 
+func TestGTM_CreateDefaultDatacenter(t *testing.T) {
+	tests := map[string]struct {
+		dcType           DefaultDCType
+		existing         bool
+		expectedRequests []string
+		expectedID       int
+		withError        bool
+	}{
+		"maps": {
+			dcType: DefaultDCMaps,
+			expectedRequests: []string{
+				"GET /config-gtm/v1/domains/example.akadns.net/datacenters/5400",
+				"POST /config-gtm/v1/domains/example.akadns.net/datacenters/default-datacenter-for-maps",
+			},
+			expectedID: 5400,
+		},
+		"IPv4": {
+			dcType: DefaultDCIPv4,
+			expectedRequests: []string{
+				"GET /config-gtm/v1/domains/example.akadns.net/datacenters/5401",
+				"POST /config-gtm/v1/domains/example.akadns.net/datacenters/datacenter-for-ip-version-selector-ipv4",
+			},
+			expectedID: 5401,
+		},
+		"IPv6": {
+			dcType: DefaultDCIPv6,
+			expectedRequests: []string{
+				"GET /config-gtm/v1/domains/example.akadns.net/datacenters/5402",
+				"POST /config-gtm/v1/domains/example.akadns.net/datacenters/datacenter-for-ip-version-selector-ipv6",
+			},
+			expectedID: 5402,
+		},
+		"already exists": {
+			dcType:           DefaultDCMaps,
+			existing:         true,
+			expectedRequests: []string{"GET /config-gtm/v1/domains/example.akadns.net/datacenters/5400"},
+			expectedID:       5400,
+		},
+		"unknown type": {
+			dcType:    "ipv5",
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.String())
+				id := test.dcType.DatacenterID()
+				if r.Method == http.MethodGet && !test.existing {
+					w.WriteHeader(http.StatusNotFound)
+					_, err := w.Write([]byte(`{"type": "Datacenter", "title": "not found"}`))
+					assert.NoError(t, err)
+					return
+				}
+				datacenter := fmt.Sprintf(`{"datacenterId": %d, "nickname": "Default Datacenter", "virtual": true}`, id)
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(datacenter))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"resource": ` + datacenter + `, "status": {"propagationStatus": "PENDING"}}`))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateDefaultDatacenter(context.Background(), "example.akadns.net", test.dcType)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, result.DatacenterID)
+			assert.True(t, result.Virtual)
+		})
+	}
+}
+
+func TestDefaultDatacenterType(t *testing.T) {
+	tests := map[string]struct {
+		datacenterID int
+		expectedType DefaultDCType
+		expectedOK   bool
+	}{
+		"maps":               {datacenterID: 5400, expectedType: DefaultDCMaps, expectedOK: true},
+		"IPv4":               {datacenterID: 5401, expectedType: DefaultDCIPv4, expectedOK: true},
+		"IPv6":               {datacenterID: 5402, expectedType: DefaultDCIPv6, expectedOK: true},
+		"regular datacenter": {datacenterID: 3131},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dcType, ok := DefaultDatacenterType(test.datacenterID)
+			assert.Equal(t, test.expectedType, dcType)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedOK, IsDefaultDatacenter(test.datacenterID))
+			if ok {
+				assert.Equal(t, test.datacenterID, dcType.DatacenterID())
+			}
+		})
+	}
+	assert.Zero(t, DefaultDCType("ipv5").DatacenterID())
+}
+
 func TestGTM_UpdateDatacenter(t *testing.T) {
 	var result DatacenterResponse
 	var req Datacenter
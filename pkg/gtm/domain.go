@@ -7,7 +7,12 @@ import (
 
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
 // Domains contains operations available on a Domain resource.
@@ -27,6 +32,7 @@ type Domains interface {
 	// See: https://techdocs.akamai.com/gtm/reference/get-domain
 	GetDomain(context.Context, string) (*Domain, error)
 	// CreateDomain creates domain.
+	// Datacenter references are checked with ValidateDomain first, unless disabled with WithDomainValidation.
 	//
 	// See: https://techdocs.akamai.com/gtm/reference/post-domain
 	CreateDomain(context.Context, *Domain, map[string]string) (*DomainResponse, error)
@@ -35,9 +41,25 @@ type Domains interface {
 	// See: ** Not Supported by API **
 	DeleteDomain(context.Context, *Domain) (*ResponseStatus, error)
 	// UpdateDomain is a method applied to a domain object resulting in an update.
+	// Datacenter references are checked with ValidateDomain first, unless disabled with WithDomainValidation.
 	//
 	// See: https://techdocs.akamai.com/gtm/reference/put-domain
 	UpdateDomain(context.Context, *Domain, map[string]string) (*ResponseStatus, error)
+	// ApplyDomain creates the domain, or updates its settings, then creates or updates its nested objects in
+	// dependency order: datacenters, resources, maps and finally the properties referencing them. Every object is
+	// validated before the first request, including the datacenter and map references unless disabled with
+	// WithDomainValidation. Nested objects missing from the domain are left in place. Datacenters unknown to the API
+	// are created, and the ID assigned to them is set on the datacenter and on the references to it in domain.
+	// The optional query arguments are those of CreateDomain. When a request fails, the returned *ApplyDomainError
+	// tells which object failed. With waitForPropagation, it waits for the change to propagate (see
+	// WaitForDomainPropagation) and returns the final status, otherwise the status of the last request.
+	ApplyDomain(ctx context.Context, domain *Domain, waitForPropagation bool, queryArgs ...map[string]string) (*ResponseStatus, error)
+	// WaitForDomainPropagation polls the status of the domain every pollInterval, DefaultDomainPropagationPollInterval
+	// when it is not positive, until its propagation status is COMPLETE. It returns an error wrapping
+	// ErrPropagationDenied when the change was denied.
+	//
+	// See: https://techdocs.akamai.com/gtm/reference/get-status-current
+	WaitForDomainPropagation(ctx context.Context, domainName string, pollInterval time.Duration) (*ResponseStatus, error)
 }
 
 // The Domain data structure represents a GTM domain
@@ -108,7 +130,8 @@ type DomainItem struct {
 	DeleteRequestID       string  `json:"deleteRequestId"`
 }
 
-// Validate validates Domain
+// Validate validates Domain: the name and type are required, the default penalties cannot be negative,
+// the load imbalance percentage is between 0 and 100 and the notification emails must be well-formed
 func (d *Domain) Validate() error {
 	if len(d.Name) < 1 {
 		return fmt.Errorf("Domain is missing Name")
@@ -117,6 +140,79 @@ func (d *Domain) Validate() error {
 		return fmt.Errorf("Domain is missing Type")
 	}
 
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"DefaultErrorPenalty":     validation.Validate(d.DefaultErrorPenalty, validation.Min(0)),
+		"DefaultTimeoutPenalty":   validation.Validate(d.DefaultTimeoutPenalty, validation.Min(0)),
+		"LoadImbalancePercentage": validation.Validate(d.LoadImbalancePercentage, validation.Min(0.0), validation.Max(100.0)),
+		"EmailNotificationList":   validation.Validate(d.EmailNotificationList, validation.Each(is.EmailFormat)),
+	})
+}
+
+// ValidateDomain checks that the traffic targets of the domain properties and the assignments of its AS, CIDR
+// and geographic maps only reference datacenters of the domain. The returned error wraps ErrDanglingDatacenterReference
+// and lists every dangling reference.
+func ValidateDomain(domain *Domain) error {
+	if domain == nil {
+		return fmt.Errorf("domain is required")
+	}
+
+	datacenters := make(map[int]struct{}, len(domain.Datacenters))
+	for _, dc := range domain.Datacenters {
+		if dc != nil {
+			datacenters[dc.DatacenterID] = struct{}{}
+		}
+	}
+
+	var dangling []string
+	check := func(id int, format string, args ...interface{}) {
+		if _, ok := datacenters[id]; !ok {
+			dangling = append(dangling, fmt.Sprintf("%s: datacenter %d", fmt.Sprintf(format, args...), id))
+		}
+	}
+	for _, p := range domain.Properties {
+		if p == nil {
+			continue
+		}
+		for i, tt := range p.TrafficTargets {
+			if tt != nil {
+				check(tt.DatacenterID, "property %q traffic target %d", p.Name, i)
+			}
+		}
+	}
+	for _, m := range domain.ASMaps {
+		if m == nil {
+			continue
+		}
+		for i, a := range m.Assignments {
+			if a != nil {
+				check(a.DatacenterID, "AS map %q assignment %d", m.Name, i)
+			}
+		}
+	}
+	for _, m := range domain.CIDRMaps {
+		if m == nil {
+			continue
+		}
+		for i, a := range m.Assignments {
+			if a != nil {
+				check(a.DatacenterID, "CIDR map %q assignment %d", m.Name, i)
+			}
+		}
+	}
+	for _, m := range domain.GeographicMaps {
+		if m == nil {
+			continue
+		}
+		for i, a := range m.Assignments {
+			if a != nil {
+				check(a.DatacenterID, "geographic map %q assignment %d", m.Name, i)
+			}
+		}
+	}
+
+	if len(dangling) > 0 {
+		return fmt.Errorf("%w:\n%s", ErrDanglingDatacenterReference, strings.Join(dangling, "\n"))
+	}
 	return nil
 }
 
@@ -229,6 +325,11 @@ func (g *gtm) CreateDomain(ctx context.Context, domain *Domain, queryArgs map[st
 	if err := domain.Validate(); err != nil {
 		return nil, fmt.Errorf("CreateDomain validation failed. %w", err)
 	}
+	if !g.skipDomainValidation {
+		if err := ValidateDomain(domain); err != nil {
+			return nil, fmt.Errorf("CreateDomain validation failed. %w", err)
+		}
+	}
 
 	postURL := fmt.Sprintf("/config-gtm/v1/domains/")
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, nil)
@@ -246,6 +347,11 @@ func (g *gtm) UpdateDomain(ctx context.Context, domain *Domain, queryArgs map[st
 	if err := domain.Validate(); err != nil {
 		return nil, fmt.Errorf("UpdateDomain validation failed. %w", err)
 	}
+	if !g.skipDomainValidation {
+		if err := ValidateDomain(domain); err != nil {
+			return nil, fmt.Errorf("UpdateDomain validation failed. %w", err)
+		}
+	}
 
 	putURL := fmt.Sprintf("/config-gtm/v1/domains/%s", domain.Name)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
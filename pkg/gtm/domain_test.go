@@ -411,6 +411,152 @@ func TestGTM_CreateDomain(t *testing.T) {
 	}
 }
 
+func TestDomain_Validate(t *testing.T) {
+	tests := map[string]struct {
+		domain    Domain
+		withError []string
+	}{
+		"boundary values": {
+			domain: Domain{
+				Name:                    "gtmdomtest.akadns.net",
+				Type:                    "basic",
+				LoadImbalancePercentage: 100,
+				EmailNotificationList:   []string{"noc@example.com", "gtm-alerts@example.co.uk"},
+			},
+		},
+		"lower bound of load imbalance percentage": {
+			domain: Domain{
+				Name:                    "gtmdomtest.akadns.net",
+				Type:                    "basic",
+				LoadImbalancePercentage: 0,
+			},
+		},
+		"missing name": {
+			domain:    Domain{Type: "basic"},
+			withError: []string{"Domain is missing Name"},
+		},
+		"negative penalties": {
+			domain: Domain{
+				Name:                  "gtmdomtest.akadns.net",
+				Type:                  "basic",
+				DefaultErrorPenalty:   -1,
+				DefaultTimeoutPenalty: -1,
+			},
+			withError: []string{
+				"DefaultErrorPenalty: must be no less than 0",
+				"DefaultTimeoutPenalty: must be no less than 0",
+			},
+		},
+		"load imbalance percentage below 0": {
+			domain: Domain{
+				Name:                    "gtmdomtest.akadns.net",
+				Type:                    "basic",
+				LoadImbalancePercentage: -0.01,
+			},
+			withError: []string{"LoadImbalancePercentage: must be no less than 0"},
+		},
+		"load imbalance percentage above 100": {
+			domain: Domain{
+				Name:                    "gtmdomtest.akadns.net",
+				Type:                    "basic",
+				LoadImbalancePercentage: 100.01,
+			},
+			withError: []string{"LoadImbalancePercentage: must be no greater than 100"},
+		},
+		"malformed email": {
+			domain: Domain{
+				Name:                  "gtmdomtest.akadns.net",
+				Type:                  "basic",
+				EmailNotificationList: []string{"noc@example.com", "not-an-email"},
+			},
+			withError: []string{"1: must be a valid email address", "EmailNotificationList[1]"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.domain.Validate()
+			if len(test.withError) > 0 {
+				require.Error(t, err)
+				for _, msg := range test.withError {
+					assert.Contains(t, err.Error(), msg)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	tests := map[string]struct {
+		domain    *Domain
+		withError string
+	}{
+		"all references exist": {
+			domain: &Domain{
+				Datacenters: []*Datacenter{{DatacenterID: 3131}, {DatacenterID: 5400}},
+				Properties: []*Property{{
+					Name:           "www",
+					TrafficTargets: []*TrafficTarget{{DatacenterID: 3131}, {DatacenterID: 5400}},
+				}},
+				ASMaps: []*ASMap{{
+					Name:        "as",
+					Assignments: []*ASAssignment{{DatacenterBase: DatacenterBase{DatacenterID: 3131}}},
+				}},
+				CIDRMaps: []*CIDRMap{{
+					Name:        "cidr",
+					Assignments: []*CIDRAssignment{{DatacenterBase: DatacenterBase{DatacenterID: 3131}}},
+				}},
+				GeographicMaps: []*GeoMap{{
+					Name:        "geo",
+					Assignments: []*GeoAssignment{{DatacenterBase: DatacenterBase{DatacenterID: 3131}}},
+				}},
+			},
+		},
+		"dangling references": {
+			domain: &Domain{
+				Datacenters: []*Datacenter{{DatacenterID: 3131}},
+				Properties: []*Property{{
+					Name:           "www",
+					TrafficTargets: []*TrafficTarget{{DatacenterID: 3131}, {DatacenterID: 3132}},
+				}},
+				ASMaps: []*ASMap{{
+					Name:        "as",
+					Assignments: []*ASAssignment{{DatacenterBase: DatacenterBase{DatacenterID: 3133}}},
+				}},
+				CIDRMaps: []*CIDRMap{{
+					Name:        "cidr",
+					Assignments: []*CIDRAssignment{{DatacenterBase: DatacenterBase{DatacenterID: 3131}}, {DatacenterBase: DatacenterBase{DatacenterID: 3134}}},
+				}},
+				GeographicMaps: []*GeoMap{{
+					Name:        "geo",
+					Assignments: []*GeoAssignment{{DatacenterBase: DatacenterBase{DatacenterID: 3135}}},
+				}},
+			},
+			withError: `reference to datacenter missing from the domain:
+property "www" traffic target 1: datacenter 3132
+AS map "as" assignment 0: datacenter 3133
+CIDR map "cidr" assignment 1: datacenter 3134
+geographic map "geo" assignment 0: datacenter 3135`,
+		},
+		"missing domain": {
+			withError: "domain is required",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateDomain(test.domain)
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestGTM_UpdateDomain(t *testing.T) {
 	var result DomainResponse
 
@@ -432,6 +578,7 @@ func TestGTM_UpdateDomain(t *testing.T) {
 		expectedResponse *ResponseStatus
 		withError        error
 		headers          http.Header
+		options          []Option
 	}{
 		"200 Success": {
 			domain: Domain{
@@ -448,6 +595,34 @@ func TestGTM_UpdateDomain(t *testing.T) {
 			expectedResponse: result.Status,
 			expectedPath:     "/config-gtm/v1/domains?contractId=1-2ABCDE",
 		},
+		"dangling datacenter reference": {
+			domain: Domain{
+				Name:        "gtmdomtest.akadns.net",
+				Type:        "basic",
+				Datacenters: []*Datacenter{{DatacenterID: 3131}},
+				Properties: []*Property{{
+					Name:           "www",
+					TrafficTargets: []*TrafficTarget{{DatacenterID: 3131}, {DatacenterID: 3132}},
+				}},
+			},
+			withError: ErrDanglingDatacenterReference,
+		},
+		"dangling datacenter reference, validation disabled": {
+			domain: Domain{
+				Name:        "gtmdomtest.akadns.net",
+				Type:        "basic",
+				Datacenters: []*Datacenter{{DatacenterID: 3131}},
+				Properties: []*Property{{
+					Name:           "www",
+					TrafficTargets: []*TrafficTarget{{DatacenterID: 3132}},
+				}},
+			},
+			options:          []Option{WithDomainValidation(false)},
+			query:            map[string]string{"contractId": "1-2ABCDE"},
+			responseStatus:   http.StatusOK,
+			responseBody:     respData,
+			expectedResponse: result.Status,
+		},
 		"500 internal server error": {
 			domain: Domain{
 				Name: "gtmdomtest.akadns.net",
@@ -481,7 +656,7 @@ func TestGTM_UpdateDomain(t *testing.T) {
 					assert.NoError(t, err)
 				}
 			}))
-			client := mockAPIClient(t, mockServer)
+			client := mockAPIClient(t, mockServer, test.options...)
 			result, err := client.UpdateDomain(
 				session.ContextWithOptions(
 					context.Background(),
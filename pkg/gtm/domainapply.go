@@ -0,0 +1,375 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultDomainPropagationPollInterval is the interval WaitForDomainPropagation polls the domain status at
+// when no interval is given
+const DefaultDomainPropagationPollInterval = 10 * time.Second
+
+// Propagation statuses of a domain reported by GetDomainStatus
+const (
+	PropagationStatusPending  = "PENDING"
+	PropagationStatusComplete = "COMPLETE"
+	PropagationStatusDenied   = "DENIED"
+)
+
+var (
+	// ErrDanglingMapReference is returned when properties of a domain reference maps missing from the domain
+	ErrDanglingMapReference = errors.New("reference to map missing from the domain")
+	// ErrPropagationDenied is returned by WaitForDomainPropagation when the change of the domain was denied
+	ErrPropagationDenied = errors.New("domain propagation denied")
+)
+
+// ApplyDomainError is returned by ApplyDomain when writing the domain or one of its nested objects failed.
+// The objects applied before it are left in place.
+type ApplyDomainError struct {
+	// Object is the kind of object which failed, e.g. "datacenter" or "property"
+	Object string
+	// Name is the name of the object, or the nickname and ID of a datacenter
+	Name string
+	Err  error
+}
+
+func (e *ApplyDomainError) Error() string {
+	return fmt.Sprintf("ApplyDomain: %s %q: %s", e.Object, e.Name, e.Err)
+}
+
+// Unwrap returns the error of the failed request
+func (e *ApplyDomainError) Unwrap() error {
+	return e.Err
+}
+
+// mapPropertyTypes maps the property types which route traffic with a map to the kind of map they reference
+var mapPropertyTypes = map[string]string{
+	"geographic":  "geographic map",
+	"cidrmapping": "CIDR map",
+	"asmapping":   "AS map",
+}
+
+func (g *gtm) ApplyDomain(ctx context.Context, domain *Domain, waitForPropagation bool, queryArgs ...map[string]string) (*ResponseStatus, error) {
+	logger := g.Log(ctx)
+	logger.Debug("ApplyDomain")
+
+	if domain == nil {
+		return nil, fmt.Errorf("ApplyDomain: domain is required")
+	}
+	if err := validateApplyDomain(domain, !g.skipDomainValidation); err != nil {
+		return nil, fmt.Errorf("ApplyDomain validation failed. %w", err)
+	}
+	var args map[string]string
+	if len(queryArgs) > 0 {
+		args = queryArgs[0]
+	}
+
+	status, err := g.applyDomainSettings(ctx, domain, args)
+	if err != nil {
+		return nil, &ApplyDomainError{Object: "domain", Name: domain.Name, Err: err}
+	}
+
+	existing, err := g.ListDatacenters(ctx, domain.Name)
+	if err != nil {
+		return nil, &ApplyDomainError{Object: "domain", Name: domain.Name, Err: err}
+	}
+	// datacenters holds the IDs of the datacenters of the domain, which the maps and resources are validated against
+	datacenters := make(map[int]struct{}, len(existing)+len(domain.Datacenters))
+	for _, dc := range existing {
+		datacenters[dc.DatacenterID] = struct{}{}
+	}
+	for _, dc := range domain.Datacenters {
+		if dc == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s (%d)", dc.Nickname, dc.DatacenterID)
+		if _, ok := datacenters[dc.DatacenterID]; ok {
+			if status, err = g.UpdateDatacenter(ctx, dc, domain.Name); err != nil {
+				return nil, &ApplyDomainError{Object: "datacenter", Name: name, Err: err}
+			}
+			continue
+		}
+		created, err := g.CreateDatacenter(ctx, dc, domain.Name)
+		if err != nil {
+			return nil, &ApplyDomainError{Object: "datacenter", Name: name, Err: err}
+		}
+		status = created.Status
+		if created.Resource != nil && created.Resource.DatacenterID != dc.DatacenterID {
+			if dc.DatacenterID != 0 {
+				remapDatacenterID(domain, dc.DatacenterID, created.Resource.DatacenterID)
+			}
+			dc.DatacenterID = created.Resource.DatacenterID
+		}
+		datacenters[dc.DatacenterID] = struct{}{}
+	}
+
+	for _, r := range domain.Resources {
+		if r == nil {
+			continue
+		}
+		saved, err := r.save(ctx, g, domain.Name, datacenters)
+		if err != nil {
+			return nil, &ApplyDomainError{Object: "resource", Name: r.Name, Err: err}
+		}
+		status = saved.Status
+	}
+	for _, m := range domain.GeographicMaps {
+		if m == nil {
+			continue
+		}
+		saved, err := m.save(ctx, g, domain.Name, datacenters)
+		if err != nil {
+			return nil, &ApplyDomainError{Object: "geographic map", Name: m.Name, Err: err}
+		}
+		status = saved.Status
+	}
+	for _, m := range domain.CIDRMaps {
+		if m == nil {
+			continue
+		}
+		saved, err := m.save(ctx, g, domain.Name, datacenters)
+		if err != nil {
+			return nil, &ApplyDomainError{Object: "CIDR map", Name: m.Name, Err: err}
+		}
+		status = saved.Status
+	}
+	for _, m := range domain.ASMaps {
+		if m == nil {
+			continue
+		}
+		saved, err := m.save(ctx, g, domain.Name, datacenters)
+		if err != nil {
+			return nil, &ApplyDomainError{Object: "AS map", Name: m.Name, Err: err}
+		}
+		status = saved.Status
+	}
+	for _, p := range domain.Properties {
+		if p == nil {
+			continue
+		}
+		if status, err = g.UpdateProperty(ctx, p, domain.Name); err != nil {
+			return nil, &ApplyDomainError{Object: "property", Name: p.Name, Err: err}
+		}
+	}
+
+	if !waitForPropagation {
+		return status, nil
+	}
+	status, err = g.WaitForDomainPropagation(ctx, domain.Name, DefaultDomainPropagationPollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("ApplyDomain: %w", err)
+	}
+	return status, nil
+}
+
+// applyDomainSettings creates the domain without its nested objects, or updates the settings of an existing domain
+// keeping its current nested objects, which are applied one by one afterwards
+func (g *gtm) applyDomainSettings(ctx context.Context, domain *Domain, queryArgs map[string]string) (*ResponseStatus, error) {
+	settings := *domain
+	current, err := g.GetDomain(ctx, domain.Name)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		settings.Datacenters, settings.Properties, settings.Resources = nil, nil, nil
+		settings.GeographicMaps, settings.CIDRMaps, settings.ASMaps = nil, nil, nil
+		created, err := g.CreateDomain(ctx, &settings, queryArgs)
+		if err != nil {
+			return nil, err
+		}
+		return created.Status, nil
+	case err != nil:
+		return nil, err
+	}
+
+	settings.Datacenters, settings.Properties, settings.Resources = current.Datacenters, current.Properties, current.Resources
+	settings.GeographicMaps, settings.CIDRMaps, settings.ASMaps = current.GeographicMaps, current.CIDRMaps, current.ASMaps
+	return g.UpdateDomain(ctx, &settings, queryArgs)
+}
+
+func (g *gtm) WaitForDomainPropagation(ctx context.Context, domainName string, pollInterval time.Duration) (*ResponseStatus, error) {
+	logger := g.Log(ctx)
+	logger.Debug("WaitForDomainPropagation")
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultDomainPropagationPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := g.GetDomainStatus(ctx, domainName)
+		if err != nil {
+			return nil, fmt.Errorf("WaitForDomainPropagation: %w", err)
+		}
+		switch strings.ToUpper(status.PropagationStatus) {
+		case PropagationStatusComplete:
+			return status, nil
+		case PropagationStatusDenied:
+			return status, fmt.Errorf("WaitForDomainPropagation: %w: %s", ErrPropagationDenied, status.Message)
+		}
+		logger.Debugf("Domain %s propagation status: %s", domainName, status.PropagationStatus)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("WaitForDomainPropagation: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// validateApplyDomain validates the domain and all its nested objects before any of them is written
+func validateApplyDomain(domain *Domain, references bool) error {
+	if err := domain.Validate(); err != nil {
+		return err
+	}
+	for _, p := range domain.Properties {
+		if p == nil {
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("property %q: %w", p.Name, err)
+		}
+	}
+	for _, m := range domain.GeographicMaps {
+		if m == nil {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("geographic map %q: %w", m.Name, err)
+		}
+	}
+	for _, m := range domain.CIDRMaps {
+		if m == nil {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("CIDR map %q: %w", m.Name, err)
+		}
+	}
+	for _, m := range domain.ASMaps {
+		if m == nil {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("AS map %q: %w", m.Name, err)
+		}
+	}
+	for _, r := range domain.Resources {
+		if r == nil {
+			continue
+		}
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("resource %q: %w", r.Name, err)
+		}
+	}
+	if !references {
+		return nil
+	}
+	if err := ValidateDomain(domain); err != nil {
+		return err
+	}
+	return validateMapReferences(domain)
+}
+
+// validateMapReferences checks that the properties routing traffic with a map reference a map of the domain
+func validateMapReferences(domain *Domain) error {
+	maps := make(map[string]bool)
+	for _, m := range domain.GeographicMaps {
+		if m != nil {
+			maps["geographic map "+m.Name] = true
+		}
+	}
+	for _, m := range domain.CIDRMaps {
+		if m != nil {
+			maps["CIDR map "+m.Name] = true
+		}
+	}
+	for _, m := range domain.ASMaps {
+		if m != nil {
+			maps["AS map "+m.Name] = true
+		}
+	}
+
+	var dangling []string
+	for _, p := range domain.Properties {
+		if p == nil {
+			continue
+		}
+		kind, ok := mapPropertyTypes[p.Type]
+		if !ok {
+			continue
+		}
+		if !maps[kind+" "+p.MapName] {
+			dangling = append(dangling, fmt.Sprintf("property %q: %s %q", p.Name, kind, p.MapName))
+		}
+	}
+	if len(dangling) > 0 {
+		return fmt.Errorf("%w:\n%s", ErrDanglingMapReference, strings.Join(dangling, "\n"))
+	}
+	return nil
+}
+
+// remapDatacenterID replaces the references to a datacenter whose ID was assigned by the API on creation
+func remapDatacenterID(domain *Domain, from, to int) {
+	for _, p := range domain.Properties {
+		if p == nil {
+			continue
+		}
+		for _, tt := range p.TrafficTargets {
+			if tt != nil && tt.DatacenterID == from {
+				tt.DatacenterID = to
+			}
+		}
+	}
+	for _, r := range domain.Resources {
+		if r == nil {
+			continue
+		}
+		for _, ri := range r.ResourceInstances {
+			if ri != nil && ri.DatacenterID == from {
+				ri.DatacenterID = to
+			}
+		}
+	}
+	for _, m := range domain.GeographicMaps {
+		if m == nil {
+			continue
+		}
+		for _, a := range m.Assignments {
+			if a != nil && a.DatacenterID == from {
+				a.DatacenterID = to
+			}
+		}
+		if m.DefaultDatacenter != nil && m.DefaultDatacenter.DatacenterID == from {
+			m.DefaultDatacenter.DatacenterID = to
+		}
+	}
+	for _, m := range domain.CIDRMaps {
+		if m == nil {
+			continue
+		}
+		for _, a := range m.Assignments {
+			if a != nil && a.DatacenterID == from {
+				a.DatacenterID = to
+			}
+		}
+		if m.DefaultDatacenter != nil && m.DefaultDatacenter.DatacenterID == from {
+			m.DefaultDatacenter.DatacenterID = to
+		}
+	}
+	for _, m := range domain.ASMaps {
+		if m == nil {
+			continue
+		}
+		for _, a := range m.Assignments {
+			if a != nil && a.DatacenterID == from {
+				a.DatacenterID = to
+			}
+		}
+		if m.DefaultDatacenter != nil && m.DefaultDatacenter.DatacenterID == from {
+			m.DefaultDatacenter.DatacenterID = to
+		}
+	}
+}
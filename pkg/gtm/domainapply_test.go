@@ -0,0 +1,268 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCall is an expected request and the response of the mock server
+type mockCall struct {
+	request string
+	status  int
+	body    string
+}
+
+func mockSequenceServer(t *testing.T, calls []mockCall) (*httptest.Server, *int) {
+	var served int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Less(t, served, len(calls), "unexpected request %s %s", r.Method, r.URL.Path) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		call := calls[served]
+		served++
+		assert.Equal(t, call.request, r.Method+" "+r.URL.Path)
+		w.WriteHeader(call.status)
+		_, err := w.Write([]byte(call.body))
+		assert.NoError(t, err)
+	}))
+	return server, &served
+}
+
+func TestGTM_ApplyDomain(t *testing.T) {
+	newDomain := func() *Domain {
+		return &Domain{
+			Name: "example.akadns.net",
+			Type: "basic",
+			Datacenters: []*Datacenter{
+				{DatacenterID: 1, Nickname: "dc1"},
+			},
+			GeographicMaps: []*GeoMap{
+				{
+					Name:              "geo",
+					DefaultDatacenter: &DatacenterBase{DatacenterID: 5400, Nickname: "default"},
+					Assignments: []*GeoAssignment{
+						{DatacenterBase: DatacenterBase{DatacenterID: 1, Nickname: "dc1"}, Countries: []string{"GB"}},
+					},
+				},
+			},
+			Properties: []*Property{
+				{
+					Name:                 "www",
+					Type:                 "geographic",
+					MapName:              "geo",
+					ScoreAggregationType: "worst",
+					HandoutMode:          "normal",
+					TrafficTargets: []*TrafficTarget{
+						{DatacenterID: 1, Enabled: true, Servers: []string{"192.0.2.1"}},
+					},
+				},
+			},
+		}
+	}
+	const (
+		domainPath   = "/config-gtm/v1/domains/example.akadns.net"
+		statusOK     = `{"status": {"changeId": "c1", "propagationStatus": "PENDING"}}`
+		datacenters  = `{"items": [{"datacenterId": 3131, "nickname": "dc1"}]}`
+		notFoundBody = `{"type": "not_found", "title": "Not Found", "status": 404}`
+	)
+
+	tests := map[string]struct {
+		domain              func() *Domain
+		waitForPropagation  bool
+		calls               []mockCall
+		expectedStatus      *ResponseStatus
+		expectedDatacenter  int
+		withApplyError      string
+		withError           error
+		expectedErrorString string
+	}{
+		"new domain, datacenter ID assigned by the API": {
+			domain: newDomain,
+			calls: []mockCall{
+				{request: "GET " + domainPath, status: http.StatusNotFound, body: notFoundBody},
+				{request: "POST /config-gtm/v1/domains/", status: http.StatusCreated, body: `{"resource": {"name": "example.akadns.net", "type": "basic"}, "status": {"changeId": "c0"}}`},
+				{request: "GET " + domainPath + "/datacenters", status: http.StatusOK, body: `{"items": []}`},
+				{request: "POST " + domainPath + "/datacenters", status: http.StatusCreated, body: `{"resource": {"datacenterId": 3131, "nickname": "dc1"}, "status": {"changeId": "c1"}}`},
+				{request: "PUT " + domainPath + "/geographic-maps/geo", status: http.StatusOK, body: `{"status": {"changeId": "c2"}}`},
+				{request: "PUT " + domainPath + "/properties/www", status: http.StatusOK, body: `{"status": {"changeId": "c3", "propagationStatus": "PENDING"}}`},
+			},
+			expectedStatus:     &ResponseStatus{ChangeID: "c3", PropagationStatus: "PENDING"},
+			expectedDatacenter: 3131,
+		},
+		"new domain, resource referencing the datacenter assigned by the API": {
+			domain: func() *Domain {
+				d := newDomain()
+				d.Resources = []*Resource{
+					{
+						Name:              "r",
+						Type:              "XML load object via HTTP",
+						ResourceInstances: []*ResourceInstance{{DatacenterID: 1}},
+					},
+				}
+				return d
+			},
+			calls: []mockCall{
+				{request: "GET " + domainPath, status: http.StatusNotFound, body: notFoundBody},
+				{request: "POST /config-gtm/v1/domains/", status: http.StatusCreated, body: `{"resource": {"name": "example.akadns.net", "type": "basic"}, "status": {"changeId": "c0"}}`},
+				{request: "GET " + domainPath + "/datacenters", status: http.StatusOK, body: `{"items": []}`},
+				{request: "POST " + domainPath + "/datacenters", status: http.StatusCreated, body: `{"resource": {"datacenterId": 3131, "nickname": "dc1"}, "status": {"changeId": "c1"}}`},
+				{request: "PUT " + domainPath + "/resources/r", status: http.StatusOK, body: `{"status": {"changeId": "c2"}}`},
+				{request: "PUT " + domainPath + "/geographic-maps/geo", status: http.StatusOK, body: `{"status": {"changeId": "c3"}}`},
+				{request: "PUT " + domainPath + "/properties/www", status: http.StatusOK, body: `{"status": {"changeId": "c4", "propagationStatus": "PENDING"}}`},
+			},
+			expectedStatus:     &ResponseStatus{ChangeID: "c4", PropagationStatus: "PENDING"},
+			expectedDatacenter: 3131,
+		},
+		"existing domain, wait for propagation": {
+			domain: func() *Domain {
+				d := newDomain()
+				d.Datacenters[0].DatacenterID = 3131
+				d.GeographicMaps[0].Assignments[0].DatacenterID = 3131
+				d.Properties[0].TrafficTargets[0].DatacenterID = 3131
+				return d
+			},
+			waitForPropagation: true,
+			calls: []mockCall{
+				{request: "GET " + domainPath, status: http.StatusOK, body: `{"name": "example.akadns.net", "type": "basic", "datacenters": [{"datacenterId": 3131}]}`},
+				{request: "PUT " + domainPath, status: http.StatusOK, body: statusOK},
+				{request: "GET " + domainPath + "/datacenters", status: http.StatusOK, body: datacenters},
+				{request: "PUT " + domainPath + "/datacenters/3131", status: http.StatusOK, body: statusOK},
+				{request: "PUT " + domainPath + "/geographic-maps/geo", status: http.StatusOK, body: statusOK},
+				{request: "PUT " + domainPath + "/properties/www", status: http.StatusOK, body: statusOK},
+				{request: "GET " + domainPath + "/status/current", status: http.StatusOK, body: `{"changeId": "c3", "propagationStatus": "COMPLETE"}`},
+			},
+			expectedStatus:     &ResponseStatus{ChangeID: "c3", PropagationStatus: "COMPLETE"},
+			expectedDatacenter: 3131,
+		},
+		"dangling map reference": {
+			domain: func() *Domain {
+				d := newDomain()
+				d.Properties[0].MapName = "missing"
+				return d
+			},
+			withError:           ErrDanglingMapReference,
+			expectedErrorString: "ApplyDomain validation failed. reference to map missing from the domain:\nproperty \"www\": geographic map \"missing\"",
+		},
+		"dangling datacenter reference": {
+			domain: func() *Domain {
+				d := newDomain()
+				d.Properties[0].TrafficTargets[0].DatacenterID = 2
+				return d
+			},
+			withError: ErrDanglingDatacenterReference,
+		},
+		"invalid property": {
+			domain: func() *Domain {
+				d := newDomain()
+				d.Properties[0].HandoutMode = ""
+				return d
+			},
+			expectedErrorString: "ApplyDomain validation failed. property \"www\": HandoutMode: cannot be blank",
+		},
+		"property failure": {
+			domain: func() *Domain {
+				d := newDomain()
+				d.Datacenters[0].DatacenterID = 3131
+				d.GeographicMaps[0].Assignments[0].DatacenterID = 3131
+				d.Properties[0].TrafficTargets[0].DatacenterID = 3131
+				return d
+			},
+			calls: []mockCall{
+				{request: "GET " + domainPath, status: http.StatusOK, body: `{"name": "example.akadns.net", "type": "basic"}`},
+				{request: "PUT " + domainPath, status: http.StatusOK, body: statusOK},
+				{request: "GET " + domainPath + "/datacenters", status: http.StatusOK, body: datacenters},
+				{request: "PUT " + domainPath + "/datacenters/3131", status: http.StatusOK, body: statusOK},
+				{request: "PUT " + domainPath + "/geographic-maps/geo", status: http.StatusOK, body: statusOK},
+				{request: "PUT " + domainPath + "/properties/www", status: http.StatusBadRequest, body: `{"type": "bad_request", "title": "Bad Request", "status": 400}`},
+			},
+			withApplyError: "property",
+			withError:      &Error{Type: "bad_request", Title: "Bad Request", StatusCode: http.StatusBadRequest},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer, served := mockSequenceServer(t, test.calls)
+			client := mockAPIClient(t, mockServer)
+			domain := test.domain()
+			result, err := client.ApplyDomain(context.Background(), domain, test.waitForPropagation)
+			assert.Equal(t, len(test.calls), *served)
+			if test.withError != nil || test.expectedErrorString != "" || test.withApplyError != "" {
+				require.Error(t, err)
+				if test.withError != nil {
+					assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				}
+				if test.expectedErrorString != "" {
+					assert.Equal(t, test.expectedErrorString, err.Error())
+				}
+				if test.withApplyError != "" {
+					var applyErr *ApplyDomainError
+					require.True(t, errors.As(err, &applyErr))
+					assert.Equal(t, test.withApplyError, applyErr.Object)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, result)
+			assert.Equal(t, test.expectedDatacenter, domain.Datacenters[0].DatacenterID)
+			assert.Equal(t, test.expectedDatacenter, domain.GeographicMaps[0].Assignments[0].DatacenterID)
+			assert.Equal(t, test.expectedDatacenter, domain.Properties[0].TrafficTargets[0].DatacenterID)
+			for _, r := range domain.Resources {
+				assert.Equal(t, test.expectedDatacenter, r.ResourceInstances[0].DatacenterID)
+			}
+		})
+	}
+}
+
+func TestGTM_WaitForDomainPropagation(t *testing.T) {
+	const statusPath = "GET /config-gtm/v1/domains/example.akadns.net/status/current"
+
+	tests := map[string]struct {
+		calls          []mockCall
+		expectedStatus *ResponseStatus
+		withError      error
+	}{
+		"complete after pending": {
+			calls: []mockCall{
+				{request: statusPath, status: http.StatusOK, body: `{"changeId": "c1", "propagationStatus": "PENDING"}`},
+				{request: statusPath, status: http.StatusOK, body: `{"changeId": "c1", "propagationStatus": "COMPLETE"}`},
+			},
+			expectedStatus: &ResponseStatus{ChangeID: "c1", PropagationStatus: "COMPLETE"},
+		},
+		"denied": {
+			calls: []mockCall{
+				{request: statusPath, status: http.StatusOK, body: `{"changeId": "c1", "propagationStatus": "DENIED", "message": "invalid configuration"}`},
+			},
+			withError: ErrPropagationDenied,
+		},
+		"status error": {
+			calls: []mockCall{
+				{request: statusPath, status: http.StatusNotFound, body: `{"title": "Not Found", "status": 404}`},
+			},
+			withError: ErrNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer, served := mockSequenceServer(t, test.calls)
+			client := mockAPIClient(t, mockServer)
+			result, err := client.WaitForDomainPropagation(context.Background(), "example.akadns.net", time.Millisecond)
+			assert.Equal(t, len(test.calls), *served)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, result)
+		})
+	}
+}
@@ -8,11 +8,19 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/errs"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
 )
 
 var (
 	// ErrNotFound used when status code is 404 Not Found
 	ErrNotFound = errors.New("404 Not Found")
+	// ErrDanglingDatacenterReference is returned when properties or maps of a domain reference datacenters missing from the domain
+	ErrDanglingDatacenterReference = errors.New("reference to datacenter missing from the domain")
+	// ErrInvalidFailoverOrder is returned by Property.SetFailoverOrder when the order does not list every traffic target once
+	ErrInvalidFailoverOrder = errors.New("invalid failover order")
+	// ErrCIDROverlap is returned by CreateCIDRMap and UpdateCIDRMap, when enabled with WithCIDROverlapCheck, when blocks
+	// of the CIDR map overlap blocks of the other CIDR maps of the domain
+	ErrCIDROverlap = errors.New("overlapping CIDR blocks")
 )
 
 type (
@@ -26,9 +34,16 @@ type (
 		ErrorLocation string  `json:"errorLocation,omitempty"`
 		StatusCode    int     `json:"-"`
 		Errors        []Error `json:"errors"`
+		Method        string  `json:"method,omitempty"`
+		URL           string  `json:"url,omitempty"`
+		ContentType   string  `json:"contentType,omitempty"`
 	}
 )
 
+// DefaultErrorBodyLimit is the default number of bytes of a response body that is not a valid API error
+// which is kept in the error detail (see WithErrorBodyLimit)
+const DefaultErrorBodyLimit = 512
+
 // Error parses an error from the response
 func (g *gtm) Error(r *http.Response) error {
 	var e Error
@@ -46,11 +61,20 @@ func (g *gtm) Error(r *http.Response) error {
 
 	if err := json.Unmarshal(body, &e); err != nil {
 		g.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		limit := g.errorBodyLimit
+		if limit <= 0 {
+			limit = DefaultErrorBodyLimit
+		}
 		e.Title = fmt.Sprintf("Failed to unmarshal error body. GTM API failed. Check details for more information.")
-		e.Detail = errs.UnescapeContent(string(body))
+		e.Detail = errs.Preview(body, limit)
+		e.ContentType = r.Header.Get("Content-Type")
 	}
 
 	e.StatusCode = r.StatusCode
+	if r.Request != nil {
+		e.Method = r.Request.Method
+		e.URL = r.Request.URL.Path
+	}
 
 	return &e
 }
@@ -63,6 +87,11 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("API error: \n%s", msg)
 }
 
+// Category classifies the error by its status and problem type (see session.ClassifyError)
+func (e *Error) Category() session.ErrorCategory {
+	return session.ClassifyError(e.StatusCode, e.Type)
+}
+
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
 
@@ -83,5 +112,14 @@ func (e *Error) Is(target error) bool {
 		return false
 	}
 
-	return e.Error() == t.Error()
+	// request details are only compared when the target sets them
+	actual := *e
+	if t.Method == "" && t.URL == "" {
+		actual.Method, actual.URL = "", ""
+	}
+	if t.ContentType == "" {
+		actual.ContentType = ""
+	}
+
+	return actual.Error() == t.Error()
 }
@@ -22,6 +22,7 @@ func TestJSONErrorUnmarshalling(t *testing.T) {
 	require.NoError(t, err)
 	tests := map[string]struct {
 		input    *http.Response
+		limit    int
 		expected *Error
 	}{
 		"API failure with HTML response": {
@@ -34,6 +35,8 @@ func TestJSONErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. GTM API failed. Check details for more information.",
 				Detail:     "<HTML><HEAD>...</HEAD><BODY>...</BODY></HTML>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with plain text response": {
@@ -46,6 +49,8 @@ func TestJSONErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. GTM API failed. Check details for more information.",
 				Detail:     "Your request did not succeed as this operation has reached  the limit for your account. Please try after 2024-01-16T15:20:55.945Z",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with XML response": {
@@ -58,6 +63,8 @@ func TestJSONErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. GTM API failed. Check details for more information.",
 				Detail:     "<Root><Item id=\"1\" name=\"Example\" /></Root>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure nested error": {
@@ -93,6 +100,25 @@ func TestJSONErrorUnmarshalling(t *testing.T) {
 						Errors: nil,
 					},
 				},
+				Method: http.MethodHead,
+				URL:    "/",
+			},
+		},
+		"API failure with HTML gateway page exceeding the body limit": {
+			input: &http.Response{
+				Request:    req,
+				Status:     "OK",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`<html><body>502 Bad Gateway</body></html>`))},
+			limit: 27,
+			expected: &Error{
+				Title:       "Failed to unmarshal error body. GTM API failed. Check details for more information.",
+				Detail:      "<html><body>502 Bad Gateway...",
+				StatusCode:  http.StatusBadGateway,
+				Method:      http.MethodHead,
+				URL:         "/",
+				ContentType: "text/html; charset=utf-8",
 			},
 		},
 	}
@@ -101,7 +127,8 @@ func TestJSONErrorUnmarshalling(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			sess, _ := session.New()
 			g := gtm{
-				Session: sess,
+				Session:        sess,
+				errorBodyLimit: test.limit,
 			}
 			assert.Equal(t, test.expected, g.Error(test.input))
 		})
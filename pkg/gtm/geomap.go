@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // GeoMaps contains operations available on a GeoMap resource.
@@ -49,15 +53,73 @@ type GeoMapList struct {
 	GeoMapItems []*GeoMap `json:"items"`
 }
 
+// isoCountryCodes contains ISO 3166-1 alpha-2 country codes accepted in GeoMap assignments
+var isoCountryCodes = func() map[string]struct{} {
+	codes := make(map[string]struct{})
+	for _, code := range []string{
+		"AD", "AE", "AF", "AG", "AI", "AL", "AM", "AO", "AQ", "AR", "AS", "AT", "AU", "AW", "AX", "AZ",
+		"BA", "BB", "BD", "BE", "BF", "BG", "BH", "BI", "BJ", "BL", "BM", "BN", "BO", "BQ", "BR", "BS",
+		"BT", "BV", "BW", "BY", "BZ", "CA", "CC", "CD", "CF", "CG", "CH", "CI", "CK", "CL", "CM", "CN",
+		"CO", "CR", "CU", "CV", "CW", "CX", "CY", "CZ", "DE", "DJ", "DK", "DM", "DO", "DZ", "EC", "EE",
+		"EG", "EH", "ER", "ES", "ET", "FI", "FJ", "FK", "FM", "FO", "FR", "GA", "GB", "GD", "GE", "GF",
+		"GG", "GH", "GI", "GL", "GM", "GN", "GP", "GQ", "GR", "GS", "GT", "GU", "GW", "GY", "HK", "HM",
+		"HN", "HR", "HT", "HU", "ID", "IE", "IL", "IM", "IN", "IO", "IQ", "IR", "IS", "IT", "JE", "JM",
+		"JO", "JP", "KE", "KG", "KH", "KI", "KM", "KN", "KP", "KR", "KW", "KY", "KZ", "LA", "LB", "LC",
+		"LI", "LK", "LR", "LS", "LT", "LU", "LV", "LY", "MA", "MC", "MD", "ME", "MF", "MG", "MH", "MK",
+		"ML", "MM", "MN", "MO", "MP", "MQ", "MR", "MS", "MT", "MU", "MV", "MW", "MX", "MY", "MZ", "NA",
+		"NC", "NE", "NF", "NG", "NI", "NL", "NO", "NP", "NR", "NU", "NZ", "OM", "PA", "PE", "PF", "PG",
+		"PH", "PK", "PL", "PM", "PN", "PR", "PS", "PT", "PW", "PY", "QA", "RE", "RO", "RS", "RU", "RW",
+		"SA", "SB", "SC", "SD", "SE", "SG", "SH", "SI", "SJ", "SK", "SL", "SM", "SN", "SO", "SR", "SS",
+		"ST", "SV", "SX", "SY", "SZ", "TC", "TD", "TF", "TG", "TH", "TJ", "TK", "TL", "TM", "TN", "TO",
+		"TR", "TT", "TV", "TW", "TZ", "UA", "UG", "UM", "US", "UY", "UZ", "VA", "VC", "VE", "VG", "VI",
+		"VN", "VU", "WF", "WS", "YE", "YT", "ZA", "ZM", "ZW",
+	} {
+		codes[code] = struct{}{}
+	}
+	return codes
+}()
+
 // Validate validates GeoMap
 func (m *GeoMap) Validate() error {
-	if len(m.Name) < 1 {
-		return fmt.Errorf("GeoMap is missing Name")
+	return m.validate(nil)
+}
+
+// validate validates GeoMap, checking also that assignments reference the given datacenters
+// unless datacenters is nil
+func (m *GeoMap) validate(datacenters map[int]struct{}) error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Name":              validation.Validate(m.Name, validation.Required),
+		"DefaultDatacenter": validation.Validate(m.DefaultDatacenter, validation.NotNil),
+		"Assignments": validation.Validate(m.Assignments, validation.Each(validation.By(func(value interface{}) error {
+			a, ok := value.(GeoAssignment)
+			if !ok {
+				return fmt.Errorf("unexpected assignment type %T", value)
+			}
+			return validation.Errors{
+				"DatacenterID": validation.Validate(a.DatacenterID, datacenterExists(datacenters)),
+				"Countries":    validation.Validate(a.Countries, validation.By(validateCountryCodes)),
+			}.Filter()
+		}))),
+	})
+}
+
+// validateCountryCodes checks that value contains ISO 3166-1 alpha-2 country codes,
+// optionally followed by a subdivision code, e.g. "US" or "US.MA"
+func validateCountryCodes(value interface{}) error {
+	codes, ok := value.([]string)
+	if !ok {
+		return fmt.Errorf("type %T is invalid, must be []string", value)
 	}
-	if m.DefaultDatacenter == nil {
-		return fmt.Errorf("GeoMap is missing DefaultDatacenter")
+	var invalid []string
+	for _, code := range codes {
+		country, _, _ := strings.Cut(code, ".")
+		if _, ok := isoCountryCodes[country]; !ok {
+			invalid = append(invalid, fmt.Sprintf("%q", code))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid ISO-3166 country codes: %s", strings.Join(invalid, ", "))
 	}
-
 	return nil
 }
 
@@ -113,14 +175,14 @@ func (g *gtm) CreateGeoMap(ctx context.Context, geo *GeoMap, domainName string)
 	logger := g.Log(ctx)
 	logger.Debug("CreateGeoMap")
 
-	return geo.save(ctx, g, domainName)
+	return geo.save(ctx, g, domainName, nil)
 }
 
 func (g *gtm) UpdateGeoMap(ctx context.Context, geo *GeoMap, domainName string) (*ResponseStatus, error) {
 	logger := g.Log(ctx)
 	logger.Debug("UpdateGeoMap")
 
-	stat, err := geo.save(ctx, g, domainName)
+	stat, err := geo.save(ctx, g, domainName, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -128,8 +190,8 @@ func (g *gtm) UpdateGeoMap(ctx context.Context, geo *GeoMap, domainName string)
 }
 
 // Save GeoMap in given domain. Common path for Create and Update.
-func (m *GeoMap) save(ctx context.Context, g *gtm, domainName string) (*GeoMapResponse, error) {
-	if err := m.Validate(); err != nil {
+func (m *GeoMap) save(ctx context.Context, g *gtm, domainName string, datacenters map[int]struct{}) (*GeoMapResponse, error) {
+	if err := g.validateDatacenters(ctx, domainName, datacenters, m.validate); err != nil {
 		return nil, fmt.Errorf("GeoMap validation failed. %w", err)
 	}
 
@@ -236,6 +236,9 @@ func TestGTM_CreateGeoMap(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
@@ -324,6 +327,9 @@ func TestGTM_UpdateGeoMap(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
@@ -432,3 +438,94 @@ func TestGTM_DeleteGeoMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGTM_CreateGeoMapAssignmentsValidation(t *testing.T) {
+	tests := map[string]struct {
+		geoMap        *GeoMap
+		expectedLists int
+		expectedError []string
+	}{
+		"invalid country codes are reported before datacenters are listed": {
+			geoMap: &GeoMap{
+				Name:              "UK Delivery",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*GeoAssignment{
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 3133},
+						Countries:      []string{"GB", "US.MA"},
+					},
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 9999},
+						Countries:      []string{"UK", "gb"},
+					},
+				},
+			},
+			expectedError: []string{
+				`Countries: invalid ISO-3166 country codes: "UK", "gb"`,
+			},
+		},
+		"unknown datacenter": {
+			geoMap: &GeoMap{
+				Name:              "UK Delivery",
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+				Assignments: []*GeoAssignment{
+					{
+						DatacenterBase: DatacenterBase{DatacenterID: 9999},
+						Countries:      []string{"GB"},
+					},
+				},
+			},
+			expectedLists: 1,
+			expectedError: []string{
+				"DatacenterID: datacenter 9999 does not exist in the domain",
+			},
+		},
+		"missing name": {
+			geoMap: &GeoMap{
+				DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+			},
+			expectedError: []string{"Name: cannot be blank"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lists int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					lists++
+					return
+				}
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			client := mockAPIClient(t, mockServer)
+			_, err := client.CreateGeoMap(context.Background(), test.geoMap, "example.akadns.net")
+			require.Error(t, err)
+			assert.Equal(t, test.expectedLists, lists)
+			for _, expected := range test.expectedError {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestGTM_CreateGeoMapWithoutDomainValidation(t *testing.T) {
+	mockServer, served := mockSequenceServer(t, []mockCall{
+		{request: "PUT /config-gtm/v1/domains/example.akadns.net/geographic-maps/UK Delivery", status: http.StatusCreated, body: `{"status": {"changeId": "c1"}}`},
+	})
+	client := mockAPIClient(t, mockServer, WithDomainValidation(false))
+	geoMap := &GeoMap{
+		Name:              "UK Delivery",
+		DefaultDatacenter: &DatacenterBase{DatacenterID: 5400},
+		Assignments: []*GeoAssignment{
+			{
+				DatacenterBase: DatacenterBase{DatacenterID: 9999},
+				Countries:      []string{"GB"},
+			},
+		},
+	}
+	result, err := client.CreateGeoMap(context.Background(), geoMap, "example.akadns.net")
+	require.NoError(t, err)
+	assert.Equal(t, "c1", result.Status.ChangeID)
+	assert.Equal(t, 1, *served)
+}
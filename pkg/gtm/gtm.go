@@ -23,6 +23,9 @@ type (
 
 	gtm struct {
 		session.Session
+		skipDomainValidation bool
+		checkCIDROverlaps    bool
+		errorBodyLimit       int
 	}
 
 	// Option defines a GTM option
@@ -44,6 +47,32 @@ func Client(sess session.Session, opts ...Option) GTM {
 	return p
 }
 
+// WithDomainValidation sets whether CreateDomain and UpdateDomain check datacenter references
+// with ValidateDomain before sending the request, and whether maps and resources are checked against
+// the datacenters listed from the domain. It is enabled by default.
+func WithDomainValidation(enabled bool) Option {
+	return func(g *gtm) {
+		g.skipDomainValidation = !enabled
+	}
+}
+
+// WithCIDROverlapCheck sets whether CreateCIDRMap and UpdateCIDRMap list the CIDR maps of the domain and fail
+// with ErrCIDROverlap when the blocks of the map overlap blocks of the other maps (see DetectCIDROverlaps).
+// It is disabled by default.
+func WithCIDROverlapCheck(enabled bool) Option {
+	return func(g *gtm) {
+		g.checkCIDROverlaps = enabled
+	}
+}
+
+// WithErrorBodyLimit sets the number of bytes of a response body which is not a valid API error,
+// e.g. an HTML page returned by a proxy, that is kept in the error detail. Defaults to DefaultErrorBodyLimit.
+func WithErrorBodyLimit(limit int) Option {
+	return func(g *gtm) {
+		g.errorBodyLimit = limit
+	}
+}
+
 // Exec overrides the session.Exec to add dns options
 func (g *gtm) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	return g.Session.Exec(r, out, in...)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
@@ -16,7 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func mockAPIClient(t *testing.T, mockServer *httptest.Server) GTM {
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) GTM {
 	serverURL, err := url.Parse(mockServer.URL)
 	require.NoError(t, err)
 	certPool := x509.NewCertPool()
@@ -30,7 +31,21 @@ func mockAPIClient(t *testing.T, mockServer *httptest.Server) GTM {
 	}
 	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
 	assert.NoError(t, err)
-	return Client(s)
+	return Client(s, opts...)
+}
+
+// mockListDatacenters serves the list of domain datacenters fetched to validate resources referencing them
+// and reports whether the request was handled
+func mockListDatacenters(t *testing.T, w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/datacenters") {
+		return false
+	}
+	data, err := loadTestData("TestGTM_ListDatacenters.resp.json")
+	require.NoError(t, err)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	assert.NoError(t, err)
+	return true
 }
 
 func dummyOpt() Option {
@@ -67,6 +82,13 @@ func TestClient(t *testing.T) {
 				Session: sess,
 			},
 		},
+		"error body limit option": {
+			options: []Option{WithErrorBodyLimit(1024)},
+			expected: &gtm{
+				Session:        sess,
+				errorBodyLimit: 1024,
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
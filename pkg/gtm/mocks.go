@@ -4,6 +4,7 @@ package gtm
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -84,6 +85,31 @@ func (p *Mock) UpdateDomain(ctx context.Context, domain *Domain, queryArgs map[s
 	return args.Get(0).(*ResponseStatus), args.Error(1)
 }
 
+func (p *Mock) ApplyDomain(ctx context.Context, domain *Domain, waitForPropagation bool, queryArgs ...map[string]string) (*ResponseStatus, error) {
+	var args mock.Arguments
+	if len(queryArgs) > 0 {
+		args = p.Called(ctx, domain, waitForPropagation, queryArgs[0])
+	} else {
+		args = p.Called(ctx, domain, waitForPropagation)
+	}
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ResponseStatus), args.Error(1)
+}
+
+func (p *Mock) WaitForDomainPropagation(ctx context.Context, domainName string, pollInterval time.Duration) (*ResponseStatus, error) {
+	args := p.Called(ctx, domainName, pollInterval)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ResponseStatus), args.Error(1)
+}
+
 func (p *Mock) GetProperty(ctx context.Context, prop string, domain string) (*Property, error) {
 	args := p.Called(ctx, prop, domain)
 
@@ -124,6 +150,26 @@ func (p *Mock) UpdateProperty(ctx context.Context, prop *Property, domain string
 	return args.Get(0).(*ResponseStatus), args.Error(1)
 }
 
+func (p *Mock) GetPropertyForUpdate(ctx context.Context, name, domain string) (*PropertyForUpdate, error) {
+	args := p.Called(ctx, name, domain)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PropertyForUpdate), args.Error(1)
+}
+
+func (p *Mock) UpdatePropertyPartial(ctx context.Context, prop *PropertyForUpdate, domain string) (*ResponseStatus, error) {
+	args := p.Called(ctx, prop, domain)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ResponseStatus), args.Error(1)
+}
+
 func (p *Mock) ListProperties(ctx context.Context, domain string) ([]*Property, error) {
 	args := p.Called(ctx, domain)
 
@@ -204,6 +250,16 @@ func (p *Mock) CreateIPv6DefaultDatacenter(ctx context.Context, domain string) (
 	return args.Get(0).(*Datacenter), args.Error(1)
 }
 
+func (p *Mock) CreateDefaultDatacenter(ctx context.Context, domain string, dcType DefaultDCType) (*Datacenter, error) {
+	args := p.Called(ctx, domain, dcType)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Datacenter), args.Error(1)
+}
+
 func (p *Mock) CreateMapsDefaultDatacenter(ctx context.Context, domainName string) (*Datacenter, error) {
 	args := p.Called(ctx, domainName)
 
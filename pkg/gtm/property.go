@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -20,6 +23,8 @@ type Properties interface {
 	// See: https://techdocs.akamai.com/gtm/reference/get-property
 	GetProperty(context.Context, string, string) (*Property, error)
 	// CreateProperty creates property.
+	// Its liveness tests are validated first, see LivenessTest.Validate, and weighted properties are required to have
+	// an enabled traffic target with a positive weight, see Property.NormalizeWeights.
 	//
 	// See: https://techdocs.akamai.com/gtm/reference/put-property
 	CreateProperty(context.Context, *Property, string) (*PropertyResponse, error)
@@ -28,11 +33,30 @@ type Properties interface {
 	// See: https://techdocs.akamai.com/gtm/reference/delete-property
 	DeleteProperty(context.Context, *Property, string) (*ResponseStatus, error)
 	// UpdateProperty is a method applied to a property object resulting in an update.
+	// Its liveness tests are validated first, see LivenessTest.Validate, and weighted properties are required to have
+	// an enabled traffic target with a positive weight, see Property.NormalizeWeights.
 	//
 	// See: https://techdocs.akamai.com/gtm/reference/put-property
 	UpdateProperty(context.Context, *Property, string) (*ResponseStatus, error)
+	// GetPropertyForUpdate retrieves a Property with the given domain and property names, to be modified and written
+	// back with UpdatePropertyPartial.
+	//
+	// See: https://techdocs.akamai.com/gtm/reference/get-property
+	GetPropertyForUpdate(context.Context, string, string) (*PropertyForUpdate, error)
+	// UpdatePropertyPartial writes back a property read with GetPropertyForUpdate. Only the fields modified since
+	// it was read are changed: the other fields, including the fields of the property, traffic targets and liveness
+	// tests which are not modeled by the structs, are written as the API returned them. Traffic targets, liveness tests
+	// and other lists are merged element by element unless elements were added or removed, in which case the list
+	// is written as it is in the Property. The server-managed fields, lastModified and links, are not written.
+	// The property is validated first like with UpdateProperty.
+	//
+	// See: https://techdocs.akamai.com/gtm/reference/put-property
+	UpdatePropertyPartial(context.Context, *PropertyForUpdate, string) (*ResponseStatus, error)
 }
 
+// TrafficTargetWeightTotal is the sum of the traffic target weights after Property.NormalizeWeights
+const TrafficTargetWeightTotal = 100.0
+
 // TrafficTarget struct contains information about where to direct data center traffic
 type TrafficTarget struct {
 	DatacenterID int      `json:"datacenterId"`
@@ -133,17 +157,89 @@ type PropertyList struct {
 	PropertyItems []*Property `json:"items"`
 }
 
-// Validate validates Property
+var (
+	// propertyTypes are the GTM property types
+	propertyTypes = []interface{}{
+		"failover", "ranked-failover", "geographic", "cidrmapping", "asmapping", "performance", "qtr",
+		"weighted-round-robin", "weighted-hashed", "weighted-round-robin-load-feedback",
+	}
+	// mappedPropertyTypes are the property types routing requests with the map named by mapName
+	mappedPropertyTypes = map[string]bool{"geographic": true, "cidrmapping": true, "asmapping": true}
+	// handoutModes are the handout modes of GTM properties
+	handoutModes = []interface{}{"normal", "persistent", "one-ip", "one-ip-hashed", "all-live-ips"}
+)
+
+// maxHandoutLimit is the maximum number of IP addresses a property hands out
+const maxHandoutLimit = 8
+
+// Validate validates Property, including the rules which depend on its type: geographic, cidrmapping and asmapping
+// properties require a map name, weighted properties an enabled traffic target with a positive weight, failover
+// properties an enabled traffic target and ranked-failover properties a single primary traffic target
 func (p *Property) Validate() error {
 	return edgegriderr.ParseValidationErrors(validation.Errors{
-		"Name":                  validation.Validate(p.Name, validation.Required),
-		"Type":                  validation.Validate(p.Type, validation.Required),
+		"Name": validation.Validate(p.Name, validation.Required),
+		"Type": validation.Validate(p.Type, validation.Required,
+			validation.In(propertyTypes...).Error(fmt.Sprintf("must be one of %s", joinValues(propertyTypes)))),
+		"MapName": validation.Validate(p.MapName,
+			validation.When(mappedPropertyTypes[p.Type], validation.Required.Error(fmt.Sprintf("is required for %s properties", p.Type)))),
 		"ScoreAggregationTypes": validation.Validate(p.ScoreAggregationType, validation.Required),
-		"HandoutMode":           validation.Validate(p.HandoutMode, validation.Required),
-		"TrafficTargets":        validation.Validate(p.TrafficTargets, validation.When(p.Type == "ranked-failover", validation.By(validateRankedFailoverTrafficTargets))),
+		"HandoutMode": validation.Validate(p.HandoutMode, validation.Required,
+			validation.In(handoutModes...).Error(fmt.Sprintf("must be one of %s", joinValues(handoutModes)))),
+		"HandoutLimit": validation.Validate(p.HandoutLimit, validation.Min(0), validation.Max(maxHandoutLimit)),
+		"TrafficTargets": validation.Validate(p.TrafficTargets,
+			validation.By(validateTrafficTargetWeights),
+			validation.By(validateUniqueTrafficTargets),
+			validation.By(validateDefaultDatacenterTrafficTargets),
+			validation.When(p.Type == "ranked-failover", validation.By(validateRankedFailoverTrafficTargets)),
+			validation.When(p.Type == "failover", validation.By(validateFailoverTrafficTargets)),
+			validation.When(strings.HasPrefix(p.Type, "weighted-"), validation.By(validateWeightedTrafficTargets))),
+		"LivenessTests": validation.Validate(p.LivenessTests),
 	})
 }
 
+// joinValues returns the values separated by commas
+func joinValues(values []interface{}) string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, fmt.Sprint(v))
+	}
+	return strings.Join(s, ", ")
+}
+
+// Validate validates LivenessTest. Fields which only apply to some test object protocols, e.g. httpError3xx for HTTP
+// and HTTPS, are rejected for the other protocols.
+func (lt *LivenessTest) Validate() error {
+	protocol := strings.ToUpper(lt.TestObjectProtocol)
+	isHTTP := protocol == "HTTP" || protocol == "HTTPS"
+	isTCP := protocol == "TCP" || protocol == "TCPS"
+	isSecure := strings.HasSuffix(protocol, "S") && protocol != "DNS"
+	onlyFor := func(applies bool, protocols string) validation.Rule {
+		return validation.When(!applies, validation.Empty.Error(fmt.Sprintf("only applies to %s test object protocols", protocols)))
+	}
+
+	return validation.Errors{
+		"Name":                 validation.Validate(lt.Name, validation.Required),
+		"TestObjectProtocol":   validation.Validate(lt.TestObjectProtocol, validation.Required),
+		"TestInterval":         validation.Validate(lt.TestInterval, validation.Required, validation.Min(1)),
+		"TestTimeout":          validation.Validate(lt.TestTimeout, validation.Required),
+		"TestObjectPort":       validation.Validate(lt.TestObjectPort, validation.Max(65535)),
+		"TestObject":           validation.Validate(lt.TestObject, validation.When(isHTTP || protocol == "FTP", validation.Required)),
+		"HTTPError3xx":         validation.Validate(lt.HTTPError3xx, onlyFor(isHTTP, "HTTP and HTTPS")),
+		"HTTPError4xx":         validation.Validate(lt.HTTPError4xx, onlyFor(isHTTP, "HTTP and HTTPS")),
+		"HTTPError5xx":         validation.Validate(lt.HTTPError5xx, onlyFor(isHTTP, "HTTP and HTTPS")),
+		"HTTPMethod":           validation.Validate(lt.HTTPMethod, onlyFor(isHTTP, "HTTP and HTTPS")),
+		"HTTPRequestBody":      validation.Validate(lt.HTTPRequestBody, onlyFor(isHTTP, "HTTP and HTTPS")),
+		"HTTPHeaders":          validation.Validate(lt.HTTPHeaders, onlyFor(isHTTP, "HTTP and HTTPS")),
+		"RequestString":        validation.Validate(lt.RequestString, onlyFor(isTCP, "TCP and TCPS")),
+		"ResponseString":       validation.Validate(lt.ResponseString, onlyFor(isTCP, "TCP and TCPS")),
+		"ResourceType":         validation.Validate(lt.ResourceType, onlyFor(protocol == "DNS", "DNS")),
+		"RecursionRequested":   validation.Validate(lt.RecursionRequested, onlyFor(protocol == "DNS", "DNS")),
+		"AnswersRequired":      validation.Validate(lt.AnswersRequired, onlyFor(protocol == "DNS", "DNS")),
+		"SSLClientCertificate": validation.Validate(lt.SSLClientCertificate, onlyFor(isSecure, "secure, e.g. HTTPS,"), validation.When(lt.SSLClientPrivateKey != "", validation.Required.Error("is required with SSLClientPrivateKey"))),
+		"SSLClientPrivateKey":  validation.Validate(lt.SSLClientPrivateKey, onlyFor(isSecure, "secure, e.g. HTTPS,"), validation.When(lt.SSLClientCertificate != "", validation.Required.Error("is required with SSLClientCertificate"))),
+	}.Filter()
+}
+
 // validateRankedFailoverTrafficTargets validates traffic targets when property type is 'ranked-failover'
 func validateRankedFailoverTrafficTargets(value interface{}) error {
 	tt := value.([]*TrafficTarget)
@@ -173,6 +269,173 @@ func validateRankedFailoverTrafficTargets(value interface{}) error {
 	return nil
 }
 
+// validateUniqueTrafficTargets checks that traffic targets do not target the same datacenter twice
+func validateUniqueTrafficTargets(value interface{}) error {
+	seen := make(map[int]struct{})
+	for _, t := range value.([]*TrafficTarget) {
+		if t == nil {
+			continue
+		}
+		if _, ok := seen[t.DatacenterID]; ok {
+			return fmt.Errorf("datacenter %d has several traffic targets", t.DatacenterID)
+		}
+		seen[t.DatacenterID] = struct{}{}
+	}
+	return nil
+}
+
+// validateDefaultDatacenterTrafficTargets checks that the traffic targets of default datacenters, which are virtual
+// datacenters (see DefaultDCType), are not assigned servers
+func validateDefaultDatacenterTrafficTargets(value interface{}) error {
+	for _, t := range value.([]*TrafficTarget) {
+		if t == nil || len(t.Servers) == 0 {
+			continue
+		}
+		if dcType, ok := DefaultDatacenterType(t.DatacenterID); ok {
+			return fmt.Errorf("datacenter %d is the %s default datacenter and cannot be assigned servers", t.DatacenterID, dcType)
+		}
+	}
+	return nil
+}
+
+// validateFailoverTrafficTargets checks that failover properties with traffic targets have an enabled one,
+// the enabled traffic targets being handed out in their order
+func validateFailoverTrafficTargets(value interface{}) error {
+	tt := value.([]*TrafficTarget)
+	if len(tt) == 0 {
+		return nil
+	}
+	for _, t := range tt {
+		if t != nil && t.Enabled {
+			return nil
+		}
+	}
+	return fmt.Errorf("failover property requires at least one enabled traffic target")
+}
+
+// validateTrafficTargetWeights checks that enabled traffic targets do not have negative weights
+func validateTrafficTargetWeights(value interface{}) error {
+	for _, t := range value.([]*TrafficTarget) {
+		if t != nil && t.Enabled && t.Weight < 0 {
+			return fmt.Errorf("traffic target %d has a negative weight", t.DatacenterID)
+		}
+	}
+	return nil
+}
+
+// validateWeightedTrafficTargets checks that weighted properties have an enabled traffic target with a positive weight,
+// otherwise the property hands out no answer
+func validateWeightedTrafficTargets(value interface{}) error {
+	for _, t := range value.([]*TrafficTarget) {
+		if t != nil && t.Enabled && t.Weight > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("weighted property requires at least one enabled traffic target with a positive weight")
+}
+
+// NormalizeWeights scales the weights of the enabled traffic targets so that they sum to TrafficTargetWeightTotal,
+// preserving their ratios. Disabled traffic targets are left unchanged. It returns an error, leaving the weights
+// unchanged, when an enabled traffic target has a negative weight or when the weights of the enabled traffic targets
+// sum to zero.
+func (p *Property) NormalizeWeights() error {
+	if err := validateTrafficTargetWeights(p.TrafficTargets); err != nil {
+		return err
+	}
+	var total float64
+	for _, t := range p.TrafficTargets {
+		if t != nil && t.Enabled {
+			total += t.Weight
+		}
+	}
+	if total == 0 {
+		return fmt.Errorf("enabled traffic targets of property %q have no weight", p.Name)
+	}
+	for _, t := range p.TrafficTargets {
+		if t != nil && t.Enabled {
+			t.Weight = t.Weight * TrafficTargetWeightTotal / total
+		}
+	}
+	return nil
+}
+
+// SetFailoverOrder reorders the traffic targets of a failover or ranked-failover property by datacenter ID,
+// from the primary to the last resort, e.g. []int{3131, 3132}. For ranked-failover properties, the precedence
+// of the traffic targets is also set to their position, 0 for the primary. It returns an error wrapping
+// ErrInvalidFailoverOrder, leaving the property unchanged, unless every datacenter of the traffic targets
+// is listed exactly once.
+func (p *Property) SetFailoverOrder(datacenterIDs []int) error {
+	if p.Type != "failover" && p.Type != "ranked-failover" {
+		return fmt.Errorf("%w: property %q of type %q is not a failover property", ErrInvalidFailoverOrder, p.Name, p.Type)
+	}
+
+	targets := make(map[int]*TrafficTarget, len(p.TrafficTargets))
+	for _, t := range p.TrafficTargets {
+		if t != nil {
+			targets[t.DatacenterID] = t
+		}
+	}
+	ordered := make([]*TrafficTarget, 0, len(datacenterIDs))
+	seen := make(map[int]bool, len(datacenterIDs))
+	for _, id := range datacenterIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate datacenter %d", ErrInvalidFailoverOrder, id)
+		}
+		seen[id] = true
+		t, ok := targets[id]
+		if !ok {
+			return fmt.Errorf("%w: property %q has no traffic target for datacenter %d", ErrInvalidFailoverOrder, p.Name, id)
+		}
+		ordered = append(ordered, t)
+	}
+	if len(ordered) != len(targets) {
+		var missing []string
+		for _, t := range p.TrafficTargets {
+			if t != nil && !seen[t.DatacenterID] {
+				missing = append(missing, strconv.Itoa(t.DatacenterID))
+			}
+		}
+		return fmt.Errorf("%w: missing datacenters %s", ErrInvalidFailoverOrder, strings.Join(missing, ", "))
+	}
+
+	if p.Type == "ranked-failover" {
+		for i, t := range ordered {
+			precedence := i
+			t.Precedence = &precedence
+		}
+	}
+	p.TrafficTargets = ordered
+	return nil
+}
+
+// GetFailoverOrder returns the datacenter IDs of the traffic targets in failover order, i.e. by precedence for
+// ranked-failover properties, a missing precedence counting as 0, and in the order of the traffic targets otherwise
+func (p *Property) GetFailoverOrder() []int {
+	targets := make([]*TrafficTarget, 0, len(p.TrafficTargets))
+	for _, t := range p.TrafficTargets {
+		if t != nil {
+			targets = append(targets, t)
+		}
+	}
+	if p.Type == "ranked-failover" {
+		precedence := func(t *TrafficTarget) int {
+			if t.Precedence == nil {
+				return 0
+			}
+			return *t.Precedence
+		}
+		sort.SliceStable(targets, func(i, j int) bool {
+			return precedence(targets[i]) < precedence(targets[j])
+		})
+	}
+
+	ids := make([]int, 0, len(targets))
+	for _, t := range targets {
+		ids = append(ids, t.DatacenterID)
+	}
+	return ids
+}
+
 func (g *gtm) ListProperties(ctx context.Context, domainName string) ([]*Property, error) {
 	logger := g.Log(ctx)
 	logger.Debug("ListProperties")
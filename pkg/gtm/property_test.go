@@ -637,6 +637,102 @@ func TestGTM_CreateProperty(t *testing.T) {
 				assert.ErrorContains(t, err, "property validation failed. TrafficTargets: 'Precedence' value has to be between 0 and 255")
 			},
 		},
+		"validation error - HTTP fields in TCP liveness test": {
+			property: &Property{
+				Name:                 "property",
+				HandoutMode:          "normal",
+				ScoreAggregationType: "mean",
+				Type:                 "failover",
+				LivenessTests: []*LivenessTest{
+					{
+						Name:               "tcp-check",
+						HTTPError3xx:       true,
+						TestInterval:       60,
+						TestObjectPort:     443,
+						TestObjectProtocol: "TCP",
+						TestTimeout:        25.0,
+						RequestString:      "PING",
+						ResponseString:     "PONG",
+					},
+				},
+			},
+			withError: true,
+			assertError: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "HTTPError3xx: only applies to HTTP and HTTPS test object protocols")
+			},
+		},
+		"validation error - invalid liveness test": {
+			property: &Property{
+				Name:                 "property",
+				HandoutMode:          "normal",
+				ScoreAggregationType: "mean",
+				Type:                 "failover",
+				LivenessTests: []*LivenessTest{
+					{
+						Name:                 "https-check",
+						TestObjectPort:       70000,
+						TestObjectProtocol:   "HTTPS",
+						SSLClientCertificate: "certificate",
+					},
+				},
+			},
+			withError: true,
+			assertError: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "property validation failed. LivenessTests")
+				assert.ErrorContains(t, err, "SSLClientPrivateKey: is required with SSLClientCertificate")
+				assert.ErrorContains(t, err, "TestInterval: cannot be blank")
+				assert.ErrorContains(t, err, "TestObject: cannot be blank")
+				assert.ErrorContains(t, err, "TestObjectPort: must be no greater than 65535")
+				assert.ErrorContains(t, err, "TestTimeout: cannot be blank")
+			},
+		},
+		"validation error - weighted property without weights": {
+			property: &Property{
+				Name:                 "property",
+				HandoutMode:          "normal",
+				ScoreAggregationType: "mean",
+				Type:                 "weighted-round-robin",
+				TrafficTargets: []*TrafficTarget{
+					{
+						DatacenterID: 1,
+						Enabled:      true,
+					},
+					{
+						DatacenterID: 2,
+						Enabled:      false,
+						Weight:       100,
+					},
+				},
+			},
+			withError: true,
+			assertError: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "property validation failed. TrafficTargets: weighted property requires at least one enabled traffic target with a positive weight")
+			},
+		},
+		"validation error - negative weight": {
+			property: &Property{
+				Name:                 "property",
+				HandoutMode:          "normal",
+				ScoreAggregationType: "mean",
+				Type:                 "weighted-round-robin",
+				TrafficTargets: []*TrafficTarget{
+					{
+						DatacenterID: 1,
+						Enabled:      true,
+						Weight:       -10,
+					},
+					{
+						DatacenterID: 2,
+						Enabled:      true,
+						Weight:       100,
+					},
+				},
+			},
+			withError: true,
+			assertError: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "property validation failed. TrafficTargets: traffic target 1 has a negative weight")
+			},
+		},
 		"500 internal server error": {
 			property: &Property{
 				Name:                 "testName",
@@ -950,6 +1046,267 @@ func TestGTM_UpdateProperty(t *testing.T) {
 	}
 }
 
+func TestProperty_NormalizeWeights(t *testing.T) {
+	tests := map[string]struct {
+		targets         []*TrafficTarget
+		expectedWeights []float64
+		withError       string
+	}{
+		"preserves ratios": {
+			targets: []*TrafficTarget{
+				{DatacenterID: 1, Enabled: true, Weight: 1},
+				{DatacenterID: 2, Enabled: true, Weight: 3},
+			},
+			expectedWeights: []float64{25, 75},
+		},
+		"disabled targets are left unchanged": {
+			targets: []*TrafficTarget{
+				{DatacenterID: 1, Enabled: true, Weight: 2},
+				{DatacenterID: 2, Enabled: false, Weight: 7},
+				{DatacenterID: 3, Enabled: true, Weight: 8},
+			},
+			expectedWeights: []float64{20, 7, 80},
+		},
+		"already normalized": {
+			targets: []*TrafficTarget{
+				{DatacenterID: 1, Enabled: true, Weight: 50},
+				{DatacenterID: 2, Enabled: true, Weight: 50},
+			},
+			expectedWeights: []float64{50, 50},
+		},
+		"all weights zero": {
+			targets: []*TrafficTarget{
+				{DatacenterID: 1, Enabled: true},
+				{DatacenterID: 2, Enabled: false, Weight: 10},
+			},
+			expectedWeights: []float64{0, 10},
+			withError:       `enabled traffic targets of property "property" have no weight`,
+		},
+		"negative weight": {
+			targets: []*TrafficTarget{
+				{DatacenterID: 1, Enabled: true, Weight: -1},
+				{DatacenterID: 2, Enabled: true, Weight: 10},
+			},
+			expectedWeights: []float64{-1, 10},
+			withError:       "traffic target 1 has a negative weight",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			property := &Property{Name: "property", Type: "weighted-round-robin", TrafficTargets: test.targets}
+			err := property.NormalizeWeights()
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+			} else {
+				require.NoError(t, err)
+			}
+			weights := make([]float64, 0, len(test.targets))
+			for _, target := range property.TrafficTargets {
+				weights = append(weights, target.Weight)
+			}
+			assert.InDeltaSlice(t, test.expectedWeights, weights, 1e-9)
+		})
+	}
+}
+
+func TestProperty_SetFailoverOrder(t *testing.T) {
+	targets := func() []*TrafficTarget {
+		return []*TrafficTarget{
+			{DatacenterID: 3131, Enabled: true},
+			{DatacenterID: 3132, Enabled: true},
+			{DatacenterID: 3133, Enabled: false},
+		}
+	}
+
+	tests := map[string]struct {
+		propertyType       string
+		order              []int
+		expectedOrder      []int
+		expectedPrecedence []int
+		withError          string
+	}{
+		"failover property": {
+			propertyType:  "failover",
+			order:         []int{3133, 3131, 3132},
+			expectedOrder: []int{3133, 3131, 3132},
+		},
+		"ranked-failover property": {
+			propertyType:       "ranked-failover",
+			order:              []int{3132, 3133, 3131},
+			expectedOrder:      []int{3132, 3133, 3131},
+			expectedPrecedence: []int{0, 1, 2},
+		},
+		"duplicate datacenter": {
+			propertyType:  "failover",
+			order:         []int{3131, 3131, 3132},
+			expectedOrder: []int{3131, 3132, 3133},
+			withError:     "invalid failover order: duplicate datacenter 3131",
+		},
+		"unknown datacenter": {
+			propertyType:  "failover",
+			order:         []int{3131, 3132, 3134},
+			expectedOrder: []int{3131, 3132, 3133},
+			withError:     `invalid failover order: property "property" has no traffic target for datacenter 3134`,
+		},
+		"missing datacenter": {
+			propertyType:  "failover",
+			order:         []int{3132},
+			expectedOrder: []int{3131, 3132, 3133},
+			withError:     "invalid failover order: missing datacenters 3131, 3133",
+		},
+		"not a failover property": {
+			propertyType:  "weighted-round-robin",
+			order:         []int{3133, 3131, 3132},
+			expectedOrder: []int{3131, 3132, 3133},
+			withError:     `invalid failover order: property "property" of type "weighted-round-robin" is not a failover property`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			property := &Property{Name: "property", Type: test.propertyType, TrafficTargets: targets()}
+			err := property.SetFailoverOrder(test.order)
+			if test.withError != "" {
+				assert.True(t, errors.Is(err, ErrInvalidFailoverOrder), "want: %s; got: %s", ErrInvalidFailoverOrder, err)
+				assert.EqualError(t, err, test.withError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedOrder, property.GetFailoverOrder())
+			for i, precedence := range test.expectedPrecedence {
+				require.NotNil(t, property.TrafficTargets[i].Precedence)
+				assert.Equal(t, precedence, *property.TrafficTargets[i].Precedence)
+			}
+		})
+	}
+}
+
+func TestProperty_GetFailoverOrder(t *testing.T) {
+	property := &Property{
+		Type: "ranked-failover",
+		TrafficTargets: []*TrafficTarget{
+			{DatacenterID: 3131, Precedence: tools.IntPtr(20)},
+			{DatacenterID: 3132, Precedence: tools.IntPtr(10)},
+			{DatacenterID: 3133},
+		},
+	}
+	assert.Equal(t, []int{3133, 3132, 3131}, property.GetFailoverOrder())
+
+	property.Type = "failover"
+	assert.Equal(t, []int{3131, 3132, 3133}, property.GetFailoverOrder())
+}
+
+func TestProperty_Validate(t *testing.T) {
+	property := func(propertyType string, modify func(*Property)) Property {
+		p := Property{
+			Name:                 "property",
+			Type:                 propertyType,
+			ScoreAggregationType: "mean",
+			HandoutMode:          "normal",
+			TrafficTargets: []*TrafficTarget{
+				{DatacenterID: 1, Enabled: true, Weight: 1},
+				{DatacenterID: 2, Enabled: true, Weight: 0},
+			},
+		}
+		if modify != nil {
+			modify(&p)
+		}
+		return p
+	}
+
+	tests := map[string]struct {
+		property  Property
+		withError []string
+	}{
+		"valid failover": {
+			property: property("failover", nil),
+		},
+		"valid geographic": {
+			property: property("geographic", func(p *Property) { p.MapName = "geomap" }),
+		},
+		"failover without traffic targets": {
+			property: property("failover", func(p *Property) { p.TrafficTargets = nil }),
+		},
+		"maximum handout limit": {
+			property: property("performance", func(p *Property) { p.HandoutLimit = 8 }),
+		},
+		"unknown type": {
+			property:  property("round-robin", nil),
+			withError: []string{"Type: must be one of failover, ranked-failover, geographic"},
+		},
+		"geographic without map name": {
+			property:  property("geographic", nil),
+			withError: []string{"MapName: is required for geographic properties"},
+		},
+		"asmapping without map name": {
+			property:  property("asmapping", nil),
+			withError: []string{"MapName: is required for asmapping properties"},
+		},
+		"unknown handout mode": {
+			property:  property("failover", func(p *Property) { p.HandoutMode = "random" }),
+			withError: []string{"HandoutMode: must be one of normal, persistent, one-ip, one-ip-hashed, all-live-ips"},
+		},
+		"handout limit out of range": {
+			property:  property("failover", func(p *Property) { p.HandoutLimit = 9 }),
+			withError: []string{"HandoutLimit: must be no greater than 8"},
+		},
+		"negative handout limit": {
+			property:  property("failover", func(p *Property) { p.HandoutLimit = -1 }),
+			withError: []string{"HandoutLimit: must be no less than 0"},
+		},
+		"failover without enabled traffic target": {
+			property: property("failover", func(p *Property) {
+				for _, tt := range p.TrafficTargets {
+					tt.Enabled = false
+				}
+			}),
+			withError: []string{"TrafficTargets: failover property requires at least one enabled traffic target"},
+		},
+		"duplicate datacenter": {
+			property: property("weighted-round-robin", func(p *Property) {
+				p.TrafficTargets[1].DatacenterID = 1
+			}),
+			withError: []string{"TrafficTargets: datacenter 1 has several traffic targets"},
+		},
+		"default datacenter without servers": {
+			property: property("geographic", func(p *Property) {
+				p.MapName = "geomap"
+				p.TrafficTargets[1].DatacenterID = MapDefaultDC
+			}),
+		},
+		"default datacenter with servers": {
+			property: property("geographic", func(p *Property) {
+				p.MapName = "geomap"
+				p.TrafficTargets[1].DatacenterID = MapDefaultDC
+				p.TrafficTargets[1].Servers = []string{"192.0.2.1"}
+			}),
+			withError: []string{"TrafficTargets: datacenter 5400 is the maps default datacenter and cannot be assigned servers"},
+		},
+		"IPv6 default datacenter with servers": {
+			property: property("performance", func(p *Property) {
+				p.TrafficTargets[0].DatacenterID = Ipv6DefaultDC
+				p.TrafficTargets[0].Servers = []string{"2001:db8::1"}
+			}),
+			withError: []string{"TrafficTargets: datacenter 5402 is the ipv6 default datacenter and cannot be assigned servers"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.property.Validate()
+			if len(test.withError) > 0 {
+				require.Error(t, err)
+				for _, msg := range test.withError {
+					assert.Contains(t, err.Error(), msg)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestGTM_DeleteProperty(t *testing.T) {
 	var result PropertyResponse
 	var req Property
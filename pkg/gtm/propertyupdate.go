@@ -0,0 +1,177 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+)
+
+// PropertyForUpdate is a property read with GetPropertyForUpdate, to be modified and written back with
+// UpdatePropertyPartial. It keeps the property as returned by the API, so that the fields which are not modified,
+// including the fields the Property struct does not model, are written back unchanged.
+type PropertyForUpdate struct {
+	*Property
+
+	// original is the property as returned by the API
+	original json.RawMessage
+}
+
+// propertyServerManagedFields are the fields of a property set by the API, which are removed from the written property
+var propertyServerManagedFields = []string{"lastModified", "links"}
+
+func (g *gtm) GetPropertyForUpdate(ctx context.Context, propertyName, domainName string) (*PropertyForUpdate, error) {
+	logger := g.Log(ctx)
+	logger.Debug("GetPropertyForUpdate")
+
+	getURL := fmt.Sprintf("/config-gtm/v1/domains/%s/properties/%s", domainName, propertyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetPropertyForUpdate request: %w", err)
+	}
+	setVersionHeader(req, schemaVersion)
+
+	var result Property
+	resp, err := g.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("GetPropertyForUpdate request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, g.Error(resp)
+	}
+
+	original, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GetPropertyForUpdate failed to read response: %w", err)
+	}
+
+	return &PropertyForUpdate{Property: &result, original: original}, nil
+}
+
+func (g *gtm) UpdatePropertyPartial(ctx context.Context, property *PropertyForUpdate, domainName string) (*ResponseStatus, error) {
+	logger := g.Log(ctx)
+	logger.Debug("UpdatePropertyPartial")
+
+	if property == nil || property.Property == nil || property.original == nil {
+		return nil, fmt.Errorf("UpdatePropertyPartial requires a property read with GetPropertyForUpdate")
+	}
+	if err := property.Validate(); err != nil {
+		return nil, fmt.Errorf("property validation failed. %w", err)
+	}
+
+	body, err := property.mergedJSON()
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePropertyPartial failed to merge property: %w", err)
+	}
+
+	putURL := fmt.Sprintf("/config-gtm/v1/domains/%s/properties/%s", domainName, property.Name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create UpdatePropertyPartial request: %w", err)
+	}
+	setVersionHeader(req, schemaVersion)
+
+	var result PropertyResponse
+	resp, err := g.Exec(req, &result, body)
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePropertyPartial request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, g.Error(resp)
+	}
+
+	return result.Status, nil
+}
+
+// mergedJSON returns the property as returned by the API with the modifications made to the Property applied,
+// i.e. the differences between the Property and the original decoded into a Property, without the server-managed fields
+func (p *PropertyForUpdate) mergedJSON() (json.RawMessage, error) {
+	var original, base, modified interface{}
+	if err := json.Unmarshal(p.original, &original); err != nil {
+		return nil, err
+	}
+
+	var decoded Property
+	if err := json.Unmarshal(p.original, &decoded); err != nil {
+		return nil, err
+	}
+	if err := remarshal(&decoded, &base); err != nil {
+		return nil, err
+	}
+	if err := remarshal(p.Property, &modified); err != nil {
+		return nil, err
+	}
+
+	merged := mergeJSONValue(original, base, modified)
+	if object, ok := merged.(map[string]interface{}); ok {
+		for _, field := range propertyServerManagedFields {
+			delete(object, field)
+		}
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// remarshal converts v to its generic JSON representation
+func remarshal(v interface{}, out *interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// mergeJSONValue applies the changes from base to modified onto original. Objects are merged field by field, so that
+// the fields of original absent from base, i.e. unknown to the struct, are kept. Arrays of the same length are merged
+// element by element, other arrays are replaced by the modified ones.
+func mergeJSONValue(original, base, modified interface{}) interface{} {
+	if reflect.DeepEqual(base, modified) {
+		return original
+	}
+
+	switch modifiedValue := modified.(type) {
+	case map[string]interface{}:
+		originalObject, ok := original.(map[string]interface{})
+		baseObject, baseOK := base.(map[string]interface{})
+		if !ok || !baseOK {
+			return modified
+		}
+		merged := make(map[string]interface{}, len(originalObject))
+		for key, value := range originalObject {
+			merged[key] = value
+		}
+		for key, value := range modifiedValue {
+			// unchanged fields are kept as they are in original, including when absent from it
+			if reflect.DeepEqual(baseObject[key], value) {
+				continue
+			}
+			merged[key] = mergeJSONValue(originalObject[key], baseObject[key], value)
+		}
+		// fields omitted from the modified object, e.g. zeroed omitempty fields, are removed
+		for key := range baseObject {
+			if _, ok := modifiedValue[key]; !ok {
+				delete(merged, key)
+			}
+		}
+		return merged
+	case []interface{}:
+		originalArray, ok := original.([]interface{})
+		baseArray, baseOK := base.([]interface{})
+		if !ok || !baseOK || len(originalArray) != len(modifiedValue) || len(baseArray) != len(modifiedValue) {
+			return modified
+		}
+		merged := make([]interface{}, len(modifiedValue))
+		for i := range modifiedValue {
+			merged[i] = mergeJSONValue(originalArray[i], baseArray[i], modifiedValue[i])
+		}
+		return merged
+	}
+	return modified
+}
@@ -0,0 +1,122 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGTM_UpdatePropertyPartial(t *testing.T) {
+	original, err := loadTestData("TestGTM_GetProperty.resp.json")
+	require.NoError(t, err)
+
+	// expectedBody returns the fetched property without its server-managed fields, modified by fn
+	expectedBody := func(fn func(property map[string]interface{})) map[string]interface{} {
+		var property map[string]interface{}
+		require.NoError(t, json.Unmarshal(original, &property))
+		delete(property, "lastModified")
+		delete(property, "links")
+		if fn != nil {
+			fn(property)
+		}
+		return property
+	}
+	trafficTarget := func(property map[string]interface{}, i int) map[string]interface{} {
+		return property["trafficTargets"].([]interface{})[i].(map[string]interface{})
+	}
+
+	tests := map[string]struct {
+		modify       func(*Property)
+		expectedBody map[string]interface{}
+	}{
+		"unchanged": {
+			modify:       func(*Property) {},
+			expectedBody: expectedBody(nil),
+		},
+		"traffic target weight changed": {
+			modify: func(p *Property) {
+				p.TrafficTargets[1].Weight = 2
+			},
+			expectedBody: expectedBody(func(property map[string]interface{}) {
+				trafficTarget(property, 1)["weight"] = 2.0
+			}),
+		},
+		"liveness test interval changed": {
+			modify: func(p *Property) {
+				p.LivenessTests[0].TestInterval = 30
+			},
+			expectedBody: expectedBody(func(property map[string]interface{}) {
+				property["livenessTests"].([]interface{})[0].(map[string]interface{})["testInterval"] = 30.0
+			}),
+		},
+		"omitempty field zeroed": {
+			modify: func(p *Property) {
+				p.DynamicTTL = 0
+			},
+			expectedBody: expectedBody(func(property map[string]interface{}) {
+				delete(property, "dynamicTTL")
+			}),
+		},
+		"traffic target added": {
+			modify: func(p *Property) {
+				p.TrafficTargets = append(p.TrafficTargets, &TrafficTarget{DatacenterID: 3135, Enabled: true, Servers: []string{"1.2.3.6"}})
+			},
+			expectedBody: expectedBody(func(property map[string]interface{}) {
+				property["trafficTargets"] = []interface{}{
+					map[string]interface{}{"datacenterId": 3134.0, "enabled": true, "servers": []interface{}{"1.2.3.5"}, "precedence": 255.0},
+					map[string]interface{}{"datacenterId": 3133.0, "enabled": true, "weight": 1.0, "servers": []interface{}{"1.2.3.4"}},
+					map[string]interface{}{"datacenterId": 3135.0, "enabled": true, "servers": []interface{}{"1.2.3.6"}},
+				}
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-gtm/v1/domains/example.akadns.net/properties/www", r.URL.String())
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write(original)
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodPut, r.Method)
+				data, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(data, &body))
+				assert.Equal(t, test.expectedBody, body)
+
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write([]byte(`{"resource": {"name": "www", "type": "failover"}, "status": {"propagationStatus": "PENDING"}}`))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+
+			property, err := client.GetPropertyForUpdate(context.Background(), "www", "example.akadns.net")
+			require.NoError(t, err)
+			test.modify(property.Property)
+
+			status, err := client.UpdatePropertyPartial(context.Background(), property, "example.akadns.net")
+			require.NoError(t, err)
+			assert.Equal(t, &ResponseStatus{PropagationStatus: "PENDING"}, status)
+		})
+	}
+}
+
+func TestGTM_UpdatePropertyPartialNotRead(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	_, err := client.UpdatePropertyPartial(context.Background(), &PropertyForUpdate{Property: &Property{Name: "www"}}, "example.akadns.net")
+	assert.Error(t, err)
+}
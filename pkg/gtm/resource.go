@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // Resources contains operations available on a Resource resource.
@@ -55,6 +58,15 @@ type Resource struct {
 	DecayRate                   float64             `json:"decayRate,omitempty"`
 }
 
+const (
+	// AggregationTypeLatest uses the latest load reported for the resource
+	AggregationTypeLatest = "latest"
+	// AggregationTypeMedian uses the median of the loads reported for the resource
+	AggregationTypeMedian = "median"
+	// AggregationTypeSum uses the sum of the loads reported for the resource
+	AggregationTypeSum = "sum"
+)
+
 // ResourceList is the structure returned by List Resources
 type ResourceList struct {
 	ResourceItems []*Resource `json:"items"`
@@ -62,14 +74,26 @@ type ResourceList struct {
 
 // Validate validates Resource
 func (r *Resource) Validate() error {
-	if len(r.Name) < 1 {
-		return fmt.Errorf("resource is missing Name")
-	}
-	if len(r.Type) < 1 {
-		return fmt.Errorf("resource is missing Type")
-	}
+	return r.validate(nil)
+}
 
-	return nil
+// validate validates Resource, checking also that resource instances reference the given datacenters
+// unless datacenters is nil
+func (r *Resource) validate(datacenters map[int]struct{}) error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Name":            validation.Validate(r.Name, validation.Required),
+		"Type":            validation.Validate(r.Type, validation.Required),
+		"AggregationType": validation.Validate(r.AggregationType, validation.In(AggregationTypeLatest, AggregationTypeMedian, AggregationTypeSum)),
+		"ResourceInstances": validation.Validate(r.ResourceInstances, validation.Each(validation.By(func(value interface{}) error {
+			instance, ok := value.(ResourceInstance)
+			if !ok {
+				return fmt.Errorf("unexpected resource instance type %T", value)
+			}
+			return validation.Errors{
+				"DatacenterID": validation.Validate(instance.DatacenterID, datacenterExists(datacenters)),
+			}.Filter()
+		}))),
+	})
 }
 
 func (g *gtm) ListResources(ctx context.Context, domainName string) ([]*Resource, error) {
@@ -124,14 +148,14 @@ func (g *gtm) CreateResource(ctx context.Context, resource *Resource, domainName
 	logger := g.Log(ctx)
 	logger.Debug("CreateResource")
 
-	return resource.save(ctx, g, domainName)
+	return resource.save(ctx, g, domainName, nil)
 }
 
 func (g *gtm) UpdateResource(ctx context.Context, resource *Resource, domainName string) (*ResponseStatus, error) {
 	logger := g.Log(ctx)
 	logger.Debug("UpdateResource")
 
-	stat, err := resource.save(ctx, g, domainName)
+	stat, err := resource.save(ctx, g, domainName, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -139,8 +163,8 @@ func (g *gtm) UpdateResource(ctx context.Context, resource *Resource, domainName
 }
 
 // save is a function that saves Resource in given domain. Common path for Create and Update.
-func (r *Resource) save(ctx context.Context, g *gtm, domainName string) (*ResourceResponse, error) {
-	if err := r.Validate(); err != nil {
+func (r *Resource) save(ctx context.Context, g *gtm, domainName string, datacenters map[int]struct{}) (*ResourceResponse, error) {
+	if err := g.validateDatacenters(ctx, domainName, datacenters, r.validate); err != nil {
 		return nil, fmt.Errorf("resource validation failed. %w", err)
 	}
 
@@ -226,6 +226,9 @@ func TestGTM_CreateResource(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
@@ -314,6 +317,9 @@ func TestGTM_UpdateResource(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					return
+				}
 				assert.Equal(t, http.MethodPut, r.Method)
 				w.WriteHeader(test.responseStatus)
 				if len(test.responseBody) > 0 {
@@ -423,3 +429,87 @@ func TestGTM_DeleteResource(t *testing.T) {
 		})
 	}
 }
+
+func TestGTM_CreateResourceValidation(t *testing.T) {
+	tests := map[string]struct {
+		resource      *Resource
+		expectedLists int
+		expectedError []string
+	}{
+		"invalid aggregation type is reported before datacenters are listed": {
+			resource: &Resource{
+				Name:                    "testResource",
+				Type:                    "XML load object via HTTP",
+				AggregationType:         "average",
+				LoadImbalancePercentage: 50,
+				ResourceInstances: []*ResourceInstance{
+					{
+						DatacenterID: 3134,
+						LoadObject: LoadObject{
+							LoadObject:  "/test1",
+							LoadServers: []string{"1.2.3.4"},
+						},
+					},
+					{
+						DatacenterID: 9999,
+						LoadObject: LoadObject{
+							LoadObject:  "/test2",
+							LoadServers: []string{"1.2.3.5"},
+						},
+					},
+				},
+			},
+			expectedError: []string{
+				"AggregationType: must be a valid value",
+			},
+		},
+		"unknown datacenter": {
+			resource: &Resource{
+				Name:                    "testResource",
+				Type:                    "XML load object via HTTP",
+				AggregationType:         AggregationTypeMedian,
+				LoadImbalancePercentage: 50,
+				ResourceInstances: []*ResourceInstance{
+					{
+						DatacenterID: 9999,
+						LoadObject: LoadObject{
+							LoadObject:  "/test2",
+							LoadServers: []string{"1.2.3.5"},
+						},
+					},
+				},
+			},
+			expectedLists: 1,
+			expectedError: []string{
+				"ResourceInstances[0]: {\n\tDatacenterID: datacenter 9999 does not exist in the domain\n}",
+			},
+		},
+		"missing type": {
+			resource: &Resource{
+				Name:            "testResource",
+				AggregationType: AggregationTypeMedian,
+			},
+			expectedError: []string{"Type: cannot be blank"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lists int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if mockListDatacenters(t, w, r) {
+					lists++
+					return
+				}
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			client := mockAPIClient(t, mockServer)
+			_, err := client.CreateResource(context.Background(), test.resource, "example.akadns.net")
+			require.Error(t, err)
+			assert.Equal(t, test.expectedLists, lists)
+			for _, expected := range test.expectedError {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
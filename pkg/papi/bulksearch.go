@@ -0,0 +1,285 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// BulkSearch contains operations searching the rule trees of all properties
+	BulkSearch interface {
+		// BulkSearchProperties submits an asynchronous search of the rule trees of the latest, staging and production
+		// versions of all properties, e.g. for the properties with rules matching a hostname, or referencing a CP code
+		// or an origin (see BulkSearchQueryHostnameCondition, BulkSearchQueryCPCode and BulkSearchQueryOrigin).
+		// The results are fetched with GetBulkSearch or WaitForBulkSearch.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-bulk-search
+		BulkSearchProperties(context.Context, BulkSearchRequest) (*BulkSearchResponse, error)
+
+		// GetBulkSearch gets the status of a bulk search and, once it is complete, its results
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-bulk-search
+		GetBulkSearch(context.Context, GetBulkSearchRequest) (*BulkSearchResults, error)
+
+		// WaitForBulkSearch polls the bulk search every pollInterval, DefaultBulkSearchPollInterval when it is not
+		// positive, until it is complete and returns its results. It returns ErrBulkSearchFailed when the search fails.
+		WaitForBulkSearch(context.Context, GetBulkSearchRequest, time.Duration) (*BulkSearchResults, error)
+	}
+
+	// BulkSearchRequest contains parameters used to submit a bulk search
+	BulkSearchRequest struct {
+		ContractID      string          `json:"-"`
+		GroupID         string          `json:"-"`
+		BulkSearchQuery BulkSearchQuery `json:"bulkSearchQuery"`
+	}
+
+	// BulkSearchQuery is a JSONPath expression matching rule tree objects, optionally filtered by qualifiers
+	// which are JSONPath expressions evaluated against each match
+	BulkSearchQuery struct {
+		Syntax               BulkSearchSyntax `json:"syntax"`
+		Match                string           `json:"match"`
+		BulkSearchQualifiers []string         `json:"bulkSearchQualifiers,omitempty"`
+	}
+
+	// BulkSearchResponse represents a response object returned by BulkSearchProperties
+	BulkSearchResponse struct {
+		BulkSearchID   string `json:"-"`
+		BulkSearchLink string `json:"bulkSearchLink"`
+	}
+
+	// GetBulkSearchRequest contains parameters used to get a bulk search
+	GetBulkSearchRequest struct {
+		BulkSearchID string
+		ContractID   string
+		GroupID      string
+	}
+
+	// BulkSearchResults represents a response object returned by GetBulkSearch
+	BulkSearchResults struct {
+		BulkSearchID       int                `json:"bulkSearchId"`
+		SearchTargetStatus BulkSearchStatus   `json:"searchTargetStatus"`
+		SearchSubmitDate   string             `json:"searchSubmitDate"`
+		SearchUpdateDate   string             `json:"searchUpdateDate"`
+		BulkSearchQuery    BulkSearchQuery    `json:"bulkSearchQuery"`
+		Results            []BulkSearchResult `json:"results"`
+	}
+
+	// BulkSearchResult is a property version whose rule tree matches the bulk search query
+	BulkSearchResult struct {
+		AccountID        string        `json:"accountId"`
+		ContractID       string        `json:"contractId"`
+		GroupID          string        `json:"groupId"`
+		PropertyID       string        `json:"propertyId"`
+		PropertyName     string        `json:"propertyName"`
+		PropertyVersion  int           `json:"propertyVersion"`
+		PropertyType     string        `json:"propertyType"`
+		IsLatest         bool          `json:"isLatest"`
+		IsLocked         bool          `json:"isLocked"`
+		IsSecure         bool          `json:"isSecure"`
+		ProductionStatus VersionStatus `json:"productionStatus"`
+		StagingStatus    VersionStatus `json:"stagingStatus"`
+		LastModifiedTime string        `json:"lastModifiedTime"`
+		MatchLocations   []string      `json:"matchLocations"`
+	}
+
+	// BulkSearchSyntax is the syntax of bulk search queries
+	BulkSearchSyntax string
+
+	// BulkSearchStatus is the status of a bulk search
+	BulkSearchStatus string
+)
+
+const (
+	// BulkSearchSyntaxJSONPath is the JSONPath syntax of bulk search queries
+	BulkSearchSyntaxJSONPath BulkSearchSyntax = "JSONPATH"
+
+	// BulkSearchStatusPending is the status of a bulk search waiting to be processed
+	BulkSearchStatusPending BulkSearchStatus = "PENDING"
+	// BulkSearchStatusInProgress is the status of a bulk search being processed
+	BulkSearchStatusInProgress BulkSearchStatus = "IN_PROGRESS"
+	// BulkSearchStatusComplete is the status of a bulk search whose results are available
+	BulkSearchStatusComplete BulkSearchStatus = "COMPLETE"
+	// BulkSearchStatusError is the status of a failed bulk search
+	BulkSearchStatusError BulkSearchStatus = "ERROR"
+
+	// DefaultBulkSearchPollInterval is the interval WaitForBulkSearch polls the bulk search at when no interval is given
+	DefaultBulkSearchPollInterval = 5 * time.Second
+)
+
+var (
+	// ErrBulkSearchProperties represents error when submitting a bulk search fails
+	ErrBulkSearchProperties = errors.New("bulk search properties")
+	// ErrGetBulkSearch represents error when fetching a bulk search fails
+	ErrGetBulkSearch = errors.New("get bulk search")
+	// ErrBulkSearchFailed is returned by WaitForBulkSearch when the bulk search ends with the ERROR status
+	ErrBulkSearchFailed = errors.New("bulk search failed")
+)
+
+// BulkSearchQueryHostnameCondition returns a query matching the rules with a hostname match condition which
+// includes hostname. It does not match the hostnames of properties, which are not part of their rule trees;
+// use SearchProperties with SearchKeyHostname to find the properties serving a hostname
+func BulkSearchQueryHostnameCondition(hostname string) BulkSearchQuery {
+	return BulkSearchQuery{
+		Syntax: BulkSearchSyntaxJSONPath,
+		Match:  fmt.Sprintf(`$..conditions[?(@.name == "hostname")].options.values[?(@ == %q)]`, hostname),
+	}
+}
+
+// BulkSearchQueryCPCode returns a query matching the cpCode behaviors of rule trees which set the CP code with id cpCodeID
+func BulkSearchQueryCPCode(cpCodeID int) BulkSearchQuery {
+	return BulkSearchQuery{
+		Syntax: BulkSearchSyntaxJSONPath,
+		Match:  fmt.Sprintf(`$..behaviors[?(@.name == "cpCode" && @.options.value.id == %d)]`, cpCodeID),
+	}
+}
+
+// BulkSearchQueryOrigin returns a query matching the origin behaviors of rule trees which use the origin hostname
+func BulkSearchQueryOrigin(hostname string) BulkSearchQuery {
+	return BulkSearchQuery{
+		Syntax: BulkSearchSyntaxJSONPath,
+		Match:  fmt.Sprintf(`$..behaviors[?(@.name == "origin" && @.options.hostname == %q)]`, hostname),
+	}
+}
+
+// ActiveNetworks returns the networks on which the property version is active
+func (r BulkSearchResult) ActiveNetworks() []ActivationNetwork {
+	var networks []ActivationNetwork
+	if r.StagingStatus == VersionStatusActive {
+		networks = append(networks, ActivationNetworkStaging)
+	}
+	if r.ProductionStatus == VersionStatusActive {
+		networks = append(networks, ActivationNetworkProduction)
+	}
+	return networks
+}
+
+// Validate validates BulkSearchRequest
+func (r BulkSearchRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"BulkSearchQuery.Syntax": validation.Validate(r.BulkSearchQuery.Syntax,
+			validation.Required,
+			validation.In(BulkSearchSyntaxJSONPath).Error(fmt.Sprintf("value '%s' is invalid. Must be: '%s'", r.BulkSearchQuery.Syntax, BulkSearchSyntaxJSONPath))),
+		"BulkSearchQuery.Match": validation.Validate(r.BulkSearchQuery.Match, validation.Required),
+		"GroupID":               validation.Validate(r.GroupID, validation.When(r.ContractID != "", validation.Required)),
+		"ContractID":            validation.Validate(r.ContractID, validation.When(r.GroupID != "", validation.Required)),
+	})
+}
+
+// Validate validates GetBulkSearchRequest
+func (r GetBulkSearchRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"BulkSearchID": validation.Validate(r.BulkSearchID, validation.Required),
+		"GroupID":      validation.Validate(r.GroupID, validation.When(r.ContractID != "", validation.Required)),
+		"ContractID":   validation.Validate(r.ContractID, validation.When(r.GroupID != "", validation.Required)),
+	})
+}
+
+func (p *papi) BulkSearchProperties(ctx context.Context, params BulkSearchRequest) (*BulkSearchResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("BulkSearchProperties")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkSearchProperties, ErrStructValidation, err)
+	}
+
+	uri := "/papi/v1/bulk/rules-search-requests"
+	if params.ContractID != "" {
+		q := url.Values{}
+		q.Add("contractId", params.ContractID)
+		q.Add("groupId", params.GroupID)
+		uri += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrBulkSearchProperties, err)
+	}
+
+	var result BulkSearchResponse
+	resp, err := p.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrBulkSearchProperties, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrBulkSearchProperties, p.Error(resp))
+	}
+
+	id, err := ResponseLinkParse(result.BulkSearchLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkSearchProperties, ErrInvalidResponseLink, err)
+	}
+	result.BulkSearchID = id
+
+	return &result, nil
+}
+
+func (p *papi) GetBulkSearch(ctx context.Context, params GetBulkSearchRequest) (*BulkSearchResults, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetBulkSearch")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkSearch, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/papi/v1/bulk/rules-search-requests/%s", params.BulkSearchID)
+	if params.ContractID != "" {
+		q := url.Values{}
+		q.Add("contractId", params.ContractID)
+		q.Add("groupId", params.GroupID)
+		uri += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkSearch, err)
+	}
+
+	var result BulkSearchResults
+	resp, err := p.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkSearch, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkSearch, p.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (p *papi) WaitForBulkSearch(ctx context.Context, params GetBulkSearchRequest, pollInterval time.Duration) (*BulkSearchResults, error) {
+	logger := p.Log(ctx)
+	logger.Debug("WaitForBulkSearch")
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultBulkSearchPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		result, err := p.GetBulkSearch(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		switch result.SearchTargetStatus {
+		case BulkSearchStatusComplete:
+			return result, nil
+		case BulkSearchStatusError:
+			return nil, fmt.Errorf("%w: bulk search %s", ErrBulkSearchFailed, params.BulkSearchID)
+		}
+		logger.Debugf("Bulk search %s: %s", params.BulkSearchID, result.SearchTargetStatus)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrGetBulkSearch, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
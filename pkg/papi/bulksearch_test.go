@@ -0,0 +1,323 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestPapi_BulkSearchProperties(t *testing.T) {
+	tests := map[string]struct {
+		params           BulkSearchRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedRequest  string
+		expectedResponse *BulkSearchResponse
+		withError        func(*testing.T, error)
+	}{
+		"202 Accepted": {
+			params: BulkSearchRequest{
+				BulkSearchQuery: BulkSearchQueryOrigin("origin.example.com"),
+			},
+			responseStatus: http.StatusAccepted,
+			responseBody: `
+{
+    "bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5"
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests",
+			expectedRequest: `
+{
+	"bulkSearchQuery": {
+		"syntax": "JSONPATH",
+		"match": "$..behaviors[?(@.name == \"origin\" && @.options.hostname == \"origin.example.com\")]"
+	}
+}`,
+			expectedResponse: &BulkSearchResponse{
+				BulkSearchID:   "5",
+				BulkSearchLink: "/papi/v1/bulk/rules-search-requests/5",
+			},
+		},
+		"202 Accepted with contract and group": {
+			params: BulkSearchRequest{
+				ContractID: "ctr_1",
+				GroupID:    "grp_2",
+				BulkSearchQuery: BulkSearchQuery{
+					Syntax:               BulkSearchSyntaxJSONPath,
+					Match:                "$..conditions[?(@.name == \"hostname\")].options.values[*]",
+					BulkSearchQualifiers: []string{"$.options[?(@.matchOperator == \"IS_ONE_OF\")]"},
+				},
+			},
+			responseStatus: http.StatusAccepted,
+			responseBody: `
+{
+    "bulkSearchLink": "/papi/v1/bulk/rules-search-requests/6?contractId=ctr_1&groupId=grp_2"
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests?contractId=ctr_1&groupId=grp_2",
+			expectedRequest: `
+{
+	"bulkSearchQuery": {
+		"syntax": "JSONPATH",
+		"match": "$..conditions[?(@.name == \"hostname\")].options.values[*]",
+		"bulkSearchQualifiers": ["$.options[?(@.matchOperator == \"IS_ONE_OF\")]"]
+	}
+}`,
+			expectedResponse: &BulkSearchResponse{
+				BulkSearchID:   "6",
+				BulkSearchLink: "/papi/v1/bulk/rules-search-requests/6?contractId=ctr_1&groupId=grp_2",
+			},
+		},
+		"500 Internal Server Error": {
+			params: BulkSearchRequest{
+				BulkSearchQuery: BulkSearchQueryCPCode(12345),
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error submitting bulk search",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests",
+			expectedRequest: `
+{
+	"bulkSearchQuery": {
+		"syntax": "JSONPATH",
+		"match": "$..behaviors[?(@.name == \"cpCode\" && @.options.value.id == 12345)]"
+	}
+}`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error submitting bulk search",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"missing match": {
+			params: BulkSearchRequest{
+				BulkSearchQuery: BulkSearchQuery{Syntax: BulkSearchSyntaxJSONPath},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "BulkSearchQuery.Match")
+			},
+		},
+		"contract without group": {
+			params: BulkSearchRequest{
+				ContractID:      "ctr_1",
+				BulkSearchQuery: BulkSearchQueryHostnameCondition("www.example.com"),
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "GroupID")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.BulkSearchProperties(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetBulkSearch(t *testing.T) {
+	tests := map[string]struct {
+		params           GetBulkSearchRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *BulkSearchResults
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			params:         GetBulkSearchRequest{BulkSearchID: "5"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "bulkSearchId": 5,
+    "searchTargetStatus": "COMPLETE",
+    "searchSubmitDate": "2018-01-18T00:00:00Z",
+    "searchUpdateDate": "2018-01-18T00:01:00Z",
+    "bulkSearchQuery": {
+        "syntax": "JSONPATH",
+        "match": "$..behaviors[?(@.name == \"origin\")]"
+    },
+    "results": [
+        {
+            "accountId": "act_1",
+            "propertyId": "prp_1",
+            "propertyName": "example.com",
+            "propertyVersion": 3,
+            "propertyType": "TRADITIONAL",
+            "isLatest": true,
+            "isLocked": true,
+            "isSecure": false,
+            "productionStatus": "ACTIVE",
+            "stagingStatus": "ACTIVE",
+            "lastModifiedTime": "2018-01-17T00:00:00Z",
+            "matchLocations": ["/rules/behaviors/0"]
+        }
+    ]
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests/5",
+			expectedResponse: &BulkSearchResults{
+				BulkSearchID:       5,
+				SearchTargetStatus: BulkSearchStatusComplete,
+				SearchSubmitDate:   "2018-01-18T00:00:00Z",
+				SearchUpdateDate:   "2018-01-18T00:01:00Z",
+				BulkSearchQuery: BulkSearchQuery{
+					Syntax: BulkSearchSyntaxJSONPath,
+					Match:  `$..behaviors[?(@.name == "origin")]`,
+				},
+				Results: []BulkSearchResult{
+					{
+						AccountID:        "act_1",
+						PropertyID:       "prp_1",
+						PropertyName:     "example.com",
+						PropertyVersion:  3,
+						PropertyType:     "TRADITIONAL",
+						IsLatest:         true,
+						IsLocked:         true,
+						ProductionStatus: VersionStatusActive,
+						StagingStatus:    VersionStatusActive,
+						LastModifiedTime: "2018-01-17T00:00:00Z",
+						MatchLocations:   []string{"/rules/behaviors/0"},
+					},
+				},
+			},
+		},
+		"404 Not Found": {
+			params:         GetBulkSearchRequest{BulkSearchID: "7", ContractID: "ctr_1", GroupID: "grp_2"},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "not_found",
+    "title": "Not Found",
+    "detail": "Bulk search not found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests/7?contractId=ctr_1&groupId=grp_2",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "not_found",
+					Title:      "Not Found",
+					Detail:     "Bulk search not found",
+					StatusCode: http.StatusNotFound,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"missing bulk search ID": {
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "BulkSearchID")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetBulkSearch(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			assert.Equal(t, []ActivationNetwork{ActivationNetworkStaging, ActivationNetworkProduction}, result.Results[0].ActiveNetworks())
+		})
+	}
+}
+
+func TestPapi_WaitForBulkSearch(t *testing.T) {
+	tests := map[string]struct {
+		statuses      []BulkSearchStatus
+		timeout       time.Duration
+		expectedCalls int
+		withError     error
+	}{
+		"complete after polling": {
+			statuses:      []BulkSearchStatus{BulkSearchStatusPending, BulkSearchStatusInProgress, BulkSearchStatusComplete},
+			timeout:       time.Second,
+			expectedCalls: 3,
+		},
+		"search failed": {
+			statuses:      []BulkSearchStatus{BulkSearchStatusInProgress, BulkSearchStatusError},
+			timeout:       time.Second,
+			expectedCalls: 2,
+			withError:     ErrBulkSearchFailed,
+		},
+		"context done": {
+			statuses:  []BulkSearchStatus{BulkSearchStatusPending},
+			timeout:   20 * time.Millisecond,
+			withError: ErrGetBulkSearch,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/bulk/rules-search-requests/5", r.URL.String())
+				call := int(atomic.AddInt32(&calls, 1)) - 1
+				status := test.statuses[len(test.statuses)-1]
+				if call < len(test.statuses) {
+					status = test.statuses[call]
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`{"bulkSearchId": 5, "searchTargetStatus": "` + string(status) + `", "results": []}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			result, err := client.WaitForBulkSearch(ctx, GetBulkSearchRequest{BulkSearchID: "5"}, time.Millisecond)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, BulkSearchStatusComplete, result.SearchTargetStatus)
+			assert.Equal(t, test.expectedCalls, int(atomic.LoadInt32(&calls)))
+		})
+	}
+}
@@ -27,7 +27,7 @@ type (
 		// See: https://techdocs.akamai.com/cp-codes/reference/get-cpcode
 		GetCPCodeDetail(context.Context, int) (*CPCodeDetailResponse, error)
 
-		// CreateCPCode creates a new CP code
+		// CreateCPCode creates a new CP code, the ID is parsed from the link in the response body or the Location header
 		//
 		// See: https://techdocs.akamai.com/property-mgr/reference/post-cpcodes
 		CreateCPCode(context.Context, CreateCPCodeRequest) (*CreateCPCodeResponse, error)
@@ -325,6 +325,9 @@ func (p *papi) CreateCPCode(ctx context.Context, r CreateCPCodeRequest) (*Create
 	if resp.StatusCode != http.StatusCreated {
 		return nil, fmt.Errorf("%s: %w", ErrCreateCPCode, p.Error(resp))
 	}
+	if createResponse.CPCodeLink == "" {
+		createResponse.CPCodeLink = resp.Header.Get("Location")
+	}
 	id, err := ResponseLinkParse(createResponse.CPCodeLink)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrCreateCPCode, ErrInvalidResponseLink, err)
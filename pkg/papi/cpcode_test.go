@@ -403,12 +403,13 @@ func TestGetCPCodeDetail(t *testing.T) {
 
 func TestPapi_CreateCPCode(t *testing.T) {
 	tests := map[string]struct {
-		params         CreateCPCodeRequest
-		responseStatus int
-		responseBody   string
-		expectedPath   string
-		expected       *CreateCPCodeResponse
-		withError      func(*testing.T, error)
+		params           CreateCPCodeRequest
+		responseStatus   int
+		responseBody     string
+		responseLocation string
+		expectedPath     string
+		expected         *CreateCPCodeResponse
+		withError        func(*testing.T, error)
 	}{
 		"201 Created": {
 			params: CreateCPCodeRequest{
@@ -430,6 +431,24 @@ func TestPapi_CreateCPCode(t *testing.T) {
 				CPCodeID:   "123",
 			},
 		},
+		"201 Created, link in Location header": {
+			params: CreateCPCodeRequest{
+				ContractID: "contract",
+				GroupID:    "group",
+				CPCode: CreateCPCode{
+					ProductID:  "productID",
+					CPCodeName: "cpcodeName",
+				},
+			},
+			responseStatus:   http.StatusCreated,
+			responseBody:     `{}`,
+			responseLocation: "/papi/v1/cpcodes/123?contractId=contract&groupId=group",
+			expectedPath:     "/papi/v1/cpcodes?contractId=contract&groupId=group",
+			expected: &CreateCPCodeResponse{
+				CPCodeLink: "/papi/v1/cpcodes/123?contractId=contract&groupId=group",
+				CPCodeID:   "123",
+			},
+		},
 		"500 Internal Server Error": {
 			params: CreateCPCodeRequest{
 				ContractID: "contract",
@@ -545,6 +564,9 @@ func TestPapi_CreateCPCode(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPost, r.Method)
+				if test.responseLocation != "" {
+					w.Header().Set("Location", test.responseLocation)
+				}
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
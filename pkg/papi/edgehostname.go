@@ -24,7 +24,7 @@ type (
 		// See: https://techdocs.akamai.com/property-mgr/reference/get-edgehostname
 		GetEdgeHostname(context.Context, GetEdgeHostnameRequest) (*GetEdgeHostnamesResponse, error)
 
-		// CreateEdgeHostname creates a new edge hostname
+		// CreateEdgeHostname creates a new edge hostname, the ID is parsed from the link in the response body or the Location header
 		//
 		// See: https://techdocs.akamai.com/property-mgr/reference/post-edgehostnames
 		CreateEdgeHostname(context.Context, CreateEdgeHostnameRequest) (*CreateEdgeHostnameResponse, error)
@@ -293,6 +293,9 @@ func (p *papi) CreateEdgeHostname(ctx context.Context, r CreateEdgeHostnameReque
 	if resp.StatusCode != http.StatusCreated {
 		return nil, fmt.Errorf("%s: %w", ErrCreateEdgeHostname, p.Error(resp))
 	}
+	if createResponse.EdgeHostnameLink == "" {
+		createResponse.EdgeHostnameLink = resp.Header.Get("Location")
+	}
 	id, err := ResponseLinkParse(createResponse.EdgeHostnameLink)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrCreateEdgeHostname, ErrInvalidResponseLink, err)
@@ -316,6 +316,7 @@ func TestPapi_CreateEdgeHostname(t *testing.T) {
 		params           CreateEdgeHostnameRequest
 		responseStatus   int
 		responseBody     string
+		responseLocation string
 		expectedPath     string
 		expectedResponse *CreateEdgeHostnameResponse
 		withError        func(*testing.T, error)
@@ -349,6 +350,27 @@ func TestPapi_CreateEdgeHostname(t *testing.T) {
 				EdgeHostnameID:   "ehID",
 			},
 		},
+		"201 Created, link in Location header": {
+			params: CreateEdgeHostnameRequest{
+				ContractID: "contract",
+				GroupID:    "group",
+				EdgeHostname: EdgeHostnameCreate{
+					ProductID:         "product",
+					DomainPrefix:      "example.com",
+					DomainSuffix:      "edgesuite.net",
+					SecureNetwork:     EHSecureNetworkStandardTLS,
+					IPVersionBehavior: EHIPVersionV6Compliance,
+				},
+			},
+			responseStatus:   http.StatusCreated,
+			responseBody:     `{}`,
+			responseLocation: "/papi/v1/edgehostnames/ehID?contractId=contract&groupId=group",
+			expectedPath:     "/papi/v1/edgehostnames?contractId=contract&groupId=group",
+			expectedResponse: &CreateEdgeHostnameResponse{
+				EdgeHostnameLink: "/papi/v1/edgehostnames/ehID?contractId=contract&groupId=group",
+				EdgeHostnameID:   "ehID",
+			},
+		},
 		"200 OK - STANDARD_TLS": {
 			params: CreateEdgeHostnameRequest{
 				ContractID: "contract",
@@ -780,6 +802,9 @@ func TestPapi_CreateEdgeHostname(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPost, r.Method)
+				if test.responseLocation != "" {
+					w.Header().Set("Location", test.responseLocation)
+				}
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/errs"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
 )
 
 type (
@@ -25,6 +26,9 @@ type (
 		LimitKey      string          `json:"limitKey,omitempty"`
 		Limit         *int            `json:"limit,omitempty"`
 		Remaining     *int            `json:"remaining,omitempty"`
+		Method        string          `json:"method,omitempty"`
+		URL           string          `json:"url,omitempty"`
+		ContentType   string          `json:"contentType,omitempty"`
 	}
 
 	// ActivationError represents errors returned in validation objects in include activation response
@@ -46,6 +50,10 @@ type (
 	}
 )
 
+// DefaultErrorBodyLimit is the default number of bytes of a response body that is not a valid API error
+// which is kept in the error detail (see WithErrorBodyLimit)
+const DefaultErrorBodyLimit = 512
+
 // Error parses an error from the response
 func (p *papi) Error(r *http.Response) error {
 	var e Error
@@ -63,11 +71,20 @@ func (p *papi) Error(r *http.Response) error {
 
 	if err := json.Unmarshal(body, &e); err != nil {
 		p.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		limit := p.errorBodyLimit
+		if limit <= 0 {
+			limit = DefaultErrorBodyLimit
+		}
 		e.Title = fmt.Sprintf("Failed to unmarshal error body. PAPI API failed. Check details for more information.")
-		e.Detail = errs.UnescapeContent(string(body))
+		e.Detail = errs.Preview(body, limit)
+		e.ContentType = r.Header.Get("Content-Type")
 	}
 
 	e.StatusCode = r.StatusCode
+	if r.Request != nil {
+		e.Method = r.Request.Method
+		e.URL = r.Request.URL.Path
+	}
 
 	return &e
 }
@@ -88,6 +105,11 @@ func (e *ActivationError) Error() string {
 	return fmt.Sprintf("API error: \n%s", msg)
 }
 
+// Category classifies the error by its status and problem type (see session.ClassifyError)
+func (e *Error) Category() session.ErrorCategory {
+	return session.ClassifyError(e.StatusCode, e.Type)
+}
+
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
 	if errors.Is(target, ErrSBDNotEnabled) {
@@ -99,6 +121,9 @@ func (e *Error) Is(target error) bool {
 	if errors.Is(target, ErrNotFound) {
 		return e.isErrNotFound()
 	}
+	if errors.Is(target, ErrPreconditionFailed) {
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
 
 	var t *Error
 	if !errors.As(target, &t) {
@@ -113,7 +138,16 @@ func (e *Error) Is(target error) bool {
 		return false
 	}
 
-	return e.Error() == t.Error()
+	// request details are only compared when the target sets them
+	actual := *e
+	if t.Method == "" && t.URL == "" {
+		actual.Method, actual.URL = "", ""
+	}
+	if t.ContentType == "" {
+		actual.ContentType = ""
+	}
+
+	return actual.Error() == t.Error()
 }
 
 // Is handles error comparisons for ActivationError type
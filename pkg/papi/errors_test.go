@@ -43,6 +43,8 @@ func TestNewError(t *testing.T) {
 				Title:      "b",
 				Detail:     "c",
 				StatusCode: http.StatusInternalServerError,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"invalid response body, assign status code": {
@@ -58,6 +60,8 @@ func TestNewError(t *testing.T) {
 				Title:      "Failed to unmarshal error body. PAPI API failed. Check details for more information.",
 				Detail:     "test",
 				StatusCode: http.StatusInternalServerError,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 	}
@@ -136,6 +140,7 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 	require.NoError(t, err)
 	tests := map[string]struct {
 		input    *http.Response
+		limit    int
 		expected *Error
 	}{
 		"API failure with HTML response": {
@@ -149,6 +154,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				Title:      "Failed to unmarshal error body. PAPI API failed. Check details for more information.",
 				Detail:     "<HTML><HEAD>...</HEAD><BODY>...</BODY></HTML>",
 				StatusCode: http.StatusServiceUnavailable,
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with plain text response": {
@@ -162,6 +169,8 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				StatusCode: http.StatusServiceUnavailable,
 				Title:      "Failed to unmarshal error body. PAPI API failed. Check details for more information.",
 				Detail:     "Your request did not succeed as this operation has reached  the limit for your account. Please try after 2024-01-16T15:20:55.945Z",
+				Method:     http.MethodHead,
+				URL:        "/",
 			},
 		},
 		"API failure with XML response": {
@@ -175,6 +184,25 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 				StatusCode: http.StatusServiceUnavailable,
 				Title:      "Failed to unmarshal error body. PAPI API failed. Check details for more information.",
 				Detail:     "<Root><Item id=\"1\" name=\"Example\" /></Root>",
+				Method:     http.MethodHead,
+				URL:        "/",
+			},
+		},
+		"API failure with HTML gateway page exceeding the body limit": {
+			input: &http.Response{
+				Request:    req,
+				Status:     "OK",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`<html><body>502 Bad Gateway</body></html>`))},
+			limit: 27,
+			expected: &Error{
+				Title:       "Failed to unmarshal error body. PAPI API failed. Check details for more information.",
+				Detail:      "<html><body>502 Bad Gateway...",
+				StatusCode:  http.StatusBadGateway,
+				Method:      http.MethodHead,
+				URL:         "/",
+				ContentType: "text/html; charset=utf-8",
 			},
 		},
 	}
@@ -183,9 +211,48 @@ func TestJsonErrorUnmarshalling(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			sess, _ := session.New()
 			p := papi{
-				Session: sess,
+				Session:        sess,
+				errorBodyLimit: test.limit,
 			}
 			assert.Equal(t, test.expected, p.Error(test.input))
 		})
 	}
 }
+
+func TestErrorCategory(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedCategory session.ErrorCategory
+	}{
+		"403 forbidden": {
+			responseStatus:   http.StatusForbidden,
+			responseBody:     `{"type": "https://problems.luna.akamaiapis.net/papi/v0/unauthorized", "title": "Forbidden", "status": 403}`,
+			expectedCategory: session.ErrorCategoryAuth,
+		},
+		"400 invalid rule tree": {
+			responseStatus:   http.StatusBadRequest,
+			responseBody:     `{"type": "https://problems.luna.akamaiapis.net/papi/v0/json-schema-invalid", "title": "Bad Request", "status": 400}`,
+			expectedCategory: session.ErrorCategoryValidation,
+		},
+		"429 too many requests": {
+			responseStatus:   http.StatusTooManyRequests,
+			responseBody:     `{"type": "https://problems.luna.akamaiapis.net/papi/v0/too-many-requests", "title": "Too many requests", "status": 429}`,
+			expectedCategory: session.ErrorCategoryRateLimit,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sess, _ := session.New()
+			p := papi{
+				Session: sess,
+			}
+			err := fmt.Errorf("%s: %w", ErrGetRuleTree, p.Error(&http.Response{
+				StatusCode: test.responseStatus,
+				Body:       ioutil.NopCloser(strings.NewReader(test.responseBody)),
+			}))
+			assert.Equal(t, test.expectedCategory, session.ErrorCategoryOf(err))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package papi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// setIfMatch makes the request conditional on the etag of the resource, as returned in the etag field of
+// PAPI responses, so that the API rejects it with a 412 Precondition Failed (see ErrPreconditionFailed)
+// when the resource was modified since the etag was read. Empty etags leave the request unconditional.
+func setIfMatch(req *http.Request, etag string) {
+	if etag == "" {
+		return
+	}
+	if !strings.HasPrefix(etag, `"`) && !strings.HasPrefix(etag, `W/"`) {
+		etag = `"` + etag + `"`
+	}
+	req.Header.Set("If-Match", etag)
+}
+
+// responseEtag returns the etag of the ETag header of the response, without quotes
+func responseEtag(resp *http.Response) string {
+	return strings.Trim(strings.TrimPrefix(resp.Header.Get("ETag"), "W/"), `"`)
+}
@@ -5,15 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
 type (
 	// Groups contains operations available on Group resource
 	Groups interface {
 		// GetGroups provides a read-only list of groups, which may contain properties.
+		// Responses are cached by the client for the duration set with WithGroupsCacheTTL.
 		//
 		// See: https://techdocs.akamai.com/property-mgr/reference/get-groups
 		GetGroups(context.Context) (*GetGroupsResponse, error)
+
+		// ResolveGroupContract returns the ID of the group with the given name and of its contract.
+		// It returns ErrGroupNotFound when no group has the name, and ErrAmbiguousGroup when several groups
+		// have the name or the group is associated with several contracts.
+		ResolveGroupContract(ctx context.Context, groupName string) (groupID, contractID string, err error)
 	}
 
 	// Group represents a property group resource
@@ -36,14 +45,99 @@ type (
 		AccountName string     `json:"accountName"`
 		Groups      GroupItems `json:"groups"`
 	}
+
+	// groupsCache holds the last GetGroups response of a client
+	groupsCache struct {
+		mu        sync.Mutex
+		ttl       time.Duration
+		groups    *GetGroupsResponse
+		fetchedAt time.Time
+	}
 )
 
+// DefaultGroupsCacheTTL is the default duration for which GetGroups responses are cached
+const DefaultGroupsCacheTTL = time.Minute
+
 var (
 	// ErrGetGroups represents error when fetching groups fails
 	ErrGetGroups = errors.New("fetching groups")
+	// ErrGroupNotFound is returned when no group has the requested name
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrAmbiguousGroup is returned when a group name does not resolve to a single group and contract
+	ErrAmbiguousGroup = errors.New("ambiguous group")
 )
 
+// WithGroupsCacheTTL sets the duration for which GetGroups responses are cached by the client,
+// DefaultGroupsCacheTTL by default. A zero or negative duration disables the cache.
+func WithGroupsCacheTTL(ttl time.Duration) Option {
+	return func(p *papi) {
+		p.groupsCache.ttl = ttl
+	}
+}
+
 func (p *papi) GetGroups(ctx context.Context) (*GetGroupsResponse, error) {
+	c := p.groupsCache
+	if c.ttl <= 0 {
+		return p.getGroups(ctx)
+	}
+
+	// the lock is held while fetching so that concurrent callers share a single request
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.groups == nil || time.Since(c.fetchedAt) >= c.ttl {
+		groups, err := p.getGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.groups, c.fetchedAt = groups, time.Now()
+	} else {
+		p.Log(ctx).Debug("GetGroups: using cached response")
+	}
+
+	return c.groups.copy(), nil
+}
+
+func (p *papi) ResolveGroupContract(ctx context.Context, groupName string) (string, string, error) {
+	logger := p.Log(ctx)
+	logger.Debug("ResolveGroupContract")
+
+	groups, err := p.GetGroups(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	var matches []*Group
+	for _, group := range groups.Groups.Items {
+		if group.GroupName == groupName {
+			matches = append(matches, group)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return "", "", fmt.Errorf("%w: %q", ErrGroupNotFound, groupName)
+	case len(matches) > 1:
+		ids := make([]string, 0, len(matches))
+		for _, group := range matches {
+			ids = append(ids, group.GroupID)
+		}
+		return "", "", fmt.Errorf("%w: %q matches groups %s", ErrAmbiguousGroup, groupName, strings.Join(ids, ", "))
+	}
+
+	group := matches[0]
+	switch len(group.ContractIDs) {
+	case 0:
+		return "", "", fmt.Errorf("%w: group %q (%s) has no contract", ErrGroupNotFound, groupName, group.GroupID)
+	case 1:
+		return group.GroupID, group.ContractIDs[0], nil
+	default:
+		return "", "", fmt.Errorf("%w: group %q (%s) has contracts %s", ErrAmbiguousGroup, groupName, group.GroupID,
+			strings.Join(group.ContractIDs, ", "))
+	}
+}
+
+func (p *papi) getGroups(ctx context.Context) (*GetGroupsResponse, error) {
 	var groups GetGroupsResponse
 
 	logger := p.Log(ctx)
@@ -65,3 +159,20 @@ func (p *papi) GetGroups(ctx context.Context) (*GetGroupsResponse, error) {
 
 	return &groups, nil
 }
+
+// copy returns a deep copy of the response, so that callers cannot modify the cached one
+func (r *GetGroupsResponse) copy() *GetGroupsResponse {
+	c := *r
+	if r.Groups.Items == nil {
+		return &c
+	}
+	c.Groups.Items = make([]*Group, 0, len(r.Groups.Items))
+	for _, group := range r.Groups.Items {
+		g := *group
+		if group.ContractIDs != nil {
+			g.ContractIDs = append(make([]string, 0, len(group.ContractIDs)), group.ContractIDs...)
+		}
+		c.Groups.Items = append(c.Groups.Items, &g)
+	}
+	return &c
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,3 +92,190 @@ func TestPapi_GetGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_GetGroupsCache(t *testing.T) {
+	const responseBody = `
+{
+	"accountId": "act_1-1TJZFB",
+	"accountName": "Example.com",
+	"groups": {
+		"items": [
+			{
+				"groupName": "Example.com-1-1TJZH5",
+				"groupId": "grp_15225",
+				"contractIds": [
+					"ctr_1-1TJZH5"
+				]
+			}
+		]
+	}
+}`
+	tests := map[string]struct {
+		options          []Option
+		failFirst        bool
+		expectedRequests int32
+	}{
+		"cached by default": {
+			expectedRequests: 1,
+		},
+		"cache disabled": {
+			options:          []Option{WithGroupsCacheTTL(0)},
+			expectedRequests: 5,
+		},
+		"cache expired": {
+			options:          []Option{WithGroupsCacheTTL(time.Nanosecond)},
+			expectedRequests: 5,
+		},
+		"errors are not cached": {
+			failFirst:        true,
+			expectedRequests: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/groups", r.URL.String())
+				if atomic.AddInt32(&requests, 1) == 1 && test.failFirst {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, test.options...)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _ = client.GetGroups(context.Background())
+				}()
+			}
+			wg.Wait()
+
+			result, err := client.GetGroups(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "grp_15225", result.Groups.Items[0].GroupID)
+
+			// modifying a response does not modify the cached one
+			result.Groups.Items[0].ContractIDs[0] = "ctr_modified"
+			result, err = client.GetGroups(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, []string{"ctr_1-1TJZH5"}, result.Groups.Items[0].ContractIDs)
+			assert.Equal(t, test.expectedRequests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestPapi_ResolveGroupContract(t *testing.T) {
+	const responseBody = `
+{
+	"accountId": "act_1-1TJZFB",
+	"accountName": "Example.com",
+	"groups": {
+		"items": [
+			{
+				"groupName": "Example.com-1-1TJZH5",
+				"groupId": "grp_15225",
+				"contractIds": ["ctr_1-1TJZH5"]
+			},
+			{
+				"groupName": "Web",
+				"groupId": "grp_15226",
+				"parentGroupId": "grp_15225",
+				"contractIds": ["ctr_1-1TJZH5", "ctr_2-2ABCDE"]
+			},
+			{
+				"groupName": "Shared",
+				"groupId": "grp_15227",
+				"contractIds": ["ctr_1-1TJZH5"]
+			},
+			{
+				"groupName": "Shared",
+				"groupId": "grp_15228",
+				"contractIds": ["ctr_1-1TJZH5"]
+			},
+			{
+				"groupName": "Empty",
+				"groupId": "grp_15229",
+				"contractIds": []
+			}
+		]
+	}
+}`
+	tests := map[string]struct {
+		groupName          string
+		responseStatus     int
+		expectedGroupID    string
+		expectedContractID string
+		withError          error
+	}{
+		"single group and contract": {
+			groupName:          "Example.com-1-1TJZH5",
+			responseStatus:     http.StatusOK,
+			expectedGroupID:    "grp_15225",
+			expectedContractID: "ctr_1-1TJZH5",
+		},
+		"group not found": {
+			groupName:      "Missing",
+			responseStatus: http.StatusOK,
+			withError:      ErrGroupNotFound,
+		},
+		"group without contract": {
+			groupName:      "Empty",
+			responseStatus: http.StatusOK,
+			withError:      ErrGroupNotFound,
+		},
+		"several groups with the name": {
+			groupName:      "Shared",
+			responseStatus: http.StatusOK,
+			withError:      ErrAmbiguousGroup,
+		},
+		"group with several contracts": {
+			groupName:      "Web",
+			responseStatus: http.StatusOK,
+			withError:      ErrAmbiguousGroup,
+		},
+		"500 internal server error": {
+			groupName:      "Web",
+			responseStatus: http.StatusInternalServerError,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching groups",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/groups", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				body := responseBody
+				if test.responseStatus != http.StatusOK {
+					body = `{"type": "internal_error", "title": "Internal Server Error", "detail": "Error fetching groups", "status": 500}`
+				}
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			groupID, contractID, err := client.ResolveGroupContract(context.Background(), test.groupName)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedGroupID, groupID)
+			assert.Equal(t, test.expectedContractID, contractID)
+		})
+	}
+}
@@ -32,7 +32,8 @@ type (
 		// See: https://techdocs.akamai.com/property-mgr/reference/delete-include-activation
 		CancelIncludeActivation(context.Context, CancelIncludeActivationRequest) (*CancelIncludeActivationResponse, error)
 
-		// GetIncludeActivation gets details about an activation
+		// GetIncludeActivation gets details about an activation, along with the properties using the include
+		// which are re-activated with it, as returned by ListIncludeParents
 		//
 		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-activation
 		GetIncludeActivation(context.Context, GetIncludeActivationRequest) (*GetIncludeActivationResponse, error)
@@ -92,13 +93,17 @@ type (
 	}
 
 	// GetIncludeActivationResponse represents a response object returned by GetIncludeActivation
+	//
+	// DependentProperties lists the properties using the include, which are re-activated along with it,
+	// as returned by ListIncludeParents
 	GetIncludeActivationResponse struct {
-		AccountID   string                `json:"accountId"`
-		ContractID  string                `json:"contractId"`
-		GroupID     string                `json:"groupId"`
-		Activations IncludeActivationsRes `json:"activations"`
-		Validations *Validations          `json:"validations,omitempty"`
-		Activation  IncludeActivation     `json:"-"`
+		AccountID           string                `json:"accountId"`
+		ContractID          string                `json:"contractId"`
+		GroupID             string                `json:"groupId"`
+		Activations         IncludeActivationsRes `json:"activations"`
+		Validations         *Validations          `json:"validations,omitempty"`
+		Activation          IncludeActivation     `json:"-"`
+		DependentProperties []ParentProperty      `json:"-"`
 	}
 
 	// Validations represent include activation validation object
@@ -536,6 +541,16 @@ func (p *papi) GetIncludeActivation(ctx context.Context, params GetIncludeActiva
 	}
 	result.Activation = result.Activations.Items[0]
 
+	parents, err := p.ListIncludeParents(ctx, ListIncludeParentsRequest{
+		ContractID: result.ContractID,
+		GroupID:    result.GroupID,
+		IncludeID:  params.IncludeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetIncludeActivation, err)
+	}
+	result.DependentProperties = parents.Properties.Items
+
 	return &result, nil
 }
 
@@ -6,8 +6,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/tools"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -619,6 +621,8 @@ func TestGetIncludeActivation(t *testing.T) {
 		responseStatus   int
 		responseBody     string
 		expectedPath     string
+		parentsStatus    int
+		parentsBody      string
 		expectedResponse *GetIncludeActivationResponse
 		withError        error
 	}{
@@ -716,6 +720,7 @@ func TestGetIncludeActivation(t *testing.T) {
 					IncludeType:    "MICROSERVICES",
 					IncludeVersion: 4,
 				},
+				DependentProperties: []ParentProperty{},
 			},
 		},
 		"200 Get include activation with includeActivationId": {
@@ -812,6 +817,229 @@ func TestGetIncludeActivation(t *testing.T) {
 					IncludeType:    "MICROSERVICES",
 					IncludeVersion: 4,
 				},
+				DependentProperties: []ParentProperty{},
+			},
+		},
+		"200 Get include activation with validation warnings": {
+			params: GetIncludeActivationRequest{
+				IncludeID:    "inc_12345",
+				ActivationID: "atv_12345",
+			},
+			expectedPath:   "/papi/v1/includes/inc_12345/activations/atv_12345",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "test_account",
+    "contractId": "test_contract",
+    "groupId": "test_group",
+    "validations": {
+        "validationSummary": {
+            "completePercent": 100.0,
+            "hasValidationError": false,
+            "hasValidationWarning": true,
+            "hasSystemError": false,
+            "hasClientError": false,
+            "messageState": "COMPLETE",
+            "errorMessage": ""
+        },
+        "validationProgressItemList": {
+            "errorItemsList": [
+                {
+                    "versionId": 123,
+                    "propertyName": "test_property",
+                    "versionNumber": 2,
+                    "hasValidationError": false,
+                    "hasValidationWarning": true,
+                    "validationResultsLink": "/papi/v1/properties/prp_123/versions/2"
+                }
+            ]
+        },
+        "network": "STAGING"
+    },
+    "activations": {
+        "items": [
+            {
+                "activationId": "atv_12345",
+                "network": "STAGING",
+                "activationType": "ACTIVATE",
+                "status": "ACTIVE",
+                "includeId": "inc_12345",
+                "includeName": "tfp_test1",
+                "includeType": "MICROSERVICES",
+                "includeVersion": 4
+            }
+        ]
+    }
+}`,
+			expectedResponse: &GetIncludeActivationResponse{
+				AccountID:  "test_account",
+				ContractID: "test_contract",
+				GroupID:    "test_group",
+				Validations: &Validations{
+					ValidationSummary: ValidationSummary{
+						CompletePercent:      100,
+						HasValidationWarning: true,
+						MessageState:         "COMPLETE",
+					},
+					ValidationProgressItemList: ValidationProgress{
+						ErrorItems: []ErrorItem{
+							{
+								VersionID:             123,
+								PropertyName:          "test_property",
+								VersionNumber:         2,
+								HasValidationWarning:  true,
+								ValidationResultsLink: "/papi/v1/properties/prp_123/versions/2",
+							},
+						},
+					},
+					Network: ActivationNetworkStaging,
+				},
+				Activations: IncludeActivationsRes{
+					Items: []IncludeActivation{
+						{
+							ActivationID:   "atv_12345",
+							Network:        ActivationNetworkStaging,
+							ActivationType: ActivationTypeActivate,
+							Status:         ActivationStatusActive,
+							IncludeID:      "inc_12345",
+							IncludeName:    "tfp_test1",
+							IncludeType:    IncludeTypeMicroServices,
+							IncludeVersion: 4,
+						},
+					},
+				},
+				Activation: IncludeActivation{
+					ActivationID:   "atv_12345",
+					Network:        ActivationNetworkStaging,
+					ActivationType: ActivationTypeActivate,
+					Status:         ActivationStatusActive,
+					IncludeID:      "inc_12345",
+					IncludeName:    "tfp_test1",
+					IncludeType:    IncludeTypeMicroServices,
+					IncludeVersion: 4,
+				},
+				DependentProperties: []ParentProperty{},
+			},
+		},
+		"200 Get include activation with dependent properties": {
+			params: GetIncludeActivationRequest{
+				IncludeID:    "inc_12345",
+				ActivationID: "atv_12345",
+			},
+			expectedPath:   "/papi/v1/includes/inc_12345/activations/atv_12345",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "test_account",
+    "contractId": "test_contract",
+    "groupId": "test_group",
+    "activations": {
+        "items": [
+            {
+                "activationId": "atv_12345",
+                "network": "PRODUCTION",
+                "activationType": "ACTIVATE",
+                "status": "PENDING",
+                "includeId": "inc_12345",
+                "includeName": "tfp_test1",
+                "includeType": "MICROSERVICES",
+                "includeVersion": 4
+            }
+        ]
+    }
+}`,
+			parentsStatus: http.StatusOK,
+			parentsBody: `
+{
+    "properties": {
+        "items": [
+            {
+                "accountId": "test_account",
+                "assetId": "aid_123",
+                "contractId": "test_contract",
+                "groupId": "test_group",
+                "productionVersion": 2,
+                "propertyId": "prp_123",
+                "propertyName": "test_property"
+            }
+        ]
+    }
+}`,
+			expectedResponse: &GetIncludeActivationResponse{
+				AccountID:  "test_account",
+				ContractID: "test_contract",
+				GroupID:    "test_group",
+				Activations: IncludeActivationsRes{
+					Items: []IncludeActivation{
+						{
+							ActivationID:   "atv_12345",
+							Network:        ActivationNetworkProduction,
+							ActivationType: ActivationTypeActivate,
+							Status:         ActivationStatusPending,
+							IncludeID:      "inc_12345",
+							IncludeName:    "tfp_test1",
+							IncludeType:    IncludeTypeMicroServices,
+							IncludeVersion: 4,
+						},
+					},
+				},
+				Activation: IncludeActivation{
+					ActivationID:   "atv_12345",
+					Network:        ActivationNetworkProduction,
+					ActivationType: ActivationTypeActivate,
+					Status:         ActivationStatusPending,
+					IncludeID:      "inc_12345",
+					IncludeName:    "tfp_test1",
+					IncludeType:    IncludeTypeMicroServices,
+					IncludeVersion: 4,
+				},
+				DependentProperties: []ParentProperty{
+					{
+						AccountID:         "test_account",
+						AssetID:           "aid_123",
+						ContractID:        "test_contract",
+						GroupID:           "test_group",
+						ProductionVersion: tools.IntPtr(2),
+						PropertyID:        "prp_123",
+						PropertyName:      "test_property",
+					},
+				},
+			},
+		},
+		"500 listing dependent properties": {
+			params: GetIncludeActivationRequest{
+				IncludeID:    "inc_12345",
+				ActivationID: "atv_12345",
+			},
+			expectedPath:   "/papi/v1/includes/inc_12345/activations/atv_12345",
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "test_account",
+    "contractId": "test_contract",
+    "groupId": "test_group",
+    "activations": {
+        "items": [
+            {
+                "activationId": "atv_12345",
+                "includeId": "inc_12345"
+            }
+        ]
+    }
+}`,
+			parentsStatus: http.StatusInternalServerError,
+			parentsBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error listing include parents",
+    "status": 500
+}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error listing include parents",
+				StatusCode: http.StatusInternalServerError,
 			},
 		},
 		"200 but with activation validation error - ErrMissingComplianceRecord expected": {
@@ -884,8 +1112,19 @@ func TestGetIncludeActivation(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodGet, r.Method)
+				if strings.HasSuffix(r.URL.Path, "/parents") {
+					assert.Equal(t, "/papi/v1/includes/inc_12345/parents?contractId=test_contract&groupId=test_group", r.URL.String())
+					status, body := test.parentsStatus, test.parentsBody
+					if body == "" {
+						status, body = http.StatusOK, `{"properties": {"items": []}}`
+					}
+					w.WriteHeader(status)
+					_, err := w.Write([]byte(body))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, test.expectedPath, r.URL.String())
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
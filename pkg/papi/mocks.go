@@ -4,6 +4,7 @@ package papi
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -53,6 +54,12 @@ func (p *Mock) GetGroups(ctx context.Context) (*GetGroupsResponse, error) {
 	return args.Get(0).(*GetGroupsResponse), args.Error(1)
 }
 
+func (p *Mock) ResolveGroupContract(ctx context.Context, groupName string) (string, string, error) {
+	args := p.Called(ctx, groupName)
+
+	return args.String(0), args.String(1), args.Error(2)
+}
+
 func (p *Mock) GetContracts(ctx context.Context) (*GetContractsResponse, error) {
 	args := p.Called(ctx)
 
@@ -159,6 +166,16 @@ func (p *Mock) GetProperties(ctx context.Context, r GetPropertiesRequest) (*GetP
 	return args.Get(0).(*GetPropertiesResponse), args.Error(1)
 }
 
+func (p *Mock) GetAllProperties(ctx context.Context, r GetPropertiesRequest) (*GetPropertiesResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetPropertiesResponse), args.Error(1)
+}
+
 func (p *Mock) CreateProperty(ctx context.Context, r CreatePropertyRequest) (*CreatePropertyResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -169,6 +186,16 @@ func (p *Mock) CreateProperty(ctx context.Context, r CreatePropertyRequest) (*Cr
 	return args.Get(0).(*CreatePropertyResponse), args.Error(1)
 }
 
+func (p *Mock) CloneProperty(ctx context.Context, r ClonePropertyRequest) (*CreatePropertyResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreatePropertyResponse), args.Error(1)
+}
+
 func (p *Mock) GetProperty(ctx context.Context, r GetPropertyRequest) (*GetPropertyResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -289,6 +316,36 @@ func (p *Mock) GetProducts(ctx context.Context, r GetProductsRequest) (*GetProdu
 	return args.Get(0).(*GetProductsResponse), args.Error(1)
 }
 
+func (p *Mock) BulkSearchProperties(ctx context.Context, r BulkSearchRequest) (*BulkSearchResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkSearchResponse), args.Error(1)
+}
+
+func (p *Mock) GetBulkSearch(ctx context.Context, r GetBulkSearchRequest) (*BulkSearchResults, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkSearchResults), args.Error(1)
+}
+
+func (p *Mock) WaitForBulkSearch(ctx context.Context, r GetBulkSearchRequest, pollInterval time.Duration) (*BulkSearchResults, error) {
+	args := p.Called(ctx, r, pollInterval)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BulkSearchResults), args.Error(1)
+}
+
 func (p *Mock) SearchProperties(ctx context.Context, r SearchRequest) (*SearchResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -355,6 +412,26 @@ func (p *Mock) UpdateRuleTree(ctx context.Context, r UpdateRulesRequest) (*Updat
 	return args.Get(0).(*UpdateRulesResponse), args.Error(1)
 }
 
+func (p *Mock) ValidateRuleTree(ctx context.Context, r ValidateRuleTreeRequest) (*ValidateRuleTreeResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ValidateRuleTreeResponse), args.Error(1)
+}
+
+func (p *Mock) PatchRuleTree(ctx context.Context, r PatchRuleTreeRequest) (*UpdateRulesResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*UpdateRulesResponse), args.Error(1)
+}
+
 func (p *Mock) GetRuleFormats(ctx context.Context) (*GetRuleFormatsResponse, error) {
 	args := p.Called(ctx)
 
@@ -24,12 +24,17 @@ var (
 
 	// ErrMissingComplianceRecord is returned when compliance record is required and is not provided
 	ErrMissingComplianceRecord = errors.New("compliance record must be specified")
+
+	// ErrPreconditionFailed is returned when a request made with an etag is rejected because the resource,
+	// e.g. the rule tree of a property version, was modified since the etag was read
+	ErrPreconditionFailed = errors.New("precondition failed, the resource was modified since its etag was read")
 )
 
 type (
 	// PAPI is the papi api interface
 	PAPI interface {
 		Activations
+		BulkSearch
 		ClientSettings
 		Contracts
 		CPCodes
@@ -51,7 +56,9 @@ type (
 
 	papi struct {
 		session.Session
-		usePrefixes bool
+		usePrefixes    bool
+		groupsCache    *groupsCache
+		errorBodyLimit int
 	}
 
 	// Option defines a PAPI option
@@ -76,6 +83,7 @@ func Client(sess session.Session, opts ...Option) PAPI {
 	p := &papi{
 		Session:     sess,
 		usePrefixes: true,
+		groupsCache: &groupsCache{ttl: DefaultGroupsCacheTTL},
 	}
 
 	for _, opt := range opts {
@@ -92,6 +100,14 @@ func WithUsePrefixes(usePrefixes bool) Option {
 	}
 }
 
+// WithErrorBodyLimit sets the number of bytes of a response body which is not a valid API error,
+// e.g. an HTML page returned by a proxy, that is kept in the error detail. Defaults to DefaultErrorBodyLimit.
+func WithErrorBodyLimit(limit int) Option {
+	return func(p *papi) {
+		p.errorBodyLimit = limit
+	}
+}
+
 // Exec overrides the session.Exec to add papi options
 func (p *papi) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	// explicitly add the PAPI-Use-Prefixes header
@@ -14,7 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func mockAPIClient(t *testing.T, mockServer *httptest.Server) PAPI {
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) PAPI {
 	serverURL, err := url.Parse(mockServer.URL)
 	require.NoError(t, err)
 	certPool := x509.NewCertPool()
@@ -28,7 +28,7 @@ func mockAPIClient(t *testing.T, mockServer *httptest.Server) PAPI {
 	}
 	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
 	assert.NoError(t, err)
-	return Client(s)
+	return Client(s, opts...)
 }
 
 func TestClient(t *testing.T) {
@@ -43,6 +43,7 @@ func TestClient(t *testing.T) {
 			expected: &papi{
 				Session:     sess,
 				usePrefixes: true,
+				groupsCache: &groupsCache{ttl: DefaultGroupsCacheTTL},
 			},
 		},
 		"papi prefixes set to false": {
@@ -50,6 +51,24 @@ func TestClient(t *testing.T) {
 			expected: &papi{
 				Session:     sess,
 				usePrefixes: false,
+				groupsCache: &groupsCache{ttl: DefaultGroupsCacheTTL},
+			},
+		},
+		"groups cache disabled": {
+			options: []Option{WithGroupsCacheTTL(0)},
+			expected: &papi{
+				Session:     sess,
+				usePrefixes: true,
+				groupsCache: &groupsCache{},
+			},
+		},
+		"error body limit option": {
+			options: []Option{WithErrorBodyLimit(1024)},
+			expected: &papi{
+				Session:        sess,
+				usePrefixes:    true,
+				groupsCache:    &groupsCache{ttl: DefaultGroupsCacheTTL},
+				errorBodyLimit: 1024,
 			},
 		},
 	}
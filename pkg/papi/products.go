@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -49,6 +50,17 @@ func (pr GetProductsRequest) Validate() error {
 	}.Filter()
 }
 
+// FindByName returns the product with the given name, compared case-insensitively, e.g. to resolve a product name
+// to the product ID required to create properties and edge hostnames
+func (p ProductsItems) FindByName(name string) (*ProductItem, bool) {
+	for i := range p.Items {
+		if strings.EqualFold(p.Items[i].ProductName, name) {
+			return &p.Items[i], true
+		}
+	}
+	return nil, false
+}
+
 var (
 	// ErrGetProducts represents error when fetching products fails
 	ErrGetProducts = errors.New("fetching products")
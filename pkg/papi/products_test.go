@@ -107,3 +107,35 @@ func TestPapi_GetProducts(t *testing.T) {
 		})
 	}
 }
+
+func TestProductsItems_FindByName(t *testing.T) {
+	products := ProductsItems{Items: []ProductItem{
+		{ProductName: "Ion Standard", ProductID: "prd_Fresca"},
+		{ProductName: "Download Delivery", ProductID: "prd_Download_Delivery"},
+	}}
+
+	tests := map[string]struct {
+		name            string
+		expectedProduct *ProductItem
+	}{
+		"exact name": {
+			name:            "Download Delivery",
+			expectedProduct: &ProductItem{ProductName: "Download Delivery", ProductID: "prd_Download_Delivery"},
+		},
+		"different case": {
+			name:            "ion standard",
+			expectedProduct: &ProductItem{ProductName: "Ion Standard", ProductID: "prd_Fresca"},
+		},
+		"not found": {
+			name: "Ion Premier",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			product, ok := products.FindByName(test.name)
+			assert.Equal(t, test.expectedProduct != nil, ok)
+			assert.Equal(t, test.expectedProduct, product)
+		})
+	}
+}
@@ -19,11 +19,25 @@ type (
 		// https://techdocs.akamai.com/property-mgr/reference/get-properties
 		GetProperties(ctx context.Context, r GetPropertiesRequest) (*GetPropertiesResponse, error)
 
+		// GetAllProperties lists all properties of the contract and group, following the pages of the response
+		// from the given offset when the listing is paginated
+		//
+		// https://techdocs.akamai.com/property-mgr/reference/get-properties
+		GetAllProperties(ctx context.Context, r GetPropertiesRequest) (*GetPropertiesResponse, error)
+
 		// CreateProperty creates a new property from scratch or bases one on another property's rule tree and optionally its set of assigned hostnames
 		//
 		// https://techdocs.akamai.com/property-mgr/reference/post-properties
 		CreateProperty(ctx context.Context, params CreatePropertyRequest) (*CreatePropertyResponse, error)
 
+		// CloneProperty creates a new property in the target contract and group from a version of a source property,
+		// e.g. to promote a configuration from one environment to another, and carries over the product and rule format
+		// of the source version unless they are overridden. The clone fails if the source version changed since it was
+		// read, or since the given CloneFromVersionEtag was read.
+		//
+		// https://techdocs.akamai.com/property-mgr/reference/post-properties
+		CloneProperty(ctx context.Context, params ClonePropertyRequest) (*CreatePropertyResponse, error)
+
 		// GetProperty gets a specific property
 		//
 		// https://techdocs.akamai.com/property-mgr/reference/get-property
@@ -59,15 +73,21 @@ type (
 		StagingVersion    *int   `json:"stagingVersion,omitempty"`
 	}
 
-	// PropertiesItems is an array of properties
+	// PropertiesItems is an array of properties, with the links to the adjacent pages of a paginated listing
 	PropertiesItems struct {
-		Items []*Property `json:"items"`
+		Items        []*Property `json:"items"`
+		NextLink     string      `json:"nextLink,omitempty"`
+		PreviousLink string      `json:"previousLink,omitempty"`
+		TotalItems   int         `json:"totalItems,omitempty"`
 	}
 
 	// GetPropertiesRequest is the argument for GetProperties
 	GetPropertiesRequest struct {
 		ContractID string
 		GroupID    string
+		// Offset and Limit select a page of the listing, all properties are listed when unset
+		Offset int
+		Limit  int
 	}
 
 	// GetPropertiesResponse is the response for GetProperties
@@ -97,6 +117,28 @@ type (
 		PropertyLink string `json:"propertyLink"`
 	}
 
+	// ClonePropertyRequest is passed to CloneProperty
+	ClonePropertyRequest struct {
+		// ContractID and GroupID identify where the new property is created
+		ContractID   string
+		GroupID      string
+		PropertyName string
+		// SourcePropertyID and SourceVersion identify the property version to clone
+		SourcePropertyID string
+		SourceVersion    int
+		// SourceContractID and SourceGroupID identify the contract and group of the source property, if different
+		// from the target ones
+		SourceContractID string
+		SourceGroupID    string
+		// CloneFromVersionEtag is the etag of the source version the clone must be made from,
+		// the etag of the source version read by CloneProperty when empty
+		CloneFromVersionEtag string
+		CopyHostnames        bool
+		// ProductID and RuleFormat override the product and rule format of the source version
+		ProductID  string
+		RuleFormat string
+	}
+
 	// GetPropertyRequest is the argument for GetProperty
 	GetPropertyRequest struct {
 		ContractID string
@@ -129,6 +171,8 @@ func (v GetPropertiesRequest) Validate() error {
 	return validation.Errors{
 		"ContractID": validation.Validate(v.ContractID, validation.Required),
 		"GroupID":    validation.Validate(v.GroupID, validation.Required),
+		"Offset":     validation.Validate(v.Offset, validation.Min(0)),
+		"Limit":      validation.Validate(v.Limit, validation.Min(0)),
 	}.Filter()
 }
 
@@ -159,6 +203,17 @@ func (c PropertyCloneFrom) Validate() error {
 	}.Filter()
 }
 
+// Validate validates ClonePropertyRequest
+func (v ClonePropertyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"ContractID":       validation.Validate(v.ContractID, validation.Required),
+		"GroupID":          validation.Validate(v.GroupID, validation.Required),
+		"PropertyName":     validation.Validate(v.PropertyName, validation.Required),
+		"SourcePropertyID": validation.Validate(v.SourcePropertyID, validation.Required),
+		"SourceVersion":    validation.Validate(v.SourceVersion, validation.Required, validation.Min(1)),
+	})
+}
+
 // Validate validates GetPropertyRequest
 func (v GetPropertyRequest) Validate() error {
 	return validation.Errors{
@@ -180,6 +235,8 @@ var (
 	ErrGetProperty = errors.New("fetching property")
 	// ErrCreateProperty represents error when creating property fails
 	ErrCreateProperty = errors.New("creating property")
+	// ErrCloneProperty represents error when cloning property fails
+	ErrCloneProperty = errors.New("cloning property")
 	// ErrRemoveProperty represents error when removing property fails
 	ErrRemoveProperty = errors.New("removing property")
 )
@@ -198,6 +255,12 @@ func (p *papi) GetProperties(ctx context.Context, params GetPropertiesRequest) (
 		"/papi/v1/properties?contractId=%s&groupId=%s",
 		params.ContractID,
 		params.GroupID)
+	if params.Offset != 0 {
+		uri += fmt.Sprintf("&offset=%d", params.Offset)
+	}
+	if params.Limit != 0 {
+		uri += fmt.Sprintf("&limit=%d", params.Limit)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
@@ -216,6 +279,27 @@ func (p *papi) GetProperties(ctx context.Context, params GetPropertiesRequest) (
 	return &rval, nil
 }
 
+func (p *papi) GetAllProperties(ctx context.Context, params GetPropertiesRequest) (*GetPropertiesResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetAllProperties")
+
+	var rval GetPropertiesResponse
+	for {
+		page, err := p.GetProperties(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		rval.Properties.Items = append(rval.Properties.Items, page.Properties.Items...)
+		rval.Properties.TotalItems = page.Properties.TotalItems
+		if page.Properties.NextLink == "" || len(page.Properties.Items) == 0 {
+			break
+		}
+		params.Offset += len(page.Properties.Items)
+	}
+
+	return &rval, nil
+}
+
 func (p *papi) CreateProperty(ctx context.Context, params CreatePropertyRequest) (*CreatePropertyResponse, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreateProperty, ErrStructValidation, err)
@@ -259,6 +343,64 @@ func (p *papi) CreateProperty(ctx context.Context, params CreatePropertyRequest)
 	return &rval, nil
 }
 
+func (p *papi) CloneProperty(ctx context.Context, params ClonePropertyRequest) (*CreatePropertyResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrCloneProperty, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("CloneProperty")
+
+	sourceContractID, sourceGroupID := params.SourceContractID, params.SourceGroupID
+	if sourceContractID == "" {
+		sourceContractID = params.ContractID
+	}
+	if sourceGroupID == "" {
+		sourceGroupID = params.GroupID
+	}
+	source, err := p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+		PropertyID:      params.SourcePropertyID,
+		PropertyVersion: params.SourceVersion,
+		ContractID:      sourceContractID,
+		GroupID:         sourceGroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+	}
+
+	property := PropertyCreate{
+		CloneFrom: &PropertyCloneFrom{
+			CloneFromVersionEtag: params.CloneFromVersionEtag,
+			CopyHostnames:        params.CopyHostnames,
+			PropertyID:           params.SourcePropertyID,
+			Version:              params.SourceVersion,
+		},
+		ProductID:    params.ProductID,
+		PropertyName: params.PropertyName,
+		RuleFormat:   params.RuleFormat,
+	}
+	if property.CloneFrom.CloneFromVersionEtag == "" {
+		property.CloneFrom.CloneFromVersionEtag = source.Version.Etag
+	}
+	if property.ProductID == "" {
+		property.ProductID = source.Version.ProductID
+	}
+	if property.RuleFormat == "" {
+		property.RuleFormat = source.Version.RuleFormat
+	}
+
+	created, err := p.CreateProperty(ctx, CreatePropertyRequest{
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		Property:   property,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+	}
+
+	return created, nil
+}
+
 func (p *papi) GetProperty(ctx context.Context, params GetPropertyRequest) (*GetPropertyResponse, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetProperty, ErrStructValidation, err)
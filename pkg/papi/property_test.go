@@ -3,8 +3,11 @@ package papi
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/tools"
@@ -66,6 +69,47 @@ func TestPapi_GetProperties(t *testing.T) {
 				}},
 			},
 		},
+		"200 OK with page": {
+			request: GetPropertiesRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Offset:     1,
+				Limit:      1,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"properties": {
+		"items": [
+			{
+				"propertyId": "prp_175781",
+				"propertyName": "example.net",
+				"latestVersion": 3,
+				"productionVersion": 3
+			}
+		],
+		"nextLink": "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166&offset=2&limit=1",
+		"previousLink": "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166&offset=0&limit=1",
+		"totalItems": 3
+	}
+}`,
+			expectedPath: "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=1&offset=1",
+			expectedResponse: &GetPropertiesResponse{
+				Properties: PropertiesItems{
+					Items: []*Property{
+						{
+							PropertyID:        "prp_175781",
+							PropertyName:      "example.net",
+							LatestVersion:     3,
+							ProductionVersion: tools.IntPtr(3),
+						},
+					},
+					NextLink:     "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166&offset=2&limit=1",
+					PreviousLink: "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166&offset=0&limit=1",
+					TotalItems:   3,
+				},
+			},
+		},
 		"500 internal server error": {
 			request: GetPropertiesRequest{
 				ContractID: "ctr_1-1TJZFW",
@@ -94,6 +138,14 @@ func TestPapi_GetProperties(t *testing.T) {
 			responseStatus: http.StatusInternalServerError,
 			withError:      ErrStructValidation,
 		},
+		"negative offset": {
+			request: GetPropertiesRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Offset:     -1,
+			},
+			withError: ErrStructValidation,
+		},
 	}
 
 	for name, test := range tests {
@@ -117,6 +169,103 @@ func TestPapi_GetProperties(t *testing.T) {
 	}
 }
 
+func TestPapi_GetAllProperties(t *testing.T) {
+	page := func(ids []string, nextLink string) string {
+		items := make([]string, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, fmt.Sprintf(`{"propertyId": %q, "propertyName": "%s.example.com", "latestVersion": 1}`, id, id))
+		}
+		return fmt.Sprintf(`{"properties": {"items": [%s], "nextLink": %q, "totalItems": 3}}`, strings.Join(items, ","), nextLink)
+	}
+	property := func(id string) *Property {
+		return &Property{PropertyID: id, PropertyName: id + ".example.com", LatestVersion: 1}
+	}
+
+	tests := map[string]struct {
+		request          GetPropertiesRequest
+		responses        map[string]string
+		responseStatus   int
+		expectedPaths    []string
+		expectedResponse *GetPropertiesResponse
+		withError        error
+	}{
+		"single page": {
+			request: GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_1"},
+			responses: map[string]string{
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1": page([]string{"prp_1", "prp_2", "prp_3"}, ""),
+			},
+			expectedPaths: []string{"/papi/v1/properties?contractId=ctr_1&groupId=grp_1"},
+			expectedResponse: &GetPropertiesResponse{Properties: PropertiesItems{
+				Items:      []*Property{property("prp_1"), property("prp_2"), property("prp_3")},
+				TotalItems: 3,
+			}},
+		},
+		"multiple pages": {
+			request: GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_1", Limit: 2},
+			responses: map[string]string{
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2":          page([]string{"prp_1", "prp_2"}, "/papi/v1/properties?offset=2"),
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2&offset=2": page([]string{"prp_3"}, ""),
+			},
+			expectedPaths: []string{
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2",
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2&offset=2",
+			},
+			expectedResponse: &GetPropertiesResponse{Properties: PropertiesItems{
+				Items:      []*Property{property("prp_1"), property("prp_2"), property("prp_3")},
+				TotalItems: 3,
+			}},
+		},
+		"error on second page": {
+			request: GetPropertiesRequest{ContractID: "ctr_1", GroupID: "grp_1", Limit: 2},
+			responses: map[string]string{
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2": page([]string{"prp_1", "prp_2"}, "/papi/v1/properties?offset=2"),
+			},
+			expectedPaths: []string{
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2",
+				"/papi/v1/properties?contractId=ctr_1&groupId=grp_1&limit=2&offset=2",
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			request:   GetPropertiesRequest{GroupID: "grp_1"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				paths = append(paths, r.URL.String())
+				body, ok := test.responses[r.URL.String()]
+				if !ok {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetAllProperties(context.Background(), test.request)
+			assert.Equal(t, test.expectedPaths, paths)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
 func TestPapi_GetProperty(t *testing.T) {
 	tests := map[string]struct {
 		request          GetPropertyRequest
@@ -345,6 +494,157 @@ func TestPapi_CreateProperty(t *testing.T) {
 	}
 }
 
+func TestPapi_CloneProperty(t *testing.T) {
+	sourceVersion := `
+{
+	"propertyId": "prp_1234",
+	"propertyName": "www.example.com",
+	"contractId": "ctr_1-PROD",
+	"groupId": "grp_PROD",
+	"versions": {
+		"items": [
+			{
+				"propertyVersion": 3,
+				"etag": "4607f363da8bc05b0c0f0f75249",
+				"productId": "prd_Fresca",
+				"ruleFormat": "v2023-01-05",
+				"productionStatus": "ACTIVE",
+				"stagingStatus": "ACTIVE"
+			}
+		]
+	}
+}`
+
+	tests := map[string]struct {
+		request             ClonePropertyRequest
+		versionStatus       int
+		versionBody         string
+		createStatus        int
+		createBody          string
+		expectedVersionPath string
+		expectedRequestBody string
+		expectedResponse    *CreatePropertyResponse
+		withError           error
+	}{
+		"201 created from a property of another contract and group": {
+			request: ClonePropertyRequest{
+				ContractID:       "ctr_1-TEST",
+				GroupID:          "grp_TEST",
+				PropertyName:     "test.example.com",
+				SourcePropertyID: "prp_1234",
+				SourceVersion:    3,
+				SourceContractID: "ctr_1-PROD",
+				SourceGroupID:    "grp_PROD",
+			},
+			versionStatus:       http.StatusOK,
+			versionBody:         sourceVersion,
+			createStatus:        http.StatusCreated,
+			createBody:          `{"propertyLink": "/papi/v1/properties/prp_5678?contractId=ctr_1-TEST&groupId=grp_TEST"}`,
+			expectedVersionPath: "/papi/v1/properties/prp_1234/versions/3?contractId=ctr_1-PROD&groupId=grp_PROD",
+			expectedRequestBody: `{"cloneFrom":{"cloneFromVersionEtag":"4607f363da8bc05b0c0f0f75249","propertyId":"prp_1234","version":3},"productId":"prd_Fresca","propertyName":"test.example.com","ruleFormat":"v2023-01-05"}`,
+			expectedResponse: &CreatePropertyResponse{
+				PropertyID:   "prp_5678",
+				PropertyLink: "/papi/v1/properties/prp_5678?contractId=ctr_1-TEST&groupId=grp_TEST",
+			},
+		},
+		"201 created with overrides": {
+			request: ClonePropertyRequest{
+				ContractID:           "ctr_1-TEST",
+				GroupID:              "grp_TEST",
+				PropertyName:         "test.example.com",
+				SourcePropertyID:     "prp_1234",
+				SourceVersion:        3,
+				CloneFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+				CopyHostnames:        true,
+				ProductID:            "prd_Site_Accel",
+				RuleFormat:           "latest",
+			},
+			versionStatus:       http.StatusOK,
+			versionBody:         sourceVersion,
+			createStatus:        http.StatusCreated,
+			createBody:          `{"propertyLink": "/papi/v1/properties/prp_5678?contractId=ctr_1-TEST&groupId=grp_TEST"}`,
+			expectedVersionPath: "/papi/v1/properties/prp_1234/versions/3?contractId=ctr_1-TEST&groupId=grp_TEST",
+			expectedRequestBody: `{"cloneFrom":{"cloneFromVersionEtag":"a9dfe78cf93090516bde891d009eaf57","copyHostnames":true,"propertyId":"prp_1234","version":3},"productId":"prd_Site_Accel","propertyName":"test.example.com","ruleFormat":"latest"}`,
+			expectedResponse: &CreatePropertyResponse{
+				PropertyID:   "prp_5678",
+				PropertyLink: "/papi/v1/properties/prp_5678?contractId=ctr_1-TEST&groupId=grp_TEST",
+			},
+		},
+		"source version changed": {
+			request: ClonePropertyRequest{
+				ContractID:           "ctr_1-TEST",
+				GroupID:              "grp_TEST",
+				PropertyName:         "test.example.com",
+				SourcePropertyID:     "prp_1234",
+				SourceVersion:        3,
+				CloneFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+			},
+			versionStatus:       http.StatusOK,
+			versionBody:         sourceVersion,
+			createStatus:        http.StatusPreconditionFailed,
+			createBody:          `{"type": "https://problems.luna.akamaiapis.net/papi/v0/property-version-etag-mismatch", "title": "Precondition Failed", "status": 412}`,
+			expectedVersionPath: "/papi/v1/properties/prp_1234/versions/3?contractId=ctr_1-TEST&groupId=grp_TEST",
+			expectedRequestBody: `{"cloneFrom":{"cloneFromVersionEtag":"a9dfe78cf93090516bde891d009eaf57","propertyId":"prp_1234","version":3},"productId":"prd_Fresca","propertyName":"test.example.com","ruleFormat":"v2023-01-05"}`,
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/papi/v0/property-version-etag-mismatch",
+				Title:      "Precondition Failed",
+				StatusCode: http.StatusPreconditionFailed,
+			},
+		},
+		"source version not found": {
+			request: ClonePropertyRequest{
+				ContractID:       "ctr_1-TEST",
+				GroupID:          "grp_TEST",
+				PropertyName:     "test.example.com",
+				SourcePropertyID: "prp_1234",
+				SourceVersion:    3,
+			},
+			versionStatus:       http.StatusOK,
+			versionBody:         `{"propertyId": "prp_1234", "versions": {"items": []}}`,
+			expectedVersionPath: "/papi/v1/properties/prp_1234/versions/3?contractId=ctr_1-TEST&groupId=grp_TEST",
+			withError:           ErrNotFound,
+		},
+		"validation error": {
+			request: ClonePropertyRequest{
+				ContractID:       "ctr_1-TEST",
+				GroupID:          "grp_TEST",
+				SourcePropertyID: "prp_1234",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					assert.Equal(t, test.expectedVersionPath, r.URL.String())
+					w.WriteHeader(test.versionStatus)
+					_, err := w.Write([]byte(test.versionBody))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/papi/v1/properties?contractId=ctr_1-TEST&groupId=grp_TEST", r.URL.String())
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.createStatus)
+				_, err = w.Write([]byte(test.createBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CloneProperty(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
 func TestPapi_RemoveProperty(t *testing.T) {
 	tests := map[string]struct {
 		request          RemovePropertyRequest
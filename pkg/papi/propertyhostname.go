@@ -17,7 +17,9 @@ type (
 		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-version-hostnames
 		GetPropertyVersionHostnames(context.Context, GetPropertyVersionHostnamesRequest) (*GetPropertyVersionHostnamesResponse, error)
 
-		// UpdatePropertyVersionHostnames modifies the set of hostnames for a property version
+		// UpdatePropertyVersionHostnames modifies the set of hostnames for a property version.
+		// When Etag is set, e.g. to the Etag returned by GetPropertyVersionHostnames, the update fails with
+		// ErrPreconditionFailed if the hostnames were modified since, instead of overwriting the changes.
 		//
 		// See: https://techdocs.akamai.com/property-mgr/reference/patch-property-version-hostnames
 		UpdatePropertyVersionHostnames(context.Context, UpdatePropertyVersionHostnamesRequest) (*UpdatePropertyVersionHostnamesResponse, error)
@@ -42,6 +44,8 @@ type (
 		PropertyVersion int                   `json:"propertyVersion"`
 		Etag            string                `json:"etag"`
 		Hostnames       HostnameResponseItems `json:"hostnames"`
+		Errors          []*Error              `json:"errors,omitempty"`
+		Warnings        []*Error              `json:"warnings,omitempty"`
 	}
 
 	// HostnameResponseItems contains the response body for GetPropertyVersionHostnamesResponse
@@ -86,6 +90,8 @@ type (
 		ValidateHostnames bool
 		IncludeCertStatus bool
 		Hostnames         []Hostname
+		// Etag is the etag of the hostnames the update is based on, sent as If-Match
+		Etag string
 	}
 
 	// UpdatePropertyVersionHostnamesResponse contains information about each of the HostnameRequestItems
@@ -97,6 +103,8 @@ type (
 		PropertyVersion int                   `json:"propertyVersion"`
 		Etag            string                `json:"etag"`
 		Hostnames       HostnameResponseItems `json:"hostnames"`
+		Errors          []*Error              `json:"errors,omitempty"`
+		Warnings        []*Error              `json:"warnings,omitempty"`
 	}
 
 	// HostnameCnameType represents HostnameCnameType enum
@@ -106,6 +114,11 @@ type (
 const (
 	// HostnameCnameTypeEdgeHostname const
 	HostnameCnameTypeEdgeHostname HostnameCnameType = "EDGE_HOSTNAME"
+
+	// CertTypeCPSManaged indicates a certificate managed in the Certificate Provisioning System (CPS)
+	CertTypeCPSManaged = "CPS_MANAGED"
+	// CertTypeDefault indicates a Default Domain Validation certificate provisioned by PAPI
+	CertTypeDefault = "DEFAULT"
 )
 
 // Validate validates GetPropertyVersionHostnamesRequest
@@ -187,6 +200,7 @@ func (p *papi) UpdatePropertyVersionHostnames(ctx context.Context, params Update
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdatePropertyVersionHostnames, err)
 	}
+	setIfMatch(req, params.Etag)
 
 	var hostnames UpdatePropertyVersionHostnamesResponse
 	newHostnames := params.Hostnames
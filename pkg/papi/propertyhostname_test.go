@@ -155,6 +155,7 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 		responseStatus   int
 		responseBody     string
 		expectedPath     string
+		expectedIfMatch  string
 		expectedResponse *UpdatePropertyVersionHostnamesResponse
 		withError        func(*testing.T, error)
 	}{
@@ -336,6 +337,96 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 				},
 			},
 		},
+		"200 ValidateHostnames true with validation problems": {
+			params: UpdatePropertyVersionHostnamesRequest{
+				PropertyID:        "prp_175780",
+				PropertyVersion:   3,
+				GroupID:           "grp_15225",
+				ContractID:        "ctr_1-1TJZH5",
+				ValidateHostnames: true,
+				Hostnames: []Hostname{
+					{
+						CnameType:            HostnameCnameTypeEdgeHostname,
+						CnameFrom:            "m.example.com",
+						CnameTo:              "example.com.edgekey.net",
+						CertProvisioningType: CertTypeDefault,
+					},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZH5",
+    "groupId": "grp_15225",
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "etag": "6aed418629b4e5c0",
+    "hostnames": {
+        "items": [
+            {
+                "cnameType": "EDGE_HOSTNAME",
+                "edgeHostnameId": "ehn_895822",
+                "cnameFrom": "m.example.com",
+                "cnameTo": "example.com.edgekey.net",
+                "certProvisioningType": "DEFAULT"
+            }
+        ]
+    },
+    "errors": [
+        {
+            "type": "https://problems.luna.akamaiapis.net/papi/v0/validation/hostname_conflict",
+            "title": "Hostname conflict",
+            "detail": "The hostname m.example.com is already in use by another property.",
+            "errorLocation": "#/hostnames/0"
+        }
+    ],
+    "warnings": [
+        {
+            "type": "https://problems.luna.akamaiapis.net/papi/v0/validation/cname_not_resolved",
+            "title": "CNAME not resolved",
+            "detail": "m.example.com does not resolve to example.com.edgekey.net.",
+            "errorLocation": "#/hostnames/0"
+        }
+    ]
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/versions/3/hostnames?contractId=ctr_1-1TJZH5&groupId=grp_15225&includeCertStatus=false&validateHostnames=true",
+			expectedResponse: &UpdatePropertyVersionHostnamesResponse{
+				AccountID:       "act_1-1TJZFB",
+				ContractID:      "ctr_1-1TJZH5",
+				GroupID:         "grp_15225",
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "6aed418629b4e5c0",
+				Hostnames: HostnameResponseItems{
+					Items: []Hostname{
+						{
+							CnameType:            HostnameCnameTypeEdgeHostname,
+							EdgeHostnameID:       "ehn_895822",
+							CnameFrom:            "m.example.com",
+							CnameTo:              "example.com.edgekey.net",
+							CertProvisioningType: CertTypeDefault,
+						},
+					},
+				},
+				Errors: []*Error{
+					{
+						Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/hostname_conflict",
+						Title:         "Hostname conflict",
+						Detail:        "The hostname m.example.com is already in use by another property.",
+						ErrorLocation: "#/hostnames/0",
+					},
+				},
+				Warnings: []*Error{
+					{
+						Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/cname_not_resolved",
+						Title:         "CNAME not resolved",
+						Detail:        "m.example.com does not resolve to example.com.edgekey.net.",
+						ErrorLocation: "#/hostnames/0",
+					},
+				},
+			},
+		},
 		"validation error PropertyID missing": {
 			params: UpdatePropertyVersionHostnamesRequest{
 				PropertyVersion: 3,
@@ -500,6 +591,51 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
 			},
 		},
+		"200 OK with matching etag": {
+			params: UpdatePropertyVersionHostnamesRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "6aed418629b4e5c0",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "etag": "7cf327b7d3b9cf4a",
+    "hostnames": {
+        "items": []
+    }
+}`,
+			expectedPath:    "/papi/v1/properties/prp_175780/versions/3/hostnames?contractId=&groupId=&includeCertStatus=false&validateHostnames=false",
+			expectedIfMatch: `"6aed418629b4e5c0"`,
+			expectedResponse: &UpdatePropertyVersionHostnamesResponse{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "7cf327b7d3b9cf4a",
+				Hostnames:       HostnameResponseItems{Items: []Hostname{}},
+			},
+		},
+		"412 Precondition Failed": {
+			params: UpdatePropertyVersionHostnamesRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "6aed418629b4e5c0",
+			},
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+{
+    "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+    "title": "Precondition Failed",
+    "detail": "The hostnames were modified since the etag was read.",
+    "status": 412
+}`,
+			expectedPath:    "/papi/v1/properties/prp_175780/versions/3/hostnames?contractId=&groupId=&includeCertStatus=false&validateHostnames=false",
+			expectedIfMatch: `"6aed418629b4e5c0"`,
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrPreconditionFailed), "want: %s; got: %s", ErrPreconditionFailed, err)
+			},
+		},
 		"500 internal server status error": {
 			params: UpdatePropertyVersionHostnamesRequest{
 				PropertyID:        "prp_175780",
@@ -533,6 +669,7 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, test.expectedIfMatch, r.Header.Get("If-Match"))
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
@@ -122,6 +122,9 @@ type (
 	CreatePropertyVersionResponse struct {
 		VersionLink     string `json:"versionLink"`
 		PropertyVersion int
+		// Etag is the etag of the new version, when returned by the API, usable as the CreateFromVersionEtag
+		// of a version or the CloneFromVersionEtag of a property created from it
+		Etag string `json:"etag,omitempty"`
 	}
 
 	// GetLatestVersionRequest contains path and query params required to fetch latest property version
@@ -447,6 +450,9 @@ func (p *papi) CreatePropertyVersion(ctx context.Context, request CreateProperty
 		return nil, fmt.Errorf("%s: %w: %s: %s", ErrCreatePropertyVersion, ErrInvalidResponseLink, "version should be a number", propertyVersion)
 	}
 	version.PropertyVersion = versionNumber
+	if version.Etag == "" {
+		version.Etag = responseEtag(resp)
+	}
 	return &version, nil
 }
 
@@ -315,6 +315,7 @@ func TestPapi_CreatePropertyVersion(t *testing.T) {
 	tests := map[string]struct {
 		params           CreatePropertyVersionRequest
 		responseStatus   int
+		responseHeader   http.Header
 		responseBody     string
 		expectedPath     string
 		expectedResponse *CreatePropertyVersionResponse
@@ -340,6 +341,51 @@ func TestPapi_CreatePropertyVersion(t *testing.T) {
 				PropertyVersion: 2,
 			},
 		},
+		"201 Created with etag": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion:     1,
+					CreateFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+				},
+			},
+			responseStatus: http.StatusCreated,
+			responseHeader: http.Header{"Etag": []string{`"71573b922a87abc3fd19eb9a8e4f01b1"`}},
+			responseBody: `
+		{
+		   "versionLink": "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group"
+		}`,
+			expectedPath: "/papi/v1/properties/propertyID/versions?contractId=contract&groupId=group",
+			expectedResponse: &CreatePropertyVersionResponse{
+				VersionLink:     "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group",
+				PropertyVersion: 2,
+				Etag:            "71573b922a87abc3fd19eb9a8e4f01b1",
+			},
+		},
+		"412 Precondition Failed": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion:     1,
+					CreateFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+				},
+			},
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+		{
+		   "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+		   "title": "Precondition Failed",
+		   "status": 412
+		}`,
+			expectedPath: "/papi/v1/properties/propertyID/versions?contractId=contract&groupId=group",
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrPreconditionFailed), "want: %s; got: %s", ErrPreconditionFailed, err)
+			},
+		},
 		"500 Internal Server Error": {
 			params: CreatePropertyVersionRequest{
 				PropertyID: "propertyID",
@@ -443,6 +489,9 @@ func TestPapi_CreatePropertyVersion(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPost, r.Method)
+				for key, values := range test.responseHeader {
+					w.Header()[key] = values
+				}
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
@@ -2,10 +2,13 @@ package papi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegriderr"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -19,10 +22,30 @@ type (
 		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-version-rules
 		GetRuleTree(context.Context, GetRuleTreeRequest) (*GetRuleTreeResponse, error)
 
-		// UpdateRuleTree updates the rule tree for a property version
+		// UpdateRuleTree updates the rule tree for a property version.
+		// When RuleFormat is set, the rules are sent and returned in that rule format,
+		// otherwise the rule format of the property version is used.
+		// When Etag is set, e.g. to the Etag returned by GetRuleTree, the update fails with ErrPreconditionFailed
+		// if the rule tree was modified since, instead of overwriting the changes.
 		//
 		// See: https://techdocs.akamai.com/property-mgr/reference/put-property-version-rules
 		UpdateRuleTree(context.Context, UpdateRulesRequest) (*UpdateRulesResponse, error)
+
+		// ValidateRuleTree validates a rule tree against a property version without saving it, i.e. with the
+		// validateRules and dryRun options of the update, and returns the errors and warnings reported by the API.
+		// When RuleFormat is set, the rules are validated against that rule format, e.g. to check a format upgrade.
+		// Use RuleError.Location and RuleWarnings.Location to find the rule and behavior at fault.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/put-property-version-rules
+		ValidateRuleTree(context.Context, ValidateRuleTreeRequest) (*ValidateRuleTreeResponse, error)
+
+		// PatchRuleTree applies JSON Patch operations to the rule tree of a property version, e.g. to change a single
+		// behavior option of a large rule tree without uploading the whole tree. The operations are applied in order,
+		// and the update fails as a whole if one of them fails.
+		// When Etag is set, the patch fails with ErrPreconditionFailed if the rule tree was modified since.
+		//
+		// See: https://techdocs.akamai.com/property-mgr/reference/patch-property-version-rules
+		PatchRuleTree(context.Context, PatchRuleTreeRequest) (*UpdateRulesResponse, error)
 	}
 
 	// GetRuleTreeRequest contains path and query params necessary to perform GET /rules request
@@ -63,6 +86,8 @@ type (
 		TemplateLink        string                  `json:"templateLink,omitempty"`
 		Variables           []RuleVariable          `json:"variables,omitempty"`
 		CriteriaMustSatisfy RuleCriteriaMustSatisfy `json:"criteriaMustSatisfy,omitempty"`
+		// AdditionalFields holds the fields of the rule unknown to this model, which are written back as they were read
+		AdditionalFields map[string]json.RawMessage `json:"-"`
 	}
 
 	// RuleBehavior contains data for both rule behaviors and rule criteria
@@ -72,6 +97,9 @@ type (
 		Options      RuleOptionsMap `json:"options"`
 		UUID         string         `json:"uuid,omitempty"`
 		TemplateUuid string         `json:"templateUuid,omitempty"`
+		// AdditionalFields holds the fields of the behavior unknown to this model, e.g. the metadata of advanced
+		// and custom behaviors, which are written back as they were read
+		AdditionalFields map[string]json.RawMessage `json:"-"`
 	}
 
 	// RuleCustomOverride represents customOverride field from Rule resource
@@ -103,7 +131,10 @@ type (
 		GroupID         string
 		ValidateMode    string
 		ValidateRules   bool
+		RuleFormat      string
 		Rules           RulesUpdate
+		// Etag is the etag of the rule tree the update is based on, sent as If-Match
+		Etag string
 	}
 
 	// RulesUpdate is a wrapper for the request body of PUT /rules request
@@ -127,6 +158,62 @@ type (
 		Warnings        []RuleWarnings `json:"warnings"`
 	}
 
+	// ValidateRuleTreeRequest contains the property version and the rule tree to validate
+	ValidateRuleTreeRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+		ValidateMode    string
+		RuleFormat      string
+		Rules           RulesUpdate
+	}
+
+	// ValidateRuleTreeResponse contains the errors and warnings found by ValidateRuleTree
+	ValidateRuleTreeResponse struct {
+		RuleFormat string
+		Errors     []RuleError
+		Warnings   []RuleWarnings
+	}
+
+	// PatchRuleTreeRequest contains the property version and the JSON Patch operations to apply to its rule tree
+	PatchRuleTreeRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+		DryRun          bool
+		ValidateMode    string
+		ValidateRules   bool
+		RuleFormat      string
+		Patches         []JSONPatchOp
+		// Etag is the etag of the rule tree the patch is based on, sent as If-Match
+		Etag string
+	}
+
+	// JSONPatchOp is a JSON Patch (RFC 6902) operation on a rule tree, e.g.
+	// {Op: "replace", Path: "/rules/behaviors/0/options/hostname", Value: "origin.example.com"}
+	JSONPatchOp struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		From  string      `json:"from,omitempty"`
+		Value interface{} `json:"value,omitempty"`
+	}
+
+	// RuleLocation is a position in a rule tree, parsed from the errorLocation JSON pointer of rule errors and warnings,
+	// e.g. "#/rules/children/1/behaviors/0/options/hostname"
+	RuleLocation struct {
+		// Children lists the indexes of the child rules leading from the default rule to the rule
+		Children []int
+		// Section is RuleLocationBehaviors, RuleLocationCriteria or RuleLocationVariables when the location points
+		// into one of them, it is empty when it points at the rule itself
+		Section string
+		// Index is the position within Section, -1 without a section
+		Index int
+		// Field is the remainder of the pointer, e.g. "options/hostname"
+		Field string
+	}
+
 	// RuleError represents an entry in error field from PUT /rules response body
 	RuleError struct {
 		Type          string `json:"type"`
@@ -147,7 +234,8 @@ type (
 		SuggestedRuleFormat string `json:"suggestedRuleFormat"`
 	}
 
-	// RuleOptionsMap is a type wrapping map[string]interface{} used for adding rule options
+	// RuleOptionsMap is a type wrapping map[string]interface{} used for adding rule options.
+	// Numbers which a float64 cannot represent as written are decoded as json.Number.
 	RuleOptionsMap map[string]interface{}
 
 	// RuleCriteriaMustSatisfy represents criteriaMustSatisfy field values
@@ -160,6 +248,26 @@ const (
 	// RuleValidateModeFull const
 	RuleValidateModeFull = "full"
 
+	// RuleLocationBehaviors const
+	RuleLocationBehaviors = "behaviors"
+	// RuleLocationCriteria const
+	RuleLocationCriteria = "criteria"
+	// RuleLocationVariables const
+	RuleLocationVariables = "variables"
+
+	// JSONPatchOpAdd const
+	JSONPatchOpAdd = "add"
+	// JSONPatchOpRemove const
+	JSONPatchOpRemove = "remove"
+	// JSONPatchOpReplace const
+	JSONPatchOpReplace = "replace"
+	// JSONPatchOpMove const
+	JSONPatchOpMove = "move"
+	// JSONPatchOpCopy const
+	JSONPatchOpCopy = "copy"
+	// JSONPatchOpTest const
+	JSONPatchOpTest = "test"
+
 	// RuleCriteriaMustSatisfyAll const
 	RuleCriteriaMustSatisfyAll RuleCriteriaMustSatisfy = "all"
 	//RuleCriteriaMustSatisfyAny const
@@ -184,11 +292,54 @@ func (r UpdateRulesRequest) Validate() error {
 		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
 		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
 		"ValidateMode":    validation.Validate(r.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"RuleFormat":      validation.Validate(r.RuleFormat, validation.Match(validRuleFormat)),
 		"Rules":           validation.Validate(r.Rules),
 	}
 	return edgegriderr.ParseValidationErrors(errs)
 }
 
+// Validate validates ValidateRuleTreeRequest struct
+func (r ValidateRuleTreeRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"ValidateMode":    validation.Validate(r.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"RuleFormat":      validation.Validate(r.RuleFormat, validation.Match(validRuleFormat)),
+		"Rules":           validation.Validate(r.Rules),
+	})
+}
+
+// Validate validates PatchRuleTreeRequest struct
+func (r PatchRuleTreeRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"ValidateMode":    validation.Validate(r.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"RuleFormat":      validation.Validate(r.RuleFormat, validation.Match(validRuleFormat)),
+		"Patches":         validation.Validate(r.Patches, validation.Required),
+	})
+}
+
+// Validate validates JSONPatchOp struct
+func (o JSONPatchOp) Validate() error {
+	return validation.Errors{
+		"Op": validation.Validate(o.Op, validation.Required, validation.In(JSONPatchOpAdd, JSONPatchOpRemove,
+			JSONPatchOpReplace, JSONPatchOpMove, JSONPatchOpCopy, JSONPatchOpTest)),
+		"Path": validation.Validate(o.Path, validation.Required, validation.By(validateJSONPointer)),
+		"From": validation.Validate(o.From, validation.When(o.Op == JSONPatchOpMove || o.Op == JSONPatchOpCopy,
+			validation.Required), validation.By(validateJSONPointer)),
+	}.Filter()
+}
+
+// validateJSONPointer checks that a non-empty JSON pointer starts with "/"
+func validateJSONPointer(value interface{}) error {
+	pointer, _ := value.(string)
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("must be a JSON pointer starting with '/'")
+	}
+	return nil
+}
+
 // Validate validates RulesUpdate struct
 func (r RulesUpdate) Validate() error {
 	return validation.Errors{
@@ -239,6 +390,12 @@ var (
 	ErrGetRuleTree = errors.New("fetching rule tree")
 	// ErrUpdateRuleTree represents error when updating rule tree fails
 	ErrUpdateRuleTree = errors.New("updating rule tree")
+	// ErrValidateRuleTree represents error when validating rule tree fails
+	ErrValidateRuleTree = errors.New("validating rule tree")
+	// ErrPatchRuleTree represents error when patching rule tree fails
+	ErrPatchRuleTree = errors.New("patching rule tree")
+	// ErrInvalidRuleLocation is returned when an error location does not point into the rule tree
+	ErrInvalidRuleLocation = errors.New("invalid rule location")
 )
 
 func (p *papi) GetRuleTree(ctx context.Context, params GetRuleTreeRequest) (*GetRuleTreeResponse, error) {
@@ -280,6 +437,9 @@ func (p *papi) GetRuleTree(ctx context.Context, params GetRuleTreeRequest) (*Get
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("%s: %w", ErrGetRuleTree, p.Error(resp))
 	}
+	if rules.Etag == "" {
+		rules.Etag = responseEtag(resp)
+	}
 
 	return &rules, nil
 }
@@ -313,6 +473,13 @@ func (p *papi) UpdateRuleTree(ctx context.Context, request UpdateRulesRequest) (
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateRuleTree, err)
 	}
 
+	if request.RuleFormat != "" {
+		mediaType := fmt.Sprintf("application/vnd.akamai.papirules.%s+json", request.RuleFormat)
+		req.Header.Set("Content-Type", mediaType)
+		req.Header.Set("Accept", mediaType)
+	}
+	setIfMatch(req, request.Etag)
+
 	var versions UpdateRulesResponse
 	resp, err := p.Exec(req, &versions, request.Rules)
 	if err != nil {
@@ -324,3 +491,180 @@ func (p *papi) UpdateRuleTree(ctx context.Context, request UpdateRulesRequest) (
 
 	return &versions, nil
 }
+
+func (p *papi) PatchRuleTree(ctx context.Context, request PatchRuleTreeRequest) (*UpdateRulesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrPatchRuleTree, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("PatchRuleTree")
+
+	patchURL := fmt.Sprintf(
+		"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
+		request.PropertyID,
+		request.PropertyVersion,
+		request.ContractID,
+		request.GroupID,
+	)
+	if request.ValidateMode != "" {
+		patchURL += fmt.Sprintf("&validateMode=%s", request.ValidateMode)
+	}
+	if !request.ValidateRules {
+		patchURL += fmt.Sprintf("&validateRules=%t", request.ValidateRules)
+	}
+	if request.DryRun {
+		patchURL += fmt.Sprintf("&dryRun=%t", request.DryRun)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, patchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrPatchRuleTree, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	if request.RuleFormat != "" {
+		req.Header.Set("Accept", fmt.Sprintf("application/vnd.akamai.papirules.%s+json", request.RuleFormat))
+	}
+	setIfMatch(req, request.Etag)
+
+	var rules UpdateRulesResponse
+	resp, err := p.Exec(req, &rules, request.Patches)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrPatchRuleTree, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrPatchRuleTree, p.Error(resp))
+	}
+
+	return &rules, nil
+}
+
+func (p *papi) ValidateRuleTree(ctx context.Context, request ValidateRuleTreeRequest) (*ValidateRuleTreeResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrValidateRuleTree, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ValidateRuleTree")
+
+	putURL := fmt.Sprintf(
+		"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s&validateRules=true&dryRun=true",
+		request.PropertyID,
+		request.PropertyVersion,
+		request.ContractID,
+		request.GroupID,
+	)
+	if request.ValidateMode != "" {
+		putURL += fmt.Sprintf("&validateMode=%s", request.ValidateMode)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrValidateRuleTree, err)
+	}
+
+	if request.RuleFormat != "" {
+		mediaType := fmt.Sprintf("application/vnd.akamai.papirules.%s+json", request.RuleFormat)
+		req.Header.Set("Content-Type", mediaType)
+		req.Header.Set("Accept", mediaType)
+	}
+
+	var rules UpdateRulesResponse
+	resp, err := p.Exec(req, &rules, request.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrValidateRuleTree, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrValidateRuleTree, p.Error(resp))
+	}
+
+	return &ValidateRuleTreeResponse{
+		RuleFormat: rules.RuleFormat,
+		Errors:     rules.Errors,
+		Warnings:   rules.Warnings,
+	}, nil
+}
+
+// Location parses the error location of the rule error, see ParseRuleLocation
+func (e RuleError) Location() (*RuleLocation, error) {
+	return ParseRuleLocation(e.ErrorLocation)
+}
+
+// Location parses the error location of the rule warning, see ParseRuleLocation
+func (w RuleWarnings) Location() (*RuleLocation, error) {
+	return ParseRuleLocation(w.ErrorLocation)
+}
+
+// ParseRuleLocation parses the errorLocation JSON pointer of a rule error or warning,
+// e.g. "#/rules/children/1/behaviors/0/options/hostname"
+func ParseRuleLocation(errorLocation string) (*RuleLocation, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(errorLocation, "#"), "/"), "/")
+	if parts[0] != "rules" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRuleLocation, errorLocation)
+	}
+
+	location := &RuleLocation{Index: -1}
+	parts = parts[1:]
+	index := func() (int, bool) {
+		if len(parts) < 2 {
+			return 0, false
+		}
+		i, err := strconv.Atoi(parts[1])
+		return i, err == nil && i >= 0
+	}
+	for len(parts) > 0 && parts[0] == "children" {
+		i, ok := index()
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRuleLocation, errorLocation)
+		}
+		location.Children = append(location.Children, i)
+		parts = parts[2:]
+	}
+	if len(parts) > 0 {
+		switch parts[0] {
+		case RuleLocationBehaviors, RuleLocationCriteria, RuleLocationVariables:
+			i, ok := index()
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidRuleLocation, errorLocation)
+			}
+			location.Section, location.Index = parts[0], i
+			parts = parts[2:]
+		}
+	}
+	location.Field = strings.Join(parts, "/")
+
+	return location, nil
+}
+
+// Rule returns the rule of the tree at the location
+func (l RuleLocation) Rule(tree *Rules) (*Rules, error) {
+	rule := tree
+	for _, i := range l.Children {
+		if i >= len(rule.Children) {
+			return nil, fmt.Errorf("%w: rule %q has no child %d", ErrInvalidRuleLocation, rule.Name, i)
+		}
+		rule = &rule.Children[i]
+	}
+	return rule, nil
+}
+
+// Behavior returns the behavior, or the criterion, of the tree at the location
+func (l RuleLocation) Behavior(tree *Rules) (*RuleBehavior, error) {
+	rule, err := l.Rule(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var behaviors []RuleBehavior
+	switch l.Section {
+	case RuleLocationBehaviors:
+		behaviors = rule.Behaviors
+	case RuleLocationCriteria:
+		behaviors = rule.Criteria
+	default:
+		return nil, fmt.Errorf("%w: location does not point at a behavior or criterion", ErrInvalidRuleLocation)
+	}
+	if l.Index >= len(behaviors) {
+		return nil, fmt.Errorf("%w: rule %q has no %s %d", ErrInvalidRuleLocation, rule.Name, l.Section, l.Index)
+	}
+	return &behaviors[l.Index], nil
+}
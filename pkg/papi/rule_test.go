@@ -3,12 +3,15 @@ package papi
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session/sessiontest"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/tools"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -645,13 +648,15 @@ func TestPapi_GetRuleTree(t *testing.T) {
 
 func TestPapi_UpdateRuleTree(t *testing.T) {
 	tests := map[string]struct {
-		params           UpdateRulesRequest
-		requestBody      string
-		responseStatus   int
-		responseBody     string
-		expectedPath     string
-		expectedResponse *UpdateRulesResponse
-		withError        func(*testing.T, error)
+		params              UpdateRulesRequest
+		requestBody         string
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedContentType string
+		expectedIfMatch     string
+		expectedResponse    *UpdateRulesResponse
+		withError           func(*testing.T, error)
 	}{
 		"200 OK": {
 			params: UpdateRulesRequest{
@@ -1989,6 +1994,181 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 				assert.Contains(t, err.Error(), "Name")
 			},
 		},
+		"200 OK with rule format and rule warnings": {
+			params: UpdateRulesRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+				ValidateRules:   true,
+				RuleFormat:      "v2023-01-05",
+				Rules: RulesUpdate{
+					Rules: Rules{
+						Name: "default",
+						Behaviors: []RuleBehavior{
+							{
+								Name:    "someFutureBehavior",
+								Options: RuleOptionsMap{"enabled": true},
+							},
+						},
+					},
+				},
+			},
+			requestBody:    `{"rules":{"behaviors":[{"name":"someFutureBehavior","options":{"enabled":true}}],"name":"default","options":{}}}`,
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "accountID",
+    "contractId": "contract",
+    "groupId": "group",
+    "propertyId": "propertyID",
+    "propertyVersion": 2,
+    "etag": "etag",
+    "ruleFormat": "v2023-01-05",
+    "rules": {
+        "name": "default",
+        "behaviors": [
+            {
+                "name": "someFutureBehavior",
+                "options": {
+                    "enabled": true
+                }
+            }
+        ]
+    },
+    "errors": [
+        {
+            "type": "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+            "errorLocation": "#/rules/behaviors/0",
+            "detail": "The origin behavior is required.",
+            "behaviorName": "origin"
+        }
+    ],
+    "warnings": [
+        {
+            "title": "Unstable rule format",
+            "type": "https://problems.luna.akamaiapis.net/papi/v0/unstable_rule_format",
+            "errorLocation": "#/rules",
+            "detail": "This property is using a version of rule format that is not frozen.",
+            "currentRuleFormat": "latest",
+            "suggestedRuleFormat": "v2023-01-05"
+        }
+    ]
+}`,
+			expectedPath:        "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			expectedContentType: "application/vnd.akamai.papirules.v2023-01-05+json",
+			expectedResponse: &UpdateRulesResponse{
+				AccountID:       "accountID",
+				ContractID:      "contract",
+				GroupID:         "group",
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				Etag:            "etag",
+				RuleFormat:      "v2023-01-05",
+				Rules: Rules{
+					Name: "default",
+					Behaviors: []RuleBehavior{
+						{
+							Name:    "someFutureBehavior",
+							Options: RuleOptionsMap{"enabled": true},
+						},
+					},
+				},
+				Errors: []RuleError{
+					{
+						Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+						ErrorLocation: "#/rules/behaviors/0",
+						Detail:        "The origin behavior is required.",
+						BehaviorName:  "origin",
+					},
+				},
+				Warnings: []RuleWarnings{
+					{
+						Title:               "Unstable rule format",
+						Type:                "https://problems.luna.akamaiapis.net/papi/v0/unstable_rule_format",
+						ErrorLocation:       "#/rules",
+						Detail:              "This property is using a version of rule format that is not frozen.",
+						CurrentRuleFormat:   "latest",
+						SuggestedRuleFormat: "v2023-01-05",
+					},
+				},
+			},
+		},
+		"200 OK with matching etag": {
+			params: UpdateRulesRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+				ValidateRules:   true,
+				Etag:            "a9dfe78cf93090516bde891d009eaf57",
+				Rules: RulesUpdate{
+					Rules: Rules{Name: "default"},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "propertyId": "propertyID",
+    "propertyVersion": 2,
+    "etag": "71573b922a87abc3fd19eb9a8e4f01b1",
+    "rules": {
+        "name": "default"
+    }
+}`,
+			expectedPath:    "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			expectedIfMatch: `"a9dfe78cf93090516bde891d009eaf57"`,
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				Etag:            "71573b922a87abc3fd19eb9a8e4f01b1",
+				Rules:           Rules{Name: "default"},
+			},
+		},
+		"412 Precondition Failed": {
+			params: UpdateRulesRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+				ValidateRules:   true,
+				Etag:            "a9dfe78cf93090516bde891d009eaf57",
+				Rules: RulesUpdate{
+					Rules: Rules{Name: "default"},
+				},
+			},
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+{
+    "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+    "title": "Precondition Failed",
+    "detail": "The rule tree was modified since the etag was read.",
+    "status": 412
+}`,
+			expectedPath:    "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			expectedIfMatch: `"a9dfe78cf93090516bde891d009eaf57"`,
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrPreconditionFailed), "want: %s; got: %s", ErrPreconditionFailed, err)
+				assert.False(t, errors.Is(err, ErrNotFound))
+			},
+		},
+		"validation error - invalid rule format": {
+			params: UpdateRulesRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				RuleFormat:      "2023-01-05",
+				Rules: RulesUpdate{
+					Rules: Rules{
+						Name: "default",
+					},
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "RuleFormat")
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -1996,6 +2176,11 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, test.expectedIfMatch, r.Header.Get("If-Match"))
+				if test.expectedContentType != "" {
+					assert.Equal(t, test.expectedContentType, r.Header.Get("Content-Type"))
+					assert.Equal(t, test.expectedContentType, r.Header.Get("Accept"))
+				}
 				if test.requestBody != "" {
 					buf := new(bytes.Buffer)
 					_, err := buf.ReadFrom(r.Body)
@@ -2018,3 +2203,415 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_ValidateRuleTree(t *testing.T) {
+	tests := map[string]struct {
+		params              ValidateRuleTreeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedContentType string
+		expectedResponse    *ValidateRuleTreeResponse
+		withError           func(*testing.T, error)
+	}{
+		"200 OK with errors and warnings": {
+			params: ValidateRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+				RuleFormat:      "v2023-01-05",
+				Rules: RulesUpdate{
+					Rules: Rules{
+						Name: "default",
+						Behaviors: []RuleBehavior{
+							{Name: "origin", Options: RuleOptionsMap{"hostname": ""}},
+						},
+					},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "propertyId": "propertyID",
+    "propertyVersion": 2,
+    "ruleFormat": "v2023-01-05",
+    "rules": {
+        "name": "default",
+        "behaviors": [{"name": "origin", "options": {"hostname": ""}}]
+    },
+    "errors": [
+        {
+            "type": "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+            "title": "Attribute required",
+            "detail": "The hostname option is required.",
+            "errorLocation": "#/rules/behaviors/0/options/hostname"
+        }
+    ],
+    "warnings": [
+        {
+            "type": "https://problems.luna.akamaiapis.net/papi/v0/validation/product_behavior_issue.cpcode_incorrect_product",
+            "title": "Unstable rule format",
+            "detail": "This property is using a frozen rule format.",
+            "errorLocation": "#/rules"
+        }
+    ]
+}`,
+			expectedPath:        "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&dryRun=true&groupId=group&validateRules=true",
+			expectedContentType: "application/vnd.akamai.papirules.v2023-01-05+json",
+			expectedResponse: &ValidateRuleTreeResponse{
+				RuleFormat: "v2023-01-05",
+				Errors: []RuleError{
+					{
+						Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+						Title:         "Attribute required",
+						Detail:        "The hostname option is required.",
+						ErrorLocation: "#/rules/behaviors/0/options/hostname",
+					},
+				},
+				Warnings: []RuleWarnings{
+					{
+						Type:          "https://problems.luna.akamaiapis.net/papi/v0/validation/product_behavior_issue.cpcode_incorrect_product",
+						Title:         "Unstable rule format",
+						Detail:        "This property is using a frozen rule format.",
+						ErrorLocation: "#/rules",
+					},
+				},
+			},
+		},
+		"200 OK valid rules in full mode": {
+			params: ValidateRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ValidateMode:    RuleValidateModeFull,
+				Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"propertyId": "propertyID", "propertyVersion": 2, "ruleFormat": "latest", "rules": {"name": "default"}}`,
+			expectedPath:     "/papi/v1/properties/propertyID/versions/2/rules?contractId=&dryRun=true&groupId=&validateMode=full&validateRules=true",
+			expectedResponse: &ValidateRuleTreeResponse{RuleFormat: "latest"},
+		},
+		"500 internal server error": {
+			params: ValidateRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error validating rule tree",
+	"status": 500
+}`,
+			expectedPath: "/papi/v1/properties/propertyID/versions/2/rules?contractId=&dryRun=true&groupId=&validateRules=true",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error validating rule tree",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"invalid rule format": {
+			params: ValidateRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				RuleFormat:      "2023-01-05",
+				Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "RuleFormat")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				if test.expectedContentType != "" {
+					assert.Equal(t, test.expectedContentType, r.Header.Get("Content-Type"))
+					assert.Equal(t, test.expectedContentType, r.Header.Get("Accept"))
+				}
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ValidateRuleTree(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_PatchRuleTree(t *testing.T) {
+	patches := []JSONPatchOp{
+		{Op: JSONPatchOpReplace, Path: "/rules/behaviors/0/options/hostname", Value: "origin.example.com"},
+		{Op: JSONPatchOpRemove, Path: "/rules/children/1"},
+	}
+
+	tests := map[string]struct {
+		params           PatchRuleTreeRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedBody     string
+		expectedAccept   string
+		expectedIfMatch  string
+		expectedResponse *UpdateRulesResponse
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+				RuleFormat:      "v2023-01-05",
+				Patches:         patches,
+				Etag:            "etag",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "propertyId": "propertyID",
+    "propertyVersion": 2,
+    "etag": "etag2",
+    "ruleFormat": "v2023-01-05",
+    "rules": {
+        "name": "default",
+        "behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com"}}]
+    }
+}`,
+			expectedPath:    "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group&validateRules=false",
+			expectedBody:    `[{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin.example.com"},{"op":"remove","path":"/rules/children/1"}]`,
+			expectedAccept:  "application/vnd.akamai.papirules.v2023-01-05+json",
+			expectedIfMatch: `"etag"`,
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				Etag:            "etag2",
+				RuleFormat:      "v2023-01-05",
+				Rules: Rules{
+					Name:      "default",
+					Behaviors: []RuleBehavior{{Name: "origin", Options: RuleOptionsMap{"hostname": "origin.example.com"}}},
+				},
+			},
+		},
+		"200 OK move with validation": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ValidateRules:   true,
+				ValidateMode:    RuleValidateModeFull,
+				DryRun:          true,
+				Patches:         []JSONPatchOp{{Op: JSONPatchOpMove, From: "/rules/children/0", Path: "/rules/children/1"}},
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"propertyId": "propertyID", "propertyVersion": 2, "ruleFormat": "latest", "rules": {"name": "default"}}`,
+			expectedPath:     "/papi/v1/properties/propertyID/versions/2/rules?contractId=&dryRun=true&groupId=&validateMode=full",
+			expectedAccept:   "application/json",
+			expectedBody:     `[{"op":"move","path":"/rules/children/1","from":"/rules/children/0"}]`,
+			expectedResponse: &UpdateRulesResponse{PropertyID: "propertyID", PropertyVersion: 2, RuleFormat: "latest", Rules: Rules{Name: "default"}},
+		},
+		"412 precondition failed": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				Patches:         patches,
+				Etag:            "stale",
+			},
+			responseStatus:  http.StatusPreconditionFailed,
+			responseBody:    `{"type": "precondition_failed", "title": "Precondition Failed", "status": 412}`,
+			expectedPath:    "/papi/v1/properties/propertyID/versions/2/rules?contractId=&groupId=&validateRules=false",
+			expectedBody:    `[{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin.example.com"},{"op":"remove","path":"/rules/children/1"}]`,
+			expectedAccept:  "application/json",
+			expectedIfMatch: `"stale"`,
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrPreconditionFailed), "want: %s; got: %s", ErrPreconditionFailed, err)
+			},
+		},
+		"missing patches": {
+			params: PatchRuleTreeRequest{PropertyID: "propertyID", PropertyVersion: 2},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "Patches: cannot be blank")
+			},
+		},
+		"invalid patch operations": {
+			params: PatchRuleTreeRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				Patches: []JSONPatchOp{
+					{Path: "/rules/name", Value: "default"},
+					{Op: "merge", Path: "/rules/name"},
+					{Op: JSONPatchOpReplace, Value: "default"},
+					{Op: JSONPatchOpAdd, Path: "rules/children/-", Value: map[string]interface{}{"name": "child"}},
+					{Op: JSONPatchOpCopy, Path: "/rules/children/-"},
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "Op: cannot be blank")
+				assert.Contains(t, err.Error(), "Op: must be a valid value")
+				assert.Contains(t, err.Error(), "Path: cannot be blank")
+				assert.Contains(t, err.Error(), "Path: must be a JSON pointer starting with '/'")
+				assert.Contains(t, err.Error(), "From: cannot be blank")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mockServer *sessiontest.MockServer
+			mockServer = sessiontest.NewMockServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "application/json-patch+json", r.Header.Get("Content-Type"))
+				assert.Equal(t, test.expectedAccept, r.Header.Get("Accept"))
+				assert.Equal(t, test.expectedIfMatch, r.Header.Get("If-Match"))
+				mockServer.AssertSigned(t, r)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedBody, string(body))
+				sessiontest.WriteJSON(w, test.responseStatus, test.responseBody)
+			}))
+			client := Client(mockServer.Session)
+			result, err := client.PatchRuleTree(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestParseRuleLocation(t *testing.T) {
+	tree := &Rules{
+		Name:      "default",
+		Behaviors: []RuleBehavior{{Name: "origin"}, {Name: "cpCode"}},
+		Children: []Rules{
+			{Name: "Performance"},
+			{
+				Name:      "Static content",
+				Criteria:  []RuleBehavior{{Name: "fileExtension"}},
+				Behaviors: []RuleBehavior{{Name: "caching"}},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		errorLocation    string
+		expectedLocation *RuleLocation
+		expectedRule     string
+		expectedBehavior string
+		withError        error
+		withLookupError  bool
+	}{
+		"default rule": {
+			errorLocation:    "#/rules",
+			expectedLocation: &RuleLocation{Index: -1},
+			expectedRule:     "default",
+			withLookupError:  true,
+		},
+		"behavior option of the default rule": {
+			errorLocation:    "#/rules/behaviors/1/options/value",
+			expectedLocation: &RuleLocation{Section: RuleLocationBehaviors, Index: 1, Field: "options/value"},
+			expectedRule:     "default",
+			expectedBehavior: "cpCode",
+		},
+		"criterion of a child rule": {
+			errorLocation:    "#/rules/children/1/criteria/0",
+			expectedLocation: &RuleLocation{Children: []int{1}, Section: RuleLocationCriteria, Index: 0},
+			expectedRule:     "Static content",
+			expectedBehavior: "fileExtension",
+		},
+		"field of a child rule": {
+			errorLocation:    "#/rules/children/0/name",
+			expectedLocation: &RuleLocation{Children: []int{0}, Index: -1, Field: "name"},
+			expectedRule:     "Performance",
+			withLookupError:  true,
+		},
+		"behavior out of range": {
+			errorLocation:    "#/rules/children/0/behaviors/3",
+			expectedLocation: &RuleLocation{Children: []int{0}, Section: RuleLocationBehaviors, Index: 3},
+			expectedRule:     "Performance",
+			withLookupError:  true,
+		},
+		"not a rule location": {
+			errorLocation: "#/comments",
+			withError:     ErrInvalidRuleLocation,
+		},
+		"invalid child index": {
+			errorLocation: "#/rules/children/x",
+			withError:     ErrInvalidRuleLocation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			location, err := RuleError{ErrorLocation: test.errorLocation}.Location()
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedLocation, location)
+
+			rule, err := location.Rule(tree)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRule, rule.Name)
+
+			behavior, err := location.Behavior(tree)
+			if test.withLookupError {
+				assert.True(t, errors.Is(err, ErrInvalidRuleLocation), "want: %s; got: %s", ErrInvalidRuleLocation, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBehavior, behavior.Name)
+		})
+	}
+}
+
+func TestRules_RoundTrip(t *testing.T) {
+	// the fixture is compact and in the order Rules and RuleBehavior encode fields, with option names sorted
+	const fixture = `{"rules":{"advancedOverride":"<match:request.type value=\"CLIENT_REQ\"/>",` +
+		`"behaviors":[{"name":"origin","options":{"hostname":"origin.example.com","originId":12345678901234567890,"ratio":1.0}},` +
+		`{"name":"advanced","options":{"description":"Custom cache key","xml":"<cache:key-policy>query</cache:key-policy>"},` +
+		`"uuid":"fd6a63bc-120a-4891-a5f2-c479765d5553","templateUuid":"bd5b9bc9-54e1-4b3f-9f81-0be2ab1b4d9d",` +
+		`"advancedMetadata":{"hidden":true,"owner":"PS"}},` +
+		`{"name":"customBehavior","options":{"behaviorId":"cbe_12345678"}}],` +
+		`"children":[{"criteria":[{"name":"path","options":{"matchOperator":"MATCHES_ONE_OF","values":["/api/*"]}}],` +
+		`"customOverride":{"name":"mdc","overrideId":"cbo_12029"},"name":"API","options":{},"futureField":[1,2]}],` +
+		`"name":"default","options":{"is_secure":true}},"ruleFormat":"v2023-01-05"}`
+
+	var tree struct {
+		Rules      Rules  `json:"rules"`
+		RuleFormat string `json:"ruleFormat"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(fixture), &tree))
+	assert.Equal(t, &RuleCustomOverride{Name: "mdc", OverrideID: "cbo_12029"}, tree.Rules.Children[0].CustomOverride)
+	assert.Equal(t, json.Number("12345678901234567890"), tree.Rules.Behaviors[0].Options["originId"])
+	assert.Equal(t, json.RawMessage(`{"hidden":true,"owner":"PS"}`), tree.Rules.Behaviors[1].AdditionalFields["advancedMetadata"])
+	assert.Nil(t, tree.Rules.Behaviors[2].AdditionalFields)
+
+	data, err := json.Marshal(tree)
+	require.NoError(t, err)
+	// json.Marshal escapes the HTML characters of strings, e.g. in advanced behaviors, which is equivalent JSON
+	var expected bytes.Buffer
+	json.HTMLEscape(&expected, []byte(fixture))
+	assert.Equal(t, expected.String(), string(data))
+}
@@ -0,0 +1,159 @@
+package papi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	rulesFields        = jsonFieldNames(reflect.TypeOf(Rules{}))
+	ruleBehaviorFields = jsonFieldNames(reflect.TypeOf(RuleBehavior{}))
+)
+
+// UnmarshalJSON decodes the rule, keeping the fields unknown to Rules in AdditionalFields
+func (r *Rules) UnmarshalJSON(data []byte) error {
+	type rules Rules
+	if err := json.Unmarshal(data, (*rules)(r)); err != nil {
+		return err
+	}
+	fields, err := additionalFields(data, rulesFields)
+	if err != nil {
+		return err
+	}
+	r.AdditionalFields = fields
+	return nil
+}
+
+// MarshalJSON encodes the rule, writing back its AdditionalFields
+func (r Rules) MarshalJSON() ([]byte, error) {
+	type rules Rules
+	return marshalWithAdditionalFields(rules(r), r.AdditionalFields)
+}
+
+// UnmarshalJSON decodes the behavior or criterion, keeping the fields unknown to RuleBehavior in AdditionalFields
+func (b *RuleBehavior) UnmarshalJSON(data []byte) error {
+	type ruleBehavior RuleBehavior
+	if err := json.Unmarshal(data, (*ruleBehavior)(b)); err != nil {
+		return err
+	}
+	fields, err := additionalFields(data, ruleBehaviorFields)
+	if err != nil {
+		return err
+	}
+	b.AdditionalFields = fields
+	return nil
+}
+
+// MarshalJSON encodes the behavior or criterion, writing back its AdditionalFields
+func (b RuleBehavior) MarshalJSON() ([]byte, error) {
+	type ruleBehavior RuleBehavior
+	return marshalWithAdditionalFields(ruleBehavior(b), b.AdditionalFields)
+}
+
+// UnmarshalJSON decodes the options like a map[string]interface{}, except for numbers which a float64 cannot
+// represent as written, e.g. 64-bit IDs or 1.0, which are kept as json.Number so that they are written back unchanged
+func (m *RuleOptionsMap) UnmarshalJSON(data []byte) error {
+	var options map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&options); err != nil {
+		return err
+	}
+	for key, value := range options {
+		options[key] = convertOptionNumbers(value)
+	}
+	*m = options
+	return nil
+}
+
+// convertOptionNumbers replaces the json.Number values which a float64 encodes back to the same text with float64
+func convertOptionNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v
+		}
+		if encoded, err := json.Marshal(f); err != nil || string(encoded) != v.String() {
+			return v
+		}
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertOptionNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertOptionNumbers(item)
+		}
+	}
+	return value
+}
+
+// additionalFields returns the fields of the JSON object which are not in known, or nil if there are none
+func additionalFields(data []byte, known map[string]bool) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	for name, value := range all {
+		if known[name] {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]json.RawMessage)
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
+
+// marshalWithAdditionalFields encodes v, a struct, and appends the additional fields in the order of their names
+func marshalWithAdditionalFields(v interface{}, fields map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := bytes.NewBuffer(data[:len(data)-1])
+	for _, name := range names {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(fields[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		names[name] = true
+	}
+	return names
+}
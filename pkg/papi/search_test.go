@@ -101,6 +101,61 @@ func TestPapi_SearchProperties(t *testing.T) {
 				},
 			},
 		},
+		"200 OK by hostname": {
+			params: SearchRequest{
+				Key:   SearchKeyHostname,
+				Value: "www.example.com",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "versions": {
+        "items": [
+            {
+                "accountId": "accountID_1",
+                "assetId": "assetID_1",
+                "contractId": "contractID_1",
+                "edgeHostname": "www.example.com.edgesuite.net",
+                "groupId": "groupID_1",
+                "hostname": "www.example.com",
+                "productionStatus": "ACTIVE",
+                "propertyId": "propertyID_1",
+                "propertyName": "propertyName_1",
+                "propertyVersion": 3,
+                "stagingStatus": "INACTIVE",
+                "updatedByUser": "user_1",
+                "updatedDate": "2017-08-07T15:39:49Z"
+            }
+        ]
+    }
+}`,
+			expectedRequest: `
+{
+	"hostname": "www.example.com"
+}`,
+			expectedPath: "/papi/v1/search/find-by-value",
+			expectedResponse: &SearchResponse{
+				Versions: SearchItems{
+					Items: []SearchItem{
+						{
+							AccountID:        "accountID_1",
+							AssetID:          "assetID_1",
+							ContractID:       "contractID_1",
+							EdgeHostname:     "www.example.com.edgesuite.net",
+							GroupID:          "groupID_1",
+							Hostname:         "www.example.com",
+							ProductionStatus: "ACTIVE",
+							PropertyID:       "propertyID_1",
+							PropertyName:     "propertyName_1",
+							PropertyVersion:  3,
+							StagingStatus:    "INACTIVE",
+							UpdatedByUser:    "user_1",
+							UpdatedDate:      "2017-08-07T15:39:49Z",
+						},
+					},
+				},
+			},
+		},
 		"500 Internal Server Error": {
 			params: SearchRequest{
 				Key:   "edgeHostname",
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// CircuitBreakerOptions configures the circuit breaker of a session, see WithCircuitBreaker
+	CircuitBreakerOptions struct {
+		// FailureThreshold is the number of consecutive failures to a host which opens its circuit,
+		// DefaultCircuitFailureThreshold when not positive
+		FailureThreshold int
+		// Cooldown is the time a circuit stays open before a probe request is let through,
+		// DefaultCircuitCooldown when not positive
+		Cooldown time.Duration
+	}
+
+	// CircuitState is the state of the circuit of a host
+	CircuitState string
+
+	// CircuitBreakerStats contains the circuit breaker state of a host
+	CircuitBreakerStats struct {
+		// State is the state of the circuit
+		State CircuitState
+		// ConsecutiveFailures is the number of failures since the last success
+		ConsecutiveFailures int
+		// OpenedAt is the time the circuit was last opened, the zero time if it never was
+		OpenedAt time.Time
+	}
+
+	// circuitBreaker tracks the failures of requests per host and rejects requests to hosts whose circuit is open
+	circuitBreaker struct {
+		threshold int
+		cooldown  time.Duration
+		now       func() time.Time
+
+		mu    sync.Mutex
+		hosts map[string]*hostCircuit
+	}
+
+	hostCircuit struct {
+		state    CircuitState
+		failures int
+		openedAt time.Time
+		// probing is set while the probe request of a half-open circuit is in flight
+		probing bool
+	}
+
+	// circuitOutcome is the result of a request as seen by the circuit breaker
+	circuitOutcome int
+)
+
+const (
+	// CircuitClosed is the state of a healthy host, requests are sent
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen is the state of a failing host, requests fail with ErrCircuitOpen until the cooldown elapses
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen is the state of a host whose cooldown elapsed, a single probe request is sent
+	// and its outcome closes or opens the circuit again
+	CircuitHalfOpen CircuitState = "half-open"
+
+	// DefaultCircuitFailureThreshold is the default number of consecutive failures opening a circuit
+	DefaultCircuitFailureThreshold = 5
+	// DefaultCircuitCooldown is the default time a circuit stays open
+	DefaultCircuitCooldown = 30 * time.Second
+)
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	// circuitIgnored is the outcome of requests canceled by the caller, which say nothing about the host
+	circuitIgnored
+)
+
+// ErrCircuitOpen is returned by Exec, without sending the request, when the circuit of the host is open
+// because of consecutive failures (see WithCircuitBreaker)
+var ErrCircuitOpen = errors.New("circuit open")
+
+// WithCircuitBreaker makes requests fail fast during outages: after FailureThreshold consecutive failures to a host,
+// i.e. network errors or 5xx responses, its circuit opens and Exec returns ErrCircuitOpen immediately for the
+// Cooldown period. The circuit then becomes half-open and lets a single probe request through, which closes the
+// circuit when it succeeds and opens it again when it fails. Any other response, including 4xx, counts as a success.
+// Use CircuitBreakerStates to inspect the state of the circuits, e.g. to export metrics.
+func WithCircuitBreaker(opts CircuitBreakerOptions) Option {
+	return func(s *session) {
+		if opts.FailureThreshold <= 0 {
+			opts.FailureThreshold = DefaultCircuitFailureThreshold
+		}
+		if opts.Cooldown <= 0 {
+			opts.Cooldown = DefaultCircuitCooldown
+		}
+		s.breaker = &circuitBreaker{
+			threshold: opts.FailureThreshold,
+			cooldown:  opts.Cooldown,
+			now:       time.Now,
+			hosts:     make(map[string]*hostCircuit),
+		}
+	}
+}
+
+// CircuitBreakerStates returns the circuit breaker state of the hosts requested so far, keyed by host.
+// It returns nil when the session was not created with WithCircuitBreaker.
+func CircuitBreakerStates(sess Session) map[string]CircuitBreakerStats {
+	s, ok := sess.(*session)
+	if !ok || s.breaker == nil {
+		return nil
+	}
+	return s.breaker.stats()
+}
+
+// allow returns the function to call with the outcome of a request to the host,
+// or an error wrapping ErrCircuitOpen when the request must not be sent
+func (b *circuitBreaker) allow(host string) (func(circuitOutcome), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.hosts[host]
+	if !ok {
+		c = &hostCircuit{state: CircuitClosed}
+		b.hosts[host] = c
+	}
+
+	if c.state == CircuitOpen && b.now().Sub(c.openedAt) >= b.cooldown {
+		c.state = CircuitHalfOpen
+	}
+	switch {
+	case c.state == CircuitOpen:
+		return nil, fmt.Errorf("%w: %s failed %d times in a row, retry after %s", ErrCircuitOpen, host,
+			c.failures, c.openedAt.Add(b.cooldown).Format(time.RFC3339))
+	case c.state == CircuitHalfOpen && c.probing:
+		return nil, fmt.Errorf("%w: %s is being probed", ErrCircuitOpen, host)
+	case c.state == CircuitHalfOpen:
+		c.probing = true
+		return func(outcome circuitOutcome) { b.report(c, true, outcome) }, nil
+	}
+	return func(outcome circuitOutcome) { b.report(c, false, outcome) }, nil
+}
+
+func (b *circuitBreaker) report(c *hostCircuit, probe bool, outcome circuitOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		c.probing = false
+	}
+	switch outcome {
+	case circuitSuccess:
+		c.state = CircuitClosed
+		c.failures = 0
+	case circuitFailure:
+		c.failures++
+		// requests sent before the circuit opened do not extend the cooldown
+		if probe || (c.state == CircuitClosed && c.failures >= b.threshold) {
+			c.state = CircuitOpen
+			c.openedAt = b.now()
+		}
+	}
+}
+
+func (b *circuitBreaker) stats() map[string]CircuitBreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := make(map[string]CircuitBreakerStats, len(b.hosts))
+	for host, c := range b.hosts {
+		state := c.state
+		if state == CircuitOpen && b.now().Sub(c.openedAt) >= b.cooldown {
+			state = CircuitHalfOpen
+		}
+		stats[host] = CircuitBreakerStats{
+			State:               state,
+			ConsecutiveFailures: c.failures,
+			OpenedAt:            c.openedAt,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,175 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	const host = "akab-host.luna.akamaiapis.net"
+	type step struct {
+		// advance moves the clock of the breaker before the request
+		advance time.Duration
+		// status is the response status, 0 for a network error
+		status        int
+		expectedState CircuitState
+		withError     error
+	}
+
+	tests := map[string]struct {
+		steps []step
+	}{
+		"opens after consecutive failures": {
+			steps: []step{
+				{status: http.StatusInternalServerError, expectedState: CircuitClosed},
+				{status: 0, expectedState: CircuitClosed},
+				{status: http.StatusBadGateway, expectedState: CircuitOpen},
+				{expectedState: CircuitOpen, withError: ErrCircuitOpen},
+			},
+		},
+		"success resets the failure count": {
+			steps: []step{
+				{status: http.StatusInternalServerError, expectedState: CircuitClosed},
+				{status: http.StatusInternalServerError, expectedState: CircuitClosed},
+				{status: http.StatusNotFound, expectedState: CircuitClosed},
+				{status: http.StatusInternalServerError, expectedState: CircuitClosed},
+				{status: http.StatusInternalServerError, expectedState: CircuitClosed},
+			},
+		},
+		"half-open probe closes the circuit": {
+			steps: []step{
+				{status: http.StatusServiceUnavailable, expectedState: CircuitClosed},
+				{status: http.StatusServiceUnavailable, expectedState: CircuitClosed},
+				{status: http.StatusServiceUnavailable, expectedState: CircuitOpen},
+				{advance: 30 * time.Second, expectedState: CircuitOpen, withError: ErrCircuitOpen},
+				{advance: 30 * time.Second, status: http.StatusOK, expectedState: CircuitClosed},
+				{status: http.StatusOK, expectedState: CircuitClosed},
+			},
+		},
+		"half-open probe failure opens the circuit again": {
+			steps: []step{
+				{status: 0, expectedState: CircuitClosed},
+				{status: 0, expectedState: CircuitClosed},
+				{status: 0, expectedState: CircuitOpen},
+				{advance: time.Minute, status: http.StatusInternalServerError, expectedState: CircuitOpen},
+				{advance: 59 * time.Second, expectedState: CircuitOpen, withError: ErrCircuitOpen},
+				{advance: time.Second, status: http.StatusOK, expectedState: CircuitClosed},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+			var requests int
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				requests++
+				status := test.steps[requests-1].status
+				if status == 0 {
+					return nil, errors.New("connection reset by peer")
+				}
+				return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+			})
+			sess, err := New(WithSigner(&edgegrid.Config{Host: host}), WithTransport(transport),
+				WithCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 3, Cooldown: time.Minute}))
+			require.NoError(t, err)
+			sess.(*session).breaker.now = func() time.Time { return now }
+
+			for i, step := range test.steps {
+				now = now.Add(step.advance)
+				sent := requests
+				req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+				require.NoError(t, err)
+				_, err = sess.Exec(req, nil)
+				switch {
+				case step.withError != nil:
+					assert.True(t, errors.Is(err, step.withError), "step %d: want: %s; got: %s", i, step.withError, err)
+					assert.Equal(t, sent, requests, "step %d: request sent while the circuit is open", i)
+					requests++
+				case step.status == 0:
+					assert.Error(t, err, "step %d", i)
+				default:
+					assert.NoError(t, err, "step %d", i)
+				}
+				assert.Equal(t, step.expectedState, CircuitBreakerStates(sess)[host].State, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestWithCircuitBreaker_SingleProbe(t *testing.T) {
+	const host = "akab-host.luna.akamaiapis.net"
+	now := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/probe" {
+			close(probing)
+			<-release
+		}
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: ioutil.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+	})
+	sess, err := New(WithSigner(&edgegrid.Config{Host: host}), WithTransport(transport),
+		WithCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1}))
+	require.NoError(t, err)
+	breaker := sess.(*session).breaker
+	breaker.now = func() time.Time { return now }
+
+	exec := func(path string) error {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		_, err = sess.Exec(req, nil)
+		return err
+	}
+	require.NoError(t, exec("/fail"))
+	stats := CircuitBreakerStates(sess)[host]
+	assert.Equal(t, CircuitBreakerStats{State: CircuitOpen, ConsecutiveFailures: 1, OpenedAt: now}, stats)
+
+	now = now.Add(DefaultCircuitCooldown)
+	assert.Equal(t, CircuitHalfOpen, CircuitBreakerStates(sess)[host].State)
+	probeDone := make(chan error)
+	go func() {
+		probeDone <- exec("/probe")
+	}()
+	<-probing
+
+	err = exec("/other")
+	assert.True(t, errors.Is(err, ErrCircuitOpen), "want: %s; got: %s", ErrCircuitOpen, err)
+
+	close(release)
+	require.NoError(t, <-probeDone)
+	assert.Equal(t, CircuitOpen, CircuitBreakerStates(sess)[host].State)
+}
+
+func TestWithCircuitBreaker_CanceledRequests(t *testing.T) {
+	const host = "akab-host.luna.akamaiapis.net"
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, r.Context().Err()
+	})
+	sess, err := New(WithSigner(&edgegrid.Config{Host: host}), WithTransport(transport),
+		WithCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/papi/v1/contracts", nil)
+	require.NoError(t, err)
+	_, err = sess.Exec(req, nil)
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+	assert.Equal(t, CircuitBreakerStats{State: CircuitClosed}, CircuitBreakerStates(sess)[host])
+}
+
+func TestCircuitBreakerStates_Disabled(t *testing.T) {
+	sess, err := New(WithSigner(&edgegrid.Config{}))
+	require.NoError(t, err)
+	assert.Nil(t, CircuitBreakerStates(sess))
+}
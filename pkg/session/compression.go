@@ -0,0 +1,95 @@
+package session
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is the Accept-Encoding header of the requests of sessions created with WithCompression
+const acceptEncoding = "gzip, deflate"
+
+// WithCompression makes the session request gzip or deflate compressed responses, which reduces the transfer time
+// of large responses such as PAPI rule trees. Exec decompresses responses with a gzip or deflate Content-Encoding
+// regardless of the option, e.g. when the Accept-Encoding header is set with WithContextHeaders, in which case
+// the transport does not decompress them itself.
+func WithCompression(enabled bool) Option {
+	return func(s *session) {
+		s.compression = enabled
+	}
+}
+
+// decodeResponseBody replaces the body of a response with a gzip or deflate Content-Encoding by a reader of the
+// decompressed body, and removes the headers describing the compressed body, like the transport does for the
+// responses to the requests it adds Accept-Encoding to. Bodies with other encodings are left as they are.
+func decodeResponseBody(resp *http.Response) {
+	if resp.Uncompressed || resp.Body == nil || resp.Body == http.NoBody {
+		return
+	}
+
+	var open func(io.Reader) (io.ReadCloser, error)
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		open = func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		}
+	case "deflate":
+		open = openDeflate
+	default:
+		return
+	}
+
+	resp.Body = &decodedBody{body: resp.Body, open: open}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// openDeflate reads a deflate Content-Encoding, which is zlib wrapped data, although some servers send raw deflate data
+func openDeflate(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(2)
+	if err != nil && (err != io.EOF || len(header) == 0) {
+		return nil, err
+	}
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
+
+// decodedBody decompresses the response body on the first read, so that empty bodies, e.g. of 204 responses,
+// do not fail on a missing compression header
+type decodedBody struct {
+	body    io.ReadCloser
+	open    func(io.Reader) (io.ReadCloser, error)
+	reader  io.ReadCloser
+	openErr error
+}
+
+func (b *decodedBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.openErr == nil {
+		b.reader, b.openErr = b.open(b.body)
+		if b.openErr == io.EOF {
+			b.openErr = nil
+			b.reader = http.NoBody
+		}
+	}
+	if b.openErr != nil {
+		return 0, fmt.Errorf("decompressing response body: %w", b.openErr)
+	}
+	return b.reader.Read(p)
+}
+
+func (b *decodedBody) Close() error {
+	if b.reader != nil {
+		_ = b.reader.Close()
+	}
+	return b.body.Close()
+}
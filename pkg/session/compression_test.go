@@ -0,0 +1,161 @@
+package session
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compress(t *testing.T, newWriter func(io.Writer) io.WriteCloser, data string) []byte {
+	var buf bytes.Buffer
+	w := newWriter(&buf)
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestExecCompression(t *testing.T) {
+	const body = `{"ruleFormat": "v2023-01-05"}`
+	gzipped := compress(t, func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }, body)
+
+	tests := map[string]struct {
+		compression            bool
+		requestHeader          http.Header
+		responseStatus         int
+		contentEncoding        string
+		responseBody           []byte
+		expectedAcceptEncoding string
+		expectedOut            string
+		expectedBody           string
+		withError              string
+	}{
+		"gzip with compression": {
+			compression:            true,
+			responseStatus:         http.StatusOK,
+			contentEncoding:        "gzip",
+			responseBody:           gzipped,
+			expectedAcceptEncoding: "gzip, deflate",
+			expectedOut:            "v2023-01-05",
+			expectedBody:           body,
+		},
+		"gzip with Accept-Encoding context header": {
+			requestHeader:          http.Header{"Accept-Encoding": {"gzip"}},
+			responseStatus:         http.StatusOK,
+			contentEncoding:        "gzip",
+			responseBody:           gzipped,
+			expectedAcceptEncoding: "gzip",
+			expectedOut:            "v2023-01-05",
+			expectedBody:           body,
+		},
+		"zlib deflate": {
+			compression:     true,
+			responseStatus:  http.StatusOK,
+			contentEncoding: "deflate",
+			responseBody: compress(t, func(w io.Writer) io.WriteCloser {
+				return zlib.NewWriter(w)
+			}, body),
+			expectedAcceptEncoding: "gzip, deflate",
+			expectedOut:            "v2023-01-05",
+			expectedBody:           body,
+		},
+		"raw deflate": {
+			compression:     true,
+			responseStatus:  http.StatusOK,
+			contentEncoding: "Deflate",
+			responseBody: compress(t, func(w io.Writer) io.WriteCloser {
+				fw, err := flate.NewWriter(w, flate.DefaultCompression)
+				require.NoError(t, err)
+				return fw
+			}, body),
+			expectedAcceptEncoding: "gzip, deflate",
+			expectedOut:            "v2023-01-05",
+			expectedBody:           body,
+		},
+		"gzip error response": {
+			compression:            true,
+			responseStatus:         http.StatusNotFound,
+			contentEncoding:        "gzip",
+			responseBody:           compress(t, func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }, `{"status": 404}`),
+			expectedAcceptEncoding: "gzip, deflate",
+			expectedBody:           `{"status": 404}`,
+		},
+		"empty gzip body": {
+			compression:            true,
+			responseStatus:         http.StatusNoContent,
+			contentEncoding:        "gzip",
+			expectedAcceptEncoding: "gzip, deflate",
+		},
+		"without compression": {
+			responseStatus: http.StatusOK,
+			responseBody:   []byte(body),
+			expectedOut:    "v2023-01-05",
+			expectedBody:   body,
+		},
+		"corrupt gzip": {
+			compression:            true,
+			responseStatus:         http.StatusOK,
+			contentEncoding:        "gzip",
+			responseBody:           []byte(body),
+			expectedAcceptEncoding: "gzip, deflate",
+			withError:              "decompressing response body: gzip: invalid header",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				assert.Equal(t, test.expectedAcceptEncoding, r.Header.Get("Accept-Encoding"))
+				header := http.Header{}
+				if test.contentEncoding != "" {
+					header.Set("Content-Encoding", test.contentEncoding)
+					header.Set("Content-Length", strconv.Itoa(len(test.responseBody)))
+				}
+				return &http.Response{
+					StatusCode:    test.responseStatus,
+					Header:        header,
+					Body:          ioutil.NopCloser(bytes.NewReader(test.responseBody)),
+					ContentLength: int64(len(test.responseBody)),
+					Request:       r,
+				}, nil
+			})
+			s, err := New(WithSigner(&edgegrid.Config{Host: "akab-host.luna.akamaiapis.net"}), WithTransport(transport), WithCompression(test.compression))
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if test.requestHeader != nil {
+				ctx = ContextWithOptions(ctx, WithContextHeaders(test.requestHeader))
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/papi/v1/properties/prp_1/versions/1/rules", nil)
+			require.NoError(t, err)
+			var out struct {
+				RuleFormat string `json:"ruleFormat"`
+			}
+			resp, err := s.Exec(req, &out)
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOut, out.RuleFormat)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+			assert.Empty(t, resp.Header.Get("Content-Length"))
+			data, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBody, string(data))
+			assert.NoError(t, resp.Body.Close())
+		})
+	}
+}
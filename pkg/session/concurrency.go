@@ -0,0 +1,177 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type (
+	// ConcurrencyStats contains the adaptive concurrency state of an endpoint
+	ConcurrencyStats struct {
+		// Limit is the number of requests currently allowed in flight
+		Limit int
+		// InFlight is the number of requests currently in flight
+		InFlight int
+	}
+
+	// adaptiveConcurrency limits the number of requests in flight per endpoint, i.e. host and API path prefix
+	// such as /papi/v1, with an additive increase, multiplicative decrease (AIMD) policy: the limit is halved
+	// when the API responds with 429 Too Many Requests and increased by one after a limit's worth of
+	// successful responses
+	adaptiveConcurrency struct {
+		initial, min, max int
+
+		mu        sync.Mutex
+		endpoints map[string]*endpointLimiter
+	}
+
+	endpointLimiter struct {
+		mu        sync.Mutex
+		limit     int
+		inFlight  int
+		successes int
+		// acquired counts acquisitions, decreasedAt is its value at the last decrease, so that a burst of 429
+		// responses to requests issued under the same limit decreases it only once
+		acquired    uint64
+		decreasedAt uint64
+		// released is closed and replaced whenever a slot may have become available
+		released chan struct{}
+	}
+)
+
+// WithAdaptiveConcurrency limits the number of requests in flight per endpoint, i.e. per host and API path prefix
+// such as /papi/v1 or /config-dns/v2, starting at initial. The limit is halved, down to min, when the API responds
+// with 429 Too Many Requests and grows by one, up to max, after as many successful responses as the current limit.
+// Requests over the limit wait for a slot or until their context is done.
+//
+// Unlike a fixed rate limit, this adapts to API limits which vary by endpoint and account. Use AdaptiveConcurrencyStats
+// to inspect the current limits.
+func WithAdaptiveConcurrency(initial, min, max int) Option {
+	return func(s *session) {
+		if min < 1 {
+			min = 1
+		}
+		if max < min {
+			max = min
+		}
+		if initial < min {
+			initial = min
+		}
+		if initial > max {
+			initial = max
+		}
+		s.concurrency = &adaptiveConcurrency{
+			initial:   initial,
+			min:       min,
+			max:       max,
+			endpoints: make(map[string]*endpointLimiter),
+		}
+	}
+}
+
+// AdaptiveConcurrencyStats returns the adaptive concurrency state of the endpoints requested so far, keyed by host
+// and API path prefix, e.g. "akab-xxx.luna.akamaiapis.net/papi/v1". It returns nil when the session was not created
+// with WithAdaptiveConcurrency.
+func AdaptiveConcurrencyStats(sess Session) map[string]ConcurrencyStats {
+	s, ok := sess.(*session)
+	if !ok || s.concurrency == nil {
+		return nil
+	}
+	return s.concurrency.stats()
+}
+
+// acquire waits until a request to the endpoint is allowed and returns the function to call with the response status code,
+// or 0 when no response was received, once it completes. It also reports whether the request had to wait.
+func (a *adaptiveConcurrency) acquire(ctx context.Context, endpoint string) (func(int), bool, error) {
+	l := a.endpoint(endpoint)
+	for waited := false; ; waited = true {
+		l.mu.Lock()
+		if l.inFlight < l.limit {
+			l.inFlight++
+			l.acquired++
+			seq := l.acquired
+			l.mu.Unlock()
+			return func(status int) {
+				a.release(l, seq, status)
+			}, waited, nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, waited, ctx.Err()
+		}
+	}
+}
+
+func (a *adaptiveConcurrency) release(l *endpointLimiter, seq uint64, status int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	switch {
+	case status == http.StatusTooManyRequests:
+		l.successes = 0
+		if seq > l.decreasedAt {
+			l.limit /= 2
+			if l.limit < a.min {
+				l.limit = a.min
+			}
+			l.decreasedAt = l.acquired
+		}
+	case status > 0:
+		l.successes++
+		if l.successes >= l.limit && l.limit < a.max {
+			l.limit++
+			l.successes = 0
+		}
+	}
+
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+func (a *adaptiveConcurrency) endpoint(key string) *endpointLimiter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	l, ok := a.endpoints[key]
+	if !ok {
+		l = &endpointLimiter{
+			limit:    a.initial,
+			released: make(chan struct{}),
+		}
+		a.endpoints[key] = l
+	}
+	return l
+}
+
+func (a *adaptiveConcurrency) stats() map[string]ConcurrencyStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := make(map[string]ConcurrencyStats, len(a.endpoints))
+	for key, l := range a.endpoints {
+		l.mu.Lock()
+		stats[key] = ConcurrencyStats{
+			Limit:    l.limit,
+			InFlight: l.inFlight,
+		}
+		l.mu.Unlock()
+	}
+	return stats
+}
+
+// endpointKey returns the host and the first two path segments of the URL, e.g. "host/papi/v1"
+func endpointKey(u *url.URL) string {
+	segments := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 3)
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	return u.Host + "/" + strings.Join(segments, "/")
+}
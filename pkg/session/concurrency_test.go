@@ -0,0 +1,146 @@
+package session
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAdaptiveConcurrency(t *testing.T) {
+	const endpoint = "akab-host.luna.akamaiapis.net/papi/v1"
+
+	var overloaded int32
+	var inFlight, maxInFlight int32
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		status := http.StatusOK
+		if atomic.LoadInt32(&overloaded) == 1 {
+			status = http.StatusTooManyRequests
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			Header:     http.Header{},
+			Request:    r,
+		}, nil
+	})
+
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host:         "akab-host.luna.akamaiapis.net",
+		ClientToken:  "akab-client-token",
+		ClientSecret: "client-secret",
+		AccessToken:  "akab-access-token",
+		MaxBody:      131072,
+	}), WithTransport(transport), WithAdaptiveConcurrency(8, 1, 16))
+	require.NoError(t, err)
+
+	burst := func(requests int) {
+		var wg sync.WaitGroup
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req, err := http.NewRequest(http.MethodGet, "/papi/v1/groups", nil)
+				require.NoError(t, err)
+				_, err = s.Exec(req, nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	}
+	limit := func() int {
+		return AdaptiveConcurrencyStats(s)[endpoint].Limit
+	}
+
+	burst(16)
+	// the limit reaches 9 after the first 8 responses
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(9), "initial limit is not applied")
+	assert.Equal(t, 9, limit(), "limit does not grow on successful responses")
+
+	// bursts of 429 responses shrink the limit down to min
+	atomic.StoreInt32(&overloaded, 1)
+	previous := limit()
+	for i := 0; i < 4; i++ {
+		burst(16)
+		assert.Less(t, limit(), previous, "limit does not shrink on 429 responses")
+		previous = limit()
+		if previous == 1 {
+			break
+		}
+	}
+	assert.Equal(t, 1, limit())
+
+	atomic.StoreInt32(&maxInFlight, 0)
+	burst(4)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxInFlight), "reduced limit is not applied")
+
+	// successful responses grow the limit back, one request at a time
+	atomic.StoreInt32(&overloaded, 0)
+	for i := 0; i < 20; i++ {
+		burst(16)
+		assert.Greater(t, limit(), previous, "limit does not grow on successful responses")
+		previous = limit()
+		if previous == 16 {
+			break
+		}
+	}
+	assert.Equal(t, 16, limit())
+	assert.Equal(t, ConcurrencyStats{Limit: 16}, AdaptiveConcurrencyStats(s)[endpoint])
+}
+
+func TestWithAdaptiveConcurrency_ContextDone(t *testing.T) {
+	release := make(chan struct{})
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		<-release
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			Header:     http.Header{},
+			Request:    r,
+		}, nil
+	})
+	s, err := New(WithSigner(&edgegrid.Config{Host: "akab-host.luna.akamaiapis.net"}),
+		WithTransport(transport), WithAdaptiveConcurrency(1, 1, 1))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, err := http.NewRequest(http.MethodGet, "/config-dns/v2/zones", nil)
+		require.NoError(t, err)
+		_, err = s.Exec(req, nil)
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool {
+		return AdaptiveConcurrencyStats(s)["akab-host.luna.akamaiapis.net/config-dns/v2"].InFlight == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/config-dns/v2/zones", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	<-done
+	assert.Nil(t, AdaptiveConcurrencyStats(Must(New(WithSigner(&edgegrid.Config{})))))
+}
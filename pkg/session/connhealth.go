@@ -0,0 +1,123 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+const (
+	// DefaultIdleConnTimeout is the idle connection timeout of WithConnectionHealth, shorter than the keep-alive
+	// timeout of most servers and load balancers, so that connections are closed by the client before the server
+	// closes them while a request is being sent
+	DefaultIdleConnTimeout = 30 * time.Second
+	// DefaultPingInterval is the time after which WithConnectionHealth checks the health of an HTTP/2 connection
+	// without any frame received with a ping
+	DefaultPingInterval = 30 * time.Second
+	// DefaultPingTimeout is the time WithConnectionHealth waits for the response to a ping before closing
+	// the HTTP/2 connection
+	DefaultPingTimeout = 15 * time.Second
+)
+
+// ConnectionHealth contains the settings of WithConnectionHealth
+type ConnectionHealth struct {
+	// IdleConnTimeout is the time after which idle connections are closed. When zero, it is the idle timeout
+	// of WithConnectionPool if set, otherwise DefaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+	// PingInterval is the time without any frame received after which an HTTP/2 connection is checked with a ping,
+	// DefaultPingInterval when zero. Pings are disabled when negative.
+	PingInterval time.Duration
+	// PingTimeout is the time to wait for the response to a ping before closing the connection,
+	// DefaultPingTimeout when zero
+	PingTimeout time.Duration
+}
+
+// WithConnectionHealth keeps the connections of long-lived sessions making sporadic calls healthy. It enables HTTP/2
+// where the API supports it, closes idle connections after a short timeout and checks idle HTTP/2 connections with
+// pings, so that stale connections are dropped before requests are sent on them. Requests can still race with a server
+// closing a keep-alive connection: Exec therefore sends idempotent requests, i.e. GET, HEAD, OPTIONS, PUT and DELETE
+// requests with a body which can be sent again, once more, signed again, when they fail with an EOF or a connection
+// reset on a reused connection. The transport only retries GET, HEAD and OPTIONS requests itself.
+//
+// Like WithConnectionPool, the option is applied to a copy of the client and its transport when the transport is
+// an *http.Transport (or nil, meaning http.DefaultTransport), otherwise only the retry applies and a warning is logged.
+func WithConnectionHealth(health ConnectionHealth) Option {
+	return func(s *session) {
+		if health.PingInterval == 0 {
+			health.PingInterval = DefaultPingInterval
+		}
+		if health.PingTimeout == 0 {
+			health.PingTimeout = DefaultPingTimeout
+		}
+		s.health = &health
+	}
+}
+
+// applyConnectionHealth sets the connection health settings on a copy of the client transport
+func (s *session) applyConnectionHealth() {
+	var transport *http.Transport
+	switch t := s.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		s.Log(context.Background()).Warnf("connection health settings ignored for custom transport %T", t)
+		return
+	}
+
+	transport.ForceAttemptHTTP2 = true
+	switch {
+	case s.health.IdleConnTimeout > 0:
+		transport.IdleConnTimeout = s.health.IdleConnTimeout
+	case s.pool == nil:
+		transport.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	// the HTTP/2 support of a transport which was already used is copied along with it, without the ping settings
+	delete(transport.TLSNextProto, "h2")
+	h2, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		s.Log(context.Background()).Warnf("HTTP/2 ping settings ignored: %s", err)
+	} else if s.health.PingInterval > 0 {
+		h2.ReadIdleTimeout = s.health.PingInterval
+		h2.PingTimeout = s.health.PingTimeout
+	}
+
+	client := *s.client
+	client.Transport = transport
+	s.client = &client
+}
+
+// withConnReuseTrace returns the request with a trace recording whether its connection was reused
+func withConnReuseTrace(r *http.Request) (*http.Request, *atomic.Bool) {
+	reused := new(atomic.Bool)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			reused.Store(info.Reused)
+		},
+	}
+	return r.WithContext(httptrace.WithClientTrace(r.Context(), trace)), reused
+}
+
+// staleConnectionError reports whether err is the failure of a request sent on a connection closed by the server
+func staleConnectionError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
+
+// canRetryStaleConnection reports whether the request failing with err on a reused connection is sent once more
+func (s *session) canRetryStaleConnection(r *http.Request, reused bool, err error) bool {
+	return s.health != nil && reused && idempotentMethod(r.Method) && replayableBody(r) &&
+		staleConnectionError(r.Context(), err)
+}
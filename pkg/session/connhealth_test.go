@@ -0,0 +1,174 @@
+package session
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithConnectionHealth(t *testing.T) {
+	tests := map[string]struct {
+		options             []Option
+		expectedIdleTimeout time.Duration
+		expectedPings       bool
+	}{
+		"defaults": {
+			options:             []Option{WithConnectionHealth(ConnectionHealth{})},
+			expectedIdleTimeout: DefaultIdleConnTimeout,
+			expectedPings:       true,
+		},
+		"custom idle timeout, pings disabled": {
+			options:             []Option{WithConnectionHealth(ConnectionHealth{IdleConnTimeout: 10 * time.Second, PingInterval: -1})},
+			expectedIdleTimeout: 10 * time.Second,
+		},
+		"idle timeout of connection pool": {
+			options: []Option{
+				WithConnectionPool(100, 32, 90*time.Second),
+				WithConnectionHealth(ConnectionHealth{}),
+			},
+			expectedIdleTimeout: 90 * time.Second,
+			expectedPings:       true,
+		},
+		"transport used before": {
+			options: []Option{
+				WithTransport(usedTransport(t)),
+				WithConnectionHealth(ConnectionHealth{}),
+			},
+			expectedIdleTimeout: DefaultIdleConnTimeout,
+			expectedPings:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(append([]Option{WithSigner(&edgegrid.Config{})}, test.options...)...)
+			require.NoError(t, err)
+
+			transport, ok := s.Client().Transport.(*http.Transport)
+			require.True(t, ok)
+			assert.True(t, transport.ForceAttemptHTTP2)
+			assert.Equal(t, test.expectedIdleTimeout, transport.IdleConnTimeout)
+			assert.Contains(t, transport.TLSNextProto, "h2")
+			assert.Equal(t, test.expectedPings, s.(*session).health.PingInterval > 0)
+		})
+	}
+}
+
+// usedTransport returns a transport whose HTTP/2 support was set up by a request
+func usedTransport(t *testing.T) *http.Transport {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Contains(t, transport.TLSNextProto, "h2")
+	return transport
+}
+
+func TestExecRetryStaleConnection(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		body         interface{}
+		health       bool
+		expectedHits int32
+		withError    bool
+	}{
+		"GET retried": {
+			method:       http.MethodGet,
+			health:       true,
+			expectedHits: 3,
+		},
+		"PUT with body retried": {
+			method:       http.MethodPut,
+			body:         map[string]string{"name": "www"},
+			health:       true,
+			expectedHits: 3,
+		},
+		"DELETE retried": {
+			method:       http.MethodDelete,
+			health:       true,
+			expectedHits: 3,
+		},
+		"POST not retried": {
+			method:       http.MethodPost,
+			body:         map[string]string{"name": "www"},
+			health:       true,
+			expectedHits: 2,
+			withError:    true,
+		},
+		"PUT not retried without connection health": {
+			method:       http.MethodPut,
+			body:         map[string]string{"name": "www"},
+			expectedHits: 2,
+			withError:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hits, connections int32
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// the second request, sent on the connection kept alive after the first one, is reset
+				if atomic.AddInt32(&hits, 1) == 2 {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					require.NoError(t, err)
+					require.NoError(t, conn.Close())
+					return
+				}
+				assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256"))
+				w.WriteHeader(http.StatusOK)
+			}))
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					atomic.AddInt32(&connections, 1)
+				}
+			}
+			server.Start()
+			defer server.Close()
+
+			options := []Option{
+				WithSigner(&edgegrid.Config{Host: strings.TrimPrefix(server.URL, "http://")}),
+				WithTransport(&http.Transport{}),
+			}
+			if test.health {
+				options = append(options, WithConnectionHealth(ConnectionHealth{}))
+			}
+			s, err := New(options...)
+			require.NoError(t, err)
+
+			exec := func() (*http.Response, error) {
+				req, err := http.NewRequestWithContext(context.Background(), test.method, server.URL+"/papi/v1/groups?contractId=ctr_1", nil)
+				require.NoError(t, err)
+				if test.body != nil {
+					return s.Exec(req, nil, test.body)
+				}
+				return s.Exec(req, nil)
+			}
+
+			resp, err := exec()
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			resp, err = exec()
+			assert.Equal(t, test.expectedHits, atomic.LoadInt32(&hits))
+			if test.withError {
+				assert.True(t, IsRetryable(err), "want a network error; got: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&connections))
+		})
+	}
+}
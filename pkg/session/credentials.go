@@ -0,0 +1,143 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+)
+
+// DefaultCredentialTTL is the time the credentials of a CredentialProvider are cached for when no TTL is set
+const DefaultCredentialTTL = 5 * time.Minute
+
+// ErrCredentials is returned by Exec when the credential provider of the session fails
+var ErrCredentials = errors.New("fetching credentials")
+
+type (
+	// Credentials are the EdgeGrid credentials signing the requests of a session
+	Credentials = edgegrid.Config
+
+	// CredentialProvider supplies the credentials of a session, e.g. from a secrets manager rotating them,
+	// so that long-running processes pick up new credentials without restarting (see WithCredentialProvider)
+	CredentialProvider interface {
+		// Credentials returns the current credentials. It is called with the context of the request being signed.
+		Credentials(ctx context.Context) (Credentials, error)
+	}
+
+	// CredentialProviderFunc is a function implementing CredentialProvider
+	CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+	// credentialCache caches the credentials of a provider for a TTL
+	credentialCache struct {
+		provider CredentialProvider
+		ttl      time.Duration
+
+		mu          sync.Mutex
+		credentials *edgegrid.Config
+		expires     time.Time
+	}
+)
+
+// Credentials calls f(ctx)
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a provider of fixed credentials
+func StaticCredentials(credentials Credentials) CredentialProvider {
+	return CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		return credentials, nil
+	})
+}
+
+// EdgercCredentials returns a provider reading the section of the edgerc file on every call,
+// edgegrid.DefaultConfigFile and edgegrid.DefaultSection when empty
+func EdgercCredentials(file, section string) CredentialProvider {
+	if file == "" {
+		file = edgegrid.DefaultConfigFile
+	}
+	if section == "" {
+		section = edgegrid.DefaultSection
+	}
+	return CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		config, err := edgegrid.New(edgegrid.WithFile(file), edgegrid.WithSection(section))
+		if err != nil {
+			return Credentials{}, err
+		}
+		return *config, nil
+	})
+}
+
+// EnvCredentials returns a provider reading the AKAMAI_{SECTION}_* environment variables on every call,
+// see edgegrid.Config.FromEnv, edgegrid.DefaultSection when empty
+func EnvCredentials(section string) CredentialProvider {
+	if section == "" {
+		section = edgegrid.DefaultSection
+	}
+	return CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		var config edgegrid.Config
+		if err := config.FromEnv(section); err != nil {
+			return Credentials{}, err
+		}
+		return config, nil
+	})
+}
+
+// WithCredentialProvider sets the provider of the default credentials of the session, used instead of the signer
+// set with WithSigner and of the default edgerc section. Exec fetches the credentials when signing a request,
+// and caches them for the TTL set with WithCredentialTTL, so that rotated credentials are picked up within the TTL.
+// Requests fail with ErrCredentials when the provider fails. Sections set with WithSigners are not affected.
+func WithCredentialProvider(provider CredentialProvider) Option {
+	return func(s *session) {
+		if s.credentials == nil {
+			s.credentials = &credentialCache{ttl: DefaultCredentialTTL}
+		}
+		s.credentials.provider = provider
+	}
+}
+
+// WithCredentialTTL sets the time the credentials of the provider set with WithCredentialProvider are cached for,
+// DefaultCredentialTTL by default. The provider is called for every request when the TTL is not positive.
+func WithCredentialTTL(ttl time.Duration) Option {
+	return func(s *session) {
+		if s.credentials == nil {
+			s.credentials = &credentialCache{}
+		}
+		s.credentials.ttl = ttl
+	}
+}
+
+// get returns the cached credentials, fetching them from the provider when they expired
+func (c *credentialCache) get(ctx context.Context) (*edgegrid.Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.credentials != nil && time.Now().Before(c.expires) {
+		return c.credentials, nil
+	}
+	credentials, err := c.provider.Credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCredentials, err)
+	}
+	if err := credentials.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCredentials, err)
+	}
+	c.credentials = &credentials
+	c.expires = time.Now().Add(c.ttl)
+	return c.credentials, nil
+}
+
+// credentialSigner returns the signer of the credentials of the provider of the session
+func (s *session) credentialSigner(ctx context.Context) (edgegrid.Signer, error) {
+	credentials, err := s.credentials.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.maxBody != 0 {
+		return s.withMaxBody(credentials), nil
+	}
+	return credentials, nil
+}
@@ -0,0 +1,185 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingProvider returns the credentials set last, counting the calls
+type rotatingProvider struct {
+	mu          sync.Mutex
+	credentials Credentials
+	calls       int
+	err         error
+}
+
+func (p *rotatingProvider) Credentials(context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	return p.credentials, p.err
+}
+
+func (p *rotatingProvider) rotate(credentials Credentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.credentials = credentials
+}
+
+func testCredentials(clientToken string) Credentials {
+	return Credentials{
+		Host:         "akab-host.luna.akamaiapis.net",
+		ClientToken:  clientToken,
+		ClientSecret: "client-secret",
+		AccessToken:  "akab-access-token",
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+}
+
+// authorizationField returns the value of the field of an EdgeGrid Authorization header
+func authorizationField(header, field string) string {
+	for _, part := range strings.Split(strings.TrimPrefix(header, "EG1-HMAC-SHA256 "), ";") {
+		if value, ok := strings.CutPrefix(part, field+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func TestWithCredentialProvider(t *testing.T) {
+	tests := map[string]struct {
+		options        []Option
+		expectedTokens []string
+		expectedCalls  int
+		providerErr    error
+		withError      error
+	}{
+		"rotation picked up after the TTL": {
+			options:        []Option{WithCredentialTTL(-1)},
+			expectedTokens: []string{"akab-client-token-1", "akab-client-token-2"},
+			expectedCalls:  2,
+		},
+		"credentials cached for the TTL": {
+			options:        []Option{WithCredentialTTL(time.Hour)},
+			expectedTokens: []string{"akab-client-token-1", "akab-client-token-1"},
+			expectedCalls:  1,
+		},
+		"provider instead of signer": {
+			options: []Option{
+				WithSigner(&edgegrid.Config{Host: "akab-host.luna.akamaiapis.net", ClientToken: "akab-signer-token"}),
+				WithCredentialTTL(-1),
+			},
+			expectedTokens: []string{"akab-client-token-1", "akab-client-token-2"},
+			expectedCalls:  2,
+		},
+		"provider error": {
+			providerErr: errors.New("secret not found"),
+			withError:   ErrCredentials,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var tokens []string
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				assert.Equal(t, "akab-host.luna.akamaiapis.net", r.URL.Host)
+				tokens = append(tokens, authorizationField(r.Header.Get("Authorization"), "client_token"))
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}, Request: r}, nil
+			})
+			provider := &rotatingProvider{credentials: testCredentials("akab-client-token-1"), err: test.providerErr}
+
+			options := append([]Option{WithTransport(transport), WithCredentialProvider(provider)}, test.options...)
+			s, err := New(options...)
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequest(http.MethodGet, "/papi/v1/groups", nil)
+				require.NoError(t, err)
+				_, err = s.Exec(req, nil)
+				if test.withError != nil {
+					assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+					assert.True(t, errors.Is(err, test.providerErr), "want: %s; got: %s", test.providerErr, err)
+					return
+				}
+				require.NoError(t, err)
+				provider.rotate(testCredentials("akab-client-token-2"))
+			}
+
+			assert.Equal(t, test.expectedTokens, tokens)
+			assert.Equal(t, test.expectedCalls, provider.calls)
+		})
+	}
+}
+
+func TestBuiltinCredentialProviders(t *testing.T) {
+	edgerc := filepath.Join(t.TempDir(), ".edgerc")
+	require.NoError(t, os.WriteFile(edgerc, []byte(`[ccu]
+host = akab-host.luna.akamaiapis.net
+client_token = akab-client-token-1
+client_secret = client-secret
+access_token = akab-access-token
+`), 0600))
+
+	t.Setenv("AKAMAI_CCU_HOST", "akab-host.luna.akamaiapis.net")
+	t.Setenv("AKAMAI_CCU_CLIENT_TOKEN", "akab-client-token-1")
+	t.Setenv("AKAMAI_CCU_CLIENT_SECRET", "client-secret")
+	t.Setenv("AKAMAI_CCU_ACCESS_TOKEN", "akab-access-token")
+
+	tests := map[string]struct {
+		provider CredentialProvider
+		rotate   func(t *testing.T)
+	}{
+		"static": {
+			provider: StaticCredentials(testCredentials("akab-client-token-1")),
+		},
+		"edgerc": {
+			provider: EdgercCredentials(edgerc, "ccu"),
+			rotate: func(t *testing.T) {
+				require.NoError(t, os.WriteFile(edgerc, []byte(`[ccu]
+host = akab-host.luna.akamaiapis.net
+client_token = akab-client-token-2
+client_secret = client-secret
+access_token = akab-access-token
+`), 0600))
+			},
+		},
+		"env": {
+			provider: EnvCredentials("ccu"),
+			rotate: func(t *testing.T) {
+				t.Setenv("AKAMAI_CCU_CLIENT_TOKEN", "akab-client-token-2")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			credentials, err := test.provider.Credentials(context.Background())
+			require.NoError(t, err)
+			expected := testCredentials("akab-client-token-1")
+			assert.Equal(t, expected.Host, credentials.Host)
+			assert.Equal(t, expected.ClientToken, credentials.ClientToken)
+			assert.Equal(t, expected.ClientSecret, credentials.ClientSecret)
+			assert.Equal(t, expected.AccessToken, credentials.AccessToken)
+			assert.Equal(t, expected.MaxBody, credentials.MaxBody)
+
+			if test.rotate == nil {
+				return
+			}
+			test.rotate(t)
+			credentials, err = test.provider.Credentials(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "akab-client-token-2", credentials.ClientToken)
+		})
+	}
+}
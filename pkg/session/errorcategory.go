@@ -0,0 +1,82 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrorCategory is a coarse classification of API errors, e.g. to bucket them in alerts or decide on retries
+type ErrorCategory string
+
+const (
+	// ErrorCategoryUnknown is the category of errors without an API response status
+	ErrorCategoryUnknown ErrorCategory = "unknown"
+	// ErrorCategoryClient is the category of 4xx errors which are not in a more specific category, e.g. 404 or 409
+	ErrorCategoryClient ErrorCategory = "client-error"
+	// ErrorCategoryServer is the category of 5xx errors
+	ErrorCategoryServer ErrorCategory = "server-error"
+	// ErrorCategoryAuth is the category of authentication and authorization errors, i.e. 401, 403 and the 400
+	// errors of the EdgeGrid authentication, e.g. an invalid timestamp
+	ErrorCategoryAuth ErrorCategory = "auth-error"
+	// ErrorCategoryRateLimit is the category of 429 errors
+	ErrorCategoryRateLimit ErrorCategory = "rate-limit"
+	// ErrorCategoryValidation is the category of 400 and 422 errors, and of 4xx errors with a validation problem type
+	ErrorCategoryValidation ErrorCategory = "validation"
+)
+
+// CategorizedError is implemented by the API errors of the sub-packages, e.g. *dns.Error or *papi.Error
+type CategorizedError interface {
+	error
+	Category() ErrorCategory
+}
+
+// ClassifyError returns the category of an API error from its response status and problem type
+func ClassifyError(statusCode int, problemType string) ErrorCategory {
+	problemType = strings.ToLower(problemType)
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimit
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case statusCode >= http.StatusInternalServerError:
+		return ErrorCategoryServer
+	case statusCode < http.StatusBadRequest:
+		return ErrorCategoryUnknown
+	case strings.Contains(problemType, "pep-authn") || strings.Contains(problemType, "pep-authz"):
+		return ErrorCategoryAuth
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity ||
+		strings.Contains(problemType, "validation"):
+		return ErrorCategoryValidation
+	}
+	return ErrorCategoryClient
+}
+
+// ErrorCategoryOf returns the category of the API error in the chain of err, or ErrorCategoryUnknown without one
+func ErrorCategoryOf(err error) ErrorCategory {
+	var categorized CategorizedError
+	if !errors.As(err, &categorized) {
+		return ErrorCategoryUnknown
+	}
+	return categorized.Category()
+}
+
+// IsAuth reports whether err is an API authentication or authorization error
+func IsAuth(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryAuth
+}
+
+// IsRateLimited reports whether err is an API error for exceeding a rate limit
+func IsRateLimited(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryRateLimit
+}
+
+// IsValidation reports whether err is an API error rejecting the request as invalid
+func IsValidation(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryValidation
+}
+
+// IsServer reports whether err is an API server error
+func IsServer(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryServer
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// problemError is an API error of a sub-package
+type problemError struct {
+	status      int
+	problemType string
+}
+
+func (e *problemError) Error() string {
+	return fmt.Sprintf("API error %d", e.status)
+}
+
+func (e *problemError) Category() ErrorCategory {
+	return ClassifyError(e.status, e.problemType)
+}
+
+func TestErrorCategory(t *testing.T) {
+	tests := map[string]struct {
+		err              error
+		expectedCategory ErrorCategory
+		isAuth           bool
+		isRateLimited    bool
+		isValidation     bool
+		isServer         bool
+	}{
+		"401 unauthorized": {
+			err:              &problemError{status: http.StatusUnauthorized, problemType: "https://problems.luna.akamaiapis.net/-/pep-authn/deny"},
+			expectedCategory: ErrorCategoryAuth,
+			isAuth:           true,
+		},
+		"403 forbidden": {
+			err:              &problemError{status: http.StatusForbidden, problemType: "https://problems.luna.akamaiapis.net/-/pep-authz/deny"},
+			expectedCategory: ErrorCategoryAuth,
+			isAuth:           true,
+		},
+		"400 invalid timestamp": {
+			err:              &problemError{status: http.StatusBadRequest, problemType: "https://problems.luna.akamaiapis.net/-/pep-authn/request-error"},
+			expectedCategory: ErrorCategoryAuth,
+			isAuth:           true,
+		},
+		"400 problem+json": {
+			err:              &problemError{status: http.StatusBadRequest, problemType: "https://problems.luna.akamaiapis.net/papi/v0/json-schema-invalid"},
+			expectedCategory: ErrorCategoryValidation,
+			isValidation:     true,
+		},
+		"409 validation problem type": {
+			err:              &problemError{status: http.StatusConflict, problemType: "https://problems.luna.akamaiapis.net/config-dns/v2/validation-error"},
+			expectedCategory: ErrorCategoryValidation,
+			isValidation:     true,
+		},
+		"404 not found": {
+			err:              &problemError{status: http.StatusNotFound, problemType: "not_found"},
+			expectedCategory: ErrorCategoryClient,
+		},
+		"429 too many requests": {
+			err:              &problemError{status: http.StatusTooManyRequests, problemType: "https://problems.luna.akamaiapis.net/papi/v0/too-many-requests"},
+			expectedCategory: ErrorCategoryRateLimit,
+			isRateLimited:    true,
+		},
+		"500 internal server error": {
+			err:              &problemError{status: http.StatusInternalServerError, problemType: "internal_error"},
+			expectedCategory: ErrorCategoryServer,
+			isServer:         true,
+		},
+		"wrapped 503": {
+			err:              fmt.Errorf("fetching rule tree: %w", &problemError{status: http.StatusServiceUnavailable}),
+			expectedCategory: ErrorCategoryServer,
+			isServer:         true,
+		},
+		"network error": {
+			err:              &NetworkError{Err: errors.New("connection refused")},
+			expectedCategory: ErrorCategoryUnknown,
+		},
+		"nil": {
+			expectedCategory: ErrorCategoryUnknown,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedCategory, ErrorCategoryOf(test.err))
+			assert.Equal(t, test.isAuth, IsAuth(test.err))
+			assert.Equal(t, test.isRateLimited, IsRateLimited(test.err))
+			assert.Equal(t, test.isValidation, IsValidation(test.err))
+			assert.Equal(t, test.isServer, IsServer(test.err))
+		})
+	}
+}
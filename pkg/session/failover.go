@@ -0,0 +1,114 @@
+package session
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// WithFallbackHosts sets API hosts which Exec tries, in the given order, when the request to the primary host,
+// i.e. the host of the credentials or of the request URL, fails with a network error or a 5xx response.
+// The request is signed again for each host, as the host is part of the signature, so the hosts must accept
+// the credentials of the session. Only idempotent requests, i.e. GET, HEAD, OPTIONS, PUT and DELETE requests,
+// fail over unless WithContextFailover allows it for the request, e.g. for POST requests which are safe to repeat.
+// Requests whose body cannot be sent again, i.e. without http.Request.GetBody, never fail over.
+// The circuit breaker and the adaptive concurrency limit only see the outcome of the primary host.
+func WithFallbackHosts(hosts []string) Option {
+	return func(s *session) {
+		s.fallbackHosts = append([]string(nil), hosts...)
+	}
+}
+
+// WithContextFailover allows or prevents the failover of the request to the fallback hosts of the session
+// (see WithFallbackHosts) regardless of its method
+func WithContextFailover(enabled bool) ContextOption {
+	return func(o *contextOptions) {
+		o.failover = &enabled
+	}
+}
+
+// canFailover reports whether the request may be sent again to the fallback hosts of the session
+func (s *session) canFailover(r *http.Request) bool {
+	if len(s.fallbackHosts) == 0 || !replayableBody(r) {
+		return false
+	}
+	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok && o.failover != nil {
+		return *o.failover
+	}
+	return idempotentMethod(r.Method)
+}
+
+// idempotentMethod reports whether requests with the method can be repeated without changing their outcome
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// replayableBody reports whether the body of the request, if any, can be sent again
+func replayableBody(r *http.Request) bool {
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+// resignedRequest returns a copy of the request sent to the host, with its unsigned query and a fresh body,
+// signed again, as the signature covers the host and is timestamped
+func (s *session) resignedRequest(r *http.Request, host, unsignedQuery string) (*http.Request, error) {
+	req := r.Clone(r.Context())
+	req.URL.Host = host
+	req.URL.RawQuery = unsignedQuery
+	req.Host = ""
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	if err := s.Sign(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// failoverNeeded reports whether the attempt failed on the host rather than because the request is done
+func failoverNeeded(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// failover sends the request to the fallback hosts in order, with its unsigned query, and returns the request and
+// the outcome of the first attempt which does not need a failover, or of the last one
+func (s *session) failover(client *http.Client, r *http.Request, unsignedQuery string, resp *http.Response, err error) (*http.Request, *http.Response, error) {
+	log := s.Log(r.Context())
+	endpoint := s.endpointKey(r.URL)
+	for _, host := range s.fallbackHosts {
+		req, reqErr := s.resignedRequest(r, host, unsignedQuery)
+		if reqErr != nil {
+			return r, resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+			log.Debugf("%s %s failed with status %d, trying fallback host %s", r.Method, redactURL(r.URL), resp.StatusCode, host)
+		} else {
+			log.Debugf("%s %s failed: %s, trying fallback host %s", r.Method, redactURL(r.URL), err, host)
+		}
+		if s.wireLog != nil {
+			logRequest(s.wireLog, req)
+		}
+
+		s.metrics.IncRetry(endpoint, req.Method, RetryReasonFailover)
+		r = req
+		resp, err = client.Do(req)
+		if !failoverNeeded(req.Context(), resp, err) {
+			break
+		}
+	}
+	return r, resp, err
+}
@@ -0,0 +1,156 @@
+package session
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hostSigner signs requests with the host they are sent to
+type hostSigner struct{}
+
+func (hostSigner) SignRequest(r *http.Request) {
+	r.Header.Set("Authorization", "signed for "+r.URL.Host)
+}
+
+func (hostSigner) CheckRequestLimit(int) {}
+
+func TestExecFailover(t *testing.T) {
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	tests := map[string]struct {
+		method          string
+		body            interface{}
+		contextFailover *bool
+		primaryStatus   int
+		fallbackStatus  []int
+		expectedStatus  int
+		expectedHits    []int
+		withError       bool
+	}{
+		"primary unreachable, fallback succeeds": {
+			method:         http.MethodGet,
+			fallbackStatus: []int{http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedHits:   []int{1},
+		},
+		"primary 5xx, second fallback succeeds": {
+			method:         http.MethodGet,
+			primaryStatus:  http.StatusServiceUnavailable,
+			fallbackStatus: []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedHits:   []int{1, 1},
+		},
+		"all hosts fail": {
+			method:         http.MethodDelete,
+			primaryStatus:  http.StatusInternalServerError,
+			fallbackStatus: []int{http.StatusInternalServerError},
+			expectedStatus: http.StatusInternalServerError,
+			expectedHits:   []int{1},
+		},
+		"primary 4xx": {
+			method:         http.MethodGet,
+			primaryStatus:  http.StatusNotFound,
+			fallbackStatus: []int{http.StatusOK},
+			expectedStatus: http.StatusNotFound,
+			expectedHits:   []int{0},
+		},
+		"POST is not failed over": {
+			method:         http.MethodPost,
+			body:           map[string]string{"name": "test"},
+			fallbackStatus: []int{http.StatusCreated},
+			expectedHits:   []int{0},
+			withError:      true,
+		},
+		"POST allowed with context failover": {
+			method:          http.MethodPost,
+			body:            map[string]string{"name": "test"},
+			contextFailover: boolPtr(true),
+			fallbackStatus:  []int{http.StatusCreated},
+			expectedStatus:  http.StatusCreated,
+			expectedHits:    []int{1},
+		},
+		"GET prevented with context failover": {
+			method:          http.MethodGet,
+			contextFailover: boolPtr(false),
+			fallbackStatus:  []int{http.StatusOK},
+			expectedHits:    []int{0},
+			withError:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			primary := unreachable
+			if test.primaryStatus != 0 {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "signed for "+r.Host, r.Header.Get("Authorization"))
+					w.WriteHeader(test.primaryStatus)
+				}))
+				defer server.Close()
+				primary = server.Listener.Addr().String()
+			}
+
+			var mu sync.Mutex
+			hits := make([]int, len(test.fallbackStatus))
+			var fallbackHosts []string
+			for i, status := range test.fallbackStatus {
+				i, status := i, status
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mu.Lock()
+					hits[i]++
+					mu.Unlock()
+					assert.Equal(t, "signed for "+r.Host, r.Header.Get("Authorization"))
+					assert.Equal(t, "a=1", r.URL.RawQuery)
+					if test.body != nil {
+						body, err := ioutil.ReadAll(r.Body)
+						assert.NoError(t, err)
+						assert.Equal(t, `{"name":"test"}`, string(body))
+					}
+					w.WriteHeader(status)
+				}))
+				defer server.Close()
+				fallbackHosts = append(fallbackHosts, server.Listener.Addr().String())
+			}
+
+			s, err := New(WithSigner(hostSigner{}), WithFallbackHosts(fallbackHosts))
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if test.contextFailover != nil {
+				ctx = ContextWithOptions(ctx, WithContextFailover(*test.contextFailover))
+			}
+			req, err := http.NewRequestWithContext(ctx, test.method, "http://"+primary+"/papi/v1/groups?a=1", nil)
+			require.NoError(t, err)
+			var in []interface{}
+			if test.body != nil {
+				in = append(in, test.body)
+			}
+			resp, err := s.Exec(req, nil, in...)
+			assert.Equal(t, test.expectedHits, hits)
+			if test.withError {
+				var networkErr *NetworkError
+				assert.ErrorAs(t, err, &networkErr)
+				assert.True(t, strings.Contains(err.Error(), unreachable), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,139 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	// RetryReasonStaleConnection is the reason of the retries of requests which failed on a reused connection,
+	// see WithConnectionHealth
+	RetryReasonStaleConnection = "stale-connection"
+	// RetryReasonFailover is the reason of the retries of requests sent to a fallback host, see WithFallbackHosts
+	RetryReasonFailover = "failover"
+)
+
+type (
+	// Collector receives the metrics of the requests of a session, e.g. to export them as expvar variables
+	// or Prometheus counters. The endpoint of a request is its host and the first two segments of its path,
+	// e.g. "akab-host.luna.akamaiapis.net/papi/v1". Methods are called concurrently.
+	Collector interface {
+		// IncRequest is called for each Exec call which sent a request, with the status of the final response,
+		// or 0 when no response was received, and the time until the response headers or the failure
+		IncRequest(endpoint, method string, status int, d time.Duration)
+		// IncError is called for each Exec call which failed with a network error, with ErrorCategoryUnknown,
+		// or received a 4xx or 5xx response, with the category of the response (see ClassifyError)
+		IncError(endpoint, method string, category ErrorCategory)
+		// IncRetry is called for each attempt of an Exec call after the first one, with RetryReasonStaleConnection
+		// or RetryReasonFailover
+		IncRetry(endpoint, method, reason string)
+	}
+
+	// NopCollector is a Collector discarding the metrics, which is the collector of sessions without WithMetrics
+	NopCollector struct{}
+
+	// ExpvarCollector is a Collector publishing the metrics as expvar maps, keyed by method and endpoint followed by
+	// the status, error category or retry reason
+	ExpvarCollector struct {
+		// Requests counts the requests, e.g. "GET akab-host.luna.akamaiapis.net/papi/v1 200"
+		Requests *expvar.Map
+		// Errors counts the errors, e.g. "GET akab-host.luna.akamaiapis.net/papi/v1 rate-limit"
+		Errors *expvar.Map
+		// Retries counts the retries, e.g. "GET akab-host.luna.akamaiapis.net/papi/v1 failover"
+		Retries *expvar.Map
+		// Seconds sums the durations of the requests, e.g. "GET akab-host.luna.akamaiapis.net/papi/v1"
+		Seconds *expvar.Map
+	}
+)
+
+// WithMetrics sets the collector of the metrics of the requests of the session, NopCollector when nil
+func WithMetrics(collector Collector) Option {
+	return func(s *session) {
+		if collector == nil {
+			collector = NopCollector{}
+		}
+		s.metrics = collector
+	}
+}
+
+// IncRequest does nothing
+func (NopCollector) IncRequest(string, string, int, time.Duration) {}
+
+// IncError does nothing
+func (NopCollector) IncError(string, string, ErrorCategory) {}
+
+// IncRetry does nothing
+func (NopCollector) IncRetry(string, string, string) {}
+
+// NewExpvarCollector returns a collector publishing its maps as the expvar map with the given name,
+// or reusing the maps already published with this name
+func NewExpvarCollector(name string) *ExpvarCollector {
+	root, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		root = expvar.NewMap(name)
+	}
+	child := func(key string) *expvar.Map {
+		if m, ok := root.Get(key).(*expvar.Map); ok {
+			return m
+		}
+		m := new(expvar.Map).Init()
+		root.Set(key, m)
+		return m
+	}
+	return &ExpvarCollector{
+		Requests: child("requests"),
+		Errors:   child("errors"),
+		Retries:  child("retries"),
+		Seconds:  child("seconds"),
+	}
+}
+
+// IncRequest counts the request and adds its duration
+func (c *ExpvarCollector) IncRequest(endpoint, method string, status int, d time.Duration) {
+	c.Requests.Add(fmt.Sprintf("%s %s %d", method, endpoint, status), 1)
+	c.Seconds.AddFloat(fmt.Sprintf("%s %s", method, endpoint), d.Seconds())
+}
+
+// IncError counts the error
+func (c *ExpvarCollector) IncError(endpoint, method string, category ErrorCategory) {
+	c.Errors.Add(fmt.Sprintf("%s %s %s", method, endpoint, category), 1)
+}
+
+// IncRetry counts the retry
+func (c *ExpvarCollector) IncRetry(endpoint, method, reason string) {
+	c.Retries.Add(fmt.Sprintf("%s %s %s", method, endpoint, reason), 1)
+}
+
+// collectResponse reports the outcome of a request to the collector of the session, with status 0 for network errors
+func (s *session) collectResponse(endpoint, method string, status int, problemType string, d time.Duration) {
+	s.metrics.IncRequest(endpoint, method, status, d)
+	if status == 0 || status >= http.StatusBadRequest {
+		s.metrics.IncError(endpoint, method, ClassifyError(status, problemType))
+	}
+}
+
+// problemType returns the type of the problem details of an error response, if any. The body is left readable.
+func problemType(resp *http.Response) (string, error) {
+	if resp.StatusCode < http.StatusBadRequest || resp.Body == nil {
+		return "", nil
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &problem); err != nil {
+		return "", nil
+	}
+	return problem.Type, nil
+}
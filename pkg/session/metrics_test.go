@@ -0,0 +1,145 @@
+package session
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCollector records the metrics as "endpoint method value" strings
+type recordingCollector struct {
+	mu       sync.Mutex
+	requests []string
+	errors   []string
+	retries  []string
+}
+
+func (c *recordingCollector) IncRequest(endpoint, method string, status int, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, fmt.Sprintf("%s %s %d", endpoint, method, status))
+}
+
+func (c *recordingCollector) IncError(endpoint, method string, category ErrorCategory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errors = append(c.errors, fmt.Sprintf("%s %s %s", endpoint, method, category))
+}
+
+func (c *recordingCollector) IncRetry(endpoint, method, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retries = append(c.retries, fmt.Sprintf("%s %s %s", endpoint, method, reason))
+}
+
+func TestWithMetrics(t *testing.T) {
+	tests := map[string]struct {
+		responses        map[string]func() (*http.Response, error)
+		options          []Option
+		expectedRequests []string
+		expectedErrors   []string
+		expectedRetries  []string
+		withError        bool
+	}{
+		"success": {
+			responses: map[string]func() (*http.Response, error){
+				"primary.luna.akamaiapis.net": statusResponse(http.StatusOK, `{}`),
+			},
+			expectedRequests: []string{"primary.luna.akamaiapis.net/papi/v1 GET 200"},
+		},
+		"error response classified by its problem type": {
+			responses: map[string]func() (*http.Response, error){
+				"primary.luna.akamaiapis.net": statusResponse(http.StatusNotFound, `{"type": "https://problems.luna.akamaiapis.net/-/pep-authz/deny"}`),
+			},
+			expectedRequests: []string{"primary.luna.akamaiapis.net/papi/v1 GET 404"},
+			expectedErrors:   []string{"primary.luna.akamaiapis.net/papi/v1 GET auth-error"},
+		},
+		"network error": {
+			responses:        map[string]func() (*http.Response, error){},
+			expectedRequests: []string{"primary.luna.akamaiapis.net/papi/v1 GET 0"},
+			expectedErrors:   []string{"primary.luna.akamaiapis.net/papi/v1 GET unknown"},
+			withError:        true,
+		},
+		"failover": {
+			responses: map[string]func() (*http.Response, error){
+				"primary.luna.akamaiapis.net":  statusResponse(http.StatusServiceUnavailable, ``),
+				"fallback.luna.akamaiapis.net": statusResponse(http.StatusOK, `{}`),
+			},
+			options:          []Option{WithFallbackHosts([]string{"fallback.luna.akamaiapis.net"})},
+			expectedRequests: []string{"primary.luna.akamaiapis.net/papi/v1 GET 200"},
+			expectedRetries:  []string{"primary.luna.akamaiapis.net/papi/v1 GET failover"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				response, ok := test.responses[r.URL.Host]
+				if !ok {
+					return nil, errors.New("connection refused")
+				}
+				resp, err := response()
+				if resp != nil {
+					resp.Request = r
+				}
+				return resp, err
+			})
+			collector := &recordingCollector{}
+			options := append([]Option{WithTransport(transport), WithSigner(hostSigner{}), WithMetrics(collector)}, test.options...)
+			s, err := New(options...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "https://primary.luna.akamaiapis.net/papi/v1/groups", nil)
+			require.NoError(t, err)
+			var out map[string]interface{}
+			_, err = s.Exec(req, &out)
+			if test.withError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedRequests, collector.requests)
+			assert.Equal(t, test.expectedErrors, collector.errors)
+			assert.Equal(t, test.expectedRetries, collector.retries)
+		})
+	}
+}
+
+func TestExpvarCollector(t *testing.T) {
+	collector := NewExpvarCollector("session_metrics_test")
+	collector.IncRequest("primary.luna.akamaiapis.net/papi/v1", http.MethodGet, http.StatusOK, 2*time.Second)
+	collector.IncRequest("primary.luna.akamaiapis.net/papi/v1", http.MethodGet, http.StatusOK, time.Second)
+	collector.IncError("primary.luna.akamaiapis.net/papi/v1", http.MethodGet, ErrorCategoryRateLimit)
+	collector.IncRetry("primary.luna.akamaiapis.net/papi/v1", http.MethodGet, RetryReasonFailover)
+
+	assert.Equal(t, "2", collector.Requests.Get("GET primary.luna.akamaiapis.net/papi/v1 200").String())
+	assert.Equal(t, "3", collector.Seconds.Get("GET primary.luna.akamaiapis.net/papi/v1").String())
+	assert.Equal(t, "1", collector.Errors.Get("GET primary.luna.akamaiapis.net/papi/v1 rate-limit").String())
+	assert.Equal(t, "1", collector.Retries.Get("GET primary.luna.akamaiapis.net/papi/v1 failover").String())
+
+	published, ok := expvar.Get("session_metrics_test").(*expvar.Map)
+	require.True(t, ok)
+	assert.Same(t, collector.Requests, published.Get("requests"))
+	assert.Same(t, collector.Requests, NewExpvarCollector("session_metrics_test").Requests)
+}
+
+// statusResponse returns a function returning a response with the given status and body
+func statusResponse(status int, body string) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WithPathPrefix sets a prefix prepended by Exec to the path of every request, e.g. "/akamai" for an API gateway
+// routing "/akamai/papi/v1/rule-formats" to "/papi/v1/rule-formats". The prefix is applied before the request is
+// signed, so the EdgeGrid signature covers the prefixed path actually sent: a gateway which strips the prefix before
+// forwarding the request to Akamai must therefore sign it again with its own credentials. The path of the request
+// passed to Exec is left unchanged, so that the request can be executed again, and redirect locations are followed
+// as they are returned.
+func WithPathPrefix(prefix string) Option {
+	return func(s *session) {
+		s.pathPrefix = strings.TrimSuffix(prefix, "/")
+		if s.pathPrefix != "" && !strings.HasPrefix(s.pathPrefix, "/") {
+			s.pathPrefix = "/" + s.pathPrefix
+		}
+	}
+}
+
+// withPathPrefix returns a shallow copy of the request with the path prefix of the session prepended to its path
+func (s *session) withPathPrefix(r *http.Request) *http.Request {
+	if s.pathPrefix == "" {
+		return r
+	}
+	u := *r.URL
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path
+	}
+	if u.RawPath != "" {
+		u.RawPath = (&url.URL{Path: s.pathPrefix}).EscapedPath() + u.RawPath
+	}
+	u.Path = s.pathPrefix + u.Path
+
+	req := r.WithContext(r.Context())
+	req.URL = &u
+	return req
+}
+
+// endpointKey returns the endpoint key of a request URL without the path prefix of the session, so that rate limits,
+// concurrency limits and metrics are keyed on the API path, e.g. "host/papi/v1"
+func (s *session) endpointKey(u *url.URL) string {
+	if s.pathPrefix == "" || !strings.HasPrefix(u.Path, s.pathPrefix+"/") {
+		return endpointKey(u)
+	}
+	unprefixed := *u
+	unprefixed.Path = strings.TrimPrefix(u.Path, s.pathPrefix)
+	return endpointKey(&unprefixed)
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPathPrefix(t *testing.T) {
+	const clientSecret = "client-secret"
+
+	tests := map[string]struct {
+		prefix          string
+		url             string
+		expectedRawPath string
+	}{
+		"prefix": {
+			prefix:          "/gateway/akamai",
+			url:             "/papi/v1/rule-formats?limit=10",
+			expectedRawPath: "/gateway/akamai/papi/v1/rule-formats",
+		},
+		"prefix without leading slash, with trailing slash": {
+			prefix:          "gateway/",
+			url:             "/papi/v1/rule-formats",
+			expectedRawPath: "/gateway/papi/v1/rule-formats",
+		},
+		"escaped path": {
+			prefix:          "/gateway",
+			url:             "/config-dns/v2/zones/example.com/names/a%2Fb.example.com/types/TXT",
+			expectedRawPath: "/gateway/config-dns/v2/zones/example.com/names/a%2Fb.example.com/types/TXT",
+		},
+		"no prefix": {
+			url:             "/papi/v1/rule-formats",
+			expectedRawPath: "/papi/v1/rule-formats",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			received := 0
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received++
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRawPath, r.URL.EscapedPath())
+				assertSignature(t, r, body, clientSecret, edgegrid.MaxBodySize)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs: certPool,
+					},
+				},
+			}
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         serverURL.Host,
+				ClientToken:  "akab-client-token",
+				ClientSecret: clientSecret,
+				AccessToken:  "akab-access-token",
+				MaxBody:      edgegrid.MaxBodySize,
+			}), WithClient(httpClient), WithPathPrefix(test.prefix))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, test.url, nil)
+			require.NoError(t, err)
+			path := req.URL.EscapedPath()
+			for i := 0; i < 2; i++ {
+				resp, err := s.Exec(req, nil, testStruct{A: "text", B: 1})
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+			assert.Equal(t, 2, received)
+			assert.Equal(t, path, req.URL.EscapedPath())
+		})
+	}
+}
+
+func TestWithPathPrefix_EndpointKey(t *testing.T) {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/akamai/papi/v1/groups", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":          []string{"application/json"},
+				"X-Ratelimit-Limit":     []string{"100"},
+				"X-Ratelimit-Remaining": []string{"42"},
+			},
+			Body:    ioutil.NopCloser(strings.NewReader(`{}`)),
+			Request: r,
+		}, nil
+	})
+	collector := &recordingCollector{}
+	s, err := New(WithSigner(hostSigner{}), WithTransport(transport), WithPathPrefix("/akamai"),
+		WithAdaptiveConcurrency(8, 1, 16), WithMetrics(collector))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://primary.luna.akamaiapis.net/papi/v1/groups", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	require.NoError(t, err)
+
+	rateLimit, ok := RateLimitStatus(s, "/papi/v1")
+	require.True(t, ok)
+	assert.Equal(t, 42, rateLimit.Remaining)
+	_, ok = RateLimitStatus(s, "/akamai")
+	assert.False(t, ok)
+
+	stats := AdaptiveConcurrencyStats(s)
+	assert.Contains(t, stats, "primary.luna.akamaiapis.net/papi/v1")
+	assert.Len(t, stats, 1)
+
+	assert.Equal(t, []string{"primary.luna.akamaiapis.net/papi/v1 GET 200"}, collector.requests)
+}
@@ -0,0 +1,139 @@
+package session
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimit contains the rate limit budget of an endpoint as reported by the headers of its latest response.
+	// Fields which were not reported are -1, or the zero time for Reset.
+	RateLimit struct {
+		// Limit is the number of requests allowed in the current window
+		Limit int
+		// Remaining is the number of requests left in the current window
+		Remaining int
+		// Reset is the time at which the budget is replenished
+		Reset time.Time
+		// ObservedAt is the time the response was received
+		ObservedAt time.Time
+	}
+
+	// rateLimits stores the latest rate limit observed per endpoint, keyed like the adaptive concurrency endpoints
+	rateLimits struct {
+		mu        sync.Mutex
+		endpoints map[string]RateLimit
+	}
+)
+
+// The rate limit headers differ across Akamai APIs, the first header found in each list is used
+var (
+	rateLimitLimitHeaders     = []string{"X-RateLimit-Limit", "X-Limit-Limit", "X-LimitLimit", "Akamai-RateLimit-Limit"}
+	rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "X-Limit-Remaining", "X-LimitRemaining", "Akamai-RateLimit-Remaining"}
+	rateLimitResetHeaders     = []string{"X-RateLimit-Reset", "X-RateLimit-Next", "Akamai-RateLimit-Next", "X-Limit-Reset"}
+)
+
+// RateLimitStatus returns the rate limit budget reported by the latest response of the endpoints matching
+// endpointPrefix, which is an API path prefix such as "/papi/v1" or "/papi", optionally preceded by the host,
+// e.g. "akab-xxx.luna.akamaiapis.net/papi/v1". It reports false when no matching response had rate limit headers.
+//
+// Exec records the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers of every response,
+// as well as their variants such as X-LimitRemaining, so that callers can slow down before being rate limited.
+func RateLimitStatus(sess Session, endpointPrefix string) (*RateLimit, bool) {
+	s, ok := sess.(*session)
+	if !ok || s.rateLimits == nil {
+		return nil, false
+	}
+	return s.rateLimits.status(endpointPrefix)
+}
+
+func (r *rateLimits) observe(endpoint string, header http.Header, now time.Time) {
+	rateLimit, ok := parseRateLimit(header, now)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints[endpoint] = rateLimit
+}
+
+func (r *rateLimits) status(endpointPrefix string) (*RateLimit, bool) {
+	prefix := strings.Trim(endpointPrefix, "/")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var latest *RateLimit
+	for key, rateLimit := range r.endpoints {
+		// the prefix is matched against the path, or against the host and path when it includes the host
+		path := key[strings.Index(key, "/")+1:]
+		if key != prefix && !strings.HasPrefix(key, prefix+"/") && path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if latest == nil || rateLimit.ObservedAt.After(latest.ObservedAt) {
+			rl := rateLimit
+			latest = &rl
+		}
+	}
+	return latest, latest != nil
+}
+
+// parseRateLimit parses the rate limit headers, ignoring those with invalid values.
+// It reports false when neither the limit nor the remaining budget is found.
+func parseRateLimit(header http.Header, now time.Time) (RateLimit, bool) {
+	rateLimit := RateLimit{
+		Limit:      headerInt(header, rateLimitLimitHeaders),
+		Remaining:  headerInt(header, rateLimitRemainingHeaders),
+		ObservedAt: now,
+	}
+	if rateLimit.Limit < 0 && rateLimit.Remaining < 0 {
+		return RateLimit{}, false
+	}
+
+	for _, name := range rateLimitResetHeaders {
+		if reset, ok := parseReset(header.Get(name), now); ok {
+			rateLimit.Reset = reset
+			break
+		}
+	}
+	return rateLimit, true
+}
+
+func headerInt(header http.Header, names []string) int {
+	for _, name := range names {
+		value := strings.TrimSpace(header.Get(name))
+		// some APIs report several windows, e.g. "100, 100;w=60", the first one is used
+		if i := strings.IndexAny(value, ",;"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return -1
+}
+
+// parseReset parses the reset time, given as Unix seconds, as seconds from now or as a date
+func parseReset(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && n >= 0 {
+		// values larger than a year of seconds are Unix timestamps
+		if n > 365*24*60*60 {
+			return time.Unix(n, 0), true
+		}
+		return now.Add(time.Duration(n) * time.Second), true
+	}
+	for _, layout := range []string{time.RFC3339, http.TimeFormat} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
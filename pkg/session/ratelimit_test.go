@@ -0,0 +1,117 @@
+package session
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitStatus(t *testing.T) {
+	reset := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		header         http.Header
+		endpointPrefix string
+		expected       *RateLimit
+		withResetIn    time.Duration
+	}{
+		"X-RateLimit headers": {
+			header: http.Header{
+				"X-Ratelimit-Limit":     []string{"100"},
+				"X-Ratelimit-Remaining": []string{"42"},
+				"X-Ratelimit-Reset":     []string{"1704164645"},
+			},
+			endpointPrefix: "/papi/v1",
+			expected:       &RateLimit{Limit: 100, Remaining: 42, Reset: time.Unix(reset.Unix(), 0)},
+		},
+		"reset in seconds": {
+			header: http.Header{
+				"X-Ratelimit-Limit":     []string{"100"},
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{"30"},
+			},
+			endpointPrefix: "papi",
+			expected:       &RateLimit{Limit: 100, Remaining: 0},
+			withResetIn:    30 * time.Second,
+		},
+		"Akamai headers with several windows": {
+			header: http.Header{
+				"X-Limitremaining":      []string{"7"},
+				"Akamai-Ratelimit-Next": []string{"2024-01-02T03:04:05Z"},
+				"X-Ratelimit-Limit":     []string{"20, 20;w=1"},
+			},
+			endpointPrefix: "akab-host.luna.akamaiapis.net/papi/v1",
+			expected:       &RateLimit{Limit: 20, Remaining: 7, Reset: reset},
+		},
+		"remaining only": {
+			header: http.Header{
+				"X-Limit-Remaining": []string{"3"},
+				"X-Ratelimit-Reset": []string{"soon"},
+			},
+			endpointPrefix: "/papi/v1/",
+			expected:       &RateLimit{Limit: -1, Remaining: 3},
+		},
+		"no rate limit headers": {
+			header:         http.Header{"X-Ratelimit-Limit": []string{"unlimited"}},
+			endpointPrefix: "/papi/v1",
+		},
+		"other endpoint": {
+			header: http.Header{
+				"X-Ratelimit-Limit":     []string{"100"},
+				"X-Ratelimit-Remaining": []string{"42"},
+			},
+			endpointPrefix: "/config-dns/v2",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     test.header,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					Request:    r,
+				}, nil
+			})
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         "akab-host.luna.akamaiapis.net",
+				ClientToken:  "akab-client-token",
+				ClientSecret: "client-secret",
+				AccessToken:  "akab-access-token",
+			}), WithTransport(transport))
+			require.NoError(t, err)
+
+			_, ok := RateLimitStatus(s, "/papi/v1")
+			assert.False(t, ok)
+
+			req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+			require.NoError(t, err)
+			before := time.Now()
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+
+			rateLimit, ok := RateLimitStatus(s, test.endpointPrefix)
+			if test.expected == nil {
+				assert.False(t, ok)
+				assert.Nil(t, rateLimit)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, test.expected.Limit, rateLimit.Limit)
+			assert.Equal(t, test.expected.Remaining, rateLimit.Remaining)
+			assert.False(t, rateLimit.ObservedAt.Before(before))
+			if test.withResetIn > 0 {
+				assert.Equal(t, rateLimit.ObservedAt.Add(test.withResetIn), rateLimit.Reset)
+			} else {
+				assert.True(t, test.expected.Reset.Equal(rateLimit.Reset), "want: %s; got: %s", test.expected.Reset, rateLimit.Reset)
+			}
+		})
+	}
+}
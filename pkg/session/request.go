@@ -2,12 +2,19 @@ package session
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
 )
 
 var (
@@ -15,17 +22,86 @@ var (
 	ErrInvalidArgument = errors.New("invalid arguments provided")
 	// ErrMarshaling represents marshaling error
 	ErrMarshaling = errors.New("marshaling input")
+	// ErrStreaming is returned by ExecStream when the response body cannot be copied to the writer
+	ErrStreaming = errors.New("streaming response body")
 	// ErrUnmarshaling represents unmarshaling error
 	ErrUnmarshaling = errors.New("unmarshaling output")
+	// ErrClockSkew is returned by Exec when the API rejects the request because the timestamp of its signature
+	// is too far from the time of the API, usually because the local clock is skewed (see WithTimeSource)
+	ErrClockSkew = errors.New("request timestamp rejected, check the local clock")
+	// ErrUnknownSection is returned when the credentials section selected with ContextWithSection
+	// was not set on the session with WithSigners
+	ErrUnknownSection = errors.New("unknown credentials section")
 )
 
+// NetworkError is returned by Exec when no response was received, e.g. because of a DNS resolution failure,
+// a TLS handshake error or a connection reset. The underlying error, usually a *url.Error wrapping a net.Error,
+// is accessible with errors.As.
+type NetworkError struct {
+	Err error
+
+	// attemptTimedOut is set when the request timed out on the session default timeout (see WithDefaultTimeout)
+	attemptTimedOut bool
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network error: %s", e.Err)
+}
+
+// Unwrap returns the underlying transport error
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable reports whether the request failing with err can be retried regardless of its method.
+// Network errors are retryable unless the request context was canceled or its deadline exceeded,
+// although a request timing out on the session default timeout is retryable.
+// Errors returned for API responses are not classified as retryable.
+func IsRetryable(err error) bool {
+	var networkErr *NetworkError
+	if !errors.As(err, &networkErr) {
+		return false
+	}
+	if networkErr.attemptTimedOut {
+		return true
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// maxRedirects is the number of redirects followed when the client does not define its own redirect policy,
+// matching the default policy of http.Client
+const maxRedirects = 10
+
 // Exec will sign and execute the request using the client edgegrid.Config
 func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	return s.exec(r, out, nil, in...)
+}
+
+// ExecStream will sign and execute the request like Exec, and copy the body of a successful response to w
+func (s *session) ExecStream(r *http.Request, w io.Writer, in ...interface{}) (*http.Response, error) {
+	if w == nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, "writer must not be nil")
+	}
+	return s.exec(r, nil, w, in...)
+}
+
+// exec executes the request, unmarshaling the body of a successful response into out or copying it to stream
+func (s *session) exec(r *http.Request, out interface{}, stream io.Writer, in ...interface{}) (*http.Response, error) {
 	if len(in) > 1 {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidArgument, "'in' argument must have 0 or 1 value")
 	}
 	log := s.Log(r.Context())
 
+	callerCtx := r.Context()
+	bufferResponse := false
+	if _, ok := r.Context().Deadline(); !ok && s.timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+		// the body must be read before the context is canceled on return
+		bufferResponse = true
+	}
+
 	// Apply any context header overrides
 	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok {
 		for k, v := range o.header {
@@ -46,6 +122,10 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		r.Header.Set("Accept", "application/json")
 	}
 
+	if s.compression && r.Header.Get("Accept-Encoding") == "" {
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
 	if r.URL.Scheme == "" {
 		r.URL.Scheme = "https"
 	}
@@ -56,14 +136,30 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 			return nil, fmt.Errorf("%w: %s", ErrMarshaling, err)
 		}
 
-		r.Body = ioutil.NopCloser(bytes.NewBuffer(data))
+		// GetBody lets the signer hash the body and redirects replay it without consuming r.Body
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		}
 		r.ContentLength = int64(len(data))
 	}
 
-	s.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+	// The client is copied so that concurrent requests and callers sharing it are not affected
+	// by the redirect policy which re-signs redirected requests
+	client := *s.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if s.client.CheckRedirect != nil {
+			if err := s.client.CheckRedirect(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
 		return s.Sign(req)
 	}
 
+	r = s.withPathPrefix(r)
+	unsignedQuery := r.URL.RawQuery
 	if err := s.Sign(r); err != nil {
 		return nil, err
 	}
@@ -77,9 +173,111 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		}
 	}
 
-	resp, err := s.client.Do(r)
+	var done func(int)
+	if s.concurrency != nil {
+		var waited bool
+		var err error
+		if done, waited, err = s.concurrency.acquire(r.Context(), s.endpointKey(r.URL)); err != nil {
+			return nil, err
+		}
+		// the signature is timestamped, so requests which waited for a slot are signed again
+		if waited {
+			if signer, err := s.signerFor(r.Context()); err == nil {
+				s.signRequest(r, signer)
+			}
+		}
+	}
+
+	var reportCircuit func(circuitOutcome)
+	if s.breaker != nil {
+		var err error
+		if reportCircuit, err = s.breaker.allow(r.URL.Host); err != nil {
+			if done != nil {
+				done(0)
+			}
+			return nil, err
+		}
+	}
+
+	if s.wireLog != nil {
+		logRequest(s.wireLog, r)
+	}
+
+	var reused *atomic.Bool
+	if s.health != nil {
+		r, reused = withConnReuseTrace(r)
+	}
+	endpoint, start := s.endpointKey(r.URL), time.Now()
+	resp, err := client.Do(r)
+	if err != nil && reused != nil && s.canRetryStaleConnection(r, reused.Load(), err) {
+		if req, reqErr := s.resignedRequest(r, r.URL.Host, unsignedQuery); reqErr == nil {
+			log.Debugf("%s %s failed on a reused connection: %s, retrying", r.Method, redactURL(r.URL), err)
+			if s.wireLog != nil {
+				logRequest(s.wireLog, req)
+			}
+			s.metrics.IncRetry(endpoint, req.Method, RetryReasonStaleConnection)
+			r = req
+			resp, err = client.Do(req)
+		}
+	}
+	if done != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		done(status)
+	}
+	if reportCircuit != nil {
+		reportCircuit(requestOutcome(callerCtx, resp, err))
+	}
+	if failoverNeeded(r.Context(), resp, err) && s.canFailover(r) {
+		r, resp, err = s.failover(&client, r, unsignedQuery, resp, err)
+	}
+	elapsed := time.Since(start)
 	if err != nil {
-		return nil, err
+		s.collectResponse(endpoint, r.Method, 0, "", elapsed)
+		if s.wireLog != nil {
+			s.wireLog.Debugf("<-- %s %s: %s", r.Method, redactURL(r.URL), err)
+		}
+		return nil, &NetworkError{Err: err, attemptTimedOut: bufferResponse && attemptTimedOut(callerCtx, err)}
+	}
+
+	// the transport only decompresses responses to requests without an Accept-Encoding header
+	decodeResponseBody(resp)
+
+	// a streamed body is copied before the context is canceled on return, so it does not need to be buffered
+	if bufferResponse && stream == nil {
+		data, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			s.collectResponse(endpoint, r.Method, 0, "", elapsed)
+			return nil, &NetworkError{Err: err, attemptTimedOut: attemptTimedOut(callerCtx, err)}
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+
+	problem, err := problemType(resp)
+	if err != nil {
+		s.collectResponse(endpoint, r.Method, 0, "", elapsed)
+		return nil, &NetworkError{Err: err, attemptTimedOut: bufferResponse && attemptTimedOut(callerCtx, err)}
+	}
+	s.collectResponse(endpoint, r.Method, resp.StatusCode, problem, elapsed)
+
+	if s.rateLimits != nil {
+		s.rateLimits.observe(s.endpointKey(r.URL), resp.Header, time.Now())
+	}
+	recordResponseMeta(r.Context(), resp)
+
+	if s.wireLog != nil {
+		if err := logResponse(s.wireLog, resp); err != nil {
+			return nil, &NetworkError{Err: err, attemptTimedOut: bufferResponse && attemptTimedOut(callerCtx, err)}
+		}
+	}
+
+	if resp.StatusCode == http.StatusBadRequest {
+		if err := clockSkewError(resp); err != nil {
+			return nil, err
+		}
 	}
 
 	if s.trace {
@@ -91,6 +289,7 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		}
 	}
 
+	var body []byte
 	if out != nil &&
 		resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices &&
 		resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusResetContent {
@@ -103,17 +302,103 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		if err := json.Unmarshal(data, out); err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrUnmarshaling, err)
 		}
+		body = data
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		s.handleWarnings(r, resp, body)
+	}
+
+	if stream != nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, err := io.Copy(stream, resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = http.NoBody
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrStreaming, err)
+		}
 	}
 
 	return resp, nil
 }
 
-// Sign will only sign a request
+// requestOutcome classifies the result of a request for the circuit breaker: network errors and 5xx responses
+// are failures, unless the caller context is done
+func requestOutcome(callerCtx context.Context, resp *http.Response, err error) circuitOutcome {
+	switch {
+	case err != nil && callerCtx.Err() != nil:
+		return circuitIgnored
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		return circuitFailure
+	}
+	return circuitSuccess
+}
+
+// attemptTimedOut reports whether err is caused by the session default timeout rather than the caller context
+func attemptTimedOut(callerCtx context.Context, err error) bool {
+	return callerCtx.Err() == nil && errors.Is(err, context.DeadlineExceeded)
+}
+
+// clockSkewError returns an error wrapping ErrClockSkew when the 400 response is the EdgeGrid authentication
+// error for an invalid timestamp. The response body is left readable.
+func clockSkewError(resp *http.Response) error {
+	data, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	var problem struct {
+		Type   string `json:"type"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(data, &problem); err != nil {
+		return nil
+	}
+	if !strings.Contains(problem.Type, "pep-authn") || !strings.Contains(strings.ToLower(problem.Detail), "timestamp") {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrClockSkew, problem.Detail)
+}
+
+// Sign will only sign a request, using the credentials section set on its context, if any
 func (s *session) Sign(r *http.Request) error {
-	s.signer.SignRequest(r)
+	signer, err := s.signerFor(r.Context())
+	if err != nil {
+		return err
+	}
+	s.signRequest(r, signer)
 
 	if s.requestLimit != 0 {
-		s.signer.CheckRequestLimit(s.requestLimit)
+		signer.CheckRequestLimit(s.requestLimit)
 	}
 	return nil
 }
+
+// signerFor returns the signer of the credentials section set on ctx, or the default signer
+func (s *session) signerFor(ctx context.Context) (edgegrid.Signer, error) {
+	section, _ := ctx.Value(contextSectionKey).(string)
+	if section == "" {
+		if s.credentials != nil {
+			return s.credentialSigner(ctx)
+		}
+		if s.signer == nil {
+			return nil, fmt.Errorf("%w: no default credentials, select a section with ContextWithSection", ErrUnknownSection)
+		}
+		return s.signer, nil
+	}
+	signer, ok := s.signers[section]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSection, section)
+	}
+	return signer, nil
+}
+
+// signRequest signs the request with the time source of the session, if any
+func (s *session) signRequest(r *http.Request, signer edgegrid.Signer) {
+	if timestampSigner, ok := signer.(edgegrid.TimestampSigner); ok && s.now != nil {
+		timestampSigner.SignRequestAt(r, s.now())
+		return
+	}
+	signer.SignRequest(r)
+}
@@ -1,13 +1,25 @@
 package session
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
 	"github.com/stretchr/testify/assert"
@@ -209,3 +221,774 @@ func TestSession_Exec(t *testing.T) {
 		})
 	}
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestSession_ExecWithCustomTransport(t *testing.T) {
+	var sent *http.Request
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		sent = r
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"a":"text","b":1}`)),
+			Header:     http.Header{},
+			Request:    r,
+		}, nil
+	})
+
+	tests := map[string]Option{
+		"with transport": WithTransport(transport),
+		"with client":    WithClient(&http.Client{Transport: transport}),
+	}
+
+	for name, option := range tests {
+		t.Run(name, func(t *testing.T) {
+			sent = nil
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         "akab-host.luna.akamaiapis.net",
+				ClientToken:  "akab-client-token",
+				ClientSecret: "client-secret",
+				AccessToken:  "akab-access-token",
+				MaxBody:      131072,
+			}), option)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/test/path", nil)
+			require.NoError(t, err)
+			var out testStruct
+			_, err = s.Exec(req, &out)
+			require.NoError(t, err)
+
+			require.NotNil(t, sent)
+			assert.Equal(t, "akab-host.luna.akamaiapis.net", sent.URL.Host)
+			assert.True(t, strings.HasPrefix(sent.Header.Get("Authorization"), "EG1-HMAC-SHA256 client_token=akab-client-token;"),
+				"request is not signed: %s", sent.Header.Get("Authorization"))
+			assert.Equal(t, testStruct{A: "text", B: 1}, out)
+			assert.Nil(t, s.Client().CheckRedirect)
+		})
+	}
+}
+
+func TestSession_ExecNetworkError(t *testing.T) {
+	tests := map[string]struct {
+		handler        http.HandlerFunc
+		closeServer    bool
+		cancelContext  bool
+		expectedStatus int
+		withNetError   bool
+		retryable      bool
+	}{
+		"dial failure": {
+			closeServer:  true,
+			withNetError: true,
+			retryable:    true,
+		},
+		"canceled context": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			cancelContext: true,
+			withNetError:  true,
+		},
+		"500 response": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewServer(test.handler)
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			if test.closeServer {
+				mockServer.Close()
+			} else {
+				defer mockServer.Close()
+			}
+
+			s, err := New(WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(&http.Client{}))
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if test.cancelContext {
+				cancel()
+			} else {
+				defer cancel()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/test/path", nil)
+			require.NoError(t, err)
+			req.URL.Scheme = "http"
+
+			resp, err := s.Exec(req, nil)
+			assert.Equal(t, test.retryable, IsRetryable(err))
+			if !test.withNetError {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedStatus, resp.StatusCode)
+				return
+			}
+			var netErr *NetworkError
+			require.True(t, errors.As(err, &netErr), "want: *NetworkError; got: %T", err)
+			var urlErr *url.Error
+			assert.True(t, errors.As(err, &urlErr), "want: *url.Error; got: %s", err)
+		})
+	}
+}
+
+func TestSession_ExecLargeBody(t *testing.T) {
+	const (
+		clientSecret = "client-secret"
+		maxBody      = 131072
+	)
+	large := struct {
+		Data string `json:"data"`
+	}{
+		Data: strings.Repeat("0123456789abcdef", 4*1024*1024/16),
+	}
+	largeJSON, err := json.Marshal(large)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		path    string
+		request func() *http.Request
+		in      []interface{}
+	}{
+		"marshaled input": {
+			path: "/test/path",
+			request: func() *http.Request {
+				req, err := http.NewRequest(http.MethodPost, "/test/path", nil)
+				require.NoError(t, err)
+				return req
+			},
+			in: []interface{}{large},
+		},
+		"marshaled input, redirected": {
+			path: "/redirect",
+			request: func() *http.Request {
+				req, err := http.NewRequest(http.MethodPost, "/redirect", nil)
+				require.NoError(t, err)
+				return req
+			},
+			in: []interface{}{large},
+		},
+		"non-seekable body, redirected": {
+			path: "/redirect",
+			request: func() *http.Request {
+				// io.MultiReader hides the underlying type, so http.NewRequest does not set GetBody
+				req, err := http.NewRequest(http.MethodPost, "/redirect", io.MultiReader(bytes.NewReader(largeJSON)))
+				require.NoError(t, err)
+				return req
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var received int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, len(largeJSON), len(body))
+				assert.True(t, bytes.Equal(largeJSON, body), "body was not fully transmitted")
+				assertSignature(t, r, body, clientSecret, maxBody)
+
+				if r.URL.Path == "/redirect" {
+					http.Redirect(w, r, "/test/path", http.StatusTemporaryRedirect)
+					return
+				}
+				received++
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer mockServer.Close()
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs: certPool,
+					},
+				},
+			}
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         serverURL.Host,
+				ClientToken:  "akab-client-token",
+				ClientSecret: clientSecret,
+				AccessToken:  "akab-access-token",
+				MaxBody:      maxBody,
+			}), WithClient(httpClient))
+			require.NoError(t, err)
+
+			resp, err := s.Exec(test.request(), nil, test.in...)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			assert.Equal(t, 1, received)
+		})
+	}
+}
+
+// assertSignature recomputes the EdgeGrid signature of the received request and compares it with the one sent
+func assertSignature(t *testing.T, r *http.Request, body []byte, clientSecret string, maxBody int) {
+	auth := r.Header.Get("Authorization")
+	i := strings.Index(auth, "signature=")
+	require.True(t, i > 0, "missing signature: %s", auth)
+	unsigned, signature := auth[:i], auth[i+len("signature="):]
+
+	var timestamp string
+	for _, field := range strings.Split(unsigned, ";") {
+		if strings.HasPrefix(field, "timestamp=") {
+			timestamp = strings.TrimPrefix(field, "timestamp=")
+		}
+	}
+	require.NotEmpty(t, timestamp)
+
+	if len(body) > maxBody {
+		body = body[:maxBody]
+	}
+	contentHash := sha256.Sum256(body)
+	msg := strings.Join([]string{
+		r.Method,
+		"https",
+		r.Host,
+		r.URL.RequestURI(),
+		"",
+		base64.StdEncoding.EncodeToString(contentHash[:]),
+		unsigned,
+	}, "\t")
+
+	hmacBase64 := func(message, secret string) string {
+		h := hmac.New(sha256.New, []byte(secret))
+		h.Write([]byte(message))
+		return base64.StdEncoding.EncodeToString(h.Sum(nil))
+	}
+	assert.Equal(t, hmacBase64(msg, hmacBase64(timestamp, clientSecret)), signature, "signature does not match")
+}
+
+func TestSession_ExecWithDefaultTimeout(t *testing.T) {
+	tests := map[string]struct {
+		callerTimeout    time.Duration
+		responseDelay    time.Duration
+		expectedDeadline bool
+		withTimeout      bool
+		retryable        bool
+	}{
+		"no caller deadline, response in time": {
+			responseDelay:    0,
+			expectedDeadline: true,
+		},
+		"no caller deadline, request times out": {
+			responseDelay:    time.Second,
+			expectedDeadline: true,
+			withTimeout:      true,
+			retryable:        true,
+		},
+		"caller deadline longer than default timeout is kept": {
+			callerTimeout:    time.Second,
+			responseDelay:    100 * time.Millisecond,
+			expectedDeadline: true,
+		},
+		"caller deadline shorter than default timeout is kept": {
+			callerTimeout:    20 * time.Millisecond,
+			responseDelay:    time.Second,
+			expectedDeadline: true,
+			withTimeout:      true,
+			retryable:        false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sentDeadline time.Time
+			var hasDeadline bool
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				sentDeadline, hasDeadline = r.Context().Deadline()
+				select {
+				case <-time.After(test.responseDelay):
+				case <-r.Context().Done():
+					return nil, r.Context().Err()
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"a":"text","b":1}`)),
+					Header:     http.Header{},
+					Request:    r,
+				}, nil
+			})
+			s, err := New(WithSigner(&edgegrid.Config{Host: "akab-host.luna.akamaiapis.net"}),
+				WithTransport(transport), WithDefaultTimeout(50*time.Millisecond))
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			var callerDeadline time.Time
+			if test.callerTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.callerTimeout)
+				defer cancel()
+				callerDeadline, _ = ctx.Deadline()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/test/path", nil)
+			require.NoError(t, err)
+
+			start := time.Now()
+			resp, err := s.Exec(req, nil)
+
+			assert.Equal(t, test.expectedDeadline, hasDeadline)
+			if test.callerTimeout > 0 {
+				assert.Equal(t, callerDeadline, sentDeadline)
+			} else {
+				assert.WithinDuration(t, start.Add(50*time.Millisecond), sentDeadline, 20*time.Millisecond)
+			}
+			if test.withTimeout {
+				assert.True(t, errors.Is(err, context.DeadlineExceeded), "want: %s; got: %s", context.DeadlineExceeded, err)
+				assert.Equal(t, test.retryable, IsRetryable(err))
+				return
+			}
+			require.NoError(t, err)
+			// the body remains readable after the default timeout context is canceled
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, `{"a":"text","b":1}`, string(body))
+		})
+	}
+}
+
+func TestSession_ExecWithTimeSource(t *testing.T) {
+	const clientSecret = "client-secret"
+	fixed := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	var signed []*http.Request
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		// the signature covers the host, which is only set on the URL of outgoing requests
+		r.Host = r.URL.Host
+		signed = append(signed, r)
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+	})
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host:         "akab-host.luna.akamaiapis.net",
+		ClientToken:  "akab-client-token",
+		ClientSecret: clientSecret,
+		AccessToken:  "akab-access-token",
+		MaxBody:      edgegrid.MaxBodySize,
+	}), WithTransport(transport), WithTimeSource(func() time.Time { return fixed }))
+	require.NoError(t, err)
+
+	body := []byte(`{"a":"text","b":1}`)
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodPost, "/test/path", nil)
+		require.NoError(t, err)
+		_, err = s.Exec(req, nil, testStruct{A: "text", B: 1})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, signed, 2)
+	for _, r := range signed {
+		assert.Contains(t, r.Header.Get("Authorization"), ";timestamp=20240102T02:04:05+0000;")
+		assertSignature(t, r, body, clientSecret, edgegrid.MaxBodySize)
+	}
+}
+
+func TestSession_ExecWithMaxBodySize(t *testing.T) {
+	const clientSecret = "client-secret"
+	body := []byte(`{"a":"` + strings.Repeat("x", 64) + `","b":1}`)
+
+	tests := map[string]struct {
+		opts            []Option
+		configMaxBody   int
+		expectedMaxBody int
+	}{
+		"limit of the config": {
+			configMaxBody:   edgegrid.MaxBodySize,
+			expectedMaxBody: edgegrid.MaxBodySize,
+		},
+		"smaller limit": {
+			opts:            []Option{WithMaxBodySize(16)},
+			configMaxBody:   edgegrid.MaxBodySize,
+			expectedMaxBody: 16,
+		},
+		"default limit": {
+			opts:            []Option{WithMaxBodySize(0)},
+			configMaxBody:   16,
+			expectedMaxBody: edgegrid.MaxBodySize,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var signed *http.Request
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				// the signature covers the host, which is only set on the URL of outgoing requests
+				r.Host = r.URL.Host
+				signed = r
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+			})
+			config := &edgegrid.Config{
+				Host:         "akab-host.luna.akamaiapis.net",
+				ClientToken:  "akab-client-token",
+				ClientSecret: clientSecret,
+				AccessToken:  "akab-access-token",
+				MaxBody:      test.configMaxBody,
+			}
+			s, err := New(append([]Option{WithSigner(config), WithTransport(transport)}, test.opts...)...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/test/path", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil, testStruct{A: strings.Repeat("x", 64), B: 1})
+			require.NoError(t, err)
+
+			require.NotNil(t, signed)
+			assertSignature(t, signed, body, clientSecret, test.expectedMaxBody)
+			assert.Equal(t, test.configMaxBody, config.MaxBody, "the config of the caller must not be modified")
+		})
+	}
+}
+
+func TestSession_ExecWithSection(t *testing.T) {
+	signers := map[string]edgegrid.Signer{
+		"staging": &edgegrid.Config{
+			Host:         "akab-staging.luna.akamaiapis.net",
+			ClientToken:  "akab-staging-client-token",
+			ClientSecret: "staging-secret",
+			AccessToken:  "akab-staging-access-token",
+			MaxBody:      edgegrid.MaxBodySize,
+		},
+		"prod": &edgegrid.Config{
+			Host:         "akab-prod.luna.akamaiapis.net",
+			ClientToken:  "akab-prod-client-token",
+			ClientSecret: "prod-secret",
+			AccessToken:  "akab-prod-access-token",
+			MaxBody:      edgegrid.MaxBodySize,
+		},
+	}
+
+	tests := map[string]struct {
+		section             string
+		expectedHost        string
+		expectedClientToken string
+		expectedSecret      string
+		withError           error
+	}{
+		"staging section": {
+			section:             "staging",
+			expectedHost:        "akab-staging.luna.akamaiapis.net",
+			expectedClientToken: "akab-staging-client-token",
+			expectedSecret:      "staging-secret",
+		},
+		"prod section": {
+			section:             "prod",
+			expectedHost:        "akab-prod.luna.akamaiapis.net",
+			expectedClientToken: "akab-prod-client-token",
+			expectedSecret:      "prod-secret",
+		},
+		"default signer": {
+			expectedHost:        "akab-default.luna.akamaiapis.net",
+			expectedClientToken: "akab-default-client-token",
+			expectedSecret:      "default-secret",
+		},
+		"unknown section": {
+			section:   "dev",
+			withError: ErrUnknownSection,
+		},
+	}
+
+	var signed []*http.Request
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		// the signature covers the host, which is only set on the URL of outgoing requests
+		r.Host = r.URL.Host
+		signed = append(signed, r)
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+	})
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host:         "akab-default.luna.akamaiapis.net",
+		ClientToken:  "akab-default-client-token",
+		ClientSecret: "default-secret",
+		AccessToken:  "akab-default-access-token",
+		MaxBody:      edgegrid.MaxBodySize,
+	}), WithSigners(signers), WithTransport(transport))
+	require.NoError(t, err)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signed = nil
+			ctx := context.Background()
+			if test.section != "" {
+				ctx = ContextWithSection(ctx, test.section)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/papi/v1/contracts", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil, testStruct{A: "text", B: 1})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Empty(t, signed)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, signed, 1)
+			assert.Equal(t, test.expectedHost, signed[0].URL.Host)
+			assert.Contains(t, signed[0].Header.Get("Authorization"), "client_token="+test.expectedClientToken+";")
+			assertSignature(t, signed[0], []byte(`{"a":"text","b":1}`), test.expectedSecret, edgegrid.MaxBodySize)
+		})
+	}
+}
+
+func TestSession_ExecWithSectionsOnly(t *testing.T) {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+	})
+	s, err := New(WithSigners(map[string]edgegrid.Signer{
+		"prod": &edgegrid.Config{Host: "akab-prod.luna.akamaiapis.net", ClientSecret: "prod-secret"},
+	}), WithTransport(transport))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	assert.True(t, errors.Is(err, ErrUnknownSection), "want: %s; got: %s", ErrUnknownSection, err)
+
+	req, err = http.NewRequestWithContext(ContextWithSection(context.Background(), "prod"), http.MethodGet, "/papi/v1/contracts", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, nil)
+	assert.NoError(t, err)
+}
+
+func TestSession_ExecClockSkew(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   string
+		withError      error
+	}{
+		"invalid timestamp": {
+			responseStatus: http.StatusBadRequest,
+			responseBody: `{
+	"type": "https://problems.luna.akamaiapis.net/-/pep-authn/request-error",
+	"title": "Bad request",
+	"status": 400,
+	"detail": "Invalid timestamp",
+	"instance": "https://akab-host.luna.akamaiapis.net/papi/v1/contracts"
+}`,
+			withError: ErrClockSkew,
+		},
+		"other bad request": {
+			responseStatus: http.StatusBadRequest,
+			responseBody: `{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/json-problem",
+	"title": "Bad request",
+	"status": 400,
+	"detail": "The timestamp field of the activation is invalid"
+}`,
+		},
+		"not a problem": {
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `timestamp`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: test.responseStatus,
+					Body:       ioutil.NopCloser(strings.NewReader(test.responseBody)),
+					Request:    r,
+				}, nil
+			})
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         "akab-host.luna.akamaiapis.net",
+				ClientToken:  "akab-client-token",
+				ClientSecret: "client-secret",
+				AccessToken:  "akab-access-token",
+			}), WithTransport(transport))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+			require.NoError(t, err)
+			resp, err := s.Exec(req, nil)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			// the body read to detect the clock skew is still readable
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.responseBody, string(body))
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestSession_ExecStream(t *testing.T) {
+	var large bytes.Buffer
+	large.WriteString(`{"recordsets": [`)
+	for i := 0; i < 100000; i++ {
+		if i > 0 {
+			large.WriteString(",")
+		}
+		fmt.Fprintf(&large, `{"name": "host%d.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.%d.%d"]}`, i, i/256%256, i%256)
+	}
+	large.WriteString(`]}`)
+
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   []byte
+		options        []Option
+		writer         func() io.Writer
+		expectedBody   []byte
+		withError      error
+	}{
+		"large body": {
+			responseStatus: http.StatusOK,
+			responseBody:   large.Bytes(),
+			expectedBody:   large.Bytes(),
+		},
+		"large body with default timeout": {
+			responseStatus: http.StatusOK,
+			responseBody:   large.Bytes(),
+			options:        []Option{WithDefaultTimeout(10 * time.Second)},
+			expectedBody:   large.Bytes(),
+		},
+		"compressed large body": {
+			responseStatus: http.StatusOK,
+			responseBody:   large.Bytes(),
+			options:        []Option{WithCompression(true)},
+			expectedBody:   large.Bytes(),
+		},
+		"error response is not streamed": {
+			responseStatus: http.StatusNotFound,
+			responseBody:   []byte(`{"type": "not_found", "title": "Not Found", "status": 404}`),
+		},
+		"nil writer": {
+			writer:    func() io.Writer { return nil },
+			withError: ErrInvalidArgument,
+		},
+		"failing writer": {
+			responseStatus: http.StatusOK,
+			responseBody:   []byte(`{"a":"text","b":1}`),
+			writer:         func() io.Writer { return failingWriter{} },
+			withError:      ErrStreaming,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/config-dns/v2/zones/example.com/zone-file", r.URL.Path)
+				var body io.Writer = w
+				if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+					w.Header().Set("Content-Encoding", "gzip")
+					gz := gzip.NewWriter(w)
+					defer func() { assert.NoError(t, gz.Close()) }()
+					body = gz
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.responseStatus)
+				_, err := body.Write(test.responseBody)
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}}
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			s, err := New(append([]Option{WithSigner(&edgegrid.Config{Host: serverURL.Host}), WithClient(httpClient)}, test.options...)...)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/config-dns/v2/zones/example.com/zone-file", nil)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			var w io.Writer = &buf
+			if test.writer != nil {
+				w = test.writer()
+			}
+			streamer, ok := s.(StreamExecutor)
+			require.True(t, ok)
+			resp, err := streamer.ExecStream(req, w)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.responseStatus, resp.StatusCode)
+			assert.True(t, bytes.Equal(test.expectedBody, buf.Bytes()), "streamed body differs: %d bytes, expected %d", buf.Len(), len(test.expectedBody))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			if test.responseStatus == http.StatusOK {
+				assert.Empty(t, body)
+			} else {
+				assert.Equal(t, test.responseBody, body)
+			}
+		})
+	}
+}
+
+func TestSession_ExecMapBodyIsStable(t *testing.T) {
+	const clientSecret = "client-secret"
+	body := map[string]interface{}{
+		"name":  "www.example.com",
+		"type":  "A",
+		"ttl":   300,
+		"rdata": []string{"10.0.0.1", "10.0.0.2"},
+		"options": map[string]interface{}{
+			"zeta": true, "alpha": 1, "mu": "m", "beta": nil, "omega": []int{3, 2, 1}, "kappa": map[string]int{"y": 2, "x": 1},
+		},
+	}
+
+	var received [][]byte
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, data)
+		assertSignature(t, r, data, clientSecret, edgegrid.MaxBodySize)
+		// the first request of each Exec is redirected, so that its body is signed again
+		if r.URL.Path == "/config-dns/v2/zones/example.com/recordsets" {
+			http.Redirect(w, r, "/config-dns/v2/zones/example.com/recordsets/redirected", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}}
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host:         serverURL.Host,
+		ClientToken:  "akab-client-token",
+		ClientSecret: clientSecret,
+		AccessToken:  "akab-access-token",
+		MaxBody:      edgegrid.MaxBodySize,
+	}), WithClient(httpClient))
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		req, err := http.NewRequest(http.MethodPost, "/config-dns/v2/zones/example.com/recordsets", nil)
+		require.NoError(t, err)
+		resp, err := s.Exec(req, nil, body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	require.Len(t, received, 20)
+	expected := `{"name":"www.example.com","options":{"alpha":1,"beta":null,"kappa":{"x":1,"y":2},"mu":"m","omega":[3,2,1],"zeta":true},"rdata":["10.0.0.1","10.0.0.2"],"ttl":300,"type":"A"}`
+	for _, data := range received {
+		assert.Equal(t, expected, string(data))
+	}
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"net/http"
+)
+
+// ResponseMeta contains the metadata of the response to a request, see ContextWithResponseMeta
+type ResponseMeta struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Header contains the response headers, e.g. ETag, Last-Modified or X-Cache
+	Header http.Header
+	// RequestID is the request identifier returned by the API in the response headers, if any,
+	// useful when reporting issues to Akamai support
+	RequestID string
+	// Warnings are the warnings of a successful response, see WithWarningHandler
+	Warnings []Warning
+}
+
+// requestIDHeaders are the response headers carrying the request identifier, in order of precedence
+var requestIDHeaders = []string{"X-Request-Id", "X-Akamai-Request-Id", "X-Trace-Id"}
+
+var contextResponseMetaKey = contextKey("sessionResponseMeta")
+
+// ContextWithResponseMeta returns a context which records the metadata of the responses to the requests made with it.
+// After any call of a sub-package, e.g. papi.GetRuleFormats, the returned ResponseMeta holds the status and headers
+// of the response to its last request, without re-issuing the request to read a header. The metadata is recorded for
+// error responses too, and left unchanged when no response is received. The ResponseMeta must not be read while
+// requests using the context are in flight, so use a separate context for each of concurrent calls.
+func ContextWithResponseMeta(ctx context.Context) (context.Context, *ResponseMeta) {
+	meta := new(ResponseMeta)
+	return context.WithValue(ctx, contextResponseMetaKey, meta), meta
+}
+
+// recordResponseMeta stores the metadata of the response in the ResponseMeta of the request context, if any
+func recordResponseMeta(ctx context.Context, resp *http.Response) {
+	meta, ok := ctx.Value(contextResponseMetaKey).(*ResponseMeta)
+	if !ok {
+		return
+	}
+	*meta = ResponseMeta{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			meta.RequestID = id
+			break
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithResponseMeta(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseHeader http.Header
+		networkError   bool
+		expectedMeta   ResponseMeta
+	}{
+		"success": {
+			responseStatus: http.StatusOK,
+			responseHeader: http.Header{
+				"Etag":          {`"abc"`},
+				"Last-Modified": {"Tue, 02 Jan 2024 03:04:05 GMT"},
+				"X-Trace-Id":    {"trace-1"},
+			},
+			expectedMeta: ResponseMeta{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Etag":          {`"abc"`},
+					"Last-Modified": {"Tue, 02 Jan 2024 03:04:05 GMT"},
+					"X-Trace-Id":    {"trace-1"},
+				},
+				RequestID: "trace-1",
+			},
+		},
+		"request ID precedence": {
+			responseStatus: http.StatusOK,
+			responseHeader: http.Header{
+				"X-Trace-Id":   {"trace-1"},
+				"X-Request-Id": {"request-1"},
+			},
+			expectedMeta: ResponseMeta{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"X-Trace-Id":   {"trace-1"},
+					"X-Request-Id": {"request-1"},
+				},
+				RequestID: "request-1",
+			},
+		},
+		"error response": {
+			responseStatus: http.StatusNotFound,
+			responseHeader: http.Header{"X-Cache": {"TCP_MISS"}},
+			expectedMeta: ResponseMeta{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"X-Cache": {"TCP_MISS"}},
+			},
+		},
+		"network error": {
+			networkError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if test.networkError {
+					return nil, errors.New("connection reset by peer")
+				}
+				return &http.Response{
+					StatusCode: test.responseStatus,
+					Header:     test.responseHeader,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					Request:    r,
+				}, nil
+			})
+			s, err := New(WithSigner(&edgegrid.Config{Host: "akab-host.luna.akamaiapis.net"}), WithTransport(transport))
+			require.NoError(t, err)
+
+			ctx, meta := ContextWithResponseMeta(context.Background())
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/papi/v1/rule-formats", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, &struct{}{})
+			if test.networkError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedMeta, *meta)
+		})
+	}
+}
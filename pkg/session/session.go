@@ -3,9 +3,11 @@ package session
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
 	"github.com/apex/log"
@@ -18,7 +20,10 @@ type (
 	Session interface {
 		// Exec will sign and execute a request returning the response
 		// The response body will be unmarshaled in to out
-		// Optionally the in value will be marshaled into the body
+		// Optionally the in value will be marshaled into the body. It is marshaled once with encoding/json, which
+		// writes map keys in sorted order, so that the body and its content hash are the same on every redirect
+		// or re-sign of the request. Prefer structs for request bodies; the MarshalJSON methods of the values
+		// must be deterministic too.
 		Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error)
 
 		// Sign will only sign a request, this is useful for circumstances
@@ -33,19 +38,54 @@ type (
 		Client() *http.Client
 	}
 
+	// StreamExecutor is implemented by the sessions returned by New, which can be type asserted to it,
+	// e.g. s.(session.StreamExecutor). It is kept out of Session so that existing Session implementations
+	// and wrappers are not broken.
+	StreamExecutor interface {
+		// ExecStream will sign and execute a request like Exec, and copy the body of a successful (2xx) response
+		// to w instead of unmarshaling it, e.g. to save a large rule tree or zone file without holding it in memory.
+		// The returned response then has an empty body. The body of an unsuccessful response is left in the
+		// response, to be turned into an API error, and nothing is written to w. Note that WithWireLog and
+		// WithHTTPTracing still buffer the body in order to log it.
+		ExecStream(r *http.Request, w io.Writer, in ...interface{}) (*http.Response, error)
+	}
+
 	// session is the base akamai http client
 	session struct {
-		client       *http.Client
-		signer       edgegrid.Signer
-		log          log.Interface
-		trace        bool
-		userAgent    string
-		requestLimit int
+		client         *http.Client
+		signer         edgegrid.Signer
+		signers        map[string]edgegrid.Signer
+		log            log.Interface
+		trace          bool
+		userAgent      string
+		requestLimit   int
+		pool           *connectionPool
+		concurrency    *adaptiveConcurrency
+		timeout        time.Duration
+		wireLog        log.Interface
+		now            func() time.Time
+		rateLimits     *rateLimits
+		breaker        *circuitBreaker
+		maxBody        int
+		compression    bool
+		fallbackHosts  []string
+		health         *ConnectionHealth
+		credentials    *credentialCache
+		metrics        Collector
+		warningHandler WarningHandler
+		pathPrefix     string
+	}
+
+	connectionPool struct {
+		maxIdle        int
+		maxIdlePerHost int
+		idleTimeout    time.Duration
 	}
 
 	contextOptions struct {
-		log    log.Interface
-		header http.Header
+		log      log.Interface
+		header   http.Header
+		failover *bool
 	}
 
 	// Option defines a client option
@@ -58,7 +98,8 @@ type (
 )
 
 var (
-	contextOptionKey = contextKey("sessionContext")
+	contextOptionKey  = contextKey("sessionContext")
+	contextSectionKey = contextKey("sessionSection")
 )
 
 const (
@@ -73,17 +114,35 @@ func New(opts ...Option) (Session, error) {
 	)
 
 	s := &session{
-		client:    http.DefaultClient,
-		log:       log.Log,
-		userAgent: defaultUserAgent,
-		trace:     false,
+		client:     http.DefaultClient,
+		log:        log.Log,
+		userAgent:  defaultUserAgent,
+		trace:      false,
+		rateLimits: &rateLimits{endpoints: make(map[string]RateLimit)},
+		metrics:    NopCollector{},
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
 
-	if s.signer == nil {
+	if s.pool != nil {
+		s.applyConnectionPool()
+	}
+	if s.health != nil {
+		s.applyConnectionHealth()
+	}
+
+	if s.credentials != nil && s.credentials.provider == nil {
+		s.credentials = nil
+	}
+
+	// sessions configured with named credentials or a credential provider only do not need the default edgerc section
+	if s.credentials != nil {
+		s.signer = nil
+	} else if s.signer == nil && len(s.signers) > 0 {
+		s.signer = s.signers["default"]
+	} else if s.signer == nil {
 		config, err := edgegrid.New()
 		if err != nil {
 			return nil, err
@@ -91,6 +150,17 @@ func New(opts ...Option) (Session, error) {
 		s.signer = config
 	}
 
+	if s.maxBody != 0 {
+		s.signer = s.withMaxBody(s.signer)
+		for section, signer := range s.signers {
+			s.signers[section] = s.withMaxBody(signer)
+		}
+	}
+
+	if _, ok := s.signer.(edgegrid.TimestampSigner); s.now != nil && s.signer != nil && !ok {
+		s.Log(context.Background()).Warnf("time source ignored for signer %T which does not implement edgegrid.TimestampSigner", s.signer)
+	}
+
 	return s, nil
 }
 
@@ -104,13 +174,56 @@ func Must(sess Session, err error) Session {
 	return sess
 }
 
-// WithClient creates a client using the specified http.Client
+// WithClient creates a client using the specified http.Client, e.g. to configure the transport with
+// connection pooling, a proxy or custom TLS settings. Requests are still signed with EdgeGrid
+// on top of the supplied client and redirects are re-signed before being followed.
+// The client is not modified by the session, so it can be shared with other code.
+//
+// Request timeouts should be set with the request context rather than with http.Client.Timeout,
+// so that callers retrying a request control the deadline of each attempt.
 func WithClient(client *http.Client) Option {
 	return func(s *session) {
 		s.client = client
 	}
 }
 
+// WithTransport sets the http.RoundTripper used to send requests, keeping the other settings of the client.
+// Requests are still signed with EdgeGrid before being passed to the transport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *session) {
+		client := *s.client
+		client.Transport = transport
+		s.client = &client
+	}
+}
+
+// WithConnectionPool configures idle connection pooling of the client transport. Go keeps only 2 idle connections
+// per host by default, so workloads issuing many concurrent requests to a single Akamai API host keep dialing
+// new connections. Values such as maxIdle 100, maxIdlePerHost 32 and idleTimeout 90s suit most high-fanout workloads.
+//
+// The option is applied to a copy of the client and its transport when the transport is an *http.Transport
+// (or nil, meaning http.DefaultTransport), otherwise it is ignored with a warning.
+func WithConnectionPool(maxIdle, maxIdlePerHost int, idleTimeout time.Duration) Option {
+	return func(s *session) {
+		s.pool = &connectionPool{
+			maxIdle:        maxIdle,
+			maxIdlePerHost: maxIdlePerHost,
+			idleTimeout:    idleTimeout,
+		}
+	}
+}
+
+// WithDefaultTimeout sets the timeout of requests whose context has no deadline, so that a stalled connection
+// cannot block forever. It covers a single Exec call, from sending the request, including redirects, to reading
+// the response body, which is buffered before Exec returns, or copied to the writer of ExecStream. A caller
+// retrying a request therefore gets a fresh timeout for each attempt; to bound the whole operation instead,
+// set a deadline on the context passed to every attempt, since deadlines set by the caller are left untouched.
+func WithDefaultTimeout(d time.Duration) Option {
+	return func(s *session) {
+		s.timeout = d
+	}
+}
+
 // WithLog sets the log interface for the client
 func WithLog(l log.Interface) Option {
 	return func(s *session) {
@@ -132,6 +245,48 @@ func WithSigner(signer edgegrid.Signer) Option {
 	}
 }
 
+// WithSigners sets named credential sets, e.g. the "staging" and "prod" sections of an edgerc file loaded with
+// edgegrid.New(edgegrid.WithSection("prod")), so that one session, and its connection pool, can issue requests
+// with several credentials. A request is signed with the signer of the section set on its context with
+// ContextWithSection, or with the default signer (see WithSigner) when none is set. Without WithSigner,
+// the "default" entry, if any, is the default signer.
+func WithSigners(signers map[string]edgegrid.Signer) Option {
+	return func(s *session) {
+		if s.signers == nil {
+			s.signers = make(map[string]edgegrid.Signer, len(signers))
+		}
+		for section, signer := range signers {
+			s.signers[section] = signer
+		}
+	}
+}
+
+// WithTimeSource sets the clock used to timestamp the EdgeGrid signature of requests, which is time.Now by default.
+// The API rejects requests whose timestamp is too far from its own clock with ErrClockSkew, so a source corrected
+// with the offset of an NTP server lets hosts with a skewed clock sign requests; tests can use a fixed time.
+// The signer must implement edgegrid.TimestampSigner, as edgegrid.Config does, otherwise the option is ignored.
+func WithTimeSource(now func() time.Time) Option {
+	return func(s *session) {
+		s.now = now
+	}
+}
+
+// WithMaxBodySize sets the maximum number of bytes of a POST request body covered by the EdgeGrid signature,
+// like the max_body setting of the edgerc file, overriding the setting of the signers of the session. It must match
+// the limit of the API client credentials, otherwise the API rejects the signature of larger bodies. A value which
+// is not positive selects the documented default of edgegrid.MaxBodySize, i.e. 131072 bytes; PAPI rule tree uploads
+// and other large bodies can exceed it, so credentials configured with a larger limit need this option or max_body.
+// Bodies of other methods, e.g. GET, are not hashed. The option applies to edgegrid.Config signers, which are copied,
+// and is ignored with a warning for other signers.
+func WithMaxBodySize(n int) Option {
+	return func(s *session) {
+		if n <= 0 {
+			n = edgegrid.MaxBodySize
+		}
+		s.maxBody = n
+	}
+}
+
 // WithRequestLimit sets the maximum number of API calls that the provider will make per second.
 func WithRequestLimit(requestLimit int) Option {
 	return func(s *session) {
@@ -146,6 +301,45 @@ func WithHTTPTracing(trace bool) Option {
 	}
 }
 
+// withMaxBody returns a copy of the signer with the max body size of the session
+func (s *session) withMaxBody(signer edgegrid.Signer) edgegrid.Signer {
+	switch config := signer.(type) {
+	case nil:
+		return nil
+	case *edgegrid.Config:
+		c := *config
+		c.MaxBody = s.maxBody
+		return &c
+	case edgegrid.Config:
+		config.MaxBody = s.maxBody
+		return config
+	}
+	s.Log(context.Background()).Warnf("max body size ignored for signer %T", signer)
+	return signer
+}
+
+// applyConnectionPool sets the connection pool settings on a copy of the client transport
+func (s *session) applyConnectionPool() {
+	var transport *http.Transport
+	switch t := s.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		s.Log(context.Background()).Warnf("connection pool settings ignored for custom transport %T", t)
+		return
+	}
+
+	transport.MaxIdleConns = s.pool.maxIdle
+	transport.MaxIdleConnsPerHost = s.pool.maxIdlePerHost
+	transport.IdleConnTimeout = s.pool.idleTimeout
+
+	client := *s.client
+	client.Transport = transport
+	s.client = &client
+}
+
 // Log will return the context logger, or the session log
 func (s *session) Log(ctx context.Context) log.Interface {
 	if o := ctx.Value(contextOptionKey); o != nil {
@@ -178,6 +372,36 @@ func ContextWithOptions(ctx context.Context, opts ...ContextOption) context.Cont
 	return context.WithValue(ctx, contextOptionKey, o)
 }
 
+// ContextWithHeaders returns a context adding the headers to the requests executed with it, e.g. a correlation ID
+// or a feature flag for a single call. The headers are merged with the headers already set on the context, replacing
+// the values of the headers set again, and the other options of the context (see ContextWithOptions) are kept.
+// Exec sets the headers before signing the request, so they are covered by the signature when the credentials sign
+// them (see edgegrid.Config.HeaderToSign). Any header can therefore be added, though Authorization is replaced by
+// the signature, Host is ignored in favour of the request URL, and Content-Type, Accept and Accept-Encoding replace
+// the values set by the session and the API clients.
+func ContextWithHeaders(ctx context.Context, header http.Header) context.Context {
+	o := new(contextOptions)
+	if existing, ok := ctx.Value(contextOptionKey).(*contextOptions); ok {
+		*o = *existing
+	}
+	merged := o.header.Clone()
+	if merged == nil {
+		merged = make(http.Header, len(header))
+	}
+	for key, values := range header {
+		merged[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	o.header = merged
+
+	return context.WithValue(ctx, contextOptionKey, o)
+}
+
+// ContextWithSection selects the named credentials, set with WithSigners, used to sign the requests made with ctx.
+// Exec returns ErrUnknownSection when the session has no credentials with that name.
+func ContextWithSection(ctx context.Context, section string) context.Context {
+	return context.WithValue(ctx, contextSectionKey, section)
+}
+
 // WithContextLog provides a context specific logger
 func WithContextLog(l log.Interface) ContextOption {
 	return func(o *contextOptions) {
@@ -2,10 +2,16 @@ package session
 
 import (
 	"context"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
 	"github.com/apex/log"
@@ -24,11 +30,13 @@ func TestNew(t *testing.T) {
 	}{
 		"no options provided, return default session": {
 			expected: &session{
-				client:    http.DefaultClient,
-				signer:    &edgegrid.Config{},
-				log:       log.Log,
-				trace:     false,
-				userAgent: "Akamai-Open-Edgegrid-golang/8.0.0 golang/" + strings.TrimPrefix(runtime.Version(), "go"),
+				client:     http.DefaultClient,
+				signer:     &edgegrid.Config{},
+				log:        log.Log,
+				trace:      false,
+				userAgent:  "Akamai-Open-Edgegrid-golang/8.0.0 golang/" + strings.TrimPrefix(runtime.Version(), "go"),
+				rateLimits: &rateLimits{endpoints: map[string]RateLimit{}},
+				metrics:    NopCollector{},
 			},
 		},
 		"with options provided": {
@@ -42,10 +50,12 @@ func TestNew(t *testing.T) {
 				client: &http.Client{
 					Timeout: 500,
 				},
-				signer:    &edgegrid.Config{},
-				log:       log.Log,
-				trace:     true,
-				userAgent: "test user agent",
+				signer:     &edgegrid.Config{},
+				log:        log.Log,
+				trace:      true,
+				userAgent:  "test user agent",
+				rateLimits: &rateLimits{endpoints: map[string]RateLimit{}},
+				metrics:    NopCollector{},
 			},
 		},
 	}
@@ -121,3 +131,181 @@ func TestSession_Log(t *testing.T) {
 		})
 	}
 }
+
+func TestWithConnectionPool(t *testing.T) {
+	tests := map[string]struct {
+		client        *http.Client
+		expectApplied bool
+	}{
+		"default client": {
+			expectApplied: true,
+		},
+		"client with http.Transport": {
+			client:        &http.Client{Transport: &http.Transport{DisableCompression: true}},
+			expectApplied: true,
+		},
+		"client with custom round tripper": {
+			client: &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return nil, nil
+			})},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := []Option{WithSigner(&edgegrid.Config{}), WithConnectionPool(100, 32, 90*time.Second), WithLog(&log.Logger{Handler: discard.New()})}
+			if test.client != nil {
+				options = append(options, WithClient(test.client))
+			}
+			sess, err := New(options...)
+			require.NoError(t, err)
+
+			transport, ok := sess.Client().Transport.(*http.Transport)
+			if !test.expectApplied {
+				assert.False(t, ok)
+				assert.Same(t, test.client, sess.Client())
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, 100, transport.MaxIdleConns)
+			assert.Equal(t, 32, transport.MaxIdleConnsPerHost)
+			assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+			if test.client != nil {
+				assert.True(t, transport.DisableCompression)
+				assert.NotSame(t, test.client.Transport, transport)
+			}
+			assert.Nil(t, http.DefaultClient.Transport)
+		})
+	}
+}
+
+func BenchmarkConnectionPool(b *testing.B) {
+	benchmarks := map[string][]Option{
+		"default transport": nil,
+		"connection pool":   {WithConnectionPool(100, 32, 90*time.Second)},
+	}
+
+	for name, poolOptions := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			var dials int64
+			mockServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"a":"text","b":1}`))
+			}))
+			mockServer.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					atomic.AddInt64(&dials, 1)
+				}
+			}
+			mockServer.Start()
+			defer mockServer.Close()
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(b, err)
+
+			options := append([]Option{
+				WithSigner(&edgegrid.Config{Host: serverURL.Host}),
+				WithClient(&http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}),
+				WithLog(&log.Logger{Handler: discard.New()}),
+			}, poolOptions...)
+			sess, err := New(options...)
+			require.NoError(b, err)
+			defer sess.Client().CloseIdleConnections()
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					req, err := http.NewRequest(http.MethodGet, "/test/path", nil)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+					req.URL.Scheme = "http"
+					var out testStruct
+					if _, err := sess.Exec(req, &out); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+			b.ReportMetric(float64(atomic.LoadInt64(&dials)), "dials")
+		})
+	}
+}
+
+func TestContextWithHeaders(t *testing.T) {
+	const clientSecret = "client-secret"
+
+	tests := map[string]struct {
+		ctx             func() context.Context
+		expectedHeaders http.Header
+		expectFailover  bool
+	}{
+		"correlation ID": {
+			ctx: func() context.Context {
+				return ContextWithHeaders(context.Background(), http.Header{"X-Correlation-ID": []string{"c0ffee"}})
+			},
+			expectedHeaders: http.Header{"X-Correlation-Id": []string{"c0ffee"}},
+		},
+		"merged with the headers of the context": {
+			ctx: func() context.Context {
+				ctx := ContextWithOptions(context.Background(), WithContextHeaders(http.Header{
+					"X-Feature-Flag": []string{"on"},
+					"X-Request-Tag":  []string{"initial"},
+				}))
+				return ContextWithHeaders(ctx, http.Header{"x-request-tag": []string{"replaced"}, "X-Correlation-ID": []string{"c0ffee"}})
+			},
+			expectedHeaders: http.Header{
+				"X-Feature-Flag":   []string{"on"},
+				"X-Request-Tag":    []string{"replaced"},
+				"X-Correlation-Id": []string{"c0ffee"},
+			},
+		},
+		"other options of the context kept": {
+			ctx: func() context.Context {
+				ctx := ContextWithOptions(context.Background(), WithContextFailover(true))
+				return ContextWithHeaders(ctx, http.Header{"X-Correlation-ID": []string{"c0ffee"}})
+			},
+			expectedHeaders: http.Header{"X-Correlation-Id": []string{"c0ffee"}},
+			expectFailover:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			received := 0
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received++
+				for key := range test.expectedHeaders {
+					assert.Equal(t, test.expectedHeaders.Values(key), r.Header.Values(key))
+				}
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assertSignature(t, r, body, clientSecret, edgegrid.MaxBodySize)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         serverURL.Host,
+				ClientToken:  "akab-client-token",
+				ClientSecret: clientSecret,
+				AccessToken:  "akab-access-token",
+				MaxBody:      edgegrid.MaxBodySize,
+			}), WithClient(mockServer.Client()))
+			require.NoError(t, err)
+
+			ctx := test.ctx()
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/papi/v1/search/find-by-value", nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil, testStruct{A: "text", B: 1})
+			require.NoError(t, err)
+			assert.Equal(t, 1, received)
+
+			o := ctx.Value(contextOptionKey).(*contextOptions)
+			assert.Equal(t, test.expectFailover, o.failover != nil && *o.failover)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package sessiontest provides a mock Akamai API server and a session signing the requests sent to it,
+// to test code using the API clients, e.g. papi or dns, against canned responses.
+package sessiontest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+)
+
+// MockServer is a TLS test server standing in for an Akamai API host, with a session trusting its certificate
+// and signing requests with test credentials
+type MockServer struct {
+	*httptest.Server
+	// Session is the session sending signed requests to the server, to be passed to the clients of the API packages
+	Session session.Session
+	// Config holds the test credentials the session signs requests with
+	Config *edgegrid.Config
+}
+
+var (
+	// ErrMissingSignature is returned by VerifySignature when the request has no EdgeGrid Authorization header
+	ErrMissingSignature = errors.New("missing EdgeGrid signature")
+	// ErrInvalidSignature is returned by VerifySignature when the signature does not match the request
+	ErrInvalidSignature = errors.New("invalid EdgeGrid signature")
+)
+
+// NewMockServer starts a mock server serving requests with the handler, and closes it when the test ends.
+// The session of the server is created with the options, which can e.g. set a logger, but not another client or signer.
+func NewMockServer(t testing.TB, handler http.Handler, opts ...session.Option) *MockServer {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parsing mock server URL: %s", err)
+	}
+	config := &edgegrid.Config{
+		Host:         serverURL.Host,
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "client-secret-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+	opts = append(opts, session.WithClient(server.Client()), session.WithSigner(config))
+	sess, err := session.New(opts...)
+	if err != nil {
+		t.Fatalf("creating mock session: %s", err)
+	}
+
+	return &MockServer{Server: server, Session: sess, Config: config}
+}
+
+// NewMockSession starts a mock server serving requests with the handler, and returns its session
+func NewMockSession(t testing.TB, handler http.HandlerFunc, opts ...session.Option) session.Session {
+	t.Helper()
+	return NewMockServer(t, handler, opts...).Session
+}
+
+// WriteJSON writes a canned JSON response with the status
+func WriteJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
+
+// AssertSigned reports a test error when the request received by the server does not carry a valid EdgeGrid
+// signature of the session credentials, and returns whether it does
+func (m *MockServer) AssertSigned(t testing.TB, r *http.Request) bool {
+	t.Helper()
+	if err := m.VerifySignature(r); err != nil {
+		t.Errorf("%s %s: %s", r.Method, r.URL, err)
+		return false
+	}
+	return true
+}
+
+// VerifySignature recomputes the EdgeGrid signature of a request received by the server with the session credentials
+// and compares it with the one of its Authorization header. The body of the request is read and restored.
+func (m *MockServer) VerifySignature(r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	i := strings.Index(auth, "signature=")
+	if !strings.HasPrefix(auth, "EG1-HMAC-SHA256 ") || i < 0 {
+		return fmt.Errorf("%w: %q", ErrMissingSignature, auth)
+	}
+	unsigned, signature := auth[:i], auth[i+len("signature="):]
+
+	var timestamp string
+	for _, field := range strings.Split(strings.TrimPrefix(unsigned, "EG1-HMAC-SHA256 "), ";") {
+		if value := strings.TrimPrefix(field, "timestamp="); value != field {
+			timestamp = value
+		}
+	}
+	if timestamp == "" {
+		return fmt.Errorf("%w: no timestamp in %q", ErrMissingSignature, auth)
+	}
+
+	var contentHash string
+	if r.Body != nil && r.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("reading request body: %w", err)
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if len(body) > m.Config.MaxBody {
+			body = body[:m.Config.MaxBody]
+		}
+		if len(body) > 0 {
+			hash := sha256.Sum256(body)
+			contentHash = base64.StdEncoding.EncodeToString(hash[:])
+		}
+	}
+
+	msg := strings.Join([]string{r.Method, "https", r.Host, r.URL.RequestURI(), "", contentHash, unsigned}, "\t")
+	if expected := hmacBase64(msg, hmacBase64(timestamp, m.Config.ClientSecret)); expected != signature {
+		return fmt.Errorf("%w: got %s, expected %s", ErrInvalidSignature, signature, expected)
+	}
+	return nil
+}
+
+func hmacBase64(message, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
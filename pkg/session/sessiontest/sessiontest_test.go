@@ -0,0 +1,100 @@
+package sessiontest_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session/sessiontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockServer_GetRuleFormats(t *testing.T) {
+	var server *sessiontest.MockServer
+	server = sessiontest.NewMockServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.AssertSigned(t, r)
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/papi/v1/rule-formats", r.URL.Path)
+		sessiontest.WriteJSON(w, http.StatusOK, `{"ruleFormats": {"items": ["latest", "v2023-01-05"]}}`)
+	}))
+
+	client := papi.Client(server.Session)
+	result, err := client.GetRuleFormats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"latest", "v2023-01-05"}, result.RuleFormats.Items)
+}
+
+func TestNewMockSession(t *testing.T) {
+	sess := sessiontest.NewMockSession(t, func(w http.ResponseWriter, r *http.Request) {
+		sessiontest.WriteJSON(w, http.StatusForbidden, `{"type": "forbidden", "title": "Forbidden", "status": 403}`)
+	})
+
+	_, err := papi.Client(sess).GetRuleFormats(context.Background())
+	var apiErr *papi.Error
+	require.True(t, errors.As(err, &apiErr), "want: *papi.Error; got: %s", err)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+}
+
+func TestMockServer_VerifySignature(t *testing.T) {
+	tests := map[string]struct {
+		method    string
+		in        []interface{}
+		tamper    func(r *http.Request)
+		withError error
+	}{
+		"signed GET": {
+			method: http.MethodGet,
+		},
+		"signed POST with body": {
+			method: http.MethodPost,
+			in:     []interface{}{map[string]string{"name": "example"}},
+		},
+		"missing signature": {
+			method:    http.MethodGet,
+			tamper:    func(r *http.Request) { r.Header.Del("Authorization") },
+			withError: sessiontest.ErrMissingSignature,
+		},
+		"tampered path": {
+			method:    http.MethodGet,
+			tamper:    func(r *http.Request) { r.URL.Path = "/papi/v1/groups" },
+			withError: sessiontest.ErrInvalidSignature,
+		},
+		"tampered body": {
+			method: http.MethodPost,
+			in:     []interface{}{map[string]string{"name": "example"}},
+			tamper: func(r *http.Request) {
+				r.Body = ioutil.NopCloser(strings.NewReader(`{"name":"other"}`))
+			},
+			withError: sessiontest.ErrInvalidSignature,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var server *sessiontest.MockServer
+			server = sessiontest.NewMockServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.tamper != nil {
+					test.tamper(r)
+				}
+				err := server.VerifySignature(r)
+				if test.withError != nil {
+					assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				} else {
+					assert.NoError(t, err)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			req, err := http.NewRequest(test.method, "/papi/v1/rule-formats?contractId=ctr_1", nil)
+			require.NoError(t, err)
+			resp, err := server.Session.Exec(req, nil, test.in...)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Warning is a non-fatal warning returned with a successful response, e.g. a deprecated behavior in a PAPI rule tree
+	Warning struct {
+		// Type, Title and Detail are the problem details of the warning. Warnings read from a Warning header
+		// have the text of the header as Title.
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+		// Header is the value of the Warning header the warning was read from, if any
+		Header string `json:"-"`
+		// Raw is the warning as returned in the response body, e.g. to decode fields specific to an API
+		// such as the errorLocation of PAPI rule warnings
+		Raw json.RawMessage `json:"-"`
+	}
+
+	// WarningHandler receives the warnings of a successful response to a request
+	WarningHandler func(r *http.Request, warnings []Warning)
+)
+
+// WithWarningHandler sets a handler called with the warnings of the successful responses of the session,
+// from the Warning headers and from the warnings array of the JSON body of the responses, e.g. to log them or to fail
+// a deployment on deprecations. Warnings are never turned into errors. Bodies are only inspected when Exec reads them,
+// i.e. when the response is unmarshalled, so streamed bodies are not consumed.
+func WithWarningHandler(handler WarningHandler) Option {
+	return func(s *session) {
+		s.warningHandler = handler
+	}
+}
+
+// handleWarnings passes the warnings of a successful response to the warning handler of the session and records
+// them in the ResponseMeta of the request context, if any. body is the body of the response, nil when it is not read.
+func (s *session) handleWarnings(r *http.Request, resp *http.Response, body []byte) {
+	meta, recordMeta := r.Context().Value(contextResponseMetaKey).(*ResponseMeta)
+	if s.warningHandler == nil && !recordMeta {
+		return
+	}
+
+	warnings := responseWarnings(resp.Header, body)
+	if recordMeta {
+		meta.Warnings = warnings
+	}
+	if s.warningHandler != nil && len(warnings) > 0 {
+		s.warningHandler(r, warnings)
+	}
+}
+
+// responseWarnings returns the warnings of the Warning headers and of the warnings array of a JSON body
+func responseWarnings(header http.Header, body []byte) []Warning {
+	var warnings []Warning
+	for _, value := range header.Values("Warning") {
+		warnings = append(warnings, Warning{Title: warningHeaderText(value), Header: value})
+	}
+
+	var document struct {
+		Warnings []json.RawMessage `json:"warnings"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &document) != nil {
+		return warnings
+	}
+	for _, raw := range document.Warnings {
+		var warning Warning
+		if err := json.Unmarshal(raw, &warning); err != nil {
+			// warnings which are not problem details, e.g. plain strings, are kept as they are
+			var text string
+			if json.Unmarshal(raw, &text) == nil {
+				warning.Title = text
+			}
+		}
+		warning.Raw = raw
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// warningHeaderText returns the text of a Warning header value such as `299 - "Deprecated API"`,
+// or the value itself when it does not have this form
+func warningHeaderText(value string) string {
+	start := strings.Index(value, `"`)
+	if start < 0 {
+		return value
+	}
+	end := start + 1
+	for end < len(value) && value[end] != '"' {
+		if value[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(value) {
+		return value
+	}
+	text, err := strconv.Unquote(value[start : end+1])
+	if err != nil {
+		return value
+	}
+	return text
+}
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWarningHandler(t *testing.T) {
+	ruleWarning := `{"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/deprecated_behavior", "title": "Deprecated behavior", "detail": "The sureRoute behavior is deprecated.", "errorLocation": "#/rules/behaviors/0"}`
+
+	tests := map[string]struct {
+		status           int
+		header           http.Header
+		body             string
+		noOut            bool
+		expectedWarnings []Warning
+	}{
+		"warnings array on 200": {
+			status: http.StatusOK,
+			body:   `{"ruleFormat": "v2023-01-05", "warnings": [` + ruleWarning + `, "plain text warning"]}`,
+			expectedWarnings: []Warning{
+				{
+					Type:   "https://problems.luna.akamaiapis.net/papi/v0/validation/deprecated_behavior",
+					Title:  "Deprecated behavior",
+					Detail: "The sureRoute behavior is deprecated.",
+					Raw:    json.RawMessage(ruleWarning),
+				},
+				{Title: "plain text warning", Raw: json.RawMessage(`"plain text warning"`)},
+			},
+		},
+		"Warning header": {
+			status: http.StatusCreated,
+			header: http.Header{"Warning": []string{`299 - "Deprecated API: use v2"`}},
+			body:   `{}`,
+			expectedWarnings: []Warning{
+				{Title: "Deprecated API: use v2", Header: `299 - "Deprecated API: use v2"`},
+			},
+		},
+		"body not read without output": {
+			status: http.StatusOK,
+			body:   `{"warnings": [` + ruleWarning + `]}`,
+			noOut:  true,
+		},
+		"no warnings": {
+			status: http.StatusOK,
+			body:   `{"ruleFormat": "v2023-01-05"}`,
+		},
+		"error response": {
+			status: http.StatusBadRequest,
+			header: http.Header{"Warning": []string{`299 - "Deprecated API"`}},
+			body:   `{"type": "invalid", "warnings": [` + ruleWarning + `]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				header := http.Header{"Content-Type": []string{"application/json"}}
+				for key, values := range test.header {
+					header[key] = values
+				}
+				return &http.Response{
+					StatusCode: test.status,
+					Header:     header,
+					Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+					Request:    r,
+				}, nil
+			})
+			var handled []Warning
+			calls := 0
+			s, err := New(WithTransport(transport), WithSigner(hostSigner{}), WithWarningHandler(func(r *http.Request, warnings []Warning) {
+				assert.Equal(t, "/papi/v1/rules", r.URL.Path)
+				handled = warnings
+				calls++
+			}))
+			require.NoError(t, err)
+
+			ctx, meta := ContextWithResponseMeta(context.Background())
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://akab-host.luna.akamaiapis.net/papi/v1/rules", nil)
+			require.NoError(t, err)
+			var out interface{}
+			if !test.noOut {
+				out = &map[string]interface{}{}
+			}
+			resp, err := s.Exec(req, out)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.status, resp.StatusCode)
+			assert.Equal(t, test.expectedWarnings, handled)
+			assert.Equal(t, test.expectedWarnings, meta.Warnings)
+			if test.expectedWarnings == nil {
+				assert.Zero(t, calls)
+			} else {
+				assert.Equal(t, 1, calls)
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apex/log"
+)
+
+// WireLogBodyLimit is the number of bytes of request and response bodies logged by WithWireLog
+const WireLogBodyLimit = 4096
+
+const redacted = "REDACTED"
+
+var (
+	// redactedHeaders are never logged by WithWireLog, keys are canonical header names
+	redactedHeaders = map[string]struct{}{
+		"Authorization":       {},
+		"Proxy-Authorization": {},
+		"Cookie":              {},
+		"Set-Cookie":          {},
+	}
+
+	// redactedParams are never logged by WithWireLog, in query strings or JSON and form bodies
+	redactedParams = []string{"client_secret", "clientSecret", "client_token", "clientToken", "access_token", "accessToken", "password"}
+
+	redactedJSONFields = regexp.MustCompile(`("(?:` + strings.Join(redactedParams, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	redactedFormFields = regexp.MustCompile(`((?:^|&)(?:` + strings.Join(redactedParams, "|") + `)=)[^&]*`)
+)
+
+// WithWireLog logs every request and response at debug level to the logger: method, URL, headers and bodies.
+// The Authorization, Cookie and Set-Cookie headers and credentials such as client_secret in query strings
+// and bodies are replaced with REDACTED, so that signing failures can be diagnosed without leaking credentials.
+// Only textual bodies, e.g. JSON, are logged, truncated to WireLogBodyLimit bytes.
+//
+// Unlike WithHTTPTracing, which dumps requests as they are, it is suitable for logs kept or shared.
+func WithWireLog(l log.Interface) Option {
+	return func(s *session) {
+		s.wireLog = l
+	}
+}
+
+// logRequest logs the request, reading its body from GetBody so that it is not consumed
+func logRequest(l log.Interface, r *http.Request) {
+	var body string
+	switch {
+	case r.Body == nil || r.Body == http.NoBody:
+	case r.GetBody == nil:
+		body = "[body not logged: not replayable]"
+	default:
+		rc, err := r.GetBody()
+		if err != nil {
+			body = fmt.Sprintf("[body not logged: %s]", err)
+			break
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(rc, WireLogBodyLimit+1))
+		_ = rc.Close()
+		if err != nil {
+			body = fmt.Sprintf("[body not logged: %s]", err)
+			break
+		}
+		body = formatBody(r.Header.Get("Content-Type"), data, r.ContentLength)
+	}
+
+	l.Debugf("--> %s %s\n%s%s", r.Method, redactURL(r.URL), formatHeaders(r.Header), body)
+}
+
+// logResponse logs the response, buffering its body so that it can still be read by the caller
+func logResponse(l log.Interface, resp *http.Response) error {
+	var body string
+	if resp.Body != nil {
+		data, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+		if len(data) > 0 {
+			body = formatBody(resp.Header.Get("Content-Type"), data, int64(len(data)))
+		}
+	}
+
+	l.Debugf("<-- %s %s %s\n%s%s", resp.Status, resp.Request.Method, redactURL(resp.Request.URL), formatHeaders(resp.Header), body)
+	return nil
+}
+
+func formatHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		for _, value := range h[name] {
+			if _, ok := redactedHeaders[http.CanonicalHeaderKey(name)]; ok {
+				value = redacted
+			}
+			fmt.Fprintf(&b, "%s: %s\n", name, value)
+		}
+	}
+	return b.String()
+}
+
+// formatBody returns the redacted and truncated body when its content type is textual
+func formatBody(contentType string, data []byte, size int64) string {
+	if size < int64(len(data)) {
+		size = int64(len(data))
+	}
+	if !isTextContent(contentType) {
+		return fmt.Sprintf("\n[%d bytes of %q body not logged]", size, contentType)
+	}
+
+	truncated := len(data) > WireLogBodyLimit
+	if truncated {
+		data = data[:WireLogBodyLimit]
+	}
+	body := redactedJSONFields.ReplaceAllString(string(data), `${1}"`+redacted+`"`)
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		body = redactedFormFields.ReplaceAllString(body, "${1}"+redacted)
+	}
+	if truncated {
+		body += fmt.Sprintf("... [truncated, %d bytes total]", size)
+	}
+	return "\n" + body
+}
+
+func isTextContent(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "json"),
+		strings.HasSuffix(mediaType, "xml"),
+		mediaType == "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
+
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redactedURL := *u
+	q := u.Query()
+	for _, param := range redactedParams {
+		if _, ok := q[param]; ok {
+			q.Set(param, redacted)
+		}
+	}
+	redactedURL.RawQuery = q.Encode()
+	return redactedURL.String()
+}
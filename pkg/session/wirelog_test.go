@@ -0,0 +1,122 @@
+package session
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWireLog(t *testing.T) {
+	tests := map[string]struct {
+		method              string
+		url                 string
+		in                  interface{}
+		responseContentType string
+		responseBody        []byte
+		expectedRequest     []string
+		expectedResponse    []string
+		notExpected         []string
+	}{
+		"json request and response": {
+			method:              http.MethodPost,
+			url:                 "/identity-management/v3/api-clients/self/credentials?client_secret=abc",
+			in:                  map[string]string{"name": "test", "client_secret": "s3cr3t"},
+			responseContentType: "application/json",
+			responseBody:        []byte(`{"clientToken": "akab-token", "clientSecret": "t0ps3cr3t"}`),
+			expectedRequest: []string{
+				"--> POST https://akab-host.luna.akamaiapis.net/identity-management/v3/api-clients/self/credentials?client_secret=REDACTED",
+				"Authorization: REDACTED",
+				"Content-Type: application/json",
+				`"client_secret":"REDACTED"`,
+				`"name":"test"`,
+			},
+			expectedResponse: []string{
+				"<-- 200 OK POST",
+				"Set-Cookie: REDACTED",
+				`"clientSecret": "REDACTED"`,
+				`"clientToken": "REDACTED"`,
+			},
+			notExpected: []string{"EG1-HMAC-SHA256", "s3cr3t", "t0ps3cr3t", "akab-token", "session=", "abc"},
+		},
+		"binary response": {
+			method:              http.MethodGet,
+			url:                 "/papi/v1/properties",
+			responseContentType: "application/octet-stream",
+			responseBody:        []byte{0x00, 0x01, 0x02},
+			expectedRequest:     []string{"--> GET https://akab-host.luna.akamaiapis.net/papi/v1/properties"},
+			expectedResponse:    []string{`[3 bytes of "application/octet-stream" body not logged]`},
+		},
+		"truncated response": {
+			method:              http.MethodGet,
+			url:                 "/config-dns/v2/zones/example.com/zone-file",
+			responseContentType: "text/dns",
+			responseBody:        bytes.Repeat([]byte("a"), WireLogBodyLimit+10),
+			expectedResponse:    []string{strings.Repeat("a", WireLogBodyLimit) + "... [truncated, 4106 bytes total]"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				header := http.Header{}
+				header.Set("Content-Type", test.responseContentType)
+				header.Set("Set-Cookie", "session=abcdef")
+				return &http.Response{
+					Status:     "200 OK",
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(test.responseBody)),
+					Header:     header,
+					Request:    r,
+				}, nil
+			})
+			handler := memory.New()
+			logger := &log.Logger{Handler: handler, Level: log.DebugLevel}
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:         "akab-host.luna.akamaiapis.net",
+				ClientToken:  "akab-client-token",
+				ClientSecret: "client-secret",
+				AccessToken:  "akab-access-token",
+				MaxBody:      131072,
+			}), WithTransport(transport), WithWireLog(logger))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(test.method, test.url, nil)
+			require.NoError(t, err)
+			var in []interface{}
+			if test.in != nil {
+				in = append(in, test.in)
+			}
+			resp, err := s.Exec(req, nil, in...)
+			require.NoError(t, err)
+
+			// the response body is still readable after being logged
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.responseBody, body)
+
+			require.Len(t, handler.Entries, 2)
+			for _, entry := range handler.Entries {
+				assert.Equal(t, log.DebugLevel, entry.Level)
+			}
+			for _, expected := range test.expectedRequest {
+				assert.Contains(t, handler.Entries[0].Message, expected)
+			}
+			for _, expected := range test.expectedResponse {
+				assert.Contains(t, handler.Entries[1].Message, expected)
+			}
+			for _, entry := range handler.Entries {
+				for _, secret := range test.notExpected {
+					assert.NotContains(t, entry.Message, secret)
+				}
+			}
+		})
+	}
+}